@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"gorm.io/gorm"
+
+	"github.com/azzamdhx/moneybro/backend/internal/config"
+	"github.com/azzamdhx/moneybro/backend/internal/database"
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+)
+
+func main() {
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using environment variables")
+	}
+
+	cfg := config.Load()
+	db, err := database.NewPostgres(cfg.DatabaseURL)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	log.Println("Starting payee backfill...")
+
+	// Step 1: Create payees from distinct expense item names
+	if err := backfillExpensePayees(db); err != nil {
+		log.Fatalf("Failed to backfill expense payees: %v", err)
+	}
+
+	// Step 2: Create payees from distinct debt person names
+	if err := backfillDebtPayees(db); err != nil {
+		log.Fatalf("Failed to backfill debt payees: %v", err)
+	}
+
+	// Step 3: Link existing records to matching payees
+	if err := linkRecords(db); err != nil {
+		log.Fatalf("Failed to link records to payees: %v", err)
+	}
+
+	log.Println("Payee backfill completed successfully!")
+}
+
+type nameCandidate struct {
+	UserID     uuid.UUID
+	Name       string
+	CategoryID *uuid.UUID
+}
+
+func backfillExpensePayees(db *gorm.DB) error {
+	log.Println("Backfilling payees from expenses...")
+
+	// Pick the most used category per item name as the payee's default category
+	var candidates []nameCandidate
+	err := db.Raw(`
+		SELECT DISTINCT ON (user_id, LOWER(TRIM(item_name)))
+			user_id, TRIM(item_name) as name, category_id
+		FROM expenses
+		WHERE TRIM(item_name) <> ''
+		GROUP BY user_id, LOWER(TRIM(item_name)), TRIM(item_name), category_id
+		ORDER BY user_id, LOWER(TRIM(item_name)), COUNT(*) DESC
+	`).Scan(&candidates).Error
+	if err != nil {
+		return err
+	}
+
+	return createPayees(db, candidates)
+}
+
+func backfillDebtPayees(db *gorm.DB) error {
+	log.Println("Backfilling payees from debts...")
+
+	var candidates []nameCandidate
+	err := db.Raw(`
+		SELECT DISTINCT ON (user_id, LOWER(TRIM(person_name)))
+			user_id, TRIM(person_name) as name
+		FROM debts
+		WHERE TRIM(person_name) <> ''
+		ORDER BY user_id, LOWER(TRIM(person_name))
+	`).Scan(&candidates).Error
+	if err != nil {
+		return err
+	}
+
+	return createPayees(db, candidates)
+}
+
+func createPayees(db *gorm.DB, candidates []nameCandidate) error {
+	created := 0
+	for _, c := range candidates {
+		exists, err := payeeExists(db, c.UserID, c.Name)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+
+		payee := models.Payee{
+			ID:                uuid.New(),
+			UserID:            c.UserID,
+			Name:              c.Name,
+			DefaultCategoryID: c.CategoryID,
+		}
+		if err := db.Create(&payee).Error; err != nil {
+			return fmt.Errorf("failed to create payee %q for user %s: %w", c.Name, c.UserID, err)
+		}
+		created++
+	}
+	log.Printf("Created %d payees", created)
+	return nil
+}
+
+func payeeExists(db *gorm.DB, userID uuid.UUID, name string) (bool, error) {
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	var count int64
+	err := db.Model(&models.Payee{}).
+		Where("user_id = ?", userID).
+		Where("LOWER(name) = ? OR id IN (SELECT payee_id FROM payee_aliases WHERE LOWER(alias) = ?)", normalized, normalized).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func linkRecords(db *gorm.DB) error {
+	log.Println("Linking records to payees...")
+
+	links := []struct {
+		table  string
+		column string
+	}{
+		{"expenses", "item_name"},
+		{"incomes", "source_name"},
+		{"debts", "person_name"},
+	}
+
+	for _, l := range links {
+		result := db.Exec(`
+			UPDATE ` + l.table + ` t SET payee_id = p.id
+			FROM payees p
+			WHERE t.payee_id IS NULL
+			  AND p.user_id = t.user_id
+			  AND (LOWER(p.name) = LOWER(TRIM(t.` + l.column + `))
+			       OR p.id IN (SELECT payee_id FROM payee_aliases WHERE LOWER(alias) = LOWER(TRIM(t.` + l.column + `))))
+		`)
+		if result.Error != nil {
+			return fmt.Errorf("failed to link %s: %w", l.table, result.Error)
+		}
+		log.Printf("Linked %d %s", result.RowsAffected, l.table)
+	}
+	return nil
+}
@@ -53,7 +53,7 @@ func main() {
 		EmailTemplatesDir: cfg.EmailTemplatesDir,
 	})
 
-	cronScheduler := cron.NewScheduler(svc.Notification)
+	cronScheduler := cron.NewScheduler(svc.Notification, svc.ExpenseTemplateGroup, svc.RecurringIncome, svc.Overdue, svc.Debt, svc.SavingsGoal, svc.RoundUp)
 	cronScheduler.Start()
 	defer cronScheduler.Stop()
 
@@ -11,15 +11,35 @@ import (
 )
 
 type Scheduler struct {
-	scheduler           *gocron.Scheduler
-	notificationService *services.NotificationService
+	scheduler                   *gocron.Scheduler
+	notificationService         *services.NotificationService
+	expenseTemplateGroupService *services.ExpenseTemplateGroupService
+	recurringIncomeService      *services.RecurringIncomeGroupService
+	overdueService              *services.OverdueService
+	debtService                 *services.DebtService
+	savingsGoalService          *services.SavingsGoalService
+	roundUpService              *services.RoundUpService
 }
 
-func NewScheduler(notificationService *services.NotificationService) *Scheduler {
+func NewScheduler(
+	notificationService *services.NotificationService,
+	expenseTemplateGroupService *services.ExpenseTemplateGroupService,
+	recurringIncomeService *services.RecurringIncomeGroupService,
+	overdueService *services.OverdueService,
+	debtService *services.DebtService,
+	savingsGoalService *services.SavingsGoalService,
+	roundUpService *services.RoundUpService,
+) *Scheduler {
 	s := gocron.NewScheduler(time.UTC)
 	return &Scheduler{
-		scheduler:           s,
-		notificationService: notificationService,
+		scheduler:                   s,
+		notificationService:         notificationService,
+		expenseTemplateGroupService: expenseTemplateGroupService,
+		recurringIncomeService:      recurringIncomeService,
+		overdueService:              overdueService,
+		debtService:                 debtService,
+		savingsGoalService:          savingsGoalService,
+		roundUpService:              roundUpService,
 	}
 }
 
@@ -33,6 +53,71 @@ func (s *Scheduler) Start() {
 		log.Println("Daily notification job completed")
 	})
 
+	s.scheduler.Every(1).Day().At("01:00").Do(func() {
+		log.Println("Running expense template auto-post job...")
+		ctx := context.Background()
+		results, err := s.expenseTemplateGroupService.AutoPostDueGroups(time.Now())
+		if err != nil {
+			log.Printf("Error running expense template auto-post job: %v", err)
+			return
+		}
+		s.notificationService.SendTemplateAutoPostSummaries(ctx, results)
+		log.Printf("Expense template auto-post job completed (%d groups posted)", len(results))
+	})
+
+	s.scheduler.Every(1).Day().At("01:00").Do(func() {
+		log.Println("Running recurring income auto-post job...")
+		runs, err := s.recurringIncomeService.AutoPostDueGroups(time.Now())
+		if err != nil {
+			log.Printf("Error running recurring income auto-post job: %v", err)
+			return
+		}
+		log.Printf("Recurring income auto-post job completed (%d runs)", len(runs))
+	})
+
+	// Runs after the income auto-post so percentage rules see that day's incomes
+	s.scheduler.Every(1).Day().At("01:30").Do(func() {
+		log.Println("Running savings auto-contribution job...")
+		ctx := context.Background()
+		runs, err := s.savingsGoalService.RunDueAutoRules(time.Now())
+		if err != nil {
+			log.Printf("Error running savings auto-contribution job: %v", err)
+			return
+		}
+		s.notificationService.SendSavingsAutoSkippedAlerts(ctx, runs)
+		log.Printf("Savings auto-contribution job completed (%d runs)", len(runs))
+	})
+
+	s.scheduler.Every(1).Day().At("00:30").Do(func() {
+		log.Println("Running round-up batch job...")
+		count, err := s.roundUpService.RunDailyBatch(time.Now())
+		if err != nil {
+			log.Printf("Error running round-up batch job: %v", err)
+			return
+		}
+		log.Printf("Round-up batch job completed (%d contributions)", count)
+	})
+
+	s.scheduler.Every(1).Day().At("02:00").Do(func() {
+		log.Println("Running late fee job...")
+		charges, err := s.overdueService.ChargeLateFees(time.Now())
+		if err != nil {
+			log.Printf("Error running late fee job: %v", err)
+			return
+		}
+		log.Printf("Late fee job completed (%d fees charged)", len(charges))
+	})
+
+	s.scheduler.Every(1).Month(1).At("03:00").Do(func() {
+		log.Println("Running debt interest accrual job...")
+		accruals, err := s.debtService.AccrueInterest(time.Now())
+		if err != nil {
+			log.Printf("Error running debt interest accrual job: %v", err)
+			return
+		}
+		log.Printf("Debt interest accrual job completed (%d months accrued)", len(accruals))
+	})
+
 	s.scheduler.StartAsync()
 	log.Println("Cron scheduler started")
 }
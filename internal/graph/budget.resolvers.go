@@ -0,0 +1,120 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.87
+
+import (
+	"context"
+
+	"github.com/azzamdhx/moneybro/backend/internal/graph/model"
+	"github.com/azzamdhx/moneybro/backend/internal/middleware"
+	"github.com/azzamdhx/moneybro/backend/internal/services"
+	"github.com/azzamdhx/moneybro/backend/internal/utils"
+	"github.com/google/uuid"
+)
+
+// CreateBudget is the resolver for the createBudget field.
+func (r *mutationResolver) CreateBudget(ctx context.Context, input model.CreateBudgetInput) (*model.Budget, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	budget, err := r.Services.Budget.Create(userID, services.CreateBudgetInput{
+		CategoryID:      input.CategoryID,
+		Amount:          int64(input.Amount),
+		AlertThresholds: input.AlertThresholds,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return budgetToModel(budget), nil
+}
+
+// UpdateBudget is the resolver for the updateBudget field.
+func (r *mutationResolver) UpdateBudget(ctx context.Context, id uuid.UUID, input model.UpdateBudgetInput) (*model.Budget, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	var amount *int64
+	if input.Amount != nil {
+		v := int64(*input.Amount)
+		amount = &v
+	}
+	budget, err := r.Services.Budget.Update(userID, id, services.UpdateBudgetInput{
+		Amount:          amount,
+		AlertThresholds: input.AlertThresholds,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return budgetToModel(budget), nil
+}
+
+// DeleteBudget is the resolver for the deleteBudget field.
+func (r *mutationResolver) DeleteBudget(ctx context.Context, id uuid.UUID) (bool, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return false, utils.UnauthorizedError(ctx)
+	}
+	err := r.Services.Budget.Delete(userID, id)
+	return err == nil, err
+}
+
+// SetBudgetOverride is the resolver for the setBudgetOverride field.
+func (r *mutationResolver) SetBudgetOverride(ctx context.Context, id uuid.UUID, input model.SetBudgetOverrideInput) (*model.Budget, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	budget, err := r.Services.Budget.SetOverride(userID, id, input.Month, input.Year, int64(input.Amount))
+	if err != nil {
+		return nil, err
+	}
+	return budgetToModel(budget), nil
+}
+
+// DeleteBudgetOverride is the resolver for the deleteBudgetOverride field.
+func (r *mutationResolver) DeleteBudgetOverride(ctx context.Context, id uuid.UUID, month int, year int) (*model.Budget, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	budget, err := r.Services.Budget.DeleteOverride(userID, id, month, year)
+	if err != nil {
+		return nil, err
+	}
+	return budgetToModel(budget), nil
+}
+
+// Budgets is the resolver for the budgets field.
+func (r *queryResolver) Budgets(ctx context.Context) ([]*model.Budget, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	budgets, err := r.Services.Budget.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.Budget, len(budgets))
+	for i, b := range budgets {
+		result[i] = budgetToModel(&b)
+	}
+	return result, nil
+}
+
+// BudgetStatus is the resolver for the budgetStatus field.
+func (r *queryResolver) BudgetStatus(ctx context.Context, month int, year int) (*model.BudgetStatus, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	status, err := r.Services.Budget.GetStatus(userID, month, year)
+	if err != nil {
+		return nil, err
+	}
+	return budgetStatusToModel(status), nil
+}
@@ -0,0 +1,54 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.87
+
+import (
+	"context"
+
+	"github.com/azzamdhx/moneybro/backend/internal/graph/model"
+	"github.com/azzamdhx/moneybro/backend/internal/middleware"
+	"github.com/azzamdhx/moneybro/backend/internal/utils"
+	"github.com/google/uuid"
+)
+
+// ConsolidateDebts is the resolver for the consolidateDebts field.
+func (r *mutationResolver) ConsolidateDebts(ctx context.Context, debtIds []uuid.UUID, installmentIds []uuid.UUID, newLoan model.ConsolidationLoanInput) (*model.DebtConsolidation, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	result, err := r.Services.Consolidation.Consolidate(userID, consolidationInputFromModel(debtIds, installmentIds, newLoan))
+	if err != nil {
+		return nil, err
+	}
+	return debtConsolidationToModel(result), nil
+}
+
+// ConsolidationPreview is the resolver for the consolidationPreview field.
+func (r *queryResolver) ConsolidationPreview(ctx context.Context, debtIds []uuid.UUID, installmentIds []uuid.UUID, newLoan model.ConsolidationLoanInput) (*model.ConsolidationPreview, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	preview, err := r.Services.Consolidation.Preview(userID, consolidationInputFromModel(debtIds, installmentIds, newLoan))
+	if err != nil {
+		return nil, err
+	}
+	return consolidationPreviewToModel(preview), nil
+}
+
+// DebtConsolidation is the resolver for the debtConsolidation field.
+func (r *queryResolver) DebtConsolidation(ctx context.Context, id uuid.UUID) (*model.DebtConsolidation, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	result, err := r.Services.Consolidation.GetByID(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return debtConsolidationToModel(result), nil
+}
@@ -1,6 +1,11 @@
 package graph
 
 import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
 	"github.com/azzamdhx/moneybro/backend/internal/graph/model"
 	"github.com/azzamdhx/moneybro/backend/internal/models"
 	"github.com/azzamdhx/moneybro/backend/internal/services"
@@ -16,6 +21,8 @@ func userToModel(u *models.User) *model.User {
 		NotifyInstallment: u.NotifyInstallment,
 		NotifyDebt:        u.NotifyDebt,
 		NotifySavingsGoal: u.NotifySavingsGoal,
+		NotifyBudget:      u.NotifyBudget,
+		NotifyReceivable:  u.NotifyReceivable,
 		NotifyDaysBefore:  u.NotifyDaysBefore,
 		CreatedAt:         u.CreatedAt,
 		UpdatedAt:         u.UpdatedAt,
@@ -26,6 +33,7 @@ func categoryToModel(c *models.Category) *model.Category {
 	return &model.Category{
 		ID:           c.ID,
 		Name:         c.Name,
+		ParentID:     c.ParentID,
 		CreatedAt:    c.CreatedAt,
 		ExpenseCount: c.ExpenseCount,
 		TotalSpent:   int(c.TotalSpent),
@@ -34,30 +42,54 @@ func categoryToModel(c *models.Category) *model.Category {
 
 func expenseToModel(e *models.Expense) *model.Expense {
 	exp := &model.Expense{
-		ID:          e.ID,
-		ItemName:    e.ItemName,
-		UnitPrice:   int(e.UnitPrice),
-		Quantity:    e.Quantity,
-		Total:       int(e.Total()),
-		Notes:       e.Notes,
-		ExpenseDate: e.ExpenseDate,
-		PocketID:    e.PocketID,
-		CreatedAt:   e.CreatedAt,
+		ID:             e.ID,
+		ItemName:       e.ItemName,
+		UnitPrice:      int(e.UnitPrice),
+		Quantity:       e.Quantity,
+		Total:          int(e.Total()),
+		RefundedAmount: int(e.RefundedAmount()),
+		NetTotal:       int(e.NetTotal()),
+		Notes:          e.Notes,
+		ExpenseDate:    e.ExpenseDate,
+		PocketID:       e.PocketID,
+		PayeeID:        e.PayeeID,
+		InstallmentID:  e.InstallmentID,
+		CreatedAt:      e.CreatedAt,
 	}
 	if e.Category != nil {
 		exp.Category = categoryToModel(e.Category)
 	}
+	exp.Refunds = make([]*model.ExpenseRefund, len(e.Refunds))
+	for i, r := range e.Refunds {
+		exp.Refunds[i] = &model.ExpenseRefund{
+			ID:         r.ID,
+			Amount:     int(r.Amount),
+			RefundDate: r.RefundDate,
+			PocketID:   r.PocketID,
+			Notes:      r.Notes,
+			CreatedAt:  r.CreatedAt,
+		}
+	}
 	return exp
 }
 
 func expenseTemplateGroupToModel(g *models.ExpenseTemplateGroup) *model.ExpenseTemplateGroup {
 	group := &model.ExpenseTemplateGroup{
-		ID:           g.ID,
-		Name:         g.Name,
-		RecurringDay: g.RecurringDay,
-		Notes:        g.Notes,
-		Total:        int(g.Total()),
-		CreatedAt:    g.CreatedAt,
+		ID:               g.ID,
+		Name:             g.Name,
+		RecurringDay:     g.RecurringDay,
+		Notes:            g.Notes,
+		AutoPost:         g.AutoPost,
+		NotifyOnAutoPost: g.NotifyOnAutoPost,
+		LastPostedDate:   g.LastPostedDate,
+		Total:            int(g.Total()),
+		CreatedAt:        g.CreatedAt,
+	}
+	if g.Recurrence.IsSet() {
+		group.Recurrence = recurrenceToModel(&g.Recurrence)
+	}
+	if schedule := g.Schedule(); schedule != nil {
+		group.NextOccurrence = schedule.Next(g.CreatedAt, nextOccurrenceAfter(g.LastPostedDate))
 	}
 	if len(g.Items) > 0 {
 		items := make([]*model.ExpenseTemplateItem, len(g.Items))
@@ -94,10 +126,14 @@ func installmentToModel(i *models.Installment) *model.Installment {
 		Tenor:              i.Tenor,
 		StartDate:          i.StartDate,
 		DueDay:             i.DueDay,
+		InterestMethod:     model.InstallmentInterestMethod(i.InterestMethod),
+		InterestRate:       i.InterestRate,
 		Status:             model.InstallmentStatus(i.Status),
 		Icon:               i.Icon,
 		CardBgColor:        i.CardBgColor,
 		Notes:              i.Notes,
+		ExpenseID:          i.ExpenseID,
+		ConsolidationID:    i.ConsolidationID,
 		CreatedAt:          i.CreatedAt,
 		InterestAmount:     int(i.InterestAmount()),
 		InterestPercentage: i.InterestPercentage(),
@@ -112,9 +148,78 @@ func installmentToModel(i *models.Installment) *model.Installment {
 		}
 		inst.Payments = payments
 	}
+	inst.Prepayments = make([]*model.InstallmentPrepayment, len(i.Prepayments))
+	for j, p := range i.Prepayments {
+		inst.Prepayments[j] = installmentPrepaymentToModel(&p)
+	}
+	history := i.TermHistory()
+	inst.TermHistory = make([]*model.InstallmentTerm, len(history))
+	for j, t := range history {
+		inst.TermHistory[j] = installmentTermToModel(&t)
+	}
+	inst.AmortizationSchedule = amortizationScheduleToModel(i.AmortizationSchedule())
+	inst.Overdue = overdueInfoToModel(i.Overdue(time.Now()))
 	return inst
 }
 
+func installmentPrepaymentToModel(p *models.InstallmentPrepayment) *model.InstallmentPrepayment {
+	return &model.InstallmentPrepayment{
+		ID:                 p.ID,
+		Amount:             int(p.Amount),
+		PaidAt:             p.PaidAt,
+		AfterPaymentNumber: p.AfterPaymentNumber,
+		Strategy:           model.PrepaymentStrategy(p.Strategy),
+		PocketID:           p.PocketID,
+		CreatedAt:          p.CreatedAt,
+	}
+}
+
+func installmentTermToModel(t *models.InstallmentTerm) *model.InstallmentTerm {
+	term := &model.InstallmentTerm{
+		Version:            t.Version,
+		EffectiveDate:      t.EffectiveDate,
+		AfterPaymentNumber: t.AfterPaymentNumber,
+		Principal:          int(t.Principal),
+		Tenor:              t.Tenor,
+		MonthlyPayment:     int(t.MonthlyPayment),
+		LoanAmount:         int(t.LoanAmount),
+		InterestMethod:     model.InstallmentInterestMethod(t.InterestMethod),
+		InterestRate:       t.InterestRate,
+		DueDay:             t.DueDay,
+		Fee:                int(t.Fee),
+		InterestAdjustment: int(t.InterestAdjustment),
+		CategoryID:         t.CategoryID,
+		Notes:              t.Notes,
+		CreatedAt:          t.CreatedAt,
+	}
+	// The original terms are not stored and have no ID
+	if t.ID != uuid.Nil {
+		term.ID = &t.ID
+	}
+	return term
+}
+
+func amortizationScheduleToModel(schedule []models.AmortizationPeriod) []*model.AmortizationPeriod {
+	periods := make([]*model.AmortizationPeriod, len(schedule))
+	for i, p := range schedule {
+		periods[i] = amortizationPeriodToModel(p)
+	}
+	return periods
+}
+
+func amortizationPeriodToModel(p models.AmortizationPeriod) *model.AmortizationPeriod {
+	return &model.AmortizationPeriod{
+		PaymentNumber:      p.PaymentNumber,
+		DueDate:            p.DueDate,
+		Payment:            int(p.Payment),
+		Principal:          int(p.Principal),
+		Interest:           int(p.Interest),
+		RemainingPrincipal: int(p.RemainingPrincipal),
+		Paid:               p.Paid,
+		PaidAt:             p.PaidAt,
+	}
+}
+
 func installmentPaymentToModel(p *models.InstallmentPayment) *model.InstallmentPayment {
 	return &model.InstallmentPayment{
 		ID:            p.ID,
@@ -126,17 +231,33 @@ func installmentPaymentToModel(p *models.InstallmentPayment) *model.InstallmentP
 	}
 }
 
+func updatePaymentInputFromModel(amount *int, paidAt *time.Time, pocketID *uuid.UUID) services.UpdatePaymentInput {
+	input := services.UpdatePaymentInput{
+		PaidAt:   paidAt,
+		PocketID: pocketID,
+	}
+	if amount != nil {
+		v := int64(*amount)
+		input.Amount = &v
+	}
+	return input
+}
+
 func debtToModel(d *models.Debt) *model.Debt {
 	debt := &model.Debt{
 		ID:              d.ID,
 		PersonName:      d.PersonName,
 		ActualAmount:    int(d.ActualAmount),
 		PaymentType:     model.DebtPaymentType(d.PaymentType),
+		Direction:       model.DebtDirection(d.Direction),
 		Status:          model.DebtStatus(d.Status),
 		Icon:            d.Icon,
 		CardBgColor:     d.CardBgColor,
 		Notes:           d.Notes,
 		DueDate:         d.DueDate,
+		PayeeID:         d.PayeeID,
+		ConsolidationID: d.ConsolidationID,
+		InterestRate:    d.InterestRate,
 		CreatedAt:       d.CreatedAt,
 		TotalToPay:      int(d.TotalToPay()),
 		PaidAmount:      int(d.PaidAmount()),
@@ -165,9 +286,38 @@ func debtToModel(d *models.Debt) *model.Debt {
 		}
 		debt.Payments = payments
 	}
+	if d.InterestCompounding != nil {
+		compounding := model.DebtInterestCompounding(*d.InterestCompounding)
+		debt.InterestCompounding = &compounding
+	}
+	debt.InterestStartDate = d.InterestStartDate
+	breakdown := d.InterestBreakdown()
+	debt.InterestBreakdown = &model.DebtInterestBreakdown{
+		AccruedInterest: int(breakdown.AccruedInterest),
+		InterestPaid:    int(breakdown.InterestPaid),
+		UnpaidInterest:  int(breakdown.UnpaidInterest),
+		PrincipalPaid:   int(breakdown.PrincipalPaid),
+	}
+	debt.InterestAccruals = make([]*model.DebtInterestAccrual, len(d.InterestAccruals))
+	for j, a := range d.InterestAccruals {
+		debt.InterestAccruals[j] = debtInterestAccrualToModel(&a)
+	}
+	debt.Overdue = overdueInfoToModel(d.Overdue(time.Now()))
 	return debt
 }
 
+func debtInterestAccrualToModel(a *models.DebtInterestAccrual) *model.DebtInterestAccrual {
+	return &model.DebtInterestAccrual{
+		ID:          a.ID,
+		Period:      a.Period,
+		PeriodStart: a.PeriodStart,
+		PeriodEnd:   a.PeriodEnd,
+		Balance:     int(a.Balance),
+		Amount:      int(a.Amount),
+		CreatedAt:   a.CreatedAt,
+	}
+}
+
 func debtPaymentToModel(p *models.DebtPayment) *model.DebtPayment {
 	return &model.DebtPayment{
 		ID:            p.ID,
@@ -183,6 +333,7 @@ func incomeCategoryToModel(c *models.IncomeCategory) *model.IncomeCategory {
 	return &model.IncomeCategory{
 		ID:        c.ID,
 		Name:      c.Name,
+		ParentID:  c.ParentID,
 		CreatedAt: c.CreatedAt,
 	}
 }
@@ -196,6 +347,7 @@ func incomeToModel(i *models.Income) *model.Income {
 		IsRecurring: i.IsRecurring,
 		Notes:       i.Notes,
 		PocketID:    i.PocketID,
+		PayeeID:     i.PayeeID,
 		CreatedAt:   i.CreatedAt,
 	}
 	if i.Category != nil {
@@ -206,13 +358,23 @@ func incomeToModel(i *models.Income) *model.Income {
 
 func recurringIncomeGroupToModel(g *models.RecurringIncomeGroup) *model.RecurringIncomeGroup {
 	group := &model.RecurringIncomeGroup{
-		ID:           g.ID,
-		Name:         g.Name,
-		RecurringDay: g.RecurringDay,
-		IsActive:     g.IsActive,
-		Notes:        g.Notes,
-		Total:        int(g.Total()),
-		CreatedAt:    g.CreatedAt,
+		ID:                 g.ID,
+		Name:               g.Name,
+		RecurringDay:       g.RecurringDay,
+		IsActive:           g.IsActive,
+		Notes:              g.Notes,
+		AutoPost:           g.AutoPost,
+		TargetPocketID:     g.TargetPocketID,
+		ShiftToBusinessDay: g.ShiftToBusinessDay,
+		LastPostedDate:     g.LastPostedDate,
+		Total:              int(g.Total()),
+		CreatedAt:          g.CreatedAt,
+	}
+	if g.Recurrence.IsSet() {
+		group.Recurrence = recurrenceToModel(&g.Recurrence)
+	}
+	if schedule := g.Schedule(); schedule != nil && g.IsActive {
+		group.NextOccurrence = schedule.Next(g.CreatedAt, nextOccurrenceAfter(g.LastPostedDate))
 	}
 	if len(g.Items) > 0 {
 		items := make([]*model.RecurringIncomeItem, len(g.Items))
@@ -224,6 +386,89 @@ func recurringIncomeGroupToModel(g *models.RecurringIncomeGroup) *model.Recurrin
 	return group
 }
 
+func recurrenceToModel(r *models.Recurrence) *model.Recurrence {
+	rec := &model.Recurrence{
+		Frequency:  model.RecurrenceFrequency(*r.Frequency),
+		Interval:   r.Interval,
+		ByWeekday:  []string{},
+		ByMonthDay: []int{},
+		ByMonth:    []int{},
+		StartDate:  r.StartDate,
+		EndDate:    r.EndDate,
+		Count:      r.Count,
+		Rrule:      r.RRule(),
+	}
+	if weekdays := strings.TrimSpace(r.ByWeekday); weekdays != "" {
+		rec.ByWeekday = strings.Split(weekdays, ",")
+	}
+	if days, err := r.MonthDays(); err == nil && days != nil {
+		rec.ByMonthDay = days
+	}
+	if months, err := r.Months(); err == nil && months != nil {
+		rec.ByMonth = months
+	}
+	return rec
+}
+
+func recurrenceInputFromModel(input *model.RecurrenceInput) *services.RecurrenceInput {
+	if input == nil {
+		return nil
+	}
+	rec := &services.RecurrenceInput{
+		Interval:   input.Interval,
+		ByWeekday:  input.ByWeekday,
+		ByMonthDay: input.ByMonthDay,
+		ByMonth:    input.ByMonth,
+		StartDate:  input.StartDate,
+		EndDate:    input.EndDate,
+		Count:      input.Count,
+		RRule:      input.Rrule,
+	}
+	if input.Frequency != nil {
+		frequency := string(*input.Frequency)
+		rec.Frequency = &frequency
+	}
+	return rec
+}
+
+// nextOccurrenceAfter is the day before the next occurrence may fall: the last
+// posted date, or yesterday when nothing later has been posted
+func nextOccurrenceAfter(lastPosted *time.Time) time.Time {
+	now := time.Now()
+	after := time.Date(now.Year(), now.Month(), now.Day()-1, 0, 0, 0, 0, time.UTC)
+	if lastPosted != nil && lastPosted.After(after) {
+		after = *lastPosted
+	}
+	return after
+}
+
+func recurringIncomeRunToModel(r *models.RecurringIncomeRun) *model.RecurringIncomeRun {
+	run := &model.RecurringIncomeRun{
+		ID:            r.ID,
+		GroupID:       r.GroupID,
+		ScheduledDate: r.ScheduledDate,
+		PostedDate:    r.PostedDate,
+		Status:        model.RecurringIncomeRunStatus(r.Status),
+		IncomeCount:   r.IncomeCount,
+		TotalAmount:   int(r.TotalAmount),
+		Error:         r.Error,
+		CreatedAt:     r.CreatedAt,
+	}
+	if r.Group != nil {
+		run.GroupName = &r.Group.Name
+	}
+	return run
+}
+
+func holidayToModel(h *models.Holiday) *model.Holiday {
+	return &model.Holiday{
+		ID:        h.ID,
+		Date:      h.Date,
+		Name:      h.Name,
+		CreatedAt: h.CreatedAt,
+	}
+}
+
 func recurringIncomeItemToModel(i *models.RecurringIncomeItem) *model.RecurringIncomeItem {
 	item := &model.RecurringIncomeItem{
 		ID:         i.ID,
@@ -364,6 +609,7 @@ func transactionEntryToModel(e *models.TransactionEntry) *model.TransactionEntry
 func dashboardToModel(d *services.Dashboard) *model.Dashboard {
 	dash := &model.Dashboard{
 		TotalActiveDebt:                   int(d.TotalActiveDebt),
+		TotalReceivable:                   int(d.TotalReceivable),
 		TotalActiveInstallment:            int(d.TotalActiveInstallment),
 		TotalExpenseThisMonth:             int(d.TotalExpenseThisMonth),
 		TotalIncomeThisMonth:              int(d.TotalIncomeThisMonth),
@@ -395,6 +641,10 @@ func dashboardToModel(d *services.Dashboard) *model.Dashboard {
 		}
 		dash.RecentExpenses = exps
 	}
+	dash.BudgetProgress = make([]*model.BudgetProgress, len(d.BudgetProgress))
+	for i, p := range d.BudgetProgress {
+		dash.BudgetProgress[i] = budgetProgressToModel(&p)
+	}
 	return dash
 }
 
@@ -412,9 +662,26 @@ func upcomingPaymentsReportToModel(report *services.UpcomingPaymentsReport) *mod
 		}
 	}
 
-	debts := make([]*model.UpcomingDebtPayment, len(report.Debts))
-	for i, debt := range report.Debts {
-		debts[i] = &model.UpcomingDebtPayment{
+	return &model.UpcomingPaymentsReport{
+		Installments:     installments,
+		Debts:            upcomingDebtPaymentsToModel(report.Debts),
+		TotalInstallment: int(report.TotalInstallment),
+		TotalDebt:        int(report.TotalDebt),
+		TotalPayments:    int(report.TotalPayments),
+		Receivables:      upcomingDebtPaymentsToModel(report.Receivables),
+		TotalReceivable:  int(report.TotalReceivable),
+
+		RecurringExpenses:     upcomingRecurringItemsToModel(report.RecurringExpenses),
+		RecurringIncomes:      upcomingRecurringItemsToModel(report.RecurringIncomes),
+		TotalRecurringExpense: int(report.TotalRecurringExpense),
+		TotalRecurringIncome:  int(report.TotalRecurringIncome),
+	}
+}
+
+func upcomingDebtPaymentsToModel(payments []services.UpcomingDebtPayment) []*model.UpcomingDebtPayment {
+	result := make([]*model.UpcomingDebtPayment, len(payments))
+	for i, debt := range payments {
+		result[i] = &model.UpcomingDebtPayment{
 			DebtID:          debt.DebtID.String(),
 			PersonName:      debt.PersonName,
 			MonthlyPayment:  int(debt.MonthlyPayment),
@@ -423,14 +690,20 @@ func upcomingPaymentsReportToModel(report *services.UpcomingPaymentsReport) *mod
 			PaymentType:     debt.PaymentType,
 		}
 	}
+	return result
+}
 
-	return &model.UpcomingPaymentsReport{
-		Installments:     installments,
-		Debts:            debts,
-		TotalInstallment: int(report.TotalInstallment),
-		TotalDebt:        int(report.TotalDebt),
-		TotalPayments:    int(report.TotalPayments),
+func upcomingRecurringItemsToModel(items []services.UpcomingRecurringItem) []*model.UpcomingRecurringItem {
+	result := make([]*model.UpcomingRecurringItem, len(items))
+	for i, item := range items {
+		result[i] = &model.UpcomingRecurringItem{
+			GroupID: item.GroupID.String(),
+			Name:    item.Name,
+			Date:    item.Date.Format("2006-01-02"),
+			Amount:  int(item.Amount),
+		}
 	}
+	return result
 }
 
 func actualPaymentsReportToModel(report *services.ActualPaymentsReport) *model.ActualPaymentsReport {
@@ -465,24 +738,33 @@ func actualPaymentsReportToModel(report *services.ActualPaymentsReport) *model.A
 	}
 }
 
-func calculateExpenseSummary(expenses []models.Expense) *model.ExpenseSummary {
+// calculateExpenseSummary groups expenses by category. When roots is set, child
+// categories are rolled up into their top-level parent.
+func calculateExpenseSummary(expenses []models.Expense, roots map[uuid.UUID]models.Category) *model.ExpenseSummary {
 	var total int64
 	count := len(expenses)
 	categoryMap := make(map[string]*model.ExpenseByCategoryGroup)
 
 	for _, exp := range expenses {
-		total += exp.Total()
+		// A purchase paid by an installment is counted through its payments
+		if exp.InstallmentID == nil {
+			total += exp.NetTotal()
+		}
 
 		// Group by category
 		if exp.Category != nil {
-			catID := exp.Category.ID.String()
+			category := *exp.Category
+			if root, ok := roots[category.ID]; ok {
+				category = root
+			}
+			catID := category.ID.String()
 			if group, exists := categoryMap[catID]; exists {
-				group.TotalAmount += int(exp.Total())
+				group.TotalAmount += int(exp.NetTotal())
 				group.Count++
 			} else {
 				categoryMap[catID] = &model.ExpenseByCategoryGroup{
-					Category:    categoryToModel(exp.Category),
-					TotalAmount: int(exp.Total()),
+					Category:    categoryToModel(&category),
+					TotalAmount: int(exp.NetTotal()),
 					Count:       1,
 				}
 			}
@@ -502,7 +784,9 @@ func calculateExpenseSummary(expenses []models.Expense) *model.ExpenseSummary {
 	}
 }
 
-func calculateIncomeSummary(incomes []models.Income) *model.IncomeSummary {
+// calculateIncomeSummary groups incomes by category. When roots is set, child
+// categories are rolled up into their top-level parent.
+func calculateIncomeSummary(incomes []models.Income, roots map[uuid.UUID]models.IncomeCategory) *model.IncomeSummary {
 	var total int64
 	count := len(incomes)
 	categoryMap := make(map[string]*model.IncomeByCategoryGroup)
@@ -512,13 +796,17 @@ func calculateIncomeSummary(incomes []models.Income) *model.IncomeSummary {
 
 		// Group by category
 		if inc.Category != nil {
-			catID := inc.Category.ID.String()
+			category := *inc.Category
+			if root, ok := roots[category.ID]; ok {
+				category = root
+			}
+			catID := category.ID.String()
 			if group, exists := categoryMap[catID]; exists {
 				group.TotalAmount += int(inc.Amount)
 				group.Count++
 			} else {
 				categoryMap[catID] = &model.IncomeByCategoryGroup{
-					Category:    incomeCategoryToModel(inc.Category),
+					Category:    incomeCategoryToModel(&category),
 					TotalAmount: int(inc.Amount),
 					Count:       1,
 				}
@@ -540,20 +828,26 @@ func calculateIncomeSummary(incomes []models.Income) *model.IncomeSummary {
 }
 
 func savingsGoalToModel(g *models.SavingsGoal) *model.SavingsGoal {
+	forecast := g.Forecast(time.Now())
 	goal := &model.SavingsGoal{
-		ID:              g.ID,
-		Name:            g.Name,
-		TargetAmount:    int(g.TargetAmount),
-		CurrentAmount:   int(g.CurrentAmount),
-		TargetDate:      g.TargetDate,
-		Icon:            g.Icon,
-		CardBgColor:     g.CardBgColor,
-		Status:          model.SavingsGoalStatus(g.Status),
-		Notes:           g.Notes,
-		Progress:        g.Progress(),
-		RemainingAmount: int(g.RemainingAmount()),
-		MonthlyTarget:   int(g.MonthlyTarget()),
-		CreatedAt:       g.CreatedAt,
+		ID:                         g.ID,
+		Name:                       g.Name,
+		TargetAmount:               int(g.TargetAmount),
+		CurrentAmount:              int(g.CurrentAmount),
+		TargetDate:                 g.TargetDate,
+		Icon:                       g.Icon,
+		CardBgColor:                g.CardBgColor,
+		Status:                     model.SavingsGoalStatus(g.Status),
+		Notes:                      g.Notes,
+		Progress:                   g.Progress(),
+		RemainingAmount:            int(g.RemainingAmount()),
+		MonthlyTarget:              int(g.MonthlyTarget()),
+		AverageMonthlyContribution: int(forecast.AverageMonthly),
+		ContributionTrend:          int(forecast.Trend),
+		ProjectedCompletionDate:    forecast.ProjectedCompletionDate,
+		Pace:                       model.SavingsGoalPace(forecast.Pace),
+		RequiredMonthlyAmount:      int(forecast.RequiredMonthly),
+		CreatedAt:                  g.CreatedAt,
 	}
 	if len(g.Contributions) > 0 {
 		contributions := make([]*model.SavingsContribution, len(g.Contributions))
@@ -562,6 +856,13 @@ func savingsGoalToModel(g *models.SavingsGoal) *model.SavingsGoal {
 		}
 		goal.Contributions = contributions
 	}
+	if len(g.Withdrawals) > 0 {
+		withdrawals := make([]*model.SavingsWithdrawal, len(g.Withdrawals))
+		for i, w := range g.Withdrawals {
+			withdrawals[i] = savingsWithdrawalToModel(&w)
+		}
+		goal.Withdrawals = withdrawals
+	}
 	return goal
 }
 
@@ -580,6 +881,78 @@ func savingsContributionToModel(c *models.SavingsContribution) *model.SavingsCon
 	return contribution
 }
 
+func savingsWithdrawalToModel(w *models.SavingsWithdrawal) *model.SavingsWithdrawal {
+	withdrawal := &model.SavingsWithdrawal{
+		ID:             w.ID,
+		Amount:         int(w.Amount),
+		WithdrawalDate: w.WithdrawalDate,
+		ToPocketID:     w.PocketID,
+		Reason:         w.Reason,
+		CreatedAt:      w.CreatedAt,
+	}
+	if w.SavingsGoal != nil {
+		withdrawal.SavingsGoal = savingsGoalToModel(w.SavingsGoal)
+	}
+	return withdrawal
+}
+
+func savingsAutoRuleToModel(r *models.SavingsAutoRule) *model.SavingsAutoRule {
+	rule := &model.SavingsAutoRule{
+		ID:             r.ID,
+		SavingsGoalID:  r.SavingsGoalID,
+		Type:           model.SavingsAutoRuleType(r.Type),
+		Percentage:     r.Percentage,
+		SourcePocketID: r.SourcePocketID,
+		Recurrence:     recurrenceToModel(&r.Recurrence),
+		IsActive:       r.IsActive,
+		PausedReason:   r.PausedReason,
+		LastRunDate:    r.LastRunDate,
+		CreatedAt:      r.CreatedAt,
+	}
+	if r.Amount != nil {
+		amount := int(*r.Amount)
+		rule.Amount = &amount
+	}
+	if r.SourcePocket != nil {
+		rule.SourcePocketName = &r.SourcePocket.Name
+	}
+	if r.IsActive {
+		rule.NextRunDate = r.Recurrence.Next(r.CreatedAt, nextOccurrenceAfter(r.LastRunDate))
+	}
+	return rule
+}
+
+func savingsAutoRuleInputFromModel(input model.SavingsAutoRuleInput) services.SavingsAutoRuleInput {
+	rule := services.SavingsAutoRuleInput{
+		Type:           models.SavingsAutoRuleType(input.Type),
+		Percentage:     input.Percentage,
+		SourcePocketID: input.SourcePocketID,
+		IsActive:       input.IsActive,
+	}
+	if input.Amount != nil {
+		amount := int64(*input.Amount)
+		rule.Amount = &amount
+	}
+	if recurrence := recurrenceInputFromModel(input.Recurrence); recurrence != nil {
+		rule.Recurrence = *recurrence
+	}
+	return rule
+}
+
+func savingsAutoRunToModel(r *models.SavingsAutoRun) *model.SavingsAutoRun {
+	return &model.SavingsAutoRun{
+		ID:             r.ID,
+		RuleID:         r.RuleID,
+		SavingsGoalID:  r.SavingsGoalID,
+		ScheduledDate:  r.ScheduledDate,
+		Status:         model.SavingsAutoRunStatus(r.Status),
+		Amount:         int(r.Amount),
+		ContributionID: r.ContributionID,
+		Reason:         r.Reason,
+		CreatedAt:      r.CreatedAt,
+	}
+}
+
 func incomeBreakdownToIncomeSummary(b *services.IncomeBreakdown) *model.IncomeSummary {
 	byCategory := make([]*model.IncomeByCategoryGroup, len(b.ByCategory))
 	for i, cs := range b.ByCategory {
@@ -633,3 +1006,492 @@ func forecastSummaryToModel(r *services.ForecastSummaryResult) *model.ForecastSu
 		TotalSavingsContribution: int(r.TotalSavingsContribution),
 	}
 }
+
+func payeeToModel(p *models.Payee) *model.Payee {
+	payee := &model.Payee{
+		ID:                p.ID,
+		Name:              p.Name,
+		Aliases:           p.AliasNames(),
+		DefaultCategoryID: p.DefaultCategoryID,
+		DefaultPocketID:   p.DefaultPocketID,
+		Notes:             p.Notes,
+		CreatedAt:         p.CreatedAt,
+		LifetimeSpend:     int(p.LifetimeSpend),
+		TransactionCount:  p.ExpenseCount,
+		MonthlyTrend:      []*model.PayeeMonthlySpending{},
+		LastTransactions:  []*model.Expense{},
+	}
+	if p.DefaultCategory != nil {
+		payee.DefaultCategory = categoryToModel(p.DefaultCategory)
+	}
+	return payee
+}
+
+func payeeDetailToModel(d *services.PayeeDetail) *model.Payee {
+	payee := payeeToModel(d.Payee)
+	payee.MonthlyTrend = make([]*model.PayeeMonthlySpending, len(d.MonthlyTrend))
+	for i, m := range d.MonthlyTrend {
+		payee.MonthlyTrend[i] = &model.PayeeMonthlySpending{
+			Month: m.Month,
+			Total: int(m.Total),
+			Count: m.Count,
+		}
+	}
+	payee.LastTransactions = make([]*model.Expense, len(d.LastTransactions))
+	for i := range d.LastTransactions {
+		payee.LastTransactions[i] = expenseToModel(&d.LastTransactions[i])
+	}
+	return payee
+}
+
+func detectedSubscriptionToModel(c *services.SubscriptionCandidate) *model.DetectedSubscription {
+	sub := &model.DetectedSubscription{
+		Key:              c.Key,
+		Name:             c.Name,
+		Cadence:          model.SubscriptionCadence(c.Cadence),
+		ChargeCount:      c.ChargeCount,
+		AverageAmount:    int(c.AverageAmount),
+		LastAmount:       int(c.LastAmount),
+		LastChargeDate:   c.LastChargeDate,
+		NextExpectedDate: c.NextExpectedDate,
+		AnnualizedCost:   int(c.AnnualizedCost),
+	}
+	if c.Payee != nil {
+		sub.Payee = payeeToModel(c.Payee)
+	}
+	if c.Category != nil {
+		sub.Category = categoryToModel(c.Category)
+	}
+	return sub
+}
+
+func budgetToModel(b *models.Budget) *model.Budget {
+	budget := &model.Budget{
+		ID:              b.ID,
+		CategoryID:      b.CategoryID,
+		Amount:          int(b.Amount),
+		AlertThresholds: b.Thresholds(),
+		CreatedAt:       b.CreatedAt,
+		Overrides:       make([]*model.BudgetOverride, len(b.Overrides)),
+	}
+	if budget.AlertThresholds == nil {
+		budget.AlertThresholds = []int{}
+	}
+	if b.Category != nil {
+		budget.Category = categoryToModel(b.Category)
+	}
+	for i, o := range b.Overrides {
+		budget.Overrides[i] = &model.BudgetOverride{
+			ID:     o.ID,
+			Month:  o.Month,
+			Year:   o.Year,
+			Amount: int(o.Amount),
+		}
+	}
+	return budget
+}
+
+func budgetProgressToModel(p *services.BudgetProgress) *model.BudgetProgress {
+	return &model.BudgetProgress{
+		Budget:         budgetToModel(&p.Budget),
+		Budgeted:       int(p.Budgeted),
+		Spent:          int(p.Spent),
+		Remaining:      int(p.Remaining),
+		Percentage:     p.Percentage,
+		ProjectedSpend: int(p.ProjectedSpend),
+	}
+}
+
+func budgetStatusToModel(r *services.BudgetStatusReport) *model.BudgetStatus {
+	status := &model.BudgetStatus{
+		Month:       r.Month,
+		Year:        r.Year,
+		DaysElapsed: r.DaysElapsed,
+		DaysInMonth: r.DaysInMonth,
+		Items:       make([]*model.BudgetProgress, len(r.Items)),
+	}
+	for i, p := range r.Items {
+		status.Items[i] = budgetProgressToModel(&p)
+	}
+	return status
+}
+
+func envelopeReportToModel(r *services.EnvelopeReport) *model.EnvelopeReport {
+	report := &model.EnvelopeReport{
+		Month:          r.Month,
+		Year:           r.Year,
+		Income:         int(r.Income),
+		PocketBalance:  int(r.PocketBalance),
+		TotalAssigned:  int(r.TotalAssigned),
+		TotalActivity:  int(r.TotalActivity),
+		TotalAvailable: int(r.TotalAvailable),
+		ToBeBudgeted:   int(r.ToBeBudgeted),
+		Envelopes:      make([]*model.Envelope, len(r.Envelopes)),
+	}
+	for i, e := range r.Envelopes {
+		report.Envelopes[i] = &model.Envelope{
+			Category:    categoryToModel(&e.Category),
+			CarriedOver: int(e.CarriedOver),
+			Assigned:    int(e.Assigned),
+			Activity:    int(e.Activity),
+			Available:   int(e.Available),
+		}
+	}
+	return report
+}
+
+func envelopeAssignmentToModel(a *models.EnvelopeAssignment) *model.EnvelopeAssignment {
+	assignment := &model.EnvelopeAssignment{
+		ID:         a.ID,
+		CategoryID: a.CategoryID,
+		Month:      a.Month,
+		Year:       a.Year,
+		Amount:     int(a.Amount),
+		Type:       model.EnvelopeAssignmentType(a.Type),
+		TransferID: a.TransferID,
+		Notes:      a.Notes,
+		CreatedAt:  a.CreatedAt,
+	}
+	if a.Category != nil {
+		assignment.Category = categoryToModel(a.Category)
+	}
+	return assignment
+}
+
+func prepaymentInputFromModel(input model.PrepaymentInput) services.PrepaymentInput {
+	return services.PrepaymentInput{
+		ExtraAmount: int64(input.ExtraAmount),
+		Date:        input.Date,
+		Strategy:    models.PrepaymentStrategy(input.Strategy),
+		PocketID:    input.PocketID,
+	}
+}
+
+func prepaymentResultToModel(r *services.PrepaymentResult) *model.PrepaymentResult {
+	result := &model.PrepaymentResult{
+		Strategy:                model.PrepaymentStrategy(r.Strategy),
+		ExtraAmount:             int(r.ExtraAmount),
+		RemainingBefore:         int(r.RemainingBefore),
+		RemainingAfter:          int(r.RemainingAfter),
+		MonthlyPaymentBefore:    int(r.MonthlyPaymentBefore),
+		MonthlyPaymentAfter:     int(r.MonthlyPaymentAfter),
+		RemainingPaymentsBefore: r.RemainingPaymentsBefore,
+		RemainingPaymentsAfter:  r.RemainingPaymentsAfter,
+		EndDateBefore:           r.EndDateBefore,
+		EndDateAfter:            r.EndDateAfter,
+		InterestSaved:           int(r.InterestSaved),
+		Schedule:                amortizationScheduleToModel(r.Schedule),
+	}
+	if r.Installment != nil {
+		result.Installment = installmentToModel(r.Installment)
+	}
+	if r.Debt != nil {
+		result.Debt = debtToModel(r.Debt)
+	}
+	return result
+}
+
+func payoffPlanToModel(r *services.PayoffPlanResult) *model.PayoffPlan {
+	plan := &model.PayoffPlan{
+		Strategy:                model.PayoffStrategy(r.Strategy),
+		ExtraMonthlyBudget:      int(r.ExtraMonthlyBudget),
+		StartDate:               r.StartDate,
+		DebtFreeDate:            r.DebtFreeDate,
+		MonthsToDebtFree:        r.MonthsToDebtFree,
+		TotalInterest:           int(r.TotalInterest),
+		TotalPaid:               int(r.TotalPaid),
+		MinimumOnlyDebtFreeDate: r.MinimumOnlyDebtFreeDate,
+		MinimumOnlyInterest:     int(r.MinimumOnlyInterest),
+		InterestSaved:           int(r.InterestSaved),
+		Loans:                   make([]*model.PayoffLoan, len(r.Loans)),
+		Months:                  make([]*model.PayoffMonth, len(r.Months)),
+	}
+	for i, l := range r.Loans {
+		plan.Loans[i] = &model.PayoffLoan{
+			ID:             l.ID,
+			Kind:           model.PayoffLoanKind(l.Kind),
+			Name:           l.Name,
+			Balance:        int(l.Balance),
+			AnnualRate:     l.AnnualRate,
+			MinimumPayment: int(l.MinimumPayment),
+			PayoffDate:     l.PayoffDate,
+			TotalInterest:  int(l.TotalInterest),
+			TotalPaid:      int(l.TotalPaid),
+		}
+	}
+	for i, m := range r.Months {
+		month := &model.PayoffMonth{
+			Year:             m.Year,
+			Month:            m.Month,
+			Payment:          int(m.Payment),
+			Interest:         int(m.Interest),
+			RemainingBalance: int(m.RemainingBalance),
+			Allocations:      make([]*model.PayoffAllocation, len(m.Allocations)),
+		}
+		for j, a := range m.Allocations {
+			month.Allocations[j] = &model.PayoffAllocation{
+				LoanID:           a.LoanID,
+				Kind:             model.PayoffLoanKind(a.Kind),
+				Name:             a.Name,
+				Payment:          int(a.Payment),
+				Interest:         int(a.Interest),
+				Extra:            int(a.Extra),
+				RemainingBalance: int(a.RemainingBalance),
+			}
+		}
+		plan.Months[i] = month
+	}
+	return plan
+}
+
+func savedPayoffPlanToModel(p *models.PayoffPlan) *model.SavedPayoffPlan {
+	plan := &model.SavedPayoffPlan{
+		ID:                 p.ID,
+		Name:               p.Name,
+		Strategy:           model.PayoffStrategy(p.Strategy),
+		ExtraMonthlyBudget: int(p.ExtraMonthlyBudget),
+		CustomOrder:        models.LoanIDs(p.CustomOrder),
+		PayoffOrder:        models.LoanIDs(p.PayoffOrder),
+		StartDate:          p.StartDate,
+		DebtFreeDate:       p.DebtFreeDate,
+		TotalInterest:      int(p.TotalInterest),
+		TotalPaid:          int(p.TotalPaid),
+		CreatedAt:          p.CreatedAt,
+		Months:             make([]*model.SavedPayoffPlanMonth, len(p.Months)),
+	}
+	if plan.CustomOrder == nil {
+		plan.CustomOrder = []uuid.UUID{}
+	}
+	if plan.PayoffOrder == nil {
+		plan.PayoffOrder = []uuid.UUID{}
+	}
+	for i, m := range p.Months {
+		plan.Months[i] = &model.SavedPayoffPlanMonth{
+			Year:           m.Year,
+			Month:          m.Month,
+			PlannedPayment: int(m.PlannedPayment),
+			PlannedBalance: int(m.PlannedBalance),
+		}
+	}
+	return plan
+}
+
+func payoffPlanComparisonToModel(c *services.PayoffPlanComparison) *model.PayoffPlanComparison {
+	comparison := &model.PayoffPlanComparison{
+		Plan:                  savedPayoffPlanToModel(c.Plan),
+		Months:                make([]*model.PayoffComparisonMonth, len(c.Months)),
+		CumulativePlanned:     int(c.CumulativePlanned),
+		CumulativeActual:      int(c.CumulativeActual),
+		ExpectedBalance:       int(c.ExpectedBalance),
+		CurrentBalance:        int(c.CurrentBalance),
+		ProjectedDebtFreeDate: c.ProjectedDebtFreeDate,
+		OnTrack:               c.OnTrack,
+	}
+	for i, m := range c.Months {
+		comparison.Months[i] = &model.PayoffComparisonMonth{
+			Year:           m.Year,
+			Month:          m.Month,
+			PlannedPayment: int(m.PlannedPayment),
+			ActualPayment:  int(m.ActualPayment),
+			PlannedBalance: int(m.PlannedBalance),
+		}
+	}
+	return comparison
+}
+
+func overdueInfoToModel(o models.OverdueInfo) *model.OverdueInfo {
+	info := &model.OverdueInfo{
+		Status:            model.OverdueStatus(o.Status),
+		DaysLate:          o.DaysLate,
+		MissedPeriodCount: len(o.MissedPeriods),
+		OverdueAmount:     int(o.OverdueAmount),
+		MissedPeriods:     make([]*model.MissedPeriod, len(o.MissedPeriods)),
+	}
+	for i, p := range o.MissedPeriods {
+		info.MissedPeriods[i] = &model.MissedPeriod{
+			PaymentNumber: p.PaymentNumber,
+			DueDate:       p.DueDate,
+			Amount:        int(p.Amount),
+		}
+	}
+	return info
+}
+
+func overdueLoanToModel(l *services.OverdueLoan) *model.OverdueLoan {
+	return &model.OverdueLoan{
+		LoanID:  l.LoanID,
+		Kind:    model.PayoffLoanKind(l.Kind),
+		Name:    l.Name,
+		Overdue: overdueInfoToModel(l.Overdue),
+	}
+}
+
+func lateFeeRuleToModel(r *models.LateFeeRule) *model.LateFeeRule {
+	rule := &model.LateFeeRule{
+		ID:         r.ID,
+		Name:       r.Name,
+		LoanID:     r.LoanID,
+		FeeType:    model.LateFeeType(r.FeeType),
+		Amount:     int(r.Amount),
+		Percentage: r.Percentage,
+		GraceDays:  r.GraceDays,
+		CategoryID: r.CategoryID,
+		PocketID:   r.PocketID,
+		IsActive:   r.IsActive,
+		CreatedAt:  r.CreatedAt,
+	}
+	if r.LoanKind != nil {
+		kind := model.PayoffLoanKind(*r.LoanKind)
+		rule.LoanKind = &kind
+	}
+	if r.Category != nil {
+		rule.Category = categoryToModel(r.Category)
+	}
+	return rule
+}
+
+func lateFeeChargeToModel(c *models.LateFeeCharge) *model.LateFeeCharge {
+	return &model.LateFeeCharge{
+		ID:            c.ID,
+		RuleID:        c.RuleID,
+		LoanKind:      model.PayoffLoanKind(c.LoanKind),
+		LoanID:        c.LoanID,
+		PaymentNumber: c.PaymentNumber,
+		DueDate:       c.DueDate,
+		Amount:        int(c.Amount),
+		ExpenseID:     c.ExpenseID,
+		CreatedAt:     c.CreatedAt,
+	}
+}
+
+func consolidationInputFromModel(debtIDs, installmentIDs []uuid.UUID, newLoan model.ConsolidationLoanInput) services.ConsolidationInput {
+	loan := services.ConsolidationLoanInput{
+		Kind:          services.PayoffLoanKind(newLoan.Kind),
+		Name:          newLoan.Name,
+		Date:          newLoan.Date,
+		Tenor:         newLoan.Tenor,
+		DueDay:        newLoan.DueDay,
+		StartDate:     newLoan.StartDate,
+		InterestRate:  newLoan.InterestRate,
+		FeeCategoryID: newLoan.FeeCategoryID,
+		Notes:         newLoan.Notes,
+	}
+	if newLoan.MonthlyPayment != nil {
+		v := int64(*newLoan.MonthlyPayment)
+		loan.MonthlyPayment = &v
+	}
+	if newLoan.InterestMethod != nil {
+		loan.InterestMethod = models.InstallmentInterestMethod(*newLoan.InterestMethod)
+	}
+	if newLoan.Fee != nil {
+		loan.Fee = int64(*newLoan.Fee)
+	}
+	return services.ConsolidationInput{
+		DebtIDs:        debtIDs,
+		InstallmentIDs: installmentIDs,
+		NewLoan:        loan,
+	}
+}
+
+func consolidationPreviewToModel(p *services.ConsolidationPreview) *model.ConsolidationPreview {
+	preview := &model.ConsolidationPreview{
+		Loans:             make([]*model.ConsolidatedLoan, len(p.Loans)),
+		PayoffAmount:      int(p.PayoffAmount),
+		Fee:               int(p.Fee),
+		Principal:         int(p.Principal),
+		OldMonthlyPayment: int(p.OldMonthlyPayment),
+		NewMonthlyPayment: int(p.NewMonthlyPayment),
+		OldTotalCost:      int(p.OldTotalCost),
+		NewTotalCost:      int(p.NewTotalCost),
+		CostDifference:    int(p.CostDifference),
+		OldEndDate:        p.OldEndDate,
+		NewEndDate:        p.NewEndDate,
+	}
+	for i, l := range p.Loans {
+		preview.Loans[i] = &model.ConsolidatedLoan{
+			ID:             l.ID,
+			Kind:           model.PayoffLoanKind(l.Kind),
+			Name:           l.Name,
+			PayoffAmount:   int(l.PayoffAmount),
+			MonthlyPayment: int(l.MonthlyPayment),
+			RemainingCost:  int(l.RemainingCost),
+			EndDate:        l.EndDate,
+		}
+	}
+	return preview
+}
+
+func debtConsolidationToModel(r *services.ConsolidationResult) *model.DebtConsolidation {
+	c := r.Consolidation
+	consolidation := &model.DebtConsolidation{
+		ID:                       c.ID,
+		Date:                     c.Date,
+		PayoffAmount:             int(c.PayoffAmount),
+		Fee:                      int(c.Fee),
+		FeeCategoryID:            c.FeeCategoryID,
+		OldTotalCost:             int(c.OldTotalCost),
+		NewTotalCost:             int(c.NewTotalCost),
+		CreatedAt:                c.CreatedAt,
+		ConsolidatedInstallments: make([]*model.Installment, len(r.ConsolidatedInstallments)),
+		ConsolidatedDebts:        make([]*model.Debt, len(r.ConsolidatedDebts)),
+	}
+	if r.Installment != nil {
+		consolidation.Installment = installmentToModel(r.Installment)
+	}
+	if r.Debt != nil {
+		consolidation.Debt = debtToModel(r.Debt)
+	}
+	for i := range r.ConsolidatedInstallments {
+		consolidation.ConsolidatedInstallments[i] = installmentToModel(&r.ConsolidatedInstallments[i])
+	}
+	for i := range r.ConsolidatedDebts {
+		consolidation.ConsolidatedDebts[i] = debtToModel(&r.ConsolidatedDebts[i])
+	}
+	return consolidation
+}
+
+func roundUpSettingsToModel(s *models.RoundUpSetting) *model.RoundUpSettings {
+	settings := &model.RoundUpSettings{
+		IsEnabled:     s.IsEnabled,
+		Increment:     int(s.Increment),
+		SavingsGoalID: s.SavingsGoalID,
+		Mode:          model.RoundUpMode(s.Mode),
+	}
+	if s.SavingsGoal != nil {
+		settings.SavingsGoalName = &s.SavingsGoal.Name
+	}
+	return settings
+}
+
+func roundUpSettingsInputFromModel(input model.UpdateRoundUpSettingsInput) services.UpdateRoundUpSettingsInput {
+	settings := services.UpdateRoundUpSettingsInput{
+		IsEnabled:     input.IsEnabled,
+		SavingsGoalID: input.SavingsGoalID,
+	}
+	if input.Increment != nil {
+		increment := int64(*input.Increment)
+		settings.Increment = &increment
+	}
+	if input.Mode != nil {
+		mode := models.RoundUpMode(*input.Mode)
+		settings.Mode = &mode
+	}
+	return settings
+}
+
+func roundUpSummaryToModel(s *services.RoundUpSummary) *model.RoundUpSummary {
+	byGoal := make([]*model.RoundUpGoalTotal, len(s.ByGoal))
+	for i, g := range s.ByGoal {
+		byGoal[i] = &model.RoundUpGoalTotal{
+			SavingsGoalID:   g.SavingsGoalID,
+			SavingsGoalName: g.SavingsGoalName,
+			TotalSaved:      int(g.TotalSaved),
+		}
+	}
+	return &model.RoundUpSummary{
+		TotalSaved:    int(s.TotalSaved),
+		PendingAmount: int(s.PendingAmount),
+		RoundUpCount:  s.RoundUpCount,
+		ByGoal:        byGoal,
+	}
+}
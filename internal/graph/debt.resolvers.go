@@ -0,0 +1,32 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.87
+
+import (
+	"context"
+	"time"
+
+	"github.com/azzamdhx/moneybro/backend/internal/graph/model"
+	"github.com/azzamdhx/moneybro/backend/internal/middleware"
+	"github.com/azzamdhx/moneybro/backend/internal/utils"
+)
+
+// AccrueDebtInterest is the resolver for the accrueDebtInterest field.
+func (r *mutationResolver) AccrueDebtInterest(ctx context.Context) ([]*model.DebtInterestAccrual, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	accruals, err := r.Services.Debt.AccrueInterestForUser(userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.DebtInterestAccrual, len(accruals))
+	for i, a := range accruals {
+		result[i] = debtInterestAccrualToModel(&a)
+	}
+	return result, nil
+}
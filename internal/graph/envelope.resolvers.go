@@ -0,0 +1,84 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.87
+
+import (
+	"context"
+
+	"github.com/azzamdhx/moneybro/backend/internal/graph/model"
+	"github.com/azzamdhx/moneybro/backend/internal/middleware"
+	"github.com/azzamdhx/moneybro/backend/internal/services"
+	"github.com/azzamdhx/moneybro/backend/internal/utils"
+)
+
+// AssignToEnvelope is the resolver for the assignToEnvelope field.
+func (r *mutationResolver) AssignToEnvelope(ctx context.Context, input model.AssignToEnvelopeInput) (*model.EnvelopeReport, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	report, err := r.Services.Envelope.Assign(userID, services.AssignToEnvelopeInput{
+		CategoryID: input.CategoryID,
+		Month:      input.Month,
+		Year:       input.Year,
+		Amount:     int64(input.Amount),
+		Notes:      input.Notes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return envelopeReportToModel(report), nil
+}
+
+// MoveMoneyBetweenEnvelopes is the resolver for the moveMoneyBetweenEnvelopes field.
+func (r *mutationResolver) MoveMoneyBetweenEnvelopes(ctx context.Context, input model.MoveMoneyBetweenEnvelopesInput) (*model.EnvelopeReport, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	report, err := r.Services.Envelope.Move(userID, services.MoveEnvelopeMoneyInput{
+		FromCategoryID: input.FromCategoryID,
+		ToCategoryID:   input.ToCategoryID,
+		Month:          input.Month,
+		Year:           input.Year,
+		Amount:         int64(input.Amount),
+		Notes:          input.Notes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return envelopeReportToModel(report), nil
+}
+
+// EnvelopeReport is the resolver for the envelopeReport field.
+func (r *queryResolver) EnvelopeReport(ctx context.Context, month int, year int) (*model.EnvelopeReport, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	report, err := r.Services.Envelope.GetReport(userID, month, year)
+	if err != nil {
+		return nil, err
+	}
+	return envelopeReportToModel(report), nil
+}
+
+// EnvelopeAssignments is the resolver for the envelopeAssignments field.
+func (r *queryResolver) EnvelopeAssignments(ctx context.Context, month int, year int) ([]*model.EnvelopeAssignment, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	assignments, err := r.Services.Envelope.GetAssignments(userID, month, year)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.EnvelopeAssignment, len(assignments))
+	for i, a := range assignments {
+		result[i] = envelopeAssignmentToModel(&a)
+	}
+	return result, nil
+}
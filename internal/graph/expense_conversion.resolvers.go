@@ -0,0 +1,42 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.87
+
+import (
+	"context"
+
+	"github.com/azzamdhx/moneybro/backend/internal/graph/model"
+	"github.com/azzamdhx/moneybro/backend/internal/middleware"
+	"github.com/azzamdhx/moneybro/backend/internal/services"
+	"github.com/azzamdhx/moneybro/backend/internal/utils"
+	"github.com/google/uuid"
+)
+
+// ConvertExpenseToInstallment is the resolver for the convertExpenseToInstallment field.
+func (r *mutationResolver) ConvertExpenseToInstallment(ctx context.Context, expenseID uuid.UUID, input model.ConvertExpenseToInstallmentInput) (*model.Installment, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	convertInput := services.ConvertExpenseInput{
+		Tenor:         input.Tenor,
+		DueDay:        input.DueDay,
+		StartDate:     input.StartDate,
+		FeeCategoryID: input.FeeCategoryID,
+	}
+	if input.MonthlyPayment != nil {
+		v := int64(*input.MonthlyPayment)
+		convertInput.MonthlyPayment = &v
+	}
+	if input.Fee != nil {
+		convertInput.Fee = int64(*input.Fee)
+	}
+	installment, err := r.Services.Installment.ConvertExpense(userID, expenseID, convertInput)
+	if err != nil {
+		return nil, err
+	}
+	return installmentToModel(installment), nil
+}
@@ -0,0 +1,55 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.87
+
+import (
+	"context"
+
+	"github.com/azzamdhx/moneybro/backend/internal/graph/model"
+	"github.com/azzamdhx/moneybro/backend/internal/middleware"
+	"github.com/azzamdhx/moneybro/backend/internal/utils"
+	"github.com/google/uuid"
+)
+
+// CreateHoliday is the resolver for the createHoliday field.
+func (r *mutationResolver) CreateHoliday(ctx context.Context, input model.CreateHolidayInput) (*model.Holiday, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	holiday, err := r.Services.Holiday.Create(userID, input.Date, input.Name)
+	if err != nil {
+		return nil, err
+	}
+	return holidayToModel(holiday), nil
+}
+
+// DeleteHoliday is the resolver for the deleteHoliday field.
+func (r *mutationResolver) DeleteHoliday(ctx context.Context, id uuid.UUID) (bool, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return false, utils.UnauthorizedError(ctx)
+	}
+	err := r.Services.Holiday.Delete(userID, id)
+	return err == nil, err
+}
+
+// Holidays is the resolver for the holidays field.
+func (r *queryResolver) Holidays(ctx context.Context, year int) ([]*model.Holiday, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	holidays, err := r.Services.Holiday.GetByYear(userID, year)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.Holiday, len(holidays))
+	for i, h := range holidays {
+		result[i] = holidayToModel(&h)
+	}
+	return result, nil
+}
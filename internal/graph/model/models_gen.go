@@ -64,6 +64,25 @@ type AddSavingsContributionInput struct {
 	PocketID         *uuid.UUID `json:"pocketId,omitempty"`
 }
 
+type AmortizationPeriod struct {
+	PaymentNumber      int        `json:"paymentNumber"`
+	DueDate            time.Time  `json:"dueDate"`
+	Payment            int        `json:"payment"`
+	Principal          int        `json:"principal"`
+	Interest           int        `json:"interest"`
+	RemainingPrincipal int        `json:"remainingPrincipal"`
+	Paid               bool       `json:"paid"`
+	PaidAt             *time.Time `json:"paidAt,omitempty"`
+}
+
+type AssignToEnvelopeInput struct {
+	CategoryID uuid.UUID `json:"categoryId"`
+	Month      int       `json:"month"`
+	Year       int       `json:"year"`
+	Amount     int       `json:"amount"`
+	Notes      *string   `json:"notes,omitempty"`
+}
+
 type AuthPayload struct {
 	Token        *string `json:"token,omitempty"`
 	RefreshToken *string `json:"refreshToken,omitempty"`
@@ -78,9 +97,10 @@ type BalanceBreakdown struct {
 }
 
 type BalanceFilterInput struct {
-	Period    BalancePeriod `json:"period"`
-	StartDate *time.Time    `json:"startDate,omitempty"`
-	EndDate   *time.Time    `json:"endDate,omitempty"`
+	Period           BalancePeriod     `json:"period"`
+	StartDate        *time.Time        `json:"startDate,omitempty"`
+	EndDate          *time.Time        `json:"endDate,omitempty"`
+	CategoryGrouping *CategoryGrouping `json:"categoryGrouping,omitempty"`
 }
 
 type BalanceReport struct {
@@ -104,9 +124,44 @@ type BalanceSummary struct {
 	Status                  BalanceStatus `json:"status"`
 }
 
+type Budget struct {
+	ID              uuid.UUID         `json:"id"`
+	CategoryID      *uuid.UUID        `json:"categoryId,omitempty"`
+	Amount          int               `json:"amount"`
+	AlertThresholds []int             `json:"alertThresholds"`
+	CreatedAt       time.Time         `json:"createdAt"`
+	Category        *Category         `json:"category,omitempty"`
+	Overrides       []*BudgetOverride `json:"overrides"`
+}
+
+type BudgetOverride struct {
+	ID     uuid.UUID `json:"id"`
+	Month  int       `json:"month"`
+	Year   int       `json:"year"`
+	Amount int       `json:"amount"`
+}
+
+type BudgetProgress struct {
+	Budget         *Budget `json:"budget"`
+	Budgeted       int     `json:"budgeted"`
+	Spent          int     `json:"spent"`
+	Remaining      int     `json:"remaining"`
+	Percentage     float64 `json:"percentage"`
+	ProjectedSpend int     `json:"projectedSpend"`
+}
+
+type BudgetStatus struct {
+	Month       int               `json:"month"`
+	Year        int               `json:"year"`
+	DaysElapsed int               `json:"daysElapsed"`
+	DaysInMonth int               `json:"daysInMonth"`
+	Items       []*BudgetProgress `json:"items"`
+}
+
 type Category struct {
 	ID           uuid.UUID  `json:"id"`
 	Name         string     `json:"name"`
+	ParentID     *uuid.UUID `json:"parentId,omitempty"`
 	CreatedAt    time.Time  `json:"createdAt"`
 	Expenses     []*Expense `json:"expenses"`
 	ExpenseCount int        `json:"expenseCount"`
@@ -119,43 +174,114 @@ type CategorySummary struct {
 	ExpenseCount int       `json:"expenseCount"`
 }
 
+type ConsolidatedLoan struct {
+	ID             uuid.UUID      `json:"id"`
+	Kind           PayoffLoanKind `json:"kind"`
+	Name           string         `json:"name"`
+	PayoffAmount   int            `json:"payoffAmount"`
+	MonthlyPayment int            `json:"monthlyPayment"`
+	RemainingCost  int            `json:"remainingCost"`
+	EndDate        *time.Time     `json:"endDate,omitempty"`
+}
+
+type ConsolidationLoanInput struct {
+	Kind           PayoffLoanKind             `json:"kind"`
+	Name           string                     `json:"name"`
+	Date           time.Time                  `json:"date"`
+	Tenor          int                        `json:"tenor"`
+	DueDay         int                        `json:"dueDay"`
+	StartDate      *time.Time                 `json:"startDate,omitempty"`
+	MonthlyPayment *int                       `json:"monthlyPayment,omitempty"`
+	InterestMethod *InstallmentInterestMethod `json:"interestMethod,omitempty"`
+	InterestRate   *float64                   `json:"interestRate,omitempty"`
+	Fee            *int                       `json:"fee,omitempty"`
+	FeeCategoryID  *uuid.UUID                 `json:"feeCategoryId,omitempty"`
+	Notes          *string                    `json:"notes,omitempty"`
+}
+
+type ConsolidationPreview struct {
+	Loans             []*ConsolidatedLoan `json:"loans"`
+	PayoffAmount      int                 `json:"payoffAmount"`
+	Fee               int                 `json:"fee"`
+	Principal         int                 `json:"principal"`
+	OldMonthlyPayment int                 `json:"oldMonthlyPayment"`
+	NewMonthlyPayment int                 `json:"newMonthlyPayment"`
+	OldTotalCost      int                 `json:"oldTotalCost"`
+	NewTotalCost      int                 `json:"newTotalCost"`
+	CostDifference    int                 `json:"costDifference"`
+	OldEndDate        *time.Time          `json:"oldEndDate,omitempty"`
+	NewEndDate        time.Time           `json:"newEndDate"`
+}
+
+type ConvertExpenseToInstallmentInput struct {
+	Tenor          int        `json:"tenor"`
+	MonthlyPayment *int       `json:"monthlyPayment,omitempty"`
+	Fee            *int       `json:"fee,omitempty"`
+	DueDay         int        `json:"dueDay"`
+	StartDate      *time.Time `json:"startDate,omitempty"`
+	FeeCategoryID  *uuid.UUID `json:"feeCategoryId,omitempty"`
+}
+
+type ConvertSubscriptionInput struct {
+	Key              string  `json:"key"`
+	Name             *string `json:"name,omitempty"`
+	AutoPost         *bool   `json:"autoPost,omitempty"`
+	NotifyOnAutoPost *bool   `json:"notifyOnAutoPost,omitempty"`
+}
+
 type CreateAccountInput struct {
 	Name        string      `json:"name"`
 	AccountType AccountType `json:"accountType"`
 }
 
+type CreateBudgetInput struct {
+	CategoryID      *uuid.UUID `json:"categoryId,omitempty"`
+	Amount          int        `json:"amount"`
+	AlertThresholds []int      `json:"alertThresholds,omitempty"`
+}
+
 type CreateCategoryInput struct {
-	Name string `json:"name"`
+	Name     string     `json:"name"`
+	ParentID *uuid.UUID `json:"parentId,omitempty"`
 }
 
 type CreateDebtInput struct {
-	PersonName     string          `json:"personName"`
-	ActualAmount   int             `json:"actualAmount"`
-	LoanAmount     *int            `json:"loanAmount,omitempty"`
-	PaymentType    DebtPaymentType `json:"paymentType"`
-	MonthlyPayment *int            `json:"monthlyPayment,omitempty"`
-	Tenor          *int            `json:"tenor,omitempty"`
-	DueDate        *time.Time      `json:"dueDate,omitempty"`
-	Icon           *string         `json:"icon,omitempty"`
-	CardBgColor    *string         `json:"cardBgColor,omitempty"`
-	Notes          *string         `json:"notes,omitempty"`
+	PersonName          string                   `json:"personName"`
+	ActualAmount        int                      `json:"actualAmount"`
+	LoanAmount          *int                     `json:"loanAmount,omitempty"`
+	PaymentType         DebtPaymentType          `json:"paymentType"`
+	Direction           *DebtDirection           `json:"direction,omitempty"`
+	MonthlyPayment      *int                     `json:"monthlyPayment,omitempty"`
+	Tenor               *int                     `json:"tenor,omitempty"`
+	DueDate             *time.Time               `json:"dueDate,omitempty"`
+	Icon                *string                  `json:"icon,omitempty"`
+	CardBgColor         *string                  `json:"cardBgColor,omitempty"`
+	Notes               *string                  `json:"notes,omitempty"`
+	PayeeID             *uuid.UUID               `json:"payeeId,omitempty"`
+	InterestRate        *float64                 `json:"interestRate,omitempty"`
+	InterestCompounding *DebtInterestCompounding `json:"interestCompounding,omitempty"`
+	InterestStartDate   *time.Time               `json:"interestStartDate,omitempty"`
 }
 
 type CreateExpenseInput struct {
-	CategoryID  uuid.UUID  `json:"categoryId"`
+	CategoryID  *uuid.UUID `json:"categoryId,omitempty"`
 	ItemName    string     `json:"itemName"`
 	UnitPrice   int        `json:"unitPrice"`
 	Quantity    int        `json:"quantity"`
 	Notes       *string    `json:"notes,omitempty"`
 	ExpenseDate *time.Time `json:"expenseDate,omitempty"`
 	PocketID    *uuid.UUID `json:"pocketId,omitempty"`
+	PayeeID     *uuid.UUID `json:"payeeId,omitempty"`
 }
 
 type CreateExpenseTemplateGroupInput struct {
-	Name         string                            `json:"name"`
-	RecurringDay *int                              `json:"recurringDay,omitempty"`
-	Notes        *string                           `json:"notes,omitempty"`
-	Items        []*CreateExpenseTemplateItemInput `json:"items"`
+	Name             string                            `json:"name"`
+	RecurringDay     *int                              `json:"recurringDay,omitempty"`
+	Notes            *string                           `json:"notes,omitempty"`
+	AutoPost         *bool                             `json:"autoPost,omitempty"`
+	NotifyOnAutoPost *bool                             `json:"notifyOnAutoPost,omitempty"`
+	Recurrence       *RecurrenceInput                  `json:"recurrence,omitempty"`
+	Items            []*CreateExpenseTemplateItemInput `json:"items"`
 }
 
 type CreateExpenseTemplateItemInput struct {
@@ -165,8 +291,14 @@ type CreateExpenseTemplateItemInput struct {
 	Quantity   int       `json:"quantity"`
 }
 
+type CreateHolidayInput struct {
+	Date time.Time `json:"date"`
+	Name string    `json:"name"`
+}
+
 type CreateIncomeCategoryInput struct {
-	Name string `json:"name"`
+	Name     string     `json:"name"`
+	ParentID *uuid.UUID `json:"parentId,omitempty"`
 }
 
 type CreateIncomeInput struct {
@@ -177,19 +309,42 @@ type CreateIncomeInput struct {
 	IsRecurring *bool      `json:"isRecurring,omitempty"`
 	Notes       *string    `json:"notes,omitempty"`
 	PocketID    *uuid.UUID `json:"pocketId,omitempty"`
+	PayeeID     *uuid.UUID `json:"payeeId,omitempty"`
 }
 
 type CreateInstallmentInput struct {
-	Name           string    `json:"name"`
-	ActualAmount   int       `json:"actualAmount"`
-	LoanAmount     int       `json:"loanAmount"`
-	MonthlyPayment int       `json:"monthlyPayment"`
-	Tenor          int       `json:"tenor"`
-	StartDate      time.Time `json:"startDate"`
-	DueDay         int       `json:"dueDay"`
-	Icon           *string   `json:"icon,omitempty"`
-	CardBgColor    *string   `json:"cardBgColor,omitempty"`
-	Notes          *string   `json:"notes,omitempty"`
+	Name           string                     `json:"name"`
+	ActualAmount   int                        `json:"actualAmount"`
+	LoanAmount     *int                       `json:"loanAmount,omitempty"`
+	MonthlyPayment *int                       `json:"monthlyPayment,omitempty"`
+	Tenor          int                        `json:"tenor"`
+	StartDate      time.Time                  `json:"startDate"`
+	DueDay         int                        `json:"dueDay"`
+	InterestMethod *InstallmentInterestMethod `json:"interestMethod,omitempty"`
+	InterestRate   *float64                   `json:"interestRate,omitempty"`
+	Icon           *string                    `json:"icon,omitempty"`
+	CardBgColor    *string                    `json:"cardBgColor,omitempty"`
+	Notes          *string                    `json:"notes,omitempty"`
+}
+
+type CreateLateFeeRuleInput struct {
+	Name       string          `json:"name"`
+	LoanKind   *PayoffLoanKind `json:"loanKind,omitempty"`
+	LoanID     *uuid.UUID      `json:"loanId,omitempty"`
+	FeeType    LateFeeType     `json:"feeType"`
+	Amount     *int            `json:"amount,omitempty"`
+	Percentage *float64        `json:"percentage,omitempty"`
+	GraceDays  *int            `json:"graceDays,omitempty"`
+	CategoryID uuid.UUID       `json:"categoryId"`
+	PocketID   *uuid.UUID      `json:"pocketId,omitempty"`
+}
+
+type CreatePayeeInput struct {
+	Name              string     `json:"name"`
+	Aliases           []string   `json:"aliases,omitempty"`
+	DefaultCategoryID *uuid.UUID `json:"defaultCategoryId,omitempty"`
+	DefaultPocketID   *uuid.UUID `json:"defaultPocketId,omitempty"`
+	Notes             *string    `json:"notes,omitempty"`
 }
 
 type CreatePocketInput struct {
@@ -199,11 +354,15 @@ type CreatePocketInput struct {
 }
 
 type CreateRecurringIncomeGroupInput struct {
-	Name         string                            `json:"name"`
-	RecurringDay *int                              `json:"recurringDay,omitempty"`
-	IsActive     *bool                             `json:"isActive,omitempty"`
-	Notes        *string                           `json:"notes,omitempty"`
-	Items        []*CreateRecurringIncomeItemInput `json:"items"`
+	Name               string                            `json:"name"`
+	RecurringDay       *int                              `json:"recurringDay,omitempty"`
+	IsActive           *bool                             `json:"isActive,omitempty"`
+	Notes              *string                           `json:"notes,omitempty"`
+	AutoPost           *bool                             `json:"autoPost,omitempty"`
+	TargetPocketID     *uuid.UUID                        `json:"targetPocketId,omitempty"`
+	ShiftToBusinessDay *bool                             `json:"shiftToBusinessDay,omitempty"`
+	Recurrence         *RecurrenceInput                  `json:"recurrence,omitempty"`
+	Items              []*CreateRecurringIncomeItemInput `json:"items"`
 }
 
 type CreateRecurringIncomeItemInput struct {
@@ -223,6 +382,7 @@ type CreateSavingsGoalInput struct {
 
 type Dashboard struct {
 	TotalActiveDebt                   int                `json:"totalActiveDebt"`
+	TotalReceivable                   int                `json:"totalReceivable"`
 	TotalActiveInstallment            int                `json:"totalActiveInstallment"`
 	TotalExpenseThisMonth             int                `json:"totalExpenseThisMonth"`
 	TotalIncomeThisMonth              int                `json:"totalIncomeThisMonth"`
@@ -231,28 +391,70 @@ type Dashboard struct {
 	ActiveSavingsGoals                []*SavingsGoal     `json:"activeSavingsGoals"`
 	ExpensesByCategory                []*CategorySummary `json:"expensesByCategory"`
 	RecentExpenses                    []*Expense         `json:"recentExpenses"`
+	BudgetProgress                    []*BudgetProgress  `json:"budgetProgress"`
 }
 
 type Debt struct {
-	ID                 uuid.UUID       `json:"id"`
-	PersonName         string          `json:"personName"`
-	ActualAmount       int             `json:"actualAmount"`
-	LoanAmount         *int            `json:"loanAmount,omitempty"`
-	PaymentType        DebtPaymentType `json:"paymentType"`
-	MonthlyPayment     *int            `json:"monthlyPayment,omitempty"`
-	Tenor              *int            `json:"tenor,omitempty"`
-	DueDate            *time.Time      `json:"dueDate,omitempty"`
-	Status             DebtStatus      `json:"status"`
-	Icon               *string         `json:"icon,omitempty"`
-	CardBgColor        *string         `json:"cardBgColor,omitempty"`
-	Notes              *string         `json:"notes,omitempty"`
-	CreatedAt          time.Time       `json:"createdAt"`
-	InterestAmount     *int            `json:"interestAmount,omitempty"`
-	InterestPercentage *float64        `json:"interestPercentage,omitempty"`
-	TotalToPay         int             `json:"totalToPay"`
-	PaidAmount         int             `json:"paidAmount"`
-	RemainingAmount    int             `json:"remainingAmount"`
-	Payments           []*DebtPayment  `json:"payments"`
+	ID                  uuid.UUID                `json:"id"`
+	PersonName          string                   `json:"personName"`
+	ActualAmount        int                      `json:"actualAmount"`
+	LoanAmount          *int                     `json:"loanAmount,omitempty"`
+	PaymentType         DebtPaymentType          `json:"paymentType"`
+	Direction           DebtDirection            `json:"direction"`
+	MonthlyPayment      *int                     `json:"monthlyPayment,omitempty"`
+	Tenor               *int                     `json:"tenor,omitempty"`
+	DueDate             *time.Time               `json:"dueDate,omitempty"`
+	Status              DebtStatus               `json:"status"`
+	Icon                *string                  `json:"icon,omitempty"`
+	CardBgColor         *string                  `json:"cardBgColor,omitempty"`
+	Notes               *string                  `json:"notes,omitempty"`
+	PayeeID             *uuid.UUID               `json:"payeeId,omitempty"`
+	ConsolidationID     *uuid.UUID               `json:"consolidationId,omitempty"`
+	InterestRate        *float64                 `json:"interestRate,omitempty"`
+	InterestCompounding *DebtInterestCompounding `json:"interestCompounding,omitempty"`
+	InterestStartDate   *time.Time               `json:"interestStartDate,omitempty"`
+	CreatedAt           time.Time                `json:"createdAt"`
+	InterestAmount      *int                     `json:"interestAmount,omitempty"`
+	InterestPercentage  *float64                 `json:"interestPercentage,omitempty"`
+	TotalToPay          int                      `json:"totalToPay"`
+	PaidAmount          int                      `json:"paidAmount"`
+	RemainingAmount     int                      `json:"remainingAmount"`
+	InterestBreakdown   *DebtInterestBreakdown   `json:"interestBreakdown"`
+	Payments            []*DebtPayment           `json:"payments"`
+	InterestAccruals    []*DebtInterestAccrual   `json:"interestAccruals"`
+	Overdue             *OverdueInfo             `json:"overdue"`
+}
+
+type DebtConsolidation struct {
+	ID                       uuid.UUID      `json:"id"`
+	Date                     time.Time      `json:"date"`
+	PayoffAmount             int            `json:"payoffAmount"`
+	Fee                      int            `json:"fee"`
+	FeeCategoryID            *uuid.UUID     `json:"feeCategoryId,omitempty"`
+	OldTotalCost             int            `json:"oldTotalCost"`
+	NewTotalCost             int            `json:"newTotalCost"`
+	CreatedAt                time.Time      `json:"createdAt"`
+	Installment              *Installment   `json:"installment,omitempty"`
+	Debt                     *Debt          `json:"debt,omitempty"`
+	ConsolidatedInstallments []*Installment `json:"consolidatedInstallments"`
+	ConsolidatedDebts        []*Debt        `json:"consolidatedDebts"`
+}
+
+type DebtInterestAccrual struct {
+	ID          uuid.UUID `json:"id"`
+	Period      string    `json:"period"`
+	PeriodStart time.Time `json:"periodStart"`
+	PeriodEnd   time.Time `json:"periodEnd"`
+	Balance     int       `json:"balance"`
+	Amount      int       `json:"amount"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type DebtInterestBreakdown struct {
+	AccruedInterest int `json:"accruedInterest"`
+	InterestPaid    int `json:"interestPaid"`
+	UnpaidInterest  int `json:"unpaidInterest"`
+	PrincipalPaid   int `json:"principalPaid"`
 }
 
 type DebtPayment struct {
@@ -269,17 +471,69 @@ type DeleteAccountInput struct {
 	Password string `json:"password"`
 }
 
+type DetectedSubscription struct {
+	Key              string              `json:"key"`
+	Name             string              `json:"name"`
+	Cadence          SubscriptionCadence `json:"cadence"`
+	ChargeCount      int                 `json:"chargeCount"`
+	AverageAmount    int                 `json:"averageAmount"`
+	LastAmount       int                 `json:"lastAmount"`
+	LastChargeDate   time.Time           `json:"lastChargeDate"`
+	NextExpectedDate time.Time           `json:"nextExpectedDate"`
+	AnnualizedCost   int                 `json:"annualizedCost"`
+	Payee            *Payee              `json:"payee,omitempty"`
+	Category         *Category           `json:"category,omitempty"`
+}
+
+type Envelope struct {
+	Category    *Category `json:"category"`
+	CarriedOver int       `json:"carriedOver"`
+	Assigned    int       `json:"assigned"`
+	Activity    int       `json:"activity"`
+	Available   int       `json:"available"`
+}
+
+type EnvelopeAssignment struct {
+	ID         uuid.UUID              `json:"id"`
+	CategoryID uuid.UUID              `json:"categoryId"`
+	Month      int                    `json:"month"`
+	Year       int                    `json:"year"`
+	Amount     int                    `json:"amount"`
+	Type       EnvelopeAssignmentType `json:"type"`
+	TransferID *uuid.UUID             `json:"transferId,omitempty"`
+	Notes      *string                `json:"notes,omitempty"`
+	CreatedAt  time.Time              `json:"createdAt"`
+	Category   *Category              `json:"category,omitempty"`
+}
+
+type EnvelopeReport struct {
+	Month          int         `json:"month"`
+	Year           int         `json:"year"`
+	Income         int         `json:"income"`
+	PocketBalance  int         `json:"pocketBalance"`
+	TotalAssigned  int         `json:"totalAssigned"`
+	TotalActivity  int         `json:"totalActivity"`
+	TotalAvailable int         `json:"totalAvailable"`
+	ToBeBudgeted   int         `json:"toBeBudgeted"`
+	Envelopes      []*Envelope `json:"envelopes"`
+}
+
 type Expense struct {
-	ID          uuid.UUID  `json:"id"`
-	ItemName    string     `json:"itemName"`
-	UnitPrice   int        `json:"unitPrice"`
-	Quantity    int        `json:"quantity"`
-	Total       int        `json:"total"`
-	Notes       *string    `json:"notes,omitempty"`
-	ExpenseDate *time.Time `json:"expenseDate,omitempty"`
-	PocketID    *uuid.UUID `json:"pocketId,omitempty"`
-	CreatedAt   time.Time  `json:"createdAt"`
-	Category    *Category  `json:"category"`
+	ID             uuid.UUID        `json:"id"`
+	ItemName       string           `json:"itemName"`
+	UnitPrice      int              `json:"unitPrice"`
+	Quantity       int              `json:"quantity"`
+	Total          int              `json:"total"`
+	RefundedAmount int              `json:"refundedAmount"`
+	NetTotal       int              `json:"netTotal"`
+	Notes          *string          `json:"notes,omitempty"`
+	ExpenseDate    *time.Time       `json:"expenseDate,omitempty"`
+	PocketID       *uuid.UUID       `json:"pocketId,omitempty"`
+	PayeeID        *uuid.UUID       `json:"payeeId,omitempty"`
+	InstallmentID  *uuid.UUID       `json:"installmentId,omitempty"`
+	CreatedAt      time.Time        `json:"createdAt"`
+	Category       *Category        `json:"category"`
+	Refunds        []*ExpenseRefund `json:"refunds"`
 }
 
 type ExpenseBreakdown struct {
@@ -295,9 +549,20 @@ type ExpenseByCategoryGroup struct {
 }
 
 type ExpenseFilter struct {
-	CategoryID *uuid.UUID `json:"categoryId,omitempty"`
-	StartDate  *time.Time `json:"startDate,omitempty"`
-	EndDate    *time.Time `json:"endDate,omitempty"`
+	CategoryID       *uuid.UUID        `json:"categoryId,omitempty"`
+	PayeeID          *uuid.UUID        `json:"payeeId,omitempty"`
+	StartDate        *time.Time        `json:"startDate,omitempty"`
+	EndDate          *time.Time        `json:"endDate,omitempty"`
+	CategoryGrouping *CategoryGrouping `json:"categoryGrouping,omitempty"`
+}
+
+type ExpenseRefund struct {
+	ID         uuid.UUID  `json:"id"`
+	Amount     int        `json:"amount"`
+	RefundDate time.Time  `json:"refundDate"`
+	PocketID   *uuid.UUID `json:"pocketId,omitempty"`
+	Notes      *string    `json:"notes,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
 }
 
 type ExpenseSummary struct {
@@ -307,13 +572,18 @@ type ExpenseSummary struct {
 }
 
 type ExpenseTemplateGroup struct {
-	ID           uuid.UUID              `json:"id"`
-	Name         string                 `json:"name"`
-	RecurringDay *int                   `json:"recurringDay,omitempty"`
-	Notes        *string                `json:"notes,omitempty"`
-	Total        int                    `json:"total"`
-	CreatedAt    time.Time              `json:"createdAt"`
-	Items        []*ExpenseTemplateItem `json:"items"`
+	ID               uuid.UUID              `json:"id"`
+	Name             string                 `json:"name"`
+	RecurringDay     *int                   `json:"recurringDay,omitempty"`
+	Notes            *string                `json:"notes,omitempty"`
+	AutoPost         bool                   `json:"autoPost"`
+	NotifyOnAutoPost bool                   `json:"notifyOnAutoPost"`
+	Recurrence       *Recurrence            `json:"recurrence,omitempty"`
+	NextOccurrence   *time.Time             `json:"nextOccurrence,omitempty"`
+	LastPostedDate   *time.Time             `json:"lastPostedDate,omitempty"`
+	Total            int                    `json:"total"`
+	CreatedAt        time.Time              `json:"createdAt"`
+	Items            []*ExpenseTemplateItem `json:"items"`
 }
 
 type ExpenseTemplateItem struct {
@@ -353,6 +623,13 @@ type HistorySummary struct {
 	TotalSavingsContribution int                   `json:"totalSavingsContribution"`
 }
 
+type Holiday struct {
+	ID        uuid.UUID `json:"id"`
+	Date      time.Time `json:"date"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
 type Income struct {
 	ID          uuid.UUID       `json:"id"`
 	SourceName  string          `json:"sourceName"`
@@ -361,6 +638,7 @@ type Income struct {
 	IsRecurring bool            `json:"isRecurring"`
 	Notes       *string         `json:"notes,omitempty"`
 	PocketID    *uuid.UUID      `json:"pocketId,omitempty"`
+	PayeeID     *uuid.UUID      `json:"payeeId,omitempty"`
 	CreatedAt   time.Time       `json:"createdAt"`
 	Category    *IncomeCategory `json:"category"`
 }
@@ -378,12 +656,13 @@ type IncomeByCategoryGroup struct {
 }
 
 type IncomeCategory struct {
-	ID          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	CreatedAt   time.Time `json:"createdAt"`
-	Incomes     []*Income `json:"incomes"`
-	IncomeCount int       `json:"incomeCount"`
-	TotalIncome int       `json:"totalIncome"`
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	ParentID    *uuid.UUID `json:"parentId,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	Incomes     []*Income  `json:"incomes"`
+	IncomeCount int        `json:"incomeCount"`
+	TotalIncome int        `json:"totalIncome"`
 }
 
 type IncomeCategorySummary struct {
@@ -393,9 +672,10 @@ type IncomeCategorySummary struct {
 }
 
 type IncomeFilter struct {
-	CategoryID *uuid.UUID `json:"categoryId,omitempty"`
-	StartDate  *time.Time `json:"startDate,omitempty"`
-	EndDate    *time.Time `json:"endDate,omitempty"`
+	CategoryID       *uuid.UUID        `json:"categoryId,omitempty"`
+	StartDate        *time.Time        `json:"startDate,omitempty"`
+	EndDate          *time.Time        `json:"endDate,omitempty"`
+	CategoryGrouping *CategoryGrouping `json:"categoryGrouping,omitempty"`
 }
 
 type IncomeSummary struct {
@@ -410,25 +690,33 @@ type IncomesWithSummary struct {
 }
 
 type Installment struct {
-	ID                 uuid.UUID             `json:"id"`
-	Name               string                `json:"name"`
-	ActualAmount       int                   `json:"actualAmount"`
-	LoanAmount         int                   `json:"loanAmount"`
-	MonthlyPayment     int                   `json:"monthlyPayment"`
-	Tenor              int                   `json:"tenor"`
-	StartDate          time.Time             `json:"startDate"`
-	DueDay             int                   `json:"dueDay"`
-	Status             InstallmentStatus     `json:"status"`
-	Icon               *string               `json:"icon,omitempty"`
-	CardBgColor        *string               `json:"cardBgColor,omitempty"`
-	Notes              *string               `json:"notes,omitempty"`
-	CreatedAt          time.Time             `json:"createdAt"`
-	InterestAmount     int                   `json:"interestAmount"`
-	InterestPercentage float64               `json:"interestPercentage"`
-	PaidCount          int                   `json:"paidCount"`
-	RemainingPayments  int                   `json:"remainingPayments"`
-	RemainingAmount    int                   `json:"remainingAmount"`
-	Payments           []*InstallmentPayment `json:"payments"`
+	ID                   uuid.UUID                 `json:"id"`
+	Name                 string                    `json:"name"`
+	ActualAmount         int                       `json:"actualAmount"`
+	LoanAmount           int                       `json:"loanAmount"`
+	MonthlyPayment       int                       `json:"monthlyPayment"`
+	Tenor                int                       `json:"tenor"`
+	StartDate            time.Time                 `json:"startDate"`
+	DueDay               int                       `json:"dueDay"`
+	InterestMethod       InstallmentInterestMethod `json:"interestMethod"`
+	InterestRate         *float64                  `json:"interestRate,omitempty"`
+	Status               InstallmentStatus         `json:"status"`
+	Icon                 *string                   `json:"icon,omitempty"`
+	CardBgColor          *string                   `json:"cardBgColor,omitempty"`
+	Notes                *string                   `json:"notes,omitempty"`
+	ExpenseID            *uuid.UUID                `json:"expenseId,omitempty"`
+	ConsolidationID      *uuid.UUID                `json:"consolidationId,omitempty"`
+	CreatedAt            time.Time                 `json:"createdAt"`
+	InterestAmount       int                       `json:"interestAmount"`
+	InterestPercentage   float64                   `json:"interestPercentage"`
+	PaidCount            int                       `json:"paidCount"`
+	RemainingPayments    int                       `json:"remainingPayments"`
+	RemainingAmount      int                       `json:"remainingAmount"`
+	Payments             []*InstallmentPayment     `json:"payments"`
+	Prepayments          []*InstallmentPrepayment  `json:"prepayments"`
+	TermHistory          []*InstallmentTerm        `json:"termHistory"`
+	AmortizationSchedule []*AmortizationPeriod     `json:"amortizationSchedule"`
+	Overdue              *OverdueInfo              `json:"overdue"`
 }
 
 type InstallmentPayment struct {
@@ -441,6 +729,63 @@ type InstallmentPayment struct {
 	Installment   *Installment `json:"installment"`
 }
 
+type InstallmentPrepayment struct {
+	ID                 uuid.UUID          `json:"id"`
+	Amount             int                `json:"amount"`
+	PaidAt             time.Time          `json:"paidAt"`
+	AfterPaymentNumber int                `json:"afterPaymentNumber"`
+	Strategy           PrepaymentStrategy `json:"strategy"`
+	PocketID           *uuid.UUID         `json:"pocketId,omitempty"`
+	CreatedAt          time.Time          `json:"createdAt"`
+}
+
+type InstallmentTerm struct {
+	ID                 *uuid.UUID                `json:"id,omitempty"`
+	Version            int                       `json:"version"`
+	EffectiveDate      time.Time                 `json:"effectiveDate"`
+	AfterPaymentNumber int                       `json:"afterPaymentNumber"`
+	Principal          int                       `json:"principal"`
+	Tenor              int                       `json:"tenor"`
+	MonthlyPayment     int                       `json:"monthlyPayment"`
+	LoanAmount         int                       `json:"loanAmount"`
+	InterestMethod     InstallmentInterestMethod `json:"interestMethod"`
+	InterestRate       *float64                  `json:"interestRate,omitempty"`
+	DueDay             int                       `json:"dueDay"`
+	Fee                int                       `json:"fee"`
+	InterestAdjustment int                       `json:"interestAdjustment"`
+	CategoryID         *uuid.UUID                `json:"categoryId,omitempty"`
+	Notes              *string                   `json:"notes,omitempty"`
+	CreatedAt          time.Time                 `json:"createdAt"`
+}
+
+type LateFeeCharge struct {
+	ID            uuid.UUID      `json:"id"`
+	RuleID        uuid.UUID      `json:"ruleId"`
+	LoanKind      PayoffLoanKind `json:"loanKind"`
+	LoanID        uuid.UUID      `json:"loanId"`
+	PaymentNumber int            `json:"paymentNumber"`
+	DueDate       time.Time      `json:"dueDate"`
+	Amount        int            `json:"amount"`
+	ExpenseID     *uuid.UUID     `json:"expenseId,omitempty"`
+	CreatedAt     time.Time      `json:"createdAt"`
+}
+
+type LateFeeRule struct {
+	ID         uuid.UUID       `json:"id"`
+	Name       string          `json:"name"`
+	LoanKind   *PayoffLoanKind `json:"loanKind,omitempty"`
+	LoanID     *uuid.UUID      `json:"loanId,omitempty"`
+	FeeType    LateFeeType     `json:"feeType"`
+	Amount     int             `json:"amount"`
+	Percentage float64         `json:"percentage"`
+	GraceDays  int             `json:"graceDays"`
+	CategoryID uuid.UUID       `json:"categoryId"`
+	Category   *Category       `json:"category,omitempty"`
+	PocketID   *uuid.UUID      `json:"pocketId,omitempty"`
+	IsActive   bool            `json:"isActive"`
+	CreatedAt  time.Time       `json:"createdAt"`
+}
+
 type LedgerSummary struct {
 	TotalAssets      int `json:"totalAssets"`
 	TotalLiabilities int `json:"totalLiabilities"`
@@ -454,11 +799,26 @@ type LoginInput struct {
 	Password string `json:"password"`
 }
 
+type MissedPeriod struct {
+	PaymentNumber int       `json:"paymentNumber"`
+	DueDate       time.Time `json:"dueDate"`
+	Amount        int       `json:"amount"`
+}
+
 type MonthYearInput struct {
 	Month int `json:"month"`
 	Year  int `json:"year"`
 }
 
+type MoveMoneyBetweenEnvelopesInput struct {
+	FromCategoryID uuid.UUID `json:"fromCategoryId"`
+	ToCategoryID   uuid.UUID `json:"toCategoryId"`
+	Month          int       `json:"month"`
+	Year           int       `json:"year"`
+	Amount         int       `json:"amount"`
+	Notes          *string   `json:"notes,omitempty"`
+}
+
 type Mutation struct {
 }
 
@@ -471,6 +831,113 @@ type NotificationLog struct {
 	CreatedAt    time.Time `json:"createdAt"`
 }
 
+type OverdueInfo struct {
+	Status            OverdueStatus   `json:"status"`
+	DaysLate          int             `json:"daysLate"`
+	MissedPeriodCount int             `json:"missedPeriodCount"`
+	OverdueAmount     int             `json:"overdueAmount"`
+	MissedPeriods     []*MissedPeriod `json:"missedPeriods"`
+}
+
+type OverdueLoan struct {
+	LoanID  uuid.UUID      `json:"loanId"`
+	Kind    PayoffLoanKind `json:"kind"`
+	Name    string         `json:"name"`
+	Overdue *OverdueInfo   `json:"overdue"`
+}
+
+type Payee struct {
+	ID                uuid.UUID               `json:"id"`
+	Name              string                  `json:"name"`
+	Aliases           []string                `json:"aliases"`
+	DefaultCategoryID *uuid.UUID              `json:"defaultCategoryId,omitempty"`
+	DefaultPocketID   *uuid.UUID              `json:"defaultPocketId,omitempty"`
+	Notes             *string                 `json:"notes,omitempty"`
+	CreatedAt         time.Time               `json:"createdAt"`
+	LifetimeSpend     int                     `json:"lifetimeSpend"`
+	TransactionCount  int                     `json:"transactionCount"`
+	MonthlyTrend      []*PayeeMonthlySpending `json:"monthlyTrend"`
+	LastTransactions  []*Expense              `json:"lastTransactions"`
+	DefaultCategory   *Category               `json:"defaultCategory,omitempty"`
+}
+
+type PayeeMonthlySpending struct {
+	Month string `json:"month"`
+	Total int    `json:"total"`
+	Count int    `json:"count"`
+}
+
+type PayoffAllocation struct {
+	LoanID           uuid.UUID      `json:"loanId"`
+	Kind             PayoffLoanKind `json:"kind"`
+	Name             string         `json:"name"`
+	Payment          int            `json:"payment"`
+	Interest         int            `json:"interest"`
+	Extra            int            `json:"extra"`
+	RemainingBalance int            `json:"remainingBalance"`
+}
+
+type PayoffComparisonMonth struct {
+	Year           int `json:"year"`
+	Month          int `json:"month"`
+	PlannedPayment int `json:"plannedPayment"`
+	ActualPayment  int `json:"actualPayment"`
+	PlannedBalance int `json:"plannedBalance"`
+}
+
+type PayoffLoan struct {
+	ID             uuid.UUID      `json:"id"`
+	Kind           PayoffLoanKind `json:"kind"`
+	Name           string         `json:"name"`
+	Balance        int            `json:"balance"`
+	AnnualRate     float64        `json:"annualRate"`
+	MinimumPayment int            `json:"minimumPayment"`
+	PayoffDate     *time.Time     `json:"payoffDate,omitempty"`
+	TotalInterest  int            `json:"totalInterest"`
+	TotalPaid      int            `json:"totalPaid"`
+}
+
+type PayoffMonth struct {
+	Year             int                 `json:"year"`
+	Month            int                 `json:"month"`
+	Payment          int                 `json:"payment"`
+	Interest         int                 `json:"interest"`
+	RemainingBalance int                 `json:"remainingBalance"`
+	Allocations      []*PayoffAllocation `json:"allocations"`
+}
+
+type PayoffPlan struct {
+	Strategy                PayoffStrategy `json:"strategy"`
+	ExtraMonthlyBudget      int            `json:"extraMonthlyBudget"`
+	StartDate               time.Time      `json:"startDate"`
+	DebtFreeDate            *time.Time     `json:"debtFreeDate,omitempty"`
+	MonthsToDebtFree        int            `json:"monthsToDebtFree"`
+	TotalInterest           int            `json:"totalInterest"`
+	TotalPaid               int            `json:"totalPaid"`
+	MinimumOnlyDebtFreeDate *time.Time     `json:"minimumOnlyDebtFreeDate,omitempty"`
+	MinimumOnlyInterest     int            `json:"minimumOnlyInterest"`
+	InterestSaved           int            `json:"interestSaved"`
+	Loans                   []*PayoffLoan  `json:"loans"`
+	Months                  []*PayoffMonth `json:"months"`
+}
+
+type PayoffPlanComparison struct {
+	Plan                  *SavedPayoffPlan         `json:"plan"`
+	Months                []*PayoffComparisonMonth `json:"months"`
+	CumulativePlanned     int                      `json:"cumulativePlanned"`
+	CumulativeActual      int                      `json:"cumulativeActual"`
+	ExpectedBalance       int                      `json:"expectedBalance"`
+	CurrentBalance        int                      `json:"currentBalance"`
+	ProjectedDebtFreeDate *time.Time               `json:"projectedDebtFreeDate,omitempty"`
+	OnTrack               bool                     `json:"onTrack"`
+}
+
+type PayoffPlanInput struct {
+	ExtraMonthlyBudget int            `json:"extraMonthlyBudget"`
+	Strategy           PayoffStrategy `json:"strategy"`
+	CustomOrder        []uuid.UUID    `json:"customOrder,omitempty"`
+}
+
 type PocketEntry struct {
 	ID              string    `json:"id"`
 	TransactionDate time.Time `json:"transactionDate"`
@@ -480,6 +947,32 @@ type PocketEntry struct {
 	ReferenceType   *string   `json:"referenceType,omitempty"`
 }
 
+type PrepaymentInput struct {
+	InstallmentID *uuid.UUID         `json:"installmentId,omitempty"`
+	DebtID        *uuid.UUID         `json:"debtId,omitempty"`
+	ExtraAmount   int                `json:"extraAmount"`
+	Date          time.Time          `json:"date"`
+	Strategy      PrepaymentStrategy `json:"strategy"`
+	PocketID      *uuid.UUID         `json:"pocketId,omitempty"`
+}
+
+type PrepaymentResult struct {
+	Strategy                PrepaymentStrategy    `json:"strategy"`
+	ExtraAmount             int                   `json:"extraAmount"`
+	RemainingBefore         int                   `json:"remainingBefore"`
+	RemainingAfter          int                   `json:"remainingAfter"`
+	MonthlyPaymentBefore    int                   `json:"monthlyPaymentBefore"`
+	MonthlyPaymentAfter     int                   `json:"monthlyPaymentAfter"`
+	RemainingPaymentsBefore int                   `json:"remainingPaymentsBefore"`
+	RemainingPaymentsAfter  int                   `json:"remainingPaymentsAfter"`
+	EndDateBefore           *time.Time            `json:"endDateBefore,omitempty"`
+	EndDateAfter            *time.Time            `json:"endDateAfter,omitempty"`
+	InterestSaved           int                   `json:"interestSaved"`
+	Schedule                []*AmortizationPeriod `json:"schedule"`
+	Installment             *Installment          `json:"installment,omitempty"`
+	Debt                    *Debt                 `json:"debt,omitempty"`
+}
+
 type Query struct {
 }
 
@@ -497,15 +990,45 @@ type RecordInstallmentPaymentInput struct {
 	PocketID      *uuid.UUID `json:"pocketId,omitempty"`
 }
 
+type Recurrence struct {
+	Frequency  RecurrenceFrequency `json:"frequency"`
+	Interval   int                 `json:"interval"`
+	ByWeekday  []string            `json:"byWeekday"`
+	ByMonthDay []int               `json:"byMonthDay"`
+	ByMonth    []int               `json:"byMonth"`
+	StartDate  *time.Time          `json:"startDate,omitempty"`
+	EndDate    *time.Time          `json:"endDate,omitempty"`
+	Count      *int                `json:"count,omitempty"`
+	Rrule      string              `json:"rrule"`
+}
+
+type RecurrenceInput struct {
+	Frequency  *RecurrenceFrequency `json:"frequency,omitempty"`
+	Interval   *int                 `json:"interval,omitempty"`
+	ByWeekday  []string             `json:"byWeekday,omitempty"`
+	ByMonthDay []int                `json:"byMonthDay,omitempty"`
+	ByMonth    []int                `json:"byMonth,omitempty"`
+	StartDate  *time.Time           `json:"startDate,omitempty"`
+	EndDate    *time.Time           `json:"endDate,omitempty"`
+	Count      *int                 `json:"count,omitempty"`
+	Rrule      *string              `json:"rrule,omitempty"`
+}
+
 type RecurringIncomeGroup struct {
-	ID           uuid.UUID              `json:"id"`
-	Name         string                 `json:"name"`
-	RecurringDay *int                   `json:"recurringDay,omitempty"`
-	IsActive     bool                   `json:"isActive"`
-	Notes        *string                `json:"notes,omitempty"`
-	Total        int                    `json:"total"`
-	CreatedAt    time.Time              `json:"createdAt"`
-	Items        []*RecurringIncomeItem `json:"items"`
+	ID                 uuid.UUID              `json:"id"`
+	Name               string                 `json:"name"`
+	RecurringDay       *int                   `json:"recurringDay,omitempty"`
+	IsActive           bool                   `json:"isActive"`
+	Notes              *string                `json:"notes,omitempty"`
+	AutoPost           bool                   `json:"autoPost"`
+	TargetPocketID     *uuid.UUID             `json:"targetPocketId,omitempty"`
+	ShiftToBusinessDay bool                   `json:"shiftToBusinessDay"`
+	Recurrence         *Recurrence            `json:"recurrence,omitempty"`
+	NextOccurrence     *time.Time             `json:"nextOccurrence,omitempty"`
+	LastPostedDate     *time.Time             `json:"lastPostedDate,omitempty"`
+	Total              int                    `json:"total"`
+	CreatedAt          time.Time              `json:"createdAt"`
+	Items              []*RecurringIncomeItem `json:"items"`
 }
 
 type RecurringIncomeItem struct {
@@ -516,6 +1039,27 @@ type RecurringIncomeItem struct {
 	Category   *IncomeCategory `json:"category"`
 }
 
+type RecurringIncomeRun struct {
+	ID            uuid.UUID                `json:"id"`
+	GroupID       uuid.UUID                `json:"groupId"`
+	GroupName     *string                  `json:"groupName,omitempty"`
+	ScheduledDate time.Time                `json:"scheduledDate"`
+	PostedDate    time.Time                `json:"postedDate"`
+	Status        RecurringIncomeRunStatus `json:"status"`
+	IncomeCount   int                      `json:"incomeCount"`
+	TotalAmount   int                      `json:"totalAmount"`
+	Error         *string                  `json:"error,omitempty"`
+	CreatedAt     time.Time                `json:"createdAt"`
+}
+
+type RefundExpenseInput struct {
+	ExpenseID uuid.UUID  `json:"expenseId"`
+	Amount    int        `json:"amount"`
+	Date      time.Time  `json:"date"`
+	PocketID  *uuid.UUID `json:"pocketId,omitempty"`
+	Notes     *string    `json:"notes,omitempty"`
+}
+
 type RegisterInput struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
@@ -527,6 +1071,105 @@ type ResetPasswordInput struct {
 	Password string `json:"password"`
 }
 
+type RestructureInstallmentInput struct {
+	Tenor              int                        `json:"tenor"`
+	MonthlyPayment     *int                       `json:"monthlyPayment,omitempty"`
+	InterestMethod     *InstallmentInterestMethod `json:"interestMethod,omitempty"`
+	InterestRate       *float64                   `json:"interestRate,omitempty"`
+	DueDay             *int                       `json:"dueDay,omitempty"`
+	Fee                *int                       `json:"fee,omitempty"`
+	InterestAdjustment *int                       `json:"interestAdjustment,omitempty"`
+	CategoryID         *uuid.UUID                 `json:"categoryId,omitempty"`
+	Notes              *string                    `json:"notes,omitempty"`
+}
+
+type RoundUpGoalTotal struct {
+	SavingsGoalID   uuid.UUID `json:"savingsGoalId"`
+	SavingsGoalName string    `json:"savingsGoalName"`
+	TotalSaved      int       `json:"totalSaved"`
+}
+
+type RoundUpSettings struct {
+	IsEnabled       bool        `json:"isEnabled"`
+	Increment       int         `json:"increment"`
+	SavingsGoalID   *uuid.UUID  `json:"savingsGoalId,omitempty"`
+	SavingsGoalName *string     `json:"savingsGoalName,omitempty"`
+	Mode            RoundUpMode `json:"mode"`
+}
+
+type RoundUpSummary struct {
+	TotalSaved    int                 `json:"totalSaved"`
+	PendingAmount int                 `json:"pendingAmount"`
+	RoundUpCount  int                 `json:"roundUpCount"`
+	ByGoal        []*RoundUpGoalTotal `json:"byGoal"`
+}
+
+type SavePayoffPlanInput struct {
+	Name               string         `json:"name"`
+	ExtraMonthlyBudget int            `json:"extraMonthlyBudget"`
+	Strategy           PayoffStrategy `json:"strategy"`
+	CustomOrder        []uuid.UUID    `json:"customOrder,omitempty"`
+}
+
+type SavedPayoffPlan struct {
+	ID                 uuid.UUID               `json:"id"`
+	Name               string                  `json:"name"`
+	Strategy           PayoffStrategy          `json:"strategy"`
+	ExtraMonthlyBudget int                     `json:"extraMonthlyBudget"`
+	CustomOrder        []uuid.UUID             `json:"customOrder"`
+	PayoffOrder        []uuid.UUID             `json:"payoffOrder"`
+	StartDate          time.Time               `json:"startDate"`
+	DebtFreeDate       time.Time               `json:"debtFreeDate"`
+	TotalInterest      int                     `json:"totalInterest"`
+	TotalPaid          int                     `json:"totalPaid"`
+	CreatedAt          time.Time               `json:"createdAt"`
+	Months             []*SavedPayoffPlanMonth `json:"months"`
+}
+
+type SavedPayoffPlanMonth struct {
+	Year           int `json:"year"`
+	Month          int `json:"month"`
+	PlannedPayment int `json:"plannedPayment"`
+	PlannedBalance int `json:"plannedBalance"`
+}
+
+type SavingsAutoRule struct {
+	ID               uuid.UUID           `json:"id"`
+	SavingsGoalID    uuid.UUID           `json:"savingsGoalId"`
+	Type             SavingsAutoRuleType `json:"type"`
+	Amount           *int                `json:"amount,omitempty"`
+	Percentage       *float64            `json:"percentage,omitempty"`
+	SourcePocketID   uuid.UUID           `json:"sourcePocketId"`
+	SourcePocketName *string             `json:"sourcePocketName,omitempty"`
+	Recurrence       *Recurrence         `json:"recurrence"`
+	IsActive         bool                `json:"isActive"`
+	PausedReason     *string             `json:"pausedReason,omitempty"`
+	LastRunDate      *time.Time          `json:"lastRunDate,omitempty"`
+	NextRunDate      *time.Time          `json:"nextRunDate,omitempty"`
+	CreatedAt        time.Time           `json:"createdAt"`
+}
+
+type SavingsAutoRuleInput struct {
+	Type           SavingsAutoRuleType `json:"type"`
+	Amount         *int                `json:"amount,omitempty"`
+	Percentage     *float64            `json:"percentage,omitempty"`
+	SourcePocketID uuid.UUID           `json:"sourcePocketId"`
+	Recurrence     *RecurrenceInput    `json:"recurrence"`
+	IsActive       *bool               `json:"isActive,omitempty"`
+}
+
+type SavingsAutoRun struct {
+	ID             uuid.UUID            `json:"id"`
+	RuleID         uuid.UUID            `json:"ruleId"`
+	SavingsGoalID  uuid.UUID            `json:"savingsGoalId"`
+	ScheduledDate  time.Time            `json:"scheduledDate"`
+	Status         SavingsAutoRunStatus `json:"status"`
+	Amount         int                  `json:"amount"`
+	ContributionID *uuid.UUID           `json:"contributionId,omitempty"`
+	Reason         *string              `json:"reason,omitempty"`
+	CreatedAt      time.Time            `json:"createdAt"`
+}
+
 type SavingsContribution struct {
 	ID               uuid.UUID    `json:"id"`
 	Amount           int          `json:"amount"`
@@ -538,20 +1181,42 @@ type SavingsContribution struct {
 }
 
 type SavingsGoal struct {
-	ID              uuid.UUID              `json:"id"`
-	Name            string                 `json:"name"`
-	TargetAmount    int                    `json:"targetAmount"`
-	CurrentAmount   int                    `json:"currentAmount"`
-	TargetDate      time.Time              `json:"targetDate"`
-	Icon            *string                `json:"icon,omitempty"`
-	CardBgColor     *string                `json:"cardBgColor,omitempty"`
-	Status          SavingsGoalStatus      `json:"status"`
-	Notes           *string                `json:"notes,omitempty"`
-	Progress        float64                `json:"progress"`
-	RemainingAmount int                    `json:"remainingAmount"`
-	MonthlyTarget   int                    `json:"monthlyTarget"`
-	CreatedAt       time.Time              `json:"createdAt"`
-	Contributions   []*SavingsContribution `json:"contributions"`
+	ID                         uuid.UUID              `json:"id"`
+	Name                       string                 `json:"name"`
+	TargetAmount               int                    `json:"targetAmount"`
+	CurrentAmount              int                    `json:"currentAmount"`
+	TargetDate                 time.Time              `json:"targetDate"`
+	Icon                       *string                `json:"icon,omitempty"`
+	CardBgColor                *string                `json:"cardBgColor,omitempty"`
+	Status                     SavingsGoalStatus      `json:"status"`
+	Notes                      *string                `json:"notes,omitempty"`
+	Progress                   float64                `json:"progress"`
+	RemainingAmount            int                    `json:"remainingAmount"`
+	MonthlyTarget              int                    `json:"monthlyTarget"`
+	AverageMonthlyContribution int                    `json:"averageMonthlyContribution"`
+	ContributionTrend          int                    `json:"contributionTrend"`
+	ProjectedCompletionDate    *time.Time             `json:"projectedCompletionDate,omitempty"`
+	Pace                       SavingsGoalPace        `json:"pace"`
+	RequiredMonthlyAmount      int                    `json:"requiredMonthlyAmount"`
+	CreatedAt                  time.Time              `json:"createdAt"`
+	Contributions              []*SavingsContribution `json:"contributions"`
+	Withdrawals                []*SavingsWithdrawal   `json:"withdrawals"`
+}
+
+type SavingsWithdrawal struct {
+	ID             uuid.UUID    `json:"id"`
+	Amount         int          `json:"amount"`
+	WithdrawalDate time.Time    `json:"withdrawalDate"`
+	ToPocketID     *uuid.UUID   `json:"toPocketId,omitempty"`
+	Reason         *string      `json:"reason,omitempty"`
+	CreatedAt      time.Time    `json:"createdAt"`
+	SavingsGoal    *SavingsGoal `json:"savingsGoal"`
+}
+
+type SetBudgetOverrideInput struct {
+	Month  int `json:"month"`
+	Year   int `json:"year"`
+	Amount int `json:"amount"`
 }
 
 type Transaction struct {
@@ -614,33 +1279,64 @@ type UpcomingPaymentsFilter struct {
 }
 
 type UpcomingPaymentsReport struct {
-	Installments     []*UpcomingInstallmentPayment `json:"installments"`
-	Debts            []*UpcomingDebtPayment        `json:"debts"`
-	TotalInstallment int                           `json:"totalInstallment"`
-	TotalDebt        int                           `json:"totalDebt"`
-	TotalPayments    int                           `json:"totalPayments"`
+	Installments          []*UpcomingInstallmentPayment `json:"installments"`
+	Debts                 []*UpcomingDebtPayment        `json:"debts"`
+	TotalInstallment      int                           `json:"totalInstallment"`
+	TotalDebt             int                           `json:"totalDebt"`
+	TotalPayments         int                           `json:"totalPayments"`
+	Receivables           []*UpcomingDebtPayment        `json:"receivables"`
+	TotalReceivable       int                           `json:"totalReceivable"`
+	RecurringExpenses     []*UpcomingRecurringItem      `json:"recurringExpenses"`
+	RecurringIncomes      []*UpcomingRecurringItem      `json:"recurringIncomes"`
+	TotalRecurringExpense int                           `json:"totalRecurringExpense"`
+	TotalRecurringIncome  int                           `json:"totalRecurringIncome"`
+}
+
+type UpcomingRecurringItem struct {
+	GroupID string `json:"groupId"`
+	Name    string `json:"name"`
+	Date    string `json:"date"`
+	Amount  int    `json:"amount"`
 }
 
 type UpdateAccountInput struct {
 	Name string `json:"name"`
 }
 
+type UpdateBudgetInput struct {
+	Amount          *int  `json:"amount,omitempty"`
+	AlertThresholds []int `json:"alertThresholds,omitempty"`
+}
+
 type UpdateCategoryInput struct {
-	Name string `json:"name"`
+	Name        string     `json:"name"`
+	ParentID    *uuid.UUID `json:"parentId,omitempty"`
+	ClearParent *bool      `json:"clearParent,omitempty"`
 }
 
 type UpdateDebtInput struct {
-	PersonName     *string          `json:"personName,omitempty"`
-	ActualAmount   *int             `json:"actualAmount,omitempty"`
-	LoanAmount     *int             `json:"loanAmount,omitempty"`
-	PaymentType    *DebtPaymentType `json:"paymentType,omitempty"`
-	MonthlyPayment *int             `json:"monthlyPayment,omitempty"`
-	Tenor          *int             `json:"tenor,omitempty"`
-	DueDate        *time.Time       `json:"dueDate,omitempty"`
-	Status         *DebtStatus      `json:"status,omitempty"`
-	Icon           *string          `json:"icon,omitempty"`
-	CardBgColor    *string          `json:"cardBgColor,omitempty"`
-	Notes          *string          `json:"notes,omitempty"`
+	PersonName          *string                  `json:"personName,omitempty"`
+	ActualAmount        *int                     `json:"actualAmount,omitempty"`
+	LoanAmount          *int                     `json:"loanAmount,omitempty"`
+	PaymentType         *DebtPaymentType         `json:"paymentType,omitempty"`
+	MonthlyPayment      *int                     `json:"monthlyPayment,omitempty"`
+	Tenor               *int                     `json:"tenor,omitempty"`
+	DueDate             *time.Time               `json:"dueDate,omitempty"`
+	Status              *DebtStatus              `json:"status,omitempty"`
+	Icon                *string                  `json:"icon,omitempty"`
+	CardBgColor         *string                  `json:"cardBgColor,omitempty"`
+	Notes               *string                  `json:"notes,omitempty"`
+	PayeeID             *uuid.UUID               `json:"payeeId,omitempty"`
+	InterestRate        *float64                 `json:"interestRate,omitempty"`
+	InterestCompounding *DebtInterestCompounding `json:"interestCompounding,omitempty"`
+	InterestStartDate   *time.Time               `json:"interestStartDate,omitempty"`
+	ClearInterestRate   *bool                    `json:"clearInterestRate,omitempty"`
+}
+
+type UpdateDebtPaymentInput struct {
+	Amount   *int       `json:"amount,omitempty"`
+	PaidAt   *time.Time `json:"paidAt,omitempty"`
+	PocketID *uuid.UUID `json:"pocketId,omitempty"`
 }
 
 type UpdateExpenseInput struct {
@@ -651,12 +1347,17 @@ type UpdateExpenseInput struct {
 	Notes       *string    `json:"notes,omitempty"`
 	ExpenseDate *time.Time `json:"expenseDate,omitempty"`
 	PocketID    *uuid.UUID `json:"pocketId,omitempty"`
+	PayeeID     *uuid.UUID `json:"payeeId,omitempty"`
 }
 
 type UpdateExpenseTemplateGroupInput struct {
-	Name         *string `json:"name,omitempty"`
-	RecurringDay *int    `json:"recurringDay,omitempty"`
-	Notes        *string `json:"notes,omitempty"`
+	Name             *string          `json:"name,omitempty"`
+	RecurringDay     *int             `json:"recurringDay,omitempty"`
+	Notes            *string          `json:"notes,omitempty"`
+	AutoPost         *bool            `json:"autoPost,omitempty"`
+	NotifyOnAutoPost *bool            `json:"notifyOnAutoPost,omitempty"`
+	Recurrence       *RecurrenceInput `json:"recurrence,omitempty"`
+	ClearRecurrence  *bool            `json:"clearRecurrence,omitempty"`
 }
 
 type UpdateExpenseTemplateItemInput struct {
@@ -667,7 +1368,9 @@ type UpdateExpenseTemplateItemInput struct {
 }
 
 type UpdateIncomeCategoryInput struct {
-	Name string `json:"name"`
+	Name        string     `json:"name"`
+	ParentID    *uuid.UUID `json:"parentId,omitempty"`
+	ClearParent *bool      `json:"clearParent,omitempty"`
 }
 
 type UpdateIncomeInput struct {
@@ -678,29 +1381,64 @@ type UpdateIncomeInput struct {
 	IsRecurring *bool      `json:"isRecurring,omitempty"`
 	Notes       *string    `json:"notes,omitempty"`
 	PocketID    *uuid.UUID `json:"pocketId,omitempty"`
+	PayeeID     *uuid.UUID `json:"payeeId,omitempty"`
 }
 
 type UpdateInstallmentInput struct {
-	Name           *string            `json:"name,omitempty"`
-	ActualAmount   *int               `json:"actualAmount,omitempty"`
-	LoanAmount     *int               `json:"loanAmount,omitempty"`
-	MonthlyPayment *int               `json:"monthlyPayment,omitempty"`
-	Tenor          *int               `json:"tenor,omitempty"`
-	StartDate      *time.Time         `json:"startDate,omitempty"`
-	DueDay         *int               `json:"dueDay,omitempty"`
-	Status         *InstallmentStatus `json:"status,omitempty"`
-	Icon           *string            `json:"icon,omitempty"`
-	CardBgColor    *string            `json:"cardBgColor,omitempty"`
-	Notes          *string            `json:"notes,omitempty"`
+	Name              *string                    `json:"name,omitempty"`
+	ActualAmount      *int                       `json:"actualAmount,omitempty"`
+	LoanAmount        *int                       `json:"loanAmount,omitempty"`
+	MonthlyPayment    *int                       `json:"monthlyPayment,omitempty"`
+	Tenor             *int                       `json:"tenor,omitempty"`
+	StartDate         *time.Time                 `json:"startDate,omitempty"`
+	DueDay            *int                       `json:"dueDay,omitempty"`
+	InterestMethod    *InstallmentInterestMethod `json:"interestMethod,omitempty"`
+	InterestRate      *float64                   `json:"interestRate,omitempty"`
+	ClearInterestRate *bool                      `json:"clearInterestRate,omitempty"`
+	Status            *InstallmentStatus         `json:"status,omitempty"`
+	Icon              *string                    `json:"icon,omitempty"`
+	CardBgColor       *string                    `json:"cardBgColor,omitempty"`
+	Notes             *string                    `json:"notes,omitempty"`
+}
+
+type UpdateInstallmentPaymentInput struct {
+	Amount   *int       `json:"amount,omitempty"`
+	PaidAt   *time.Time `json:"paidAt,omitempty"`
+	PocketID *uuid.UUID `json:"pocketId,omitempty"`
+}
+
+type UpdateLateFeeRuleInput struct {
+	Name          *string         `json:"name,omitempty"`
+	LoanKind      *PayoffLoanKind `json:"loanKind,omitempty"`
+	ClearLoanKind *bool           `json:"clearLoanKind,omitempty"`
+	LoanID        *uuid.UUID      `json:"loanId,omitempty"`
+	ClearLoanID   *bool           `json:"clearLoanId,omitempty"`
+	FeeType       *LateFeeType    `json:"feeType,omitempty"`
+	Amount        *int            `json:"amount,omitempty"`
+	Percentage    *float64        `json:"percentage,omitempty"`
+	GraceDays     *int            `json:"graceDays,omitempty"`
+	CategoryID    *uuid.UUID      `json:"categoryId,omitempty"`
+	PocketID      *uuid.UUID      `json:"pocketId,omitempty"`
+	IsActive      *bool           `json:"isActive,omitempty"`
 }
 
 type UpdateNotificationSettingsInput struct {
 	NotifyInstallment *bool `json:"notifyInstallment,omitempty"`
 	NotifyDebt        *bool `json:"notifyDebt,omitempty"`
 	NotifySavingsGoal *bool `json:"notifySavingsGoal,omitempty"`
+	NotifyBudget      *bool `json:"notifyBudget,omitempty"`
+	NotifyReceivable  *bool `json:"notifyReceivable,omitempty"`
 	NotifyDaysBefore  *int  `json:"notifyDaysBefore,omitempty"`
 }
 
+type UpdatePayeeInput struct {
+	Name              *string    `json:"name,omitempty"`
+	Aliases           []string   `json:"aliases,omitempty"`
+	DefaultCategoryID *uuid.UUID `json:"defaultCategoryId,omitempty"`
+	DefaultPocketID   *uuid.UUID `json:"defaultPocketId,omitempty"`
+	Notes             *string    `json:"notes,omitempty"`
+}
+
 type UpdatePocketInput struct {
 	Name        *string `json:"name,omitempty"`
 	Icon        *string `json:"icon,omitempty"`
@@ -717,10 +1455,16 @@ type UpdateProfileInput struct {
 }
 
 type UpdateRecurringIncomeGroupInput struct {
-	Name         *string `json:"name,omitempty"`
-	RecurringDay *int    `json:"recurringDay,omitempty"`
-	IsActive     *bool   `json:"isActive,omitempty"`
-	Notes        *string `json:"notes,omitempty"`
+	Name               *string          `json:"name,omitempty"`
+	RecurringDay       *int             `json:"recurringDay,omitempty"`
+	IsActive           *bool            `json:"isActive,omitempty"`
+	Notes              *string          `json:"notes,omitempty"`
+	AutoPost           *bool            `json:"autoPost,omitempty"`
+	TargetPocketID     *uuid.UUID       `json:"targetPocketId,omitempty"`
+	ClearTargetPocket  *bool            `json:"clearTargetPocket,omitempty"`
+	ShiftToBusinessDay *bool            `json:"shiftToBusinessDay,omitempty"`
+	Recurrence         *RecurrenceInput `json:"recurrence,omitempty"`
+	ClearRecurrence    *bool            `json:"clearRecurrence,omitempty"`
 }
 
 type UpdateRecurringIncomeItemInput struct {
@@ -729,6 +1473,13 @@ type UpdateRecurringIncomeItemInput struct {
 	Amount     *int       `json:"amount,omitempty"`
 }
 
+type UpdateRoundUpSettingsInput struct {
+	IsEnabled     *bool        `json:"isEnabled,omitempty"`
+	Increment     *int         `json:"increment,omitempty"`
+	SavingsGoalID *uuid.UUID   `json:"savingsGoalId,omitempty"`
+	Mode          *RoundUpMode `json:"mode,omitempty"`
+}
+
 type UpdateSavingsGoalInput struct {
 	Name         *string            `json:"name,omitempty"`
 	TargetAmount *int               `json:"targetAmount,omitempty"`
@@ -748,6 +1499,8 @@ type User struct {
 	NotifyInstallment bool       `json:"notifyInstallment"`
 	NotifyDebt        bool       `json:"notifyDebt"`
 	NotifySavingsGoal bool       `json:"notifySavingsGoal"`
+	NotifyBudget      bool       `json:"notifyBudget"`
+	NotifyReceivable  bool       `json:"notifyReceivable"`
 	NotifyDaysBefore  int        `json:"notifyDaysBefore"`
 	CreatedAt         time.Time  `json:"createdAt"`
 	UpdatedAt         *time.Time `json:"updatedAt,omitempty"`
@@ -758,6 +1511,14 @@ type Verify2FAInput struct {
 	Code      string `json:"code"`
 }
 
+type WithdrawFromSavingsGoalInput struct {
+	SavingsGoalID  uuid.UUID  `json:"savingsGoalId"`
+	Amount         int        `json:"amount"`
+	WithdrawalDate time.Time  `json:"withdrawalDate"`
+	ToPocketID     *uuid.UUID `json:"toPocketId,omitempty"`
+	Reason         *string    `json:"reason,omitempty"`
+}
+
 type AccountType string
 
 const (
@@ -933,6 +1694,173 @@ func (e BalanceStatus) MarshalJSON() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+type CategoryGrouping string
+
+const (
+	CategoryGroupingLeaf   CategoryGrouping = "LEAF"
+	CategoryGroupingRollup CategoryGrouping = "ROLLUP"
+)
+
+var AllCategoryGrouping = []CategoryGrouping{
+	CategoryGroupingLeaf,
+	CategoryGroupingRollup,
+}
+
+func (e CategoryGrouping) IsValid() bool {
+	switch e {
+	case CategoryGroupingLeaf, CategoryGroupingRollup:
+		return true
+	}
+	return false
+}
+
+func (e CategoryGrouping) String() string {
+	return string(e)
+}
+
+func (e *CategoryGrouping) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CategoryGrouping(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CategoryGrouping", str)
+	}
+	return nil
+}
+
+func (e CategoryGrouping) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *CategoryGrouping) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e CategoryGrouping) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type DebtDirection string
+
+const (
+	DebtDirectionPayable    DebtDirection = "PAYABLE"
+	DebtDirectionReceivable DebtDirection = "RECEIVABLE"
+)
+
+var AllDebtDirection = []DebtDirection{
+	DebtDirectionPayable,
+	DebtDirectionReceivable,
+}
+
+func (e DebtDirection) IsValid() bool {
+	switch e {
+	case DebtDirectionPayable, DebtDirectionReceivable:
+		return true
+	}
+	return false
+}
+
+func (e DebtDirection) String() string {
+	return string(e)
+}
+
+func (e *DebtDirection) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DebtDirection(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid DebtDirection", str)
+	}
+	return nil
+}
+
+func (e DebtDirection) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *DebtDirection) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e DebtDirection) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type DebtInterestCompounding string
+
+const (
+	DebtInterestCompoundingSimple  DebtInterestCompounding = "SIMPLE"
+	DebtInterestCompoundingMonthly DebtInterestCompounding = "MONTHLY"
+	DebtInterestCompoundingDaily   DebtInterestCompounding = "DAILY"
+)
+
+var AllDebtInterestCompounding = []DebtInterestCompounding{
+	DebtInterestCompoundingSimple,
+	DebtInterestCompoundingMonthly,
+	DebtInterestCompoundingDaily,
+}
+
+func (e DebtInterestCompounding) IsValid() bool {
+	switch e {
+	case DebtInterestCompoundingSimple, DebtInterestCompoundingMonthly, DebtInterestCompoundingDaily:
+		return true
+	}
+	return false
+}
+
+func (e DebtInterestCompounding) String() string {
+	return string(e)
+}
+
+func (e *DebtInterestCompounding) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DebtInterestCompounding(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid DebtInterestCompounding", str)
+	}
+	return nil
+}
+
+func (e DebtInterestCompounding) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *DebtInterestCompounding) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e DebtInterestCompounding) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
 type DebtPaymentType string
 
 const (
@@ -1043,48 +1971,48 @@ func (e DebtStatus) MarshalJSON() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-type InstallmentStatus string
+type EnvelopeAssignmentType string
 
 const (
-	InstallmentStatusActive    InstallmentStatus = "ACTIVE"
-	InstallmentStatusCompleted InstallmentStatus = "COMPLETED"
+	EnvelopeAssignmentTypeAssign EnvelopeAssignmentType = "ASSIGN"
+	EnvelopeAssignmentTypeMove   EnvelopeAssignmentType = "MOVE"
 )
 
-var AllInstallmentStatus = []InstallmentStatus{
-	InstallmentStatusActive,
-	InstallmentStatusCompleted,
+var AllEnvelopeAssignmentType = []EnvelopeAssignmentType{
+	EnvelopeAssignmentTypeAssign,
+	EnvelopeAssignmentTypeMove,
 }
 
-func (e InstallmentStatus) IsValid() bool {
+func (e EnvelopeAssignmentType) IsValid() bool {
 	switch e {
-	case InstallmentStatusActive, InstallmentStatusCompleted:
+	case EnvelopeAssignmentTypeAssign, EnvelopeAssignmentTypeMove:
 		return true
 	}
 	return false
 }
 
-func (e InstallmentStatus) String() string {
+func (e EnvelopeAssignmentType) String() string {
 	return string(e)
 }
 
-func (e *InstallmentStatus) UnmarshalGQL(v any) error {
+func (e *EnvelopeAssignmentType) UnmarshalGQL(v any) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
 	}
 
-	*e = InstallmentStatus(str)
+	*e = EnvelopeAssignmentType(str)
 	if !e.IsValid() {
-		return fmt.Errorf("%s is not a valid InstallmentStatus", str)
+		return fmt.Errorf("%s is not a valid EnvelopeAssignmentType", str)
 	}
 	return nil
 }
 
-func (e InstallmentStatus) MarshalGQL(w io.Writer) {
+func (e EnvelopeAssignmentType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
-func (e *InstallmentStatus) UnmarshalJSON(b []byte) error {
+func (e *EnvelopeAssignmentType) UnmarshalJSON(b []byte) error {
 	s, err := strconv.Unquote(string(b))
 	if err != nil {
 		return err
@@ -1092,56 +2020,56 @@ func (e *InstallmentStatus) UnmarshalJSON(b []byte) error {
 	return e.UnmarshalGQL(s)
 }
 
-func (e InstallmentStatus) MarshalJSON() ([]byte, error) {
+func (e EnvelopeAssignmentType) MarshalJSON() ([]byte, error) {
 	var buf bytes.Buffer
 	e.MarshalGQL(&buf)
 	return buf.Bytes(), nil
 }
 
-type SavingsGoalStatus string
+type InstallmentInterestMethod string
 
 const (
-	SavingsGoalStatusActive    SavingsGoalStatus = "ACTIVE"
-	SavingsGoalStatusCompleted SavingsGoalStatus = "COMPLETED"
-	SavingsGoalStatusCancelled SavingsGoalStatus = "CANCELLED"
+	InstallmentInterestMethodFlat           InstallmentInterestMethod = "FLAT"
+	InstallmentInterestMethodAnnuity        InstallmentInterestMethod = "ANNUITY"
+	InstallmentInterestMethodFixedPrincipal InstallmentInterestMethod = "FIXED_PRINCIPAL"
 )
 
-var AllSavingsGoalStatus = []SavingsGoalStatus{
-	SavingsGoalStatusActive,
-	SavingsGoalStatusCompleted,
-	SavingsGoalStatusCancelled,
+var AllInstallmentInterestMethod = []InstallmentInterestMethod{
+	InstallmentInterestMethodFlat,
+	InstallmentInterestMethodAnnuity,
+	InstallmentInterestMethodFixedPrincipal,
 }
 
-func (e SavingsGoalStatus) IsValid() bool {
+func (e InstallmentInterestMethod) IsValid() bool {
 	switch e {
-	case SavingsGoalStatusActive, SavingsGoalStatusCompleted, SavingsGoalStatusCancelled:
+	case InstallmentInterestMethodFlat, InstallmentInterestMethodAnnuity, InstallmentInterestMethodFixedPrincipal:
 		return true
 	}
 	return false
 }
 
-func (e SavingsGoalStatus) String() string {
+func (e InstallmentInterestMethod) String() string {
 	return string(e)
 }
 
-func (e *SavingsGoalStatus) UnmarshalGQL(v any) error {
+func (e *InstallmentInterestMethod) UnmarshalGQL(v any) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
 	}
 
-	*e = SavingsGoalStatus(str)
+	*e = InstallmentInterestMethod(str)
 	if !e.IsValid() {
-		return fmt.Errorf("%s is not a valid SavingsGoalStatus", str)
+		return fmt.Errorf("%s is not a valid InstallmentInterestMethod", str)
 	}
 	return nil
 }
 
-func (e SavingsGoalStatus) MarshalGQL(w io.Writer) {
+func (e InstallmentInterestMethod) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
-func (e *SavingsGoalStatus) UnmarshalJSON(b []byte) error {
+func (e *InstallmentInterestMethod) UnmarshalJSON(b []byte) error {
 	s, err := strconv.Unquote(string(b))
 	if err != nil {
 		return err
@@ -1149,7 +2077,795 @@ func (e *SavingsGoalStatus) UnmarshalJSON(b []byte) error {
 	return e.UnmarshalGQL(s)
 }
 
-func (e SavingsGoalStatus) MarshalJSON() ([]byte, error) {
+func (e InstallmentInterestMethod) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type InstallmentStatus string
+
+const (
+	InstallmentStatusActive    InstallmentStatus = "ACTIVE"
+	InstallmentStatusCompleted InstallmentStatus = "COMPLETED"
+)
+
+var AllInstallmentStatus = []InstallmentStatus{
+	InstallmentStatusActive,
+	InstallmentStatusCompleted,
+}
+
+func (e InstallmentStatus) IsValid() bool {
+	switch e {
+	case InstallmentStatusActive, InstallmentStatusCompleted:
+		return true
+	}
+	return false
+}
+
+func (e InstallmentStatus) String() string {
+	return string(e)
+}
+
+func (e *InstallmentStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = InstallmentStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid InstallmentStatus", str)
+	}
+	return nil
+}
+
+func (e InstallmentStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *InstallmentStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e InstallmentStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type LateFeeType string
+
+const (
+	LateFeeTypeFixed      LateFeeType = "FIXED"
+	LateFeeTypePercentage LateFeeType = "PERCENTAGE"
+)
+
+var AllLateFeeType = []LateFeeType{
+	LateFeeTypeFixed,
+	LateFeeTypePercentage,
+}
+
+func (e LateFeeType) IsValid() bool {
+	switch e {
+	case LateFeeTypeFixed, LateFeeTypePercentage:
+		return true
+	}
+	return false
+}
+
+func (e LateFeeType) String() string {
+	return string(e)
+}
+
+func (e *LateFeeType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = LateFeeType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid LateFeeType", str)
+	}
+	return nil
+}
+
+func (e LateFeeType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *LateFeeType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e LateFeeType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type OverdueStatus string
+
+const (
+	OverdueStatusCurrent OverdueStatus = "CURRENT"
+	OverdueStatusOverdue OverdueStatus = "OVERDUE"
+)
+
+var AllOverdueStatus = []OverdueStatus{
+	OverdueStatusCurrent,
+	OverdueStatusOverdue,
+}
+
+func (e OverdueStatus) IsValid() bool {
+	switch e {
+	case OverdueStatusCurrent, OverdueStatusOverdue:
+		return true
+	}
+	return false
+}
+
+func (e OverdueStatus) String() string {
+	return string(e)
+}
+
+func (e *OverdueStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OverdueStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OverdueStatus", str)
+	}
+	return nil
+}
+
+func (e OverdueStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *OverdueStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e OverdueStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type PayoffLoanKind string
+
+const (
+	PayoffLoanKindInstallment PayoffLoanKind = "INSTALLMENT"
+	PayoffLoanKindDebt        PayoffLoanKind = "DEBT"
+)
+
+var AllPayoffLoanKind = []PayoffLoanKind{
+	PayoffLoanKindInstallment,
+	PayoffLoanKindDebt,
+}
+
+func (e PayoffLoanKind) IsValid() bool {
+	switch e {
+	case PayoffLoanKindInstallment, PayoffLoanKindDebt:
+		return true
+	}
+	return false
+}
+
+func (e PayoffLoanKind) String() string {
+	return string(e)
+}
+
+func (e *PayoffLoanKind) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PayoffLoanKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PayoffLoanKind", str)
+	}
+	return nil
+}
+
+func (e PayoffLoanKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PayoffLoanKind) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PayoffLoanKind) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type PayoffStrategy string
+
+const (
+	PayoffStrategySnowball    PayoffStrategy = "SNOWBALL"
+	PayoffStrategyAvalanche   PayoffStrategy = "AVALANCHE"
+	PayoffStrategyCustomOrder PayoffStrategy = "CUSTOM_ORDER"
+)
+
+var AllPayoffStrategy = []PayoffStrategy{
+	PayoffStrategySnowball,
+	PayoffStrategyAvalanche,
+	PayoffStrategyCustomOrder,
+}
+
+func (e PayoffStrategy) IsValid() bool {
+	switch e {
+	case PayoffStrategySnowball, PayoffStrategyAvalanche, PayoffStrategyCustomOrder:
+		return true
+	}
+	return false
+}
+
+func (e PayoffStrategy) String() string {
+	return string(e)
+}
+
+func (e *PayoffStrategy) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PayoffStrategy(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PayoffStrategy", str)
+	}
+	return nil
+}
+
+func (e PayoffStrategy) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PayoffStrategy) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PayoffStrategy) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type PrepaymentStrategy string
+
+const (
+	PrepaymentStrategyReduceTenor   PrepaymentStrategy = "REDUCE_TENOR"
+	PrepaymentStrategyReducePayment PrepaymentStrategy = "REDUCE_PAYMENT"
+)
+
+var AllPrepaymentStrategy = []PrepaymentStrategy{
+	PrepaymentStrategyReduceTenor,
+	PrepaymentStrategyReducePayment,
+}
+
+func (e PrepaymentStrategy) IsValid() bool {
+	switch e {
+	case PrepaymentStrategyReduceTenor, PrepaymentStrategyReducePayment:
+		return true
+	}
+	return false
+}
+
+func (e PrepaymentStrategy) String() string {
+	return string(e)
+}
+
+func (e *PrepaymentStrategy) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PrepaymentStrategy(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PrepaymentStrategy", str)
+	}
+	return nil
+}
+
+func (e PrepaymentStrategy) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PrepaymentStrategy) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PrepaymentStrategy) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type RecurrenceFrequency string
+
+const (
+	RecurrenceFrequencyDaily   RecurrenceFrequency = "DAILY"
+	RecurrenceFrequencyWeekly  RecurrenceFrequency = "WEEKLY"
+	RecurrenceFrequencyMonthly RecurrenceFrequency = "MONTHLY"
+	RecurrenceFrequencyYearly  RecurrenceFrequency = "YEARLY"
+)
+
+var AllRecurrenceFrequency = []RecurrenceFrequency{
+	RecurrenceFrequencyDaily,
+	RecurrenceFrequencyWeekly,
+	RecurrenceFrequencyMonthly,
+	RecurrenceFrequencyYearly,
+}
+
+func (e RecurrenceFrequency) IsValid() bool {
+	switch e {
+	case RecurrenceFrequencyDaily, RecurrenceFrequencyWeekly, RecurrenceFrequencyMonthly, RecurrenceFrequencyYearly:
+		return true
+	}
+	return false
+}
+
+func (e RecurrenceFrequency) String() string {
+	return string(e)
+}
+
+func (e *RecurrenceFrequency) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = RecurrenceFrequency(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid RecurrenceFrequency", str)
+	}
+	return nil
+}
+
+func (e RecurrenceFrequency) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *RecurrenceFrequency) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e RecurrenceFrequency) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type RecurringIncomeRunStatus string
+
+const (
+	RecurringIncomeRunStatusSuccess RecurringIncomeRunStatus = "SUCCESS"
+	RecurringIncomeRunStatusPartial RecurringIncomeRunStatus = "PARTIAL"
+	RecurringIncomeRunStatusFailed  RecurringIncomeRunStatus = "FAILED"
+)
+
+var AllRecurringIncomeRunStatus = []RecurringIncomeRunStatus{
+	RecurringIncomeRunStatusSuccess,
+	RecurringIncomeRunStatusPartial,
+	RecurringIncomeRunStatusFailed,
+}
+
+func (e RecurringIncomeRunStatus) IsValid() bool {
+	switch e {
+	case RecurringIncomeRunStatusSuccess, RecurringIncomeRunStatusPartial, RecurringIncomeRunStatusFailed:
+		return true
+	}
+	return false
+}
+
+func (e RecurringIncomeRunStatus) String() string {
+	return string(e)
+}
+
+func (e *RecurringIncomeRunStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = RecurringIncomeRunStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid RecurringIncomeRunStatus", str)
+	}
+	return nil
+}
+
+func (e RecurringIncomeRunStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *RecurringIncomeRunStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e RecurringIncomeRunStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type RoundUpMode string
+
+const (
+	RoundUpModePerExpense RoundUpMode = "PER_EXPENSE"
+	RoundUpModeDaily      RoundUpMode = "DAILY"
+)
+
+var AllRoundUpMode = []RoundUpMode{
+	RoundUpModePerExpense,
+	RoundUpModeDaily,
+}
+
+func (e RoundUpMode) IsValid() bool {
+	switch e {
+	case RoundUpModePerExpense, RoundUpModeDaily:
+		return true
+	}
+	return false
+}
+
+func (e RoundUpMode) String() string {
+	return string(e)
+}
+
+func (e *RoundUpMode) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = RoundUpMode(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid RoundUpMode", str)
+	}
+	return nil
+}
+
+func (e RoundUpMode) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *RoundUpMode) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e RoundUpMode) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type SavingsAutoRuleType string
+
+const (
+	SavingsAutoRuleTypeFixed            SavingsAutoRuleType = "FIXED"
+	SavingsAutoRuleTypeIncomePercentage SavingsAutoRuleType = "INCOME_PERCENTAGE"
+)
+
+var AllSavingsAutoRuleType = []SavingsAutoRuleType{
+	SavingsAutoRuleTypeFixed,
+	SavingsAutoRuleTypeIncomePercentage,
+}
+
+func (e SavingsAutoRuleType) IsValid() bool {
+	switch e {
+	case SavingsAutoRuleTypeFixed, SavingsAutoRuleTypeIncomePercentage:
+		return true
+	}
+	return false
+}
+
+func (e SavingsAutoRuleType) String() string {
+	return string(e)
+}
+
+func (e *SavingsAutoRuleType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SavingsAutoRuleType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SavingsAutoRuleType", str)
+	}
+	return nil
+}
+
+func (e SavingsAutoRuleType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *SavingsAutoRuleType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e SavingsAutoRuleType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type SavingsAutoRunStatus string
+
+const (
+	SavingsAutoRunStatusSuccess           SavingsAutoRunStatus = "SUCCESS"
+	SavingsAutoRunStatusSkipped           SavingsAutoRunStatus = "SKIPPED"
+	SavingsAutoRunStatusInsufficientFunds SavingsAutoRunStatus = "INSUFFICIENT_FUNDS"
+	SavingsAutoRunStatusFailed            SavingsAutoRunStatus = "FAILED"
+)
+
+var AllSavingsAutoRunStatus = []SavingsAutoRunStatus{
+	SavingsAutoRunStatusSuccess,
+	SavingsAutoRunStatusSkipped,
+	SavingsAutoRunStatusInsufficientFunds,
+	SavingsAutoRunStatusFailed,
+}
+
+func (e SavingsAutoRunStatus) IsValid() bool {
+	switch e {
+	case SavingsAutoRunStatusSuccess, SavingsAutoRunStatusSkipped, SavingsAutoRunStatusInsufficientFunds, SavingsAutoRunStatusFailed:
+		return true
+	}
+	return false
+}
+
+func (e SavingsAutoRunStatus) String() string {
+	return string(e)
+}
+
+func (e *SavingsAutoRunStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SavingsAutoRunStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SavingsAutoRunStatus", str)
+	}
+	return nil
+}
+
+func (e SavingsAutoRunStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *SavingsAutoRunStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e SavingsAutoRunStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type SavingsGoalPace string
+
+const (
+	SavingsGoalPaceAhead   SavingsGoalPace = "AHEAD"
+	SavingsGoalPaceOnTrack SavingsGoalPace = "ON_TRACK"
+	SavingsGoalPaceBehind  SavingsGoalPace = "BEHIND"
+)
+
+var AllSavingsGoalPace = []SavingsGoalPace{
+	SavingsGoalPaceAhead,
+	SavingsGoalPaceOnTrack,
+	SavingsGoalPaceBehind,
+}
+
+func (e SavingsGoalPace) IsValid() bool {
+	switch e {
+	case SavingsGoalPaceAhead, SavingsGoalPaceOnTrack, SavingsGoalPaceBehind:
+		return true
+	}
+	return false
+}
+
+func (e SavingsGoalPace) String() string {
+	return string(e)
+}
+
+func (e *SavingsGoalPace) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SavingsGoalPace(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SavingsGoalPace", str)
+	}
+	return nil
+}
+
+func (e SavingsGoalPace) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *SavingsGoalPace) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e SavingsGoalPace) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type SavingsGoalStatus string
+
+const (
+	SavingsGoalStatusActive    SavingsGoalStatus = "ACTIVE"
+	SavingsGoalStatusCompleted SavingsGoalStatus = "COMPLETED"
+	SavingsGoalStatusCancelled SavingsGoalStatus = "CANCELLED"
+)
+
+var AllSavingsGoalStatus = []SavingsGoalStatus{
+	SavingsGoalStatusActive,
+	SavingsGoalStatusCompleted,
+	SavingsGoalStatusCancelled,
+}
+
+func (e SavingsGoalStatus) IsValid() bool {
+	switch e {
+	case SavingsGoalStatusActive, SavingsGoalStatusCompleted, SavingsGoalStatusCancelled:
+		return true
+	}
+	return false
+}
+
+func (e SavingsGoalStatus) String() string {
+	return string(e)
+}
+
+func (e *SavingsGoalStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SavingsGoalStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SavingsGoalStatus", str)
+	}
+	return nil
+}
+
+func (e SavingsGoalStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *SavingsGoalStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e SavingsGoalStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type SubscriptionCadence string
+
+const (
+	SubscriptionCadenceWeekly  SubscriptionCadence = "WEEKLY"
+	SubscriptionCadenceMonthly SubscriptionCadence = "MONTHLY"
+	SubscriptionCadenceYearly  SubscriptionCadence = "YEARLY"
+)
+
+var AllSubscriptionCadence = []SubscriptionCadence{
+	SubscriptionCadenceWeekly,
+	SubscriptionCadenceMonthly,
+	SubscriptionCadenceYearly,
+}
+
+func (e SubscriptionCadence) IsValid() bool {
+	switch e {
+	case SubscriptionCadenceWeekly, SubscriptionCadenceMonthly, SubscriptionCadenceYearly:
+		return true
+	}
+	return false
+}
+
+func (e SubscriptionCadence) String() string {
+	return string(e)
+}
+
+func (e *SubscriptionCadence) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SubscriptionCadence(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SubscriptionCadence", str)
+	}
+	return nil
+}
+
+func (e SubscriptionCadence) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *SubscriptionCadence) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e SubscriptionCadence) MarshalJSON() ([]byte, error) {
 	var buf bytes.Buffer
 	e.MarshalGQL(&buf)
 	return buf.Bytes(), nil
@@ -0,0 +1,165 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.87
+
+import (
+	"context"
+	"time"
+
+	"github.com/azzamdhx/moneybro/backend/internal/graph/model"
+	"github.com/azzamdhx/moneybro/backend/internal/middleware"
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+	"github.com/azzamdhx/moneybro/backend/internal/services"
+	"github.com/azzamdhx/moneybro/backend/internal/utils"
+	"github.com/google/uuid"
+)
+
+// CreateLateFeeRule is the resolver for the createLateFeeRule field.
+func (r *mutationResolver) CreateLateFeeRule(ctx context.Context, input model.CreateLateFeeRuleInput) (*model.LateFeeRule, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	ruleInput := services.CreateLateFeeRuleInput{
+		Name:       input.Name,
+		LoanID:     input.LoanID,
+		FeeType:    models.LateFeeType(input.FeeType),
+		CategoryID: input.CategoryID,
+		PocketID:   input.PocketID,
+	}
+	if input.LoanKind != nil {
+		kind := models.LoanKind(*input.LoanKind)
+		ruleInput.LoanKind = &kind
+	}
+	if input.Amount != nil {
+		ruleInput.Amount = int64(*input.Amount)
+	}
+	if input.Percentage != nil {
+		ruleInput.Percentage = *input.Percentage
+	}
+	if input.GraceDays != nil {
+		ruleInput.GraceDays = *input.GraceDays
+	}
+	rule, err := r.Services.Overdue.CreateRule(userID, ruleInput)
+	if err != nil {
+		return nil, err
+	}
+	return lateFeeRuleToModel(rule), nil
+}
+
+// UpdateLateFeeRule is the resolver for the updateLateFeeRule field.
+func (r *mutationResolver) UpdateLateFeeRule(ctx context.Context, id uuid.UUID, input model.UpdateLateFeeRuleInput) (*model.LateFeeRule, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	ruleInput := services.UpdateLateFeeRuleInput{
+		Name:          input.Name,
+		ClearLoanKind: input.ClearLoanKind != nil && *input.ClearLoanKind,
+		LoanID:        input.LoanID,
+		ClearLoanID:   input.ClearLoanID != nil && *input.ClearLoanID,
+		Percentage:    input.Percentage,
+		GraceDays:     input.GraceDays,
+		CategoryID:    input.CategoryID,
+		PocketID:      input.PocketID,
+		IsActive:      input.IsActive,
+	}
+	if input.LoanKind != nil {
+		kind := models.LoanKind(*input.LoanKind)
+		ruleInput.LoanKind = &kind
+	}
+	if input.FeeType != nil {
+		feeType := models.LateFeeType(*input.FeeType)
+		ruleInput.FeeType = &feeType
+	}
+	if input.Amount != nil {
+		v := int64(*input.Amount)
+		ruleInput.Amount = &v
+	}
+	rule, err := r.Services.Overdue.UpdateRule(userID, id, ruleInput)
+	if err != nil {
+		return nil, err
+	}
+	return lateFeeRuleToModel(rule), nil
+}
+
+// DeleteLateFeeRule is the resolver for the deleteLateFeeRule field.
+func (r *mutationResolver) DeleteLateFeeRule(ctx context.Context, id uuid.UUID) (bool, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return false, utils.UnauthorizedError(ctx)
+	}
+	err := r.Services.Overdue.DeleteRule(userID, id)
+	return err == nil, err
+}
+
+// ChargeLateFees is the resolver for the chargeLateFees field.
+func (r *mutationResolver) ChargeLateFees(ctx context.Context) ([]*model.LateFeeCharge, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	charges, err := r.Services.Overdue.ChargeLateFeesForUser(userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.LateFeeCharge, len(charges))
+	for i, c := range charges {
+		result[i] = lateFeeChargeToModel(&c)
+	}
+	return result, nil
+}
+
+// OverdueLoans is the resolver for the overdueLoans field.
+func (r *queryResolver) OverdueLoans(ctx context.Context) ([]*model.OverdueLoan, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	loans, err := r.Services.Overdue.GetOverdueLoans(userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.OverdueLoan, len(loans))
+	for i, l := range loans {
+		result[i] = overdueLoanToModel(&l)
+	}
+	return result, nil
+}
+
+// LateFeeRules is the resolver for the lateFeeRules field.
+func (r *queryResolver) LateFeeRules(ctx context.Context) ([]*model.LateFeeRule, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	rules, err := r.Services.Overdue.GetRules(userID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.LateFeeRule, len(rules))
+	for i, rule := range rules {
+		result[i] = lateFeeRuleToModel(&rule)
+	}
+	return result, nil
+}
+
+// LateFeeCharges is the resolver for the lateFeeCharges field.
+func (r *queryResolver) LateFeeCharges(ctx context.Context, loanID *uuid.UUID) ([]*model.LateFeeCharge, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	charges, err := r.Services.Overdue.GetCharges(userID, loanID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.LateFeeCharge, len(charges))
+	for i, c := range charges {
+		result[i] = lateFeeChargeToModel(&c)
+	}
+	return result, nil
+}
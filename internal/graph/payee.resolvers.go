@@ -0,0 +1,94 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.87
+
+import (
+	"context"
+
+	"github.com/azzamdhx/moneybro/backend/internal/graph/model"
+	"github.com/azzamdhx/moneybro/backend/internal/middleware"
+	"github.com/azzamdhx/moneybro/backend/internal/services"
+	"github.com/azzamdhx/moneybro/backend/internal/utils"
+	"github.com/google/uuid"
+)
+
+// CreatePayee is the resolver for the createPayee field.
+func (r *mutationResolver) CreatePayee(ctx context.Context, input model.CreatePayeeInput) (*model.Payee, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	payee, err := r.Services.Payee.Create(userID, services.CreatePayeeInput{
+		Name:              input.Name,
+		Aliases:           input.Aliases,
+		DefaultCategoryID: input.DefaultCategoryID,
+		DefaultPocketID:   input.DefaultPocketID,
+		Notes:             input.Notes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return payeeToModel(payee), nil
+}
+
+// UpdatePayee is the resolver for the updatePayee field.
+func (r *mutationResolver) UpdatePayee(ctx context.Context, id uuid.UUID, input model.UpdatePayeeInput) (*model.Payee, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	payee, err := r.Services.Payee.Update(userID, id, services.UpdatePayeeInput{
+		Name:              input.Name,
+		Aliases:           input.Aliases,
+		DefaultCategoryID: input.DefaultCategoryID,
+		DefaultPocketID:   input.DefaultPocketID,
+		Notes:             input.Notes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return payeeToModel(payee), nil
+}
+
+// DeletePayee is the resolver for the deletePayee field.
+func (r *mutationResolver) DeletePayee(ctx context.Context, id uuid.UUID) (bool, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return false, utils.UnauthorizedError(ctx)
+	}
+	err := r.Services.Payee.Delete(userID, id)
+	return err == nil, err
+}
+
+// Payees is the resolver for the payees field.
+func (r *queryResolver) Payees(ctx context.Context) ([]*model.Payee, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	payees, err := r.Services.Payee.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.Payee, len(payees))
+	for i := range payees {
+		result[i] = payeeToModel(&payees[i])
+	}
+	return result, nil
+}
+
+// Payee is the resolver for the payee field.
+func (r *queryResolver) Payee(ctx context.Context, id uuid.UUID) (*model.Payee, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	detail, err := r.Services.Payee.GetDetail(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return payeeDetailToModel(detail), nil
+}
@@ -0,0 +1,91 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.87
+
+import (
+	"context"
+
+	"github.com/azzamdhx/moneybro/backend/internal/graph/model"
+	"github.com/azzamdhx/moneybro/backend/internal/middleware"
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+	"github.com/azzamdhx/moneybro/backend/internal/services"
+	"github.com/azzamdhx/moneybro/backend/internal/utils"
+	"github.com/google/uuid"
+)
+
+// SavePayoffPlan is the resolver for the savePayoffPlan field.
+func (r *mutationResolver) SavePayoffPlan(ctx context.Context, input model.SavePayoffPlanInput) (*model.SavedPayoffPlan, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	plan, err := r.Services.PayoffPlan.Save(userID, input.Name, services.PayoffPlanInput{
+		ExtraMonthlyBudget: int64(input.ExtraMonthlyBudget),
+		Strategy:           models.PayoffStrategy(input.Strategy),
+		CustomOrder:        input.CustomOrder,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return savedPayoffPlanToModel(plan), nil
+}
+
+// DeletePayoffPlan is the resolver for the deletePayoffPlan field.
+func (r *mutationResolver) DeletePayoffPlan(ctx context.Context, id uuid.UUID) (bool, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return false, utils.UnauthorizedError(ctx)
+	}
+	err := r.Services.PayoffPlan.Delete(userID, id)
+	return err == nil, err
+}
+
+// PayoffPlan is the resolver for the payoffPlan field.
+func (r *queryResolver) PayoffPlan(ctx context.Context, input model.PayoffPlanInput) (*model.PayoffPlan, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	result, err := r.Services.PayoffPlan.Simulate(userID, services.PayoffPlanInput{
+		ExtraMonthlyBudget: int64(input.ExtraMonthlyBudget),
+		Strategy:           models.PayoffStrategy(input.Strategy),
+		CustomOrder:        input.CustomOrder,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return payoffPlanToModel(result), nil
+}
+
+// SavedPayoffPlans is the resolver for the savedPayoffPlans field.
+func (r *queryResolver) SavedPayoffPlans(ctx context.Context) ([]*model.SavedPayoffPlan, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	plans, err := r.Services.PayoffPlan.GetSaved(userID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.SavedPayoffPlan, len(plans))
+	for i, p := range plans {
+		result[i] = savedPayoffPlanToModel(&p)
+	}
+	return result, nil
+}
+
+// PayoffPlanComparison is the resolver for the payoffPlanComparison field.
+func (r *queryResolver) PayoffPlanComparison(ctx context.Context, id uuid.UUID) (*model.PayoffPlanComparison, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	comparison, err := r.Services.PayoffPlan.Compare(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return payoffPlanComparisonToModel(comparison), nil
+}
@@ -0,0 +1,60 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.87
+
+import (
+	"context"
+	"errors"
+
+	"github.com/azzamdhx/moneybro/backend/internal/graph/model"
+	"github.com/azzamdhx/moneybro/backend/internal/middleware"
+	"github.com/azzamdhx/moneybro/backend/internal/services"
+	"github.com/azzamdhx/moneybro/backend/internal/utils"
+)
+
+// ApplyPrepayment is the resolver for the applyPrepayment field.
+func (r *mutationResolver) ApplyPrepayment(ctx context.Context, input model.PrepaymentInput) (*model.PrepaymentResult, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	var result *services.PrepaymentResult
+	var err error
+	switch {
+	case input.InstallmentID != nil && input.DebtID == nil:
+		result, err = r.Services.Installment.ApplyPrepayment(userID, *input.InstallmentID, prepaymentInputFromModel(input))
+	case input.DebtID != nil && input.InstallmentID == nil:
+		result, err = r.Services.Debt.ApplyPrepayment(userID, *input.DebtID, prepaymentInputFromModel(input))
+	default:
+		return nil, errors.New("set either installmentId or debtId")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return prepaymentResultToModel(result), nil
+}
+
+// SimulatePrepayment is the resolver for the simulatePrepayment field.
+func (r *queryResolver) SimulatePrepayment(ctx context.Context, input model.PrepaymentInput) (*model.PrepaymentResult, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	var result *services.PrepaymentResult
+	var err error
+	switch {
+	case input.InstallmentID != nil && input.DebtID == nil:
+		result, err = r.Services.Installment.SimulatePrepayment(userID, *input.InstallmentID, prepaymentInputFromModel(input))
+	case input.DebtID != nil && input.InstallmentID == nil:
+		result, err = r.Services.Debt.SimulatePrepayment(userID, *input.DebtID, prepaymentInputFromModel(input))
+	default:
+		return nil, errors.New("set either installmentId or debtId")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return prepaymentResultToModel(result), nil
+}
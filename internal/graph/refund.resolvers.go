@@ -0,0 +1,44 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.87
+
+import (
+	"context"
+
+	"github.com/azzamdhx/moneybro/backend/internal/graph/model"
+	"github.com/azzamdhx/moneybro/backend/internal/middleware"
+	"github.com/azzamdhx/moneybro/backend/internal/services"
+	"github.com/azzamdhx/moneybro/backend/internal/utils"
+	"github.com/google/uuid"
+)
+
+// RefundExpense is the resolver for the refundExpense field.
+func (r *mutationResolver) RefundExpense(ctx context.Context, input model.RefundExpenseInput) (*model.Expense, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	exp, err := r.Services.Expense.Refund(userID, input.ExpenseID, services.RefundExpenseInput{
+		Amount:     int64(input.Amount),
+		RefundDate: input.Date,
+		PocketID:   input.PocketID,
+		Notes:      input.Notes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return expenseToModel(exp), nil
+}
+
+// DeleteExpenseRefund is the resolver for the deleteExpenseRefund field.
+func (r *mutationResolver) DeleteExpenseRefund(ctx context.Context, id uuid.UUID) (bool, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return false, utils.UnauthorizedError(ctx)
+	}
+	err := r.Services.Expense.DeleteRefund(userID, id)
+	return err == nil, err
+}
@@ -0,0 +1,51 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.87
+
+import (
+	"context"
+	"time"
+
+	"github.com/azzamdhx/moneybro/backend/internal/graph/model"
+	"github.com/azzamdhx/moneybro/backend/internal/middleware"
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+	"github.com/azzamdhx/moneybro/backend/internal/services"
+	"github.com/azzamdhx/moneybro/backend/internal/utils"
+	"github.com/google/uuid"
+)
+
+// RestructureInstallment is the resolver for the restructureInstallment field.
+func (r *mutationResolver) RestructureInstallment(ctx context.Context, id uuid.UUID, effectiveDate time.Time, input model.RestructureInstallmentInput) (*model.Installment, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	restructureInput := services.RestructureInput{
+		EffectiveDate: effectiveDate,
+		Tenor:         input.Tenor,
+		InterestRate:  input.InterestRate,
+		DueDay:        input.DueDay,
+		CategoryID:    input.CategoryID,
+		Notes:         input.Notes,
+	}
+	if input.MonthlyPayment != nil {
+		restructureInput.MonthlyPayment = int64(*input.MonthlyPayment)
+	}
+	if input.InterestMethod != nil {
+		restructureInput.InterestMethod = models.InstallmentInterestMethod(*input.InterestMethod)
+	}
+	if input.Fee != nil {
+		restructureInput.Fee = int64(*input.Fee)
+	}
+	if input.InterestAdjustment != nil {
+		restructureInput.InterestAdjustment = int64(*input.InterestAdjustment)
+	}
+	installment, err := r.Services.Installment.Restructure(userID, id, restructureInput)
+	if err != nil {
+		return nil, err
+	}
+	return installmentToModel(installment), nil
+}
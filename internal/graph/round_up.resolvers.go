@@ -0,0 +1,54 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.87
+
+import (
+	"context"
+	"time"
+
+	"github.com/azzamdhx/moneybro/backend/internal/graph/model"
+	"github.com/azzamdhx/moneybro/backend/internal/middleware"
+	"github.com/azzamdhx/moneybro/backend/internal/utils"
+)
+
+// UpdateRoundUpSettings is the resolver for the updateRoundUpSettings field.
+func (r *mutationResolver) UpdateRoundUpSettings(ctx context.Context, input model.UpdateRoundUpSettingsInput) (*model.RoundUpSettings, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	setting, err := r.Services.RoundUp.UpdateSettings(userID, roundUpSettingsInputFromModel(input))
+	if err != nil {
+		return nil, err
+	}
+	return roundUpSettingsToModel(setting), nil
+}
+
+// RoundUpSettings is the resolver for the roundUpSettings field.
+func (r *queryResolver) RoundUpSettings(ctx context.Context) (*model.RoundUpSettings, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	setting, err := r.Services.RoundUp.GetSettings(userID)
+	if err != nil {
+		return nil, err
+	}
+	return roundUpSettingsToModel(setting), nil
+}
+
+// RoundUpSummary is the resolver for the roundUpSummary field.
+func (r *queryResolver) RoundUpSummary(ctx context.Context, startDate time.Time, endDate time.Time) (*model.RoundUpSummary, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	summary, err := r.Services.RoundUp.GetSummary(userID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	return roundUpSummaryToModel(summary), nil
+}
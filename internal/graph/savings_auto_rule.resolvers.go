@@ -0,0 +1,89 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.87
+
+import (
+	"context"
+
+	"github.com/azzamdhx/moneybro/backend/internal/graph/model"
+	"github.com/azzamdhx/moneybro/backend/internal/middleware"
+	"github.com/azzamdhx/moneybro/backend/internal/utils"
+	"github.com/google/uuid"
+)
+
+// CreateSavingsAutoRule is the resolver for the createSavingsAutoRule field.
+func (r *mutationResolver) CreateSavingsAutoRule(ctx context.Context, savingsGoalID uuid.UUID, input model.SavingsAutoRuleInput) (*model.SavingsAutoRule, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	rule, err := r.Services.SavingsGoal.CreateAutoRule(userID, savingsGoalID, savingsAutoRuleInputFromModel(input))
+	if err != nil {
+		return nil, err
+	}
+	return savingsAutoRuleToModel(rule), nil
+}
+
+// UpdateSavingsAutoRule is the resolver for the updateSavingsAutoRule field.
+func (r *mutationResolver) UpdateSavingsAutoRule(ctx context.Context, id uuid.UUID, input model.SavingsAutoRuleInput) (*model.SavingsAutoRule, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	rule, err := r.Services.SavingsGoal.UpdateAutoRule(userID, id, savingsAutoRuleInputFromModel(input))
+	if err != nil {
+		return nil, err
+	}
+	return savingsAutoRuleToModel(rule), nil
+}
+
+// DeleteSavingsAutoRule is the resolver for the deleteSavingsAutoRule field.
+func (r *mutationResolver) DeleteSavingsAutoRule(ctx context.Context, id uuid.UUID) (bool, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return false, utils.UnauthorizedError(ctx)
+	}
+	err := r.Services.SavingsGoal.DeleteAutoRule(userID, id)
+	return err == nil, err
+}
+
+// SavingsAutoRules is the resolver for the savingsAutoRules field.
+func (r *queryResolver) SavingsAutoRules(ctx context.Context, savingsGoalID uuid.UUID) ([]*model.SavingsAutoRule, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	rules, err := r.Services.SavingsGoal.GetAutoRules(userID, savingsGoalID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.SavingsAutoRule, len(rules))
+	for i, rule := range rules {
+		result[i] = savingsAutoRuleToModel(&rule)
+	}
+	return result, nil
+}
+
+// SavingsAutoRuns is the resolver for the savingsAutoRuns field.
+func (r *queryResolver) SavingsAutoRuns(ctx context.Context, ruleID *uuid.UUID, limit *int) ([]*model.SavingsAutoRun, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	maxRuns := 50
+	if limit != nil {
+		maxRuns = *limit
+	}
+	runs, err := r.Services.SavingsGoal.GetAutoRuns(userID, ruleID, maxRuns)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.SavingsAutoRun, len(runs))
+	for i, run := range runs {
+		result[i] = savingsAutoRunToModel(&run)
+	}
+	return result, nil
+}
@@ -153,7 +153,7 @@ func (r *mutationResolver) UpdateNotificationSettings(ctx context.Context, input
 	if !ok {
 		return nil, utils.UnauthorizedError(ctx)
 	}
-	user, err := r.Services.User.UpdateNotificationSettings(userID, input.NotifyInstallment, input.NotifyDebt, input.NotifySavingsGoal, input.NotifyDaysBefore)
+	user, err := r.Services.User.UpdateNotificationSettings(userID, input.NotifyInstallment, input.NotifyDebt, input.NotifySavingsGoal, input.NotifyBudget, input.NotifyReceivable, input.NotifyDaysBefore)
 	if err != nil {
 		return nil, err
 	}
@@ -194,7 +194,7 @@ func (r *mutationResolver) CreateCategory(ctx context.Context, input model.Creat
 	if !ok {
 		return nil, utils.UnauthorizedError(ctx)
 	}
-	cat, err := r.Services.Category.Create(userID, input.Name)
+	cat, err := r.Services.Category.Create(userID, input.Name, input.ParentID)
 	if err != nil {
 		return nil, err
 	}
@@ -203,7 +203,8 @@ func (r *mutationResolver) CreateCategory(ctx context.Context, input model.Creat
 
 // UpdateCategory is the resolver for the updateCategory field.
 func (r *mutationResolver) UpdateCategory(ctx context.Context, id uuid.UUID, input model.UpdateCategoryInput) (*model.Category, error) {
-	cat, err := r.Services.Category.Update(id, input.Name)
+	clearParent := input.ClearParent != nil && *input.ClearParent
+	cat, err := r.Services.Category.Update(id, input.Name, input.ParentID, clearParent)
 	if err != nil {
 		return nil, err
 	}
@@ -216,20 +217,38 @@ func (r *mutationResolver) DeleteCategory(ctx context.Context, id uuid.UUID) (bo
 	return err == nil, err
 }
 
+// MergeCategories is the resolver for the mergeCategories field.
+func (r *mutationResolver) MergeCategories(ctx context.Context, sourceIds []uuid.UUID, targetID uuid.UUID) (*model.Category, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	cat, err := r.Services.Category.Merge(userID, sourceIds, targetID)
+	if err != nil {
+		return nil, err
+	}
+	return categoryToModel(cat), nil
+}
+
 // CreateExpense is the resolver for the createExpense field.
 func (r *mutationResolver) CreateExpense(ctx context.Context, input model.CreateExpenseInput) (*model.Expense, error) {
 	userID, ok := middleware.GetUserID(ctx)
 	if !ok {
 		return nil, utils.UnauthorizedError(ctx)
 	}
+	var categoryID uuid.UUID
+	if input.CategoryID != nil {
+		categoryID = *input.CategoryID
+	}
 	exp, err := r.Services.Expense.Create(userID, services.CreateExpenseInput{
-		CategoryID:  input.CategoryID,
+		CategoryID:  categoryID,
 		ItemName:    input.ItemName,
 		UnitPrice:   int64(input.UnitPrice),
 		Quantity:    input.Quantity,
 		Notes:       input.Notes,
 		ExpenseDate: input.ExpenseDate,
 		PocketID:    input.PocketID,
+		PayeeID:     input.PayeeID,
 	})
 	if err != nil {
 		return nil, err
@@ -252,6 +271,7 @@ func (r *mutationResolver) UpdateExpense(ctx context.Context, id uuid.UUID, inpu
 		Notes:       input.Notes,
 		ExpenseDate: input.ExpenseDate,
 		PocketID:    input.PocketID,
+		PayeeID:     input.PayeeID,
 	})
 	if err != nil {
 		return nil, err
@@ -281,10 +301,13 @@ func (r *mutationResolver) CreateExpenseTemplateGroup(ctx context.Context, input
 		}
 	}
 	group, err := r.Services.ExpenseTemplateGroup.Create(userID, services.CreateExpenseTemplateGroupInput{
-		Name:         input.Name,
-		RecurringDay: input.RecurringDay,
-		Notes:        input.Notes,
-		Items:        items,
+		Name:             input.Name,
+		RecurringDay:     input.RecurringDay,
+		Notes:            input.Notes,
+		AutoPost:         input.AutoPost != nil && *input.AutoPost,
+		NotifyOnAutoPost: input.NotifyOnAutoPost != nil && *input.NotifyOnAutoPost,
+		Recurrence:       recurrenceInputFromModel(input.Recurrence),
+		Items:            items,
 	})
 	if err != nil {
 		return nil, err
@@ -295,9 +318,13 @@ func (r *mutationResolver) CreateExpenseTemplateGroup(ctx context.Context, input
 // UpdateExpenseTemplateGroup is the resolver for the updateExpenseTemplateGroup field.
 func (r *mutationResolver) UpdateExpenseTemplateGroup(ctx context.Context, id uuid.UUID, input model.UpdateExpenseTemplateGroupInput) (*model.ExpenseTemplateGroup, error) {
 	group, err := r.Services.ExpenseTemplateGroup.Update(id, services.UpdateExpenseTemplateGroupInput{
-		Name:         input.Name,
-		RecurringDay: input.RecurringDay,
-		Notes:        input.Notes,
+		Name:             input.Name,
+		RecurringDay:     input.RecurringDay,
+		Notes:            input.Notes,
+		AutoPost:         input.AutoPost,
+		NotifyOnAutoPost: input.NotifyOnAutoPost,
+		Recurrence:       recurrenceInputFromModel(input.Recurrence),
+		ClearRecurrence:  input.ClearRecurrence != nil && *input.ClearRecurrence,
 	})
 	if err != nil {
 		return nil, err
@@ -373,14 +400,27 @@ func (r *mutationResolver) CreateInstallment(ctx context.Context, input model.Cr
 	if !ok {
 		return nil, utils.UnauthorizedError(ctx)
 	}
+	var loanAmount, monthlyPayment int64
+	if input.LoanAmount != nil {
+		loanAmount = int64(*input.LoanAmount)
+	}
+	if input.MonthlyPayment != nil {
+		monthlyPayment = int64(*input.MonthlyPayment)
+	}
+	var interestMethod models.InstallmentInterestMethod
+	if input.InterestMethod != nil {
+		interestMethod = models.InstallmentInterestMethod(*input.InterestMethod)
+	}
 	inst, err := r.Services.Installment.Create(userID, services.CreateInstallmentInput{
 		Name:           input.Name,
 		ActualAmount:   int64(input.ActualAmount),
-		LoanAmount:     int64(input.LoanAmount),
-		MonthlyPayment: int64(input.MonthlyPayment),
+		LoanAmount:     loanAmount,
+		MonthlyPayment: monthlyPayment,
 		Tenor:          input.Tenor,
 		StartDate:      input.StartDate,
 		DueDay:         input.DueDay,
+		InterestMethod: interestMethod,
+		InterestRate:   input.InterestRate,
 		Icon:           input.Icon,
 		CardBgColor:    input.CardBgColor,
 		Notes:          input.Notes,
@@ -425,6 +465,17 @@ func (r *mutationResolver) UpdateInstallment(ctx context.Context, id uuid.UUID,
 	if input.DueDay != nil {
 		dueDay = *input.DueDay
 	}
+	interestMethod := inst.InterestMethod
+	if input.InterestMethod != nil {
+		interestMethod = models.InstallmentInterestMethod(*input.InterestMethod)
+	}
+	interestRate := inst.InterestRate
+	if input.InterestRate != nil {
+		interestRate = input.InterestRate
+	}
+	if input.ClearInterestRate != nil && *input.ClearInterestRate {
+		interestRate = nil
+	}
 	var status *models.InstallmentStatus
 	if input.Status != nil {
 		s := models.InstallmentStatus(*input.Status)
@@ -438,6 +489,8 @@ func (r *mutationResolver) UpdateInstallment(ctx context.Context, id uuid.UUID,
 		Tenor:          tenor,
 		StartDate:      startDate,
 		DueDay:         dueDay,
+		InterestMethod: interestMethod,
+		InterestRate:   interestRate,
 		Icon:           input.Icon,
 		CardBgColor:    input.CardBgColor,
 		Notes:          input.Notes,
@@ -463,6 +516,29 @@ func (r *mutationResolver) RecordInstallmentPayment(ctx context.Context, input m
 	return installmentPaymentToModel(payment), nil
 }
 
+// UpdateInstallmentPayment is the resolver for the updateInstallmentPayment field.
+func (r *mutationResolver) UpdateInstallmentPayment(ctx context.Context, id uuid.UUID, input model.UpdateInstallmentPaymentInput) (*model.InstallmentPayment, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	payment, err := r.Services.Installment.UpdatePayment(userID, id, updatePaymentInputFromModel(input.Amount, input.PaidAt, input.PocketID))
+	if err != nil {
+		return nil, err
+	}
+	return installmentPaymentToModel(payment), nil
+}
+
+// DeleteInstallmentPayment is the resolver for the deleteInstallmentPayment field.
+func (r *mutationResolver) DeleteInstallmentPayment(ctx context.Context, id uuid.UUID) (bool, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return false, utils.UnauthorizedError(ctx)
+	}
+	err := r.Services.Installment.DeletePayment(userID, id)
+	return err == nil, err
+}
+
 // MarkInstallmentComplete is the resolver for the markInstallmentComplete field.
 func (r *mutationResolver) MarkInstallmentComplete(ctx context.Context, id uuid.UUID) (*model.Installment, error) {
 	installment, err := r.Services.Installment.MarkComplete(id)
@@ -488,17 +564,31 @@ func (r *mutationResolver) CreateDebt(ctx context.Context, input model.CreateDeb
 		v := int64(*input.MonthlyPayment)
 		monthlyPayment = &v
 	}
+	var direction models.DebtDirection
+	if input.Direction != nil {
+		direction = models.DebtDirection(*input.Direction)
+	}
+	var compounding *models.DebtInterestCompounding
+	if input.InterestCompounding != nil {
+		c := models.DebtInterestCompounding(*input.InterestCompounding)
+		compounding = &c
+	}
 	debt, err := r.Services.Debt.Create(userID, services.CreateDebtInput{
-		PersonName:     input.PersonName,
-		ActualAmount:   int64(input.ActualAmount),
-		LoanAmount:     loanAmount,
-		PaymentType:    models.DebtPaymentType(input.PaymentType),
-		MonthlyPayment: monthlyPayment,
-		Tenor:          input.Tenor,
-		DueDate:        input.DueDate,
-		Icon:           input.Icon,
-		CardBgColor:    input.CardBgColor,
-		Notes:          input.Notes,
+		PersonName:          input.PersonName,
+		ActualAmount:        int64(input.ActualAmount),
+		LoanAmount:          loanAmount,
+		PaymentType:         models.DebtPaymentType(input.PaymentType),
+		Direction:           direction,
+		MonthlyPayment:      monthlyPayment,
+		Tenor:               input.Tenor,
+		DueDate:             input.DueDate,
+		Icon:                input.Icon,
+		CardBgColor:         input.CardBgColor,
+		Notes:               input.Notes,
+		PayeeID:             input.PayeeID,
+		InterestRate:        input.InterestRate,
+		InterestCompounding: compounding,
+		InterestStartDate:   input.InterestStartDate,
 	})
 	if err != nil {
 		return nil, err
@@ -542,22 +632,46 @@ func (r *mutationResolver) UpdateDebt(ctx context.Context, id uuid.UUID, input m
 	if input.DueDate != nil {
 		dueDate = input.DueDate
 	}
+	payeeID := debt.PayeeID
+	if input.PayeeID != nil {
+		payeeID = input.PayeeID
+	}
+	interestRate := debt.InterestRate
+	if input.InterestRate != nil {
+		interestRate = input.InterestRate
+	}
+	if input.ClearInterestRate != nil && *input.ClearInterestRate {
+		interestRate = nil
+	}
+	compounding := debt.InterestCompounding
+	if input.InterestCompounding != nil {
+		c := models.DebtInterestCompounding(*input.InterestCompounding)
+		compounding = &c
+	}
+	interestStartDate := debt.InterestStartDate
+	if input.InterestStartDate != nil {
+		interestStartDate = input.InterestStartDate
+	}
 	var status *models.DebtStatus
 	if input.Status != nil {
 		s := models.DebtStatus(*input.Status)
 		status = &s
 	}
 	updated, err := r.Services.Debt.Update(id, services.CreateDebtInput{
-		PersonName:     personName,
-		ActualAmount:   actualAmount,
-		LoanAmount:     loanAmount,
-		PaymentType:    paymentType,
-		MonthlyPayment: monthlyPayment,
-		Tenor:          tenor,
-		DueDate:        dueDate,
-		Icon:           input.Icon,
-		CardBgColor:    input.CardBgColor,
-		Notes:          input.Notes,
+		PersonName:          personName,
+		ActualAmount:        actualAmount,
+		LoanAmount:          loanAmount,
+		PaymentType:         paymentType,
+		MonthlyPayment:      monthlyPayment,
+		Tenor:               tenor,
+		DueDate:             dueDate,
+		Icon:                input.Icon,
+		CardBgColor:         input.CardBgColor,
+		Notes:               input.Notes,
+		PayeeID:             payeeID,
+		InterestRate:        interestRate,
+		InterestCompounding: compounding,
+		InterestStartDate:   interestStartDate,
 	}, status)
 	if err != nil {
 		return nil, err
@@ -580,6 +694,29 @@ func (r *mutationResolver) RecordDebtPayment(ctx context.Context, input model.Re
 	return debtPaymentToModel(payment), nil
 }
 
+// UpdateDebtPayment is the resolver for the updateDebtPayment field.
+func (r *mutationResolver) UpdateDebtPayment(ctx context.Context, id uuid.UUID, input model.UpdateDebtPaymentInput) (*model.DebtPayment, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	payment, err := r.Services.Debt.UpdatePayment(userID, id, updatePaymentInputFromModel(input.Amount, input.PaidAt, input.PocketID))
+	if err != nil {
+		return nil, err
+	}
+	return debtPaymentToModel(payment), nil
+}
+
+// DeleteDebtPayment is the resolver for the deleteDebtPayment field.
+func (r *mutationResolver) DeleteDebtPayment(ctx context.Context, id uuid.UUID) (bool, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return false, utils.UnauthorizedError(ctx)
+	}
+	err := r.Services.Debt.DeletePayment(userID, id)
+	return err == nil, err
+}
+
 // MarkDebtComplete is the resolver for the markDebtComplete field.
 func (r *mutationResolver) MarkDebtComplete(ctx context.Context, id uuid.UUID) (*model.Debt, error) {
 	debt, err := r.Services.Debt.MarkComplete(id)
@@ -595,7 +732,7 @@ func (r *mutationResolver) CreateIncomeCategory(ctx context.Context, input model
 	if !ok {
 		return nil, utils.UnauthorizedError(ctx)
 	}
-	cat, err := r.Services.IncomeCategory.Create(userID, input.Name)
+	cat, err := r.Services.IncomeCategory.Create(userID, input.Name, input.ParentID)
 	if err != nil {
 		return nil, err
 	}
@@ -604,7 +741,8 @@ func (r *mutationResolver) CreateIncomeCategory(ctx context.Context, input model
 
 // UpdateIncomeCategory is the resolver for the updateIncomeCategory field.
 func (r *mutationResolver) UpdateIncomeCategory(ctx context.Context, id uuid.UUID, input model.UpdateIncomeCategoryInput) (*model.IncomeCategory, error) {
-	cat, err := r.Services.IncomeCategory.Update(id, input.Name)
+	clearParent := input.ClearParent != nil && *input.ClearParent
+	cat, err := r.Services.IncomeCategory.Update(id, input.Name, input.ParentID, clearParent)
 	if err != nil {
 		return nil, err
 	}
@@ -617,6 +755,19 @@ func (r *mutationResolver) DeleteIncomeCategory(ctx context.Context, id uuid.UUI
 	return err == nil, err
 }
 
+// MergeIncomeCategories is the resolver for the mergeIncomeCategories field.
+func (r *mutationResolver) MergeIncomeCategories(ctx context.Context, sourceIds []uuid.UUID, targetID uuid.UUID) (*model.IncomeCategory, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	cat, err := r.Services.IncomeCategory.Merge(userID, sourceIds, targetID)
+	if err != nil {
+		return nil, err
+	}
+	return incomeCategoryToModel(cat), nil
+}
+
 // CreateIncome is the resolver for the createIncome field.
 func (r *mutationResolver) CreateIncome(ctx context.Context, input model.CreateIncomeInput) (*model.Income, error) {
 	userID, ok := middleware.GetUserID(ctx)
@@ -635,6 +786,7 @@ func (r *mutationResolver) CreateIncome(ctx context.Context, input model.CreateI
 		IsRecurring: isRecurring,
 		Notes:       input.Notes,
 		PocketID:    input.PocketID,
+		PayeeID:     input.PayeeID,
 	})
 	if err != nil {
 		return nil, err
@@ -657,6 +809,7 @@ func (r *mutationResolver) UpdateIncome(ctx context.Context, id uuid.UUID, input
 		IsRecurring: input.IsRecurring,
 		Notes:       input.Notes,
 		PocketID:    input.PocketID,
+		PayeeID:     input.PayeeID,
 	})
 	if err != nil {
 		return nil, err
@@ -685,11 +838,15 @@ func (r *mutationResolver) CreateRecurringIncomeGroup(ctx context.Context, input
 		}
 	}
 	group, err := r.Services.RecurringIncome.Create(userID, services.CreateRecurringIncomeGroupInput{
-		Name:         input.Name,
-		RecurringDay: input.RecurringDay,
-		IsActive:     input.IsActive,
-		Notes:        input.Notes,
-		Items:        items,
+		Name:               input.Name,
+		RecurringDay:       input.RecurringDay,
+		IsActive:           input.IsActive,
+		Notes:              input.Notes,
+		AutoPost:           input.AutoPost != nil && *input.AutoPost,
+		TargetPocketID:     input.TargetPocketID,
+		ShiftToBusinessDay: input.ShiftToBusinessDay != nil && *input.ShiftToBusinessDay,
+		Recurrence:         recurrenceInputFromModel(input.Recurrence),
+		Items:              items,
 	})
 	if err != nil {
 		return nil, err
@@ -700,10 +857,16 @@ func (r *mutationResolver) CreateRecurringIncomeGroup(ctx context.Context, input
 // UpdateRecurringIncomeGroup is the resolver for the updateRecurringIncomeGroup field.
 func (r *mutationResolver) UpdateRecurringIncomeGroup(ctx context.Context, id uuid.UUID, input model.UpdateRecurringIncomeGroupInput) (*model.RecurringIncomeGroup, error) {
 	group, err := r.Services.RecurringIncome.Update(id, services.UpdateRecurringIncomeGroupInput{
-		Name:         input.Name,
-		RecurringDay: input.RecurringDay,
-		IsActive:     input.IsActive,
-		Notes:        input.Notes,
+		Name:               input.Name,
+		RecurringDay:       input.RecurringDay,
+		IsActive:           input.IsActive,
+		Notes:              input.Notes,
+		AutoPost:           input.AutoPost,
+		TargetPocketID:     input.TargetPocketID,
+		ClearTargetPocket:  input.ClearTargetPocket != nil && *input.ClearTargetPocket,
+		ShiftToBusinessDay: input.ShiftToBusinessDay,
+		Recurrence:         recurrenceInputFromModel(input.Recurrence),
+		ClearRecurrence:    input.ClearRecurrence != nil && *input.ClearRecurrence,
 	})
 	if err != nil {
 		return nil, err
@@ -842,6 +1005,19 @@ func (r *mutationResolver) WithdrawSavingsContribution(ctx context.Context, id u
 	return err == nil, err
 }
 
+// WithdrawFromSavingsGoal is the resolver for the withdrawFromSavingsGoal field.
+func (r *mutationResolver) WithdrawFromSavingsGoal(ctx context.Context, input model.WithdrawFromSavingsGoalInput) (*model.SavingsWithdrawal, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	withdrawal, err := r.Services.SavingsGoal.WithdrawFromGoal(userID, input.SavingsGoalID, int64(input.Amount), input.WithdrawalDate, input.ToPocketID, input.Reason)
+	if err != nil {
+		return nil, err
+	}
+	return savingsWithdrawalToModel(withdrawal), nil
+}
+
 // MarkSavingsGoalComplete is the resolver for the markSavingsGoalComplete field.
 func (r *mutationResolver) MarkSavingsGoalComplete(ctx context.Context, id uuid.UUID) (*model.SavingsGoal, error) {
 	goal, err := r.Services.SavingsGoal.MarkComplete(id)
@@ -1009,6 +1185,7 @@ func (r *queryResolver) Expenses(ctx context.Context, filter *model.ExpenseFilte
 	if filter != nil {
 		repoFilter = &repository.ExpenseFilter{
 			CategoryID: filter.CategoryID,
+			PayeeID:    filter.PayeeID,
 		}
 		if filter.StartDate != nil {
 			s := filter.StartDate.Format("2006-01-02")
@@ -1030,8 +1207,15 @@ func (r *queryResolver) Expenses(ctx context.Context, filter *model.ExpenseFilte
 		items[i] = expenseToModel(&e)
 	}
 
-	// Calculate summary
-	summary := calculateExpenseSummary(exps)
+	// Calculate summary, optionally rolling child categories up into their parent
+	var roots map[uuid.UUID]models.Category
+	if filter != nil && filter.CategoryGrouping != nil && *filter.CategoryGrouping == model.CategoryGroupingRollup {
+		roots, err = r.Services.Category.GetRootMap(userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	summary := calculateExpenseSummary(exps, roots)
 
 	return &model.ExpensesWithSummary{
 		Items:   items,
@@ -1106,7 +1290,7 @@ func (r *queryResolver) Installment(ctx context.Context, id uuid.UUID) (*model.I
 }
 
 // Debts is the resolver for the debts field.
-func (r *queryResolver) Debts(ctx context.Context, status *model.DebtStatus) ([]*model.Debt, error) {
+func (r *queryResolver) Debts(ctx context.Context, status *model.DebtStatus, direction *model.DebtDirection) ([]*model.Debt, error) {
 	userID, ok := middleware.GetUserID(ctx)
 	if !ok {
 		return nil, utils.UnauthorizedError(ctx)
@@ -1116,7 +1300,12 @@ func (r *queryResolver) Debts(ctx context.Context, status *model.DebtStatus) ([]
 		st := models.DebtStatus(*status)
 		s = &st
 	}
-	debts, err := r.Services.Debt.GetByUserID(userID, s)
+	var d *models.DebtDirection
+	if direction != nil {
+		dir := models.DebtDirection(*direction)
+		d = &dir
+	}
+	debts, err := r.Services.Debt.GetByUserID(userID, s, d)
 	if err != nil {
 		return nil, err
 	}
@@ -1193,8 +1382,15 @@ func (r *queryResolver) Incomes(ctx context.Context, filter *model.IncomeFilter)
 		items[i] = incomeToModel(&inc)
 	}
 
-	// Calculate summary
-	summary := calculateIncomeSummary(incomes)
+	// Calculate summary, optionally rolling child categories up into their parent
+	var roots map[uuid.UUID]models.IncomeCategory
+	if filter != nil && filter.CategoryGrouping != nil && *filter.CategoryGrouping == model.CategoryGroupingRollup {
+		roots, err = r.Services.IncomeCategory.GetRootMap(userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	summary := calculateIncomeSummary(incomes, roots)
 
 	return &model.IncomesWithSummary{
 		Items:   items,
@@ -1228,6 +1424,27 @@ func (r *queryResolver) RecurringIncomeGroups(ctx context.Context, isActive *boo
 	return result, nil
 }
 
+// RecurringIncomeRuns is the resolver for the recurringIncomeRuns field.
+func (r *queryResolver) RecurringIncomeRuns(ctx context.Context, groupID *uuid.UUID, limit *int) ([]*model.RecurringIncomeRun, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	maxRuns := 50
+	if limit != nil {
+		maxRuns = *limit
+	}
+	runs, err := r.Services.RecurringIncome.GetRuns(userID, groupID, maxRuns)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.RecurringIncomeRun, len(runs))
+	for i, run := range runs {
+		result[i] = recurringIncomeRunToModel(&run)
+	}
+	return result, nil
+}
+
 // RecurringIncomeGroup is the resolver for the recurringIncomeGroup field.
 func (r *queryResolver) RecurringIncomeGroup(ctx context.Context, id uuid.UUID) (*model.RecurringIncomeGroup, error) {
 	group, err := r.Services.RecurringIncome.GetByID(id)
@@ -1252,10 +1469,16 @@ func (r *queryResolver) Balance(ctx context.Context, filter model.BalanceFilterI
 		endDate = filter.EndDate
 	}
 
+	var grouping services.CategoryGrouping
+	if filter.CategoryGrouping != nil {
+		grouping = services.CategoryGrouping(*filter.CategoryGrouping)
+	}
+
 	report, err := r.Services.Balance.GetBalance(userID, services.BalanceFilterInput{
-		Period:    services.BalancePeriod(filter.Period),
-		StartDate: startDate,
-		EndDate:   endDate,
+		Period:           services.BalancePeriod(filter.Period),
+		StartDate:        startDate,
+		EndDate:          endDate,
+		CategoryGrouping: grouping,
 	})
 	if err != nil {
 		return nil, err
@@ -1337,12 +1560,16 @@ func (r *queryResolver) ForecastSummary(ctx context.Context, filter *model.Month
 }
 
 // Dashboard is the resolver for the dashboard field.
-func (r *queryResolver) Dashboard(ctx context.Context) (*model.Dashboard, error) {
+func (r *queryResolver) Dashboard(ctx context.Context, categoryGrouping *model.CategoryGrouping) (*model.Dashboard, error) {
 	userID, ok := middleware.GetUserID(ctx)
 	if !ok {
 		return nil, utils.UnauthorizedError(ctx)
 	}
-	dash, err := r.Services.Dashboard.GetDashboard(userID)
+	var grouping services.CategoryGrouping
+	if categoryGrouping != nil {
+		grouping = services.CategoryGrouping(*categoryGrouping)
+	}
+	dash, err := r.Services.Dashboard.GetDashboard(userID, grouping)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,50 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.87
+
+import (
+	"context"
+
+	"github.com/azzamdhx/moneybro/backend/internal/graph/model"
+	"github.com/azzamdhx/moneybro/backend/internal/middleware"
+	"github.com/azzamdhx/moneybro/backend/internal/services"
+	"github.com/azzamdhx/moneybro/backend/internal/utils"
+)
+
+// ConvertSubscriptionToTemplate is the resolver for the convertSubscriptionToTemplate field.
+func (r *mutationResolver) ConvertSubscriptionToTemplate(ctx context.Context, input model.ConvertSubscriptionInput) (*model.ExpenseTemplateGroup, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	group, err := r.Services.Subscription.ConvertToTemplate(userID, services.ConvertSubscriptionInput{
+		Key:              input.Key,
+		Name:             input.Name,
+		AutoPost:         input.AutoPost != nil && *input.AutoPost,
+		NotifyOnAutoPost: input.NotifyOnAutoPost != nil && *input.NotifyOnAutoPost,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return expenseTemplateGroupToModel(group), nil
+}
+
+// DetectedSubscriptions is the resolver for the detectedSubscriptions field.
+func (r *queryResolver) DetectedSubscriptions(ctx context.Context) ([]*model.DetectedSubscription, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, utils.UnauthorizedError(ctx)
+	}
+	candidates, err := r.Services.Subscription.DetectSubscriptions(userID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.DetectedSubscription, len(candidates))
+	for i, c := range candidates {
+		result[i] = detectedSubscriptionToModel(&c)
+	}
+	return result, nil
+}
@@ -0,0 +1,559 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AmortizationPeriod is one scheduled payment of an installment
+type AmortizationPeriod struct {
+	PaymentNumber      int
+	DueDate            time.Time
+	Payment            int64
+	Principal          int64
+	Interest           int64
+	RemainingPrincipal int64
+	Paid               bool
+	PaidAt             *time.Time
+}
+
+// IsValid reports whether m is a known interest method
+func (m InstallmentInterestMethod) IsValid() bool {
+	switch m {
+	case InstallmentInterestMethodFlat, InstallmentInterestMethodAnnuity, InstallmentInterestMethodFixedPrincipal:
+		return true
+	}
+	return false
+}
+
+// CalculateInstallmentPayment returns the first monthly payment and the total
+// of all payments for a principal at an annual interest rate (percent)
+func CalculateInstallmentPayment(method InstallmentInterestMethod, principal int64, annualRate float64, tenor int) (monthlyPayment, total int64) {
+	for _, p := range buildAmortization(method, principal, annualRate, tenor, 0) {
+		if monthlyPayment == 0 {
+			monthlyPayment = p.Payment
+		}
+		total += p.Payment
+	}
+	return monthlyPayment, total
+}
+
+// AmortizationSchedule lists every period of the installment. Installments with
+// an interest rate follow their interest method; without one, the principal
+// (ActualAmount) and the interest (LoanAmount - ActualAmount) are spread evenly
+// over the tenor. Each prepayment re-amortizes the principal left after it over
+// the tenor that followed, and each restructure replaces the periods after it
+// with the ones of its terms. Due dates follow DueDay, clamped to the end of
+// short months.
+func (i *Installment) AmortizationSchedule() []AmortizationPeriod {
+	events := i.scheduleEvents()
+	original := i.originalTerms()
+	periods := original.build(i.ActualAmount, original.tenor)
+
+	method, rate := original.reamortization(i.ActualAmount)
+	for n, e := range events {
+		after := min(e.after, len(periods))
+		total := i.Tenor
+		if n+1 < len(events) {
+			total = events[n+1].previousTenor
+		}
+
+		var rest []AmortizationPeriod
+		if e.term != nil {
+			terms := e.term.loanTerms()
+			rest = terms.build(e.term.Principal, total-after)
+			method, rate = terms.reamortization(e.term.Principal)
+		} else if remaining := unpaidPrincipal(periods, after) - e.prepayment.Amount; remaining > 0 && total > after {
+			rest = buildAmortization(method, remaining, rate, total-after, 0)
+		}
+
+		periods = periods[:after]
+		for _, p := range rest {
+			p.PaymentNumber += after
+			periods = append(periods, p)
+		}
+	}
+
+	paid := make(map[int]time.Time, len(i.Payments))
+	for _, p := range i.Payments {
+		paid[p.PaymentNumber] = p.PaidAt
+	}
+
+	for n := range periods {
+		periods[n].DueDate = i.DueDateFor(periods[n].PaymentNumber)
+		if paidAt, ok := paid[periods[n].PaymentNumber]; ok {
+			periods[n].Paid = true
+			periods[n].PaidAt = &paidAt
+		}
+	}
+	return periods
+}
+
+// ApplyPrepayment takes an extra payment off the principal left after the
+// payments made so far and updates Tenor, MonthlyPayment and LoanAmount to the
+// re-amortized schedule. REDUCE_TENOR picks the shortest tenor whose payment does
+// not exceed the current one; REDUCE_PAYMENT keeps the tenor. The prepayment is
+// appended to Prepayments but not saved.
+func (i *Installment) ApplyPrepayment(amount int64, paidAt time.Time, strategy PrepaymentStrategy) (*InstallmentPrepayment, error) {
+	if amount <= 0 {
+		return nil, errors.New("extra amount must be positive")
+	}
+	if !strategy.IsValid() {
+		return nil, errors.New("invalid prepayment strategy")
+	}
+
+	schedule := i.AmortizationSchedule()
+	after := i.PaidCount()
+	if after >= len(schedule) {
+		return nil, errors.New("installment is already paid off")
+	}
+	remaining := unpaidPrincipal(schedule, after)
+	if amount > remaining {
+		return nil, errors.New("extra amount exceeds the remaining principal")
+	}
+
+	prepayment := &InstallmentPrepayment{
+		ID:                     uuid.New(),
+		InstallmentID:          i.ID,
+		Amount:                 amount,
+		PaidAt:                 paidAt,
+		AfterPaymentNumber:     after,
+		Strategy:               strategy,
+		PreviousTenor:          i.Tenor,
+		PreviousMonthlyPayment: i.MonthlyPayment,
+		PreviousLoanAmount:     i.LoanAmount,
+	}
+
+	// Terms for the re-amortized part must be read before the prepayment is added
+	method, rate := i.reamortizationTerms()
+	left := remaining - amount
+	switch {
+	case left == 0:
+		i.Tenor = after
+	case strategy == PrepaymentStrategyReduceTenor:
+		current := schedule[after].Payment
+		periods := i.Tenor - after
+		for m := 1; m < periods; m++ {
+			if buildAmortization(method, left, rate, m, 0)[0].Payment <= current {
+				periods = m
+				break
+			}
+		}
+		i.Tenor = after + periods
+	}
+	i.Prepayments = append(i.Prepayments, *prepayment)
+
+	schedule = i.AmortizationSchedule()
+	i.LoanAmount = loanTotal(i, schedule)
+	if after < len(schedule) {
+		i.MonthlyPayment = schedule[after].Payment
+	} else {
+		i.Status = InstallmentStatusCompleted
+	}
+
+	return prepayment, nil
+}
+
+// Restructure replaces the terms of the payments left after the ones made so
+// far. The principal left plus the term's Fee and InterestAdjustment is
+// re-amortized over term.Tenor payments at the term's rate, or at
+// term.MonthlyPayment without one. Tenor, MonthlyPayment, LoanAmount, DueDay
+// and the interest terms of the installment are updated. The term is appended
+// to Terms but not saved.
+func (i *Installment) Restructure(term InstallmentTerm) (*InstallmentTerm, error) {
+	if term.EffectiveDate.IsZero() {
+		return nil, errors.New("effective date is required")
+	}
+	if term.Tenor <= 0 {
+		return nil, errors.New("tenor must be positive")
+	}
+	if term.DueDay < 1 || term.DueDay > 31 {
+		return nil, errors.New("due day must be between 1 and 31")
+	}
+	if term.InterestMethod == "" {
+		term.InterestMethod = InstallmentInterestMethodFlat
+	}
+	if !term.InterestMethod.IsValid() {
+		return nil, errors.New("invalid interest method")
+	}
+	if term.Fee < 0 {
+		return nil, errors.New("fee cannot be negative")
+	}
+
+	schedule := i.AmortizationSchedule()
+	after := i.PaidCount()
+	if after >= len(schedule) {
+		return nil, errors.New("installment is already paid off")
+	}
+	term.Principal = unpaidPrincipal(schedule, after) + term.Capitalized()
+	if term.Principal <= 0 {
+		return nil, errors.New("interest adjustment exceeds the remaining principal")
+	}
+
+	if term.InterestRate != nil {
+		if *term.InterestRate < 0 || *term.InterestRate > 100 {
+			return nil, errors.New("interest rate must be between 0 and 100 percent")
+		}
+		term.MonthlyPayment, term.LoanAmount = CalculateInstallmentPayment(term.InterestMethod, term.Principal, *term.InterestRate, term.Tenor)
+	} else {
+		if term.MonthlyPayment <= 0 {
+			return nil, errors.New("monthly payment is required without an interest rate")
+		}
+		term.InterestMethod = InstallmentInterestMethodFlat
+		term.LoanAmount = term.MonthlyPayment * int64(term.Tenor)
+		if term.LoanAmount < term.Principal {
+			return nil, errors.New("monthly payments do not cover the remaining principal")
+		}
+	}
+
+	term.ID = uuid.New()
+	term.InstallmentID = i.ID
+	term.Version = len(i.Terms) + 2
+	term.AfterPaymentNumber = after
+	term.PreviousTenor = i.Tenor
+	term.PreviousMonthlyPayment = i.MonthlyPayment
+	term.PreviousLoanAmount = i.LoanAmount
+	term.PreviousInterestMethod = i.InterestMethod
+	term.PreviousInterestRate = i.InterestRate
+	term.PreviousDueDay = i.DueDay
+	i.Terms = append(i.Terms, term)
+
+	i.Tenor = after + term.Tenor
+	i.MonthlyPayment = term.MonthlyPayment
+	i.InterestMethod = term.InterestMethod
+	i.InterestRate = term.InterestRate
+	i.DueDay = term.DueDay
+	i.LoanAmount = loanTotal(i, i.AmortizationSchedule())
+
+	return &term, nil
+}
+
+// TermHistory lists the versions of the installment's terms, starting with the
+// ones it was created with
+func (i *Installment) TermHistory() []InstallmentTerm {
+	original := i.originalTerms()
+	monthlyPayment := i.MonthlyPayment
+	if events := i.scheduleEvents(); len(events) > 0 {
+		if events[0].term != nil {
+			monthlyPayment = events[0].term.PreviousMonthlyPayment
+		} else {
+			monthlyPayment = events[0].prepayment.PreviousMonthlyPayment
+		}
+	}
+
+	history := []InstallmentTerm{{
+		InstallmentID:  i.ID,
+		Version:        1,
+		EffectiveDate:  i.StartDate,
+		Principal:      i.ActualAmount,
+		Tenor:          original.tenor,
+		MonthlyPayment: monthlyPayment,
+		LoanAmount:     original.loanAmount,
+		InterestMethod: original.method,
+		InterestRate:   original.rate,
+		DueDay:         original.dueDay,
+		CreatedAt:      i.CreatedAt,
+	}}
+	return append(history, i.sortedTerms()...)
+}
+
+// loanTerms are the terms a stretch of the schedule is built from
+type loanTerms struct {
+	tenor      int
+	loanAmount int64
+	method     InstallmentInterestMethod
+	rate       *float64
+	dueDay     int
+}
+
+func (t *InstallmentTerm) loanTerms() loanTerms {
+	return loanTerms{
+		tenor:      t.Tenor,
+		loanAmount: t.LoanAmount,
+		method:     t.InterestMethod,
+		rate:       t.InterestRate,
+		dueDay:     t.DueDay,
+	}
+}
+
+// build amortizes principal over tenor periods. Without a rate the interest
+// of the terms (loanAmount - principal) is spread evenly.
+func (t loanTerms) build(principal int64, tenor int) []AmortizationPeriod {
+	if t.rate != nil {
+		return buildAmortization(t.method, principal, *t.rate, tenor, 0)
+	}
+	return buildAmortization(InstallmentInterestMethodFlat, principal, 0, tenor, t.loanAmount-principal)
+}
+
+// reamortization returns the method and annual rate used after a prepayment.
+// Without a rate the flat interest of the terms is turned into an equivalent
+// rate.
+func (t loanTerms) reamortization(principal int64) (InstallmentInterestMethod, float64) {
+	if t.rate != nil {
+		return t.method, *t.rate
+	}
+	if principal <= 0 || t.tenor <= 0 {
+		return InstallmentInterestMethodFlat, 0
+	}
+	return InstallmentInterestMethodFlat, float64(t.loanAmount-principal) / float64(principal) / float64(t.tenor) * 12 * 100
+}
+
+// originalTerms returns the terms the installment was created with
+func (i *Installment) originalTerms() loanTerms {
+	terms := loanTerms{
+		tenor:      i.Tenor,
+		loanAmount: i.LoanAmount,
+		method:     i.InterestMethod,
+		rate:       i.InterestRate,
+		dueDay:     i.DueDay,
+	}
+	if events := i.scheduleEvents(); len(events) > 0 {
+		terms.tenor, terms.loanAmount = events[0].previousTenor, events[0].previousLoanAmount
+	}
+	if sorted := i.sortedTerms(); len(sorted) > 0 {
+		terms.method, terms.rate, terms.dueDay = sorted[0].PreviousInterestMethod, sorted[0].PreviousInterestRate, sorted[0].PreviousDueDay
+	}
+	return terms
+}
+
+// reamortizationTerms returns the method and annual rate used after a
+// prepayment under the current terms
+func (i *Installment) reamortizationTerms() (InstallmentInterestMethod, float64) {
+	if sorted := i.sortedTerms(); len(sorted) > 0 {
+		current := sorted[len(sorted)-1]
+		return current.loanTerms().reamortization(current.Principal)
+	}
+	return i.originalTerms().reamortization(i.ActualAmount)
+}
+
+// scheduleEvent is a prepayment or a restructure replayed on the schedule
+type scheduleEvent struct {
+	after              int
+	date               time.Time
+	createdAt          time.Time
+	previousTenor      int
+	previousLoanAmount int64
+	prepayment         *InstallmentPrepayment
+	term               *InstallmentTerm
+}
+
+// scheduleEvents lists the prepayments and restructures in the order they were
+// made. Ones not saved yet have no CreatedAt and come last.
+func (i *Installment) scheduleEvents() []scheduleEvent {
+	events := make([]scheduleEvent, 0, len(i.Prepayments)+len(i.Terms))
+	for n := range i.Prepayments {
+		p := &i.Prepayments[n]
+		events = append(events, scheduleEvent{
+			after:              p.AfterPaymentNumber,
+			date:               p.PaidAt,
+			createdAt:          p.CreatedAt,
+			previousTenor:      p.PreviousTenor,
+			previousLoanAmount: p.PreviousLoanAmount,
+			prepayment:         p,
+		})
+	}
+	for n := range i.Terms {
+		t := &i.Terms[n]
+		events = append(events, scheduleEvent{
+			after:              t.AfterPaymentNumber,
+			date:               t.EffectiveDate,
+			createdAt:          t.CreatedAt,
+			previousTenor:      t.PreviousTenor,
+			previousLoanAmount: t.PreviousLoanAmount,
+			term:               t,
+		})
+	}
+
+	sort.SliceStable(events, func(a, b int) bool {
+		if events[a].after != events[b].after {
+			return events[a].after < events[b].after
+		}
+		if !events[a].date.Equal(events[b].date) {
+			return events[a].date.Before(events[b].date)
+		}
+		if events[a].createdAt.IsZero() || events[b].createdAt.IsZero() {
+			return !events[a].createdAt.IsZero()
+		}
+		return events[a].createdAt.Before(events[b].createdAt)
+	})
+	return events
+}
+
+func (i *Installment) sortedTerms() []InstallmentTerm {
+	terms := append([]InstallmentTerm(nil), i.Terms...)
+	sort.SliceStable(terms, func(a, b int) bool { return terms[a].Version < terms[b].Version })
+	return terms
+}
+
+// loanTotal returns the principal owed over the life of the installment,
+// including amounts added by restructures, plus the interest of the schedule
+func loanTotal(i *Installment, schedule []AmortizationPeriod) int64 {
+	total := i.ActualAmount
+	for _, t := range i.Terms {
+		total += t.Capitalized()
+	}
+	for _, p := range schedule {
+		total += p.Interest
+	}
+	return total
+}
+
+// unpaidPrincipal returns the principal of the periods after the first n
+func unpaidPrincipal(periods []AmortizationPeriod, n int) int64 {
+	var total int64
+	for _, p := range periods[n:] {
+		total += p.Principal
+	}
+	return total
+}
+
+// DueDateFor returns the due date of a payment number, counting the start
+// month as the first period. Payments after a restructure count from the month
+// of its effective date.
+func (i *Installment) DueDateFor(paymentNumber int) time.Time {
+	start, day, n := i.periodTerms(paymentNumber)
+	month := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, n-1, 0)
+	lastDay := month.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(month.Year(), month.Month(), day, 0, 0, 0, 0, time.UTC)
+}
+
+// PeriodDate returns the date a payment's period starts: a month after the
+// previous one, counted from StartDate or from the effective date of the
+// restructure governing it
+func (i *Installment) PeriodDate(paymentNumber int) time.Time {
+	start, _, n := i.periodTerms(paymentNumber)
+	return start.AddDate(0, n-1, 0)
+}
+
+// MonthSpan returns the number of months from the start month to the month of
+// the last payment
+func (i *Installment) MonthSpan() int {
+	if i.Tenor <= 0 {
+		return 0
+	}
+	last := i.DueDateFor(i.Tenor)
+	return (last.Year()-i.StartDate.Year())*12 + int(last.Month()) - int(i.StartDate.Month()) + 1
+}
+
+// periodTerms returns the start date and due day of the terms governing a
+// payment number, and the payment's number under those terms
+func (i *Installment) periodTerms(paymentNumber int) (time.Time, int, int) {
+	terms := i.sortedTerms()
+	for n := len(terms) - 1; n >= 0; n-- {
+		if paymentNumber > terms[n].AfterPaymentNumber {
+			return terms[n].EffectiveDate, terms[n].DueDay, paymentNumber - terms[n].AfterPaymentNumber
+		}
+	}
+	return i.StartDate, i.originalTerms().dueDay, paymentNumber
+}
+
+// buildAmortization splits principal and interest over the tenor. flatInterest
+// is the total interest of a flat schedule without a rate. Rounding differences
+// go to the last period so the principal is fully repaid.
+func buildAmortization(method InstallmentInterestMethod, principal int64, annualRate float64, tenor int, flatInterest int64) []AmortizationPeriod {
+	if tenor <= 0 {
+		return []AmortizationPeriod{}
+	}
+
+	rate := annualRate / 12 / 100
+	if method == InstallmentInterestMethodFlat && annualRate > 0 {
+		flatInterest = int64(math.Round(float64(principal)*rate)) * int64(tenor)
+	}
+
+	var annuity int64
+	if method == InstallmentInterestMethodAnnuity {
+		if rate > 0 {
+			annuity = int64(math.Round(float64(principal) * rate / (1 - math.Pow(1+rate, -float64(tenor)))))
+		} else {
+			annuity = int64(math.Round(float64(principal) / float64(tenor)))
+		}
+	}
+
+	periods := make([]AmortizationPeriod, tenor)
+	remaining := principal
+	for n := 0; n < tenor; n++ {
+		last := n == tenor-1
+		var principalPart, interest int64
+
+		switch method {
+		case InstallmentInterestMethodAnnuity:
+			interest = int64(math.Round(float64(remaining) * rate))
+			principalPart = annuity - interest
+		case InstallmentInterestMethodFixedPrincipal:
+			interest = int64(math.Round(float64(remaining) * rate))
+			principalPart = principal / int64(tenor)
+		default:
+			interest = flatInterest / int64(tenor)
+			if last {
+				interest = flatInterest - interest*int64(tenor-1)
+			}
+			principalPart = principal / int64(tenor)
+		}
+
+		if last || principalPart > remaining {
+			principalPart = remaining
+		}
+		remaining -= principalPart
+
+		periods[n] = AmortizationPeriod{
+			PaymentNumber:      n + 1,
+			Payment:            principalPart + interest,
+			Principal:          principalPart,
+			Interest:           interest,
+			RemainingPrincipal: remaining,
+		}
+	}
+	return periods
+}
+
+// RemainingPrincipal returns the principal not yet covered by the payments made
+func (i *Installment) RemainingPrincipal() int64 {
+	schedule := i.AmortizationSchedule()
+	paid := i.PaidCount()
+	if paid > len(schedule) {
+		paid = len(schedule)
+	}
+	return unpaidPrincipal(schedule, paid)
+}
+
+// EffectiveAnnualRate returns the annual rate (percent) charged on the remaining
+// principal under the current terms. Flat loans are converted to the annuity
+// rate that gives the same payment over the same tenor.
+func (i *Installment) EffectiveAnnualRate() float64 {
+	terms, amount := i.originalTerms(), i.ActualAmount
+	if sorted := i.sortedTerms(); len(sorted) > 0 {
+		current := sorted[len(sorted)-1]
+		terms, amount = current.loanTerms(), current.Principal
+	}
+	if terms.rate != nil && terms.method != InstallmentInterestMethodFlat {
+		return *terms.rate
+	}
+
+	tenor, loanAmount := terms.tenor, terms.loanAmount
+	if terms.rate != nil {
+		_, loanAmount = CalculateInstallmentPayment(InstallmentInterestMethodFlat, amount, *terms.rate, tenor)
+	}
+	if amount <= 0 || tenor <= 0 || loanAmount <= amount {
+		return 0
+	}
+
+	principal := float64(amount)
+	payment := float64(loanAmount) / float64(tenor)
+	low, high := 0.0, 1.0
+	for n := 0; n < 100; n++ {
+		rate := (low + high) / 2
+		if principal*rate/(1-math.Pow(1+rate, -float64(tenor))) > payment {
+			high = rate
+		} else {
+			low = rate
+		}
+	}
+	return (low + high) / 2 * 12 * 100
+}
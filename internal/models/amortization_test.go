@@ -0,0 +1,229 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// newTestInstallment is 1.200.000 without interest over 12 months, due on the 10th
+func newTestInstallment(paid int) *Installment {
+	installment := &Installment{
+		ID:             uuid.New(),
+		ActualAmount:   1200000,
+		LoanAmount:     1200000,
+		MonthlyPayment: 100000,
+		Tenor:          12,
+		StartDate:      date(2026, time.January, 10),
+		DueDay:         10,
+		InterestMethod: InstallmentInterestMethodFlat,
+		Status:         InstallmentStatusActive,
+	}
+	for n := 1; n <= paid; n++ {
+		installment.Payments = append(installment.Payments, InstallmentPayment{
+			ID:            uuid.New(),
+			InstallmentID: installment.ID,
+			PaymentNumber: n,
+			Amount:        installment.MonthlyPayment,
+			PaidAt:        installment.DueDateFor(n),
+		})
+	}
+	return installment
+}
+
+func mustPrepay(t *testing.T, installment *Installment, amount int64, strategy PrepaymentStrategy) {
+	t.Helper()
+	prepayment, err := installment.ApplyPrepayment(amount, date(2026, time.April, 1), strategy)
+	if err != nil {
+		t.Fatalf("ApplyPrepayment: %v", err)
+	}
+	// Saved prepayments come back with a creation time
+	installment.Prepayments[len(installment.Prepayments)-1].CreatedAt = prepayment.PaidAt
+}
+
+func mustRestructure(t *testing.T, installment *Installment, term InstallmentTerm) {
+	t.Helper()
+	if _, err := installment.Restructure(term); err != nil {
+		t.Fatalf("Restructure: %v", err)
+	}
+	installment.Terms[len(installment.Terms)-1].CreatedAt = term.EffectiveDate
+}
+
+func TestInstallmentAmortizationSchedule(t *testing.T) {
+	tests := []struct {
+		name string
+		// build returns the installment with its prepayments and restructures replayed
+		build          func(t *testing.T) *Installment
+		wantPeriods    int
+		wantPrincipal  int64
+		wantPayments   map[int]int64
+		wantDueDates   map[int]time.Time
+		wantPeriodDate map[int]time.Time
+		wantLoanAmount int64
+	}{
+		{
+			name:           "original terms",
+			build:          func(t *testing.T) *Installment { return newTestInstallment(0) },
+			wantPeriods:    12,
+			wantPrincipal:  1200000,
+			wantPayments:   map[int]int64{1: 100000, 12: 100000},
+			wantDueDates:   map[int]time.Time{1: date(2026, time.January, 10), 12: date(2026, time.December, 10)},
+			wantPeriodDate: map[int]time.Time{1: date(2026, time.January, 10), 3: date(2026, time.March, 10)},
+			wantLoanAmount: 1200000,
+		},
+		{
+			name: "prepayment reducing the payment",
+			build: func(t *testing.T) *Installment {
+				installment := newTestInstallment(3)
+				mustPrepay(t, installment, 300000, PrepaymentStrategyReducePayment)
+				return installment
+			},
+			wantPeriods:    12,
+			wantPrincipal:  900000,
+			wantPayments:   map[int]int64{3: 100000, 4: 66666, 12: 66672},
+			wantDueDates:   map[int]time.Time{4: date(2026, time.April, 10), 12: date(2026, time.December, 10)},
+			wantPeriodDate: map[int]time.Time{4: date(2026, time.April, 10)},
+			wantLoanAmount: 1200000,
+		},
+		{
+			name: "prepayment reducing the tenor",
+			build: func(t *testing.T) *Installment {
+				installment := newTestInstallment(3)
+				mustPrepay(t, installment, 300000, PrepaymentStrategyReduceTenor)
+				return installment
+			},
+			wantPeriods:    9,
+			wantPrincipal:  900000,
+			wantPayments:   map[int]int64{4: 100000, 9: 100000},
+			wantDueDates:   map[int]time.Time{9: date(2026, time.September, 10)},
+			wantLoanAmount: 1200000,
+		},
+		{
+			name: "restructure with a new due day",
+			build: func(t *testing.T) *Installment {
+				installment := newTestInstallment(3)
+				mustRestructure(t, installment, InstallmentTerm{
+					EffectiveDate:  date(2026, time.June, 15),
+					Tenor:          6,
+					MonthlyPayment: 160000,
+					DueDay:         25,
+				})
+				return installment
+			},
+			wantPeriods:   9,
+			wantPrincipal: 1200000,
+			wantPayments:  map[int]int64{3: 100000, 4: 160000, 9: 160000},
+			wantDueDates: map[int]time.Time{
+				3: date(2026, time.March, 10),
+				4: date(2026, time.June, 25),
+				9: date(2026, time.November, 25),
+			},
+			wantPeriodDate: map[int]time.Time{
+				3: date(2026, time.March, 10),
+				4: date(2026, time.June, 15),
+				5: date(2026, time.July, 15),
+			},
+			wantLoanAmount: 1260000,
+		},
+		{
+			name: "restructure with a fee",
+			build: func(t *testing.T) *Installment {
+				installment := newTestInstallment(3)
+				mustRestructure(t, installment, InstallmentTerm{
+					EffectiveDate:  date(2026, time.April, 1),
+					Tenor:          10,
+					MonthlyPayment: 100000,
+					DueDay:         10,
+					Fee:            50000,
+				})
+				return installment
+			},
+			wantPeriods:    13,
+			wantPrincipal:  1250000,
+			wantPayments:   map[int]int64{4: 100000, 13: 100000},
+			wantDueDates:   map[int]time.Time{13: date(2027, time.January, 10)},
+			wantPeriodDate: map[int]time.Time{4: date(2026, time.April, 1)},
+			wantLoanAmount: 1300000,
+		},
+		{
+			name: "prepayment then restructure",
+			build: func(t *testing.T) *Installment {
+				installment := newTestInstallment(3)
+				mustPrepay(t, installment, 300000, PrepaymentStrategyReducePayment)
+				installment.Payments = append(installment.Payments, InstallmentPayment{
+					ID:            uuid.New(),
+					InstallmentID: installment.ID,
+					PaymentNumber: 4,
+					Amount:        installment.MonthlyPayment,
+					PaidAt:        date(2026, time.April, 10),
+				})
+				mustRestructure(t, installment, InstallmentTerm{
+					EffectiveDate:  date(2026, time.May, 1),
+					Tenor:          6,
+					MonthlyPayment: 100000,
+					DueDay:         5,
+				})
+				return installment
+			},
+			wantPeriods:   10,
+			wantPrincipal: 900000,
+			wantPayments:  map[int]int64{3: 100000, 4: 66666, 5: 100000, 10: 100000},
+			wantDueDates: map[int]time.Time{
+				4:  date(2026, time.April, 10),
+				5:  date(2026, time.May, 5),
+				10: date(2026, time.October, 5),
+			},
+			wantPeriodDate: map[int]time.Time{4: date(2026, time.April, 10), 5: date(2026, time.May, 1)},
+			wantLoanAmount: 1266666,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			installment := tt.build(t)
+			schedule := installment.AmortizationSchedule()
+
+			if len(schedule) != tt.wantPeriods {
+				t.Fatalf("got %d periods, want %d", len(schedule), tt.wantPeriods)
+			}
+			if installment.Tenor != tt.wantPeriods {
+				t.Errorf("tenor = %d, want %d", installment.Tenor, tt.wantPeriods)
+			}
+			var principal int64
+			for _, p := range schedule {
+				principal += p.Principal
+			}
+			if principal != tt.wantPrincipal {
+				t.Errorf("principal repaid = %d, want %d", principal, tt.wantPrincipal)
+			}
+			for number, want := range tt.wantPayments {
+				if got := schedule[number-1].Payment; got != want {
+					t.Errorf("payment %d = %d, want %d", number, got, want)
+				}
+			}
+			for number, want := range tt.wantDueDates {
+				if got := schedule[number-1].DueDate; !got.Equal(want) {
+					t.Errorf("due date %d = %s, want %s", number, got.Format("2006-01-02"), want.Format("2006-01-02"))
+				}
+			}
+			for number, want := range tt.wantPeriodDate {
+				if got := installment.PeriodDate(number); !got.Equal(want) {
+					t.Errorf("period date %d = %s, want %s", number, got.Format("2006-01-02"), want.Format("2006-01-02"))
+				}
+			}
+			if installment.LoanAmount != tt.wantLoanAmount {
+				t.Errorf("loan amount = %d, want %d", installment.LoanAmount, tt.wantLoanAmount)
+			}
+			for n, p := range schedule {
+				if p.Paid != (n < installment.PaidCount()) {
+					t.Errorf("period %d paid = %v with %d payments made", p.PaymentNumber, p.Paid, installment.PaidCount())
+				}
+			}
+		})
+	}
+}
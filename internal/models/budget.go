@@ -0,0 +1,70 @@
+package models
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Budget is a monthly spending limit for an expense category, or for all
+// spending when CategoryID is nil. A category budget also covers its child
+// categories. Overrides replace the amount for single months.
+//
+// AlertThresholds holds the comma separated spending percentages (e.g. "80,100")
+// at which a budget alert email is sent; empty disables alerts.
+type Budget struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID          uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	CategoryID      *uuid.UUID `gorm:"type:uuid" json:"category_id,omitempty"`
+	Amount          int64      `gorm:"not null" json:"amount"`
+	AlertThresholds string     `gorm:"type:varchar(50);not null;default:'80,100'" json:"alert_thresholds"`
+	CreatedAt       time.Time  `gorm:"default:now()" json:"created_at"`
+
+	User      *User            `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Category  *Category        `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
+	Overrides []BudgetOverride `gorm:"foreignKey:BudgetID" json:"overrides,omitempty"`
+}
+
+func (Budget) TableName() string {
+	return "budgets"
+}
+
+// AmountFor returns the budgeted amount of a month, taking overrides into account
+func (b *Budget) AmountFor(month, year int) int64 {
+	for _, o := range b.Overrides {
+		if o.Month == month && o.Year == year {
+			return o.Amount
+		}
+	}
+	return b.Amount
+}
+
+// Thresholds returns the alert thresholds in ascending order
+func (b *Budget) Thresholds() []int {
+	var thresholds []int
+	for _, part := range strings.Split(b.AlertThresholds, ",") {
+		if n, err := strconv.Atoi(strings.TrimSpace(part)); err == nil && n > 0 {
+			thresholds = append(thresholds, n)
+		}
+	}
+	sort.Ints(thresholds)
+	return thresholds
+}
+
+type BudgetOverride struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	BudgetID  uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_budget_overrides_budget_month" json:"budget_id"`
+	Month     int       `gorm:"not null;uniqueIndex:idx_budget_overrides_budget_month" json:"month"`
+	Year      int       `gorm:"not null;uniqueIndex:idx_budget_overrides_budget_month" json:"year"`
+	Amount    int64     `gorm:"not null" json:"amount"`
+	CreatedAt time.Time `gorm:"default:now()" json:"created_at"`
+
+	Budget *Budget `gorm:"foreignKey:BudgetID" json:"budget,omitempty"`
+}
+
+func (BudgetOverride) TableName() string {
+	return "budget_overrides"
+}
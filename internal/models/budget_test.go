@@ -0,0 +1,28 @@
+package models
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBudgetThresholds(t *testing.T) {
+	tests := []struct {
+		name       string
+		thresholds string
+		want       []int
+	}{
+		{name: "default", thresholds: "80,100", want: []int{80, 100}},
+		{name: "unsorted with spaces", thresholds: "150, 50 ,100", want: []int{50, 100, 150}},
+		{name: "alerts disabled", thresholds: ""},
+		{name: "invalid entries are skipped", thresholds: "80,abc,0,-10,100", want: []int{80, 100}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			budget := Budget{AlertThresholds: tt.thresholds}
+			if got := budget.Thresholds(); !slices.Equal(got, tt.want) {
+				t.Errorf("Thresholds() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
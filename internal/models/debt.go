@@ -8,6 +8,7 @@ import (
 
 type DebtStatus string
 type DebtPaymentType string
+type DebtDirection string
 
 const (
 	DebtStatusActive    DebtStatus = "ACTIVE"
@@ -15,8 +16,18 @@ const (
 
 	DebtPaymentTypeOneTime     DebtPaymentType = "ONE_TIME"
 	DebtPaymentTypeInstallment DebtPaymentType = "INSTALLMENT"
+
+	// DebtDirectionPayable is money the user owes, DebtDirectionReceivable is
+	// money the user lent to someone else
+	DebtDirectionPayable    DebtDirection = "PAYABLE"
+	DebtDirectionReceivable DebtDirection = "RECEIVABLE"
 )
 
+// IsValid reports whether d is a known debt direction
+func (d DebtDirection) IsValid() bool {
+	return d == DebtDirectionPayable || d == DebtDirectionReceivable
+}
+
 type Debt struct {
 	ID             uuid.UUID       `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
 	UserID         uuid.UUID       `gorm:"type:uuid;not null" json:"user_id"`
@@ -24,6 +35,7 @@ type Debt struct {
 	ActualAmount   int64           `gorm:"not null" json:"actual_amount"`
 	LoanAmount     *int64          `json:"loan_amount,omitempty"`
 	PaymentType    DebtPaymentType `gorm:"type:varchar(20);not null" json:"payment_type"`
+	Direction      DebtDirection   `gorm:"type:varchar(20);not null;default:'PAYABLE'" json:"direction"`
 	MonthlyPayment *int64          `json:"monthly_payment,omitempty"`
 	Tenor          *int            `json:"tenor,omitempty"`
 	DueDate        *time.Time      `gorm:"type:date" json:"due_date,omitempty"`
@@ -31,10 +43,19 @@ type Debt struct {
 	Icon           *string         `gorm:"type:varchar(50)" json:"icon,omitempty"`
 	CardBgColor    *string         `gorm:"type:varchar(50)" json:"card_bg_color,omitempty"`
 	Notes          *string         `gorm:"type:text" json:"notes,omitempty"`
-	CreatedAt      time.Time       `gorm:"default:now()" json:"created_at"`
-
-	User     *User         `gorm:"foreignKey:UserID" json:"user,omitempty"`
-	Payments []DebtPayment `gorm:"foreignKey:DebtID" json:"payments,omitempty"`
+	PayeeID        *uuid.UUID      `gorm:"type:uuid" json:"payee_id,omitempty"`
+	// ConsolidationID is the consolidation that paid the debt off
+	ConsolidationID *uuid.UUID `gorm:"type:uuid" json:"consolidation_id,omitempty"`
+	// InterestRate is an annual percentage accrued monthly on the balance left
+	InterestRate        *float64                 `gorm:"type:decimal(7,4)" json:"interest_rate,omitempty"`
+	InterestCompounding *DebtInterestCompounding `gorm:"type:varchar(20)" json:"interest_compounding,omitempty"`
+	InterestStartDate   *time.Time               `gorm:"type:date" json:"interest_start_date,omitempty"`
+	CreatedAt           time.Time                `gorm:"default:now()" json:"created_at"`
+
+	User             *User                 `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Payee            *Payee                `gorm:"foreignKey:PayeeID" json:"payee,omitempty"`
+	Payments         []DebtPayment         `gorm:"foreignKey:DebtID" json:"payments,omitempty"`
+	InterestAccruals []DebtInterestAccrual `gorm:"foreignKey:DebtID" json:"interest_accruals,omitempty"`
 }
 
 func (Debt) TableName() string {
@@ -73,8 +94,9 @@ func (d *Debt) PaidAmount() int64 {
 	return total
 }
 
+// RemainingAmount includes the interest accrued so far
 func (d *Debt) RemainingAmount() int64 {
-	return d.TotalToPay() - d.PaidAmount()
+	return d.TotalToPay() + d.AccruedInterest() - d.PaidAmount()
 }
 
 func (d *Debt) PaidCount() int {
@@ -91,3 +113,7 @@ func (d *Debt) RemainingPayments() int {
 func (d *Debt) IsInstallment() bool {
 	return d.PaymentType == DebtPaymentTypeInstallment
 }
+
+func (d *Debt) IsReceivable() bool {
+	return d.Direction == DebtDirectionReceivable
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DebtConsolidation pays off installments and debts with one new loan, either
+// InstallmentID or DebtID. The loans paid off link back to it through their
+// ConsolidationID. PayoffAmount is the balance carried over to the new loan
+// and Fee the origination fee added on top; the total costs are what was left
+// to pay on the old loans and what the new loan costs.
+type DebtConsolidation struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID        uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	InstallmentID *uuid.UUID `gorm:"type:uuid" json:"installment_id,omitempty"`
+	DebtID        *uuid.UUID `gorm:"type:uuid" json:"debt_id,omitempty"`
+	Date          time.Time  `gorm:"type:date;not null" json:"date"`
+	PayoffAmount  int64      `gorm:"not null" json:"payoff_amount"`
+	Fee           int64      `gorm:"not null;default:0" json:"fee"`
+	FeeCategoryID *uuid.UUID `gorm:"type:uuid" json:"fee_category_id,omitempty"`
+	OldTotalCost  int64      `gorm:"not null" json:"old_total_cost"`
+	NewTotalCost  int64      `gorm:"not null" json:"new_total_cost"`
+	CreatedAt     time.Time  `gorm:"default:now()" json:"created_at"`
+}
+
+func (DebtConsolidation) TableName() string {
+	return "debt_consolidations"
+}
@@ -0,0 +1,209 @@
+package models
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DebtInterestCompounding string
+
+const (
+	// DebtInterestCompoundingSimple charges interest on the principal left only
+	DebtInterestCompoundingSimple DebtInterestCompounding = "SIMPLE"
+	// DebtInterestCompoundingMonthly adds unpaid interest to the balance that
+	// earns interest from the next month
+	DebtInterestCompoundingMonthly DebtInterestCompounding = "MONTHLY"
+	// DebtInterestCompoundingDaily adds interest to the balance every day
+	DebtInterestCompoundingDaily DebtInterestCompounding = "DAILY"
+)
+
+// IsValid reports whether c is a known compounding period
+func (c DebtInterestCompounding) IsValid() bool {
+	switch c {
+	case DebtInterestCompoundingSimple, DebtInterestCompoundingMonthly, DebtInterestCompoundingDaily:
+		return true
+	}
+	return false
+}
+
+// DebtInterestAccrual is the interest accrued on a debt from PeriodStart to
+// PeriodEnd (inclusive) within one month. Balance is the average daily balance
+// the interest was charged on.
+type DebtInterestAccrual struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	DebtID      uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_debt_interest_accrual_period" json:"debt_id"`
+	Period      string    `gorm:"type:varchar(7);not null;uniqueIndex:idx_debt_interest_accrual_period" json:"period"`
+	PeriodStart time.Time `gorm:"type:date;not null" json:"period_start"`
+	PeriodEnd   time.Time `gorm:"type:date;not null" json:"period_end"`
+	Balance     int64     `gorm:"not null" json:"balance"`
+	Amount      int64     `gorm:"not null" json:"amount"`
+	CreatedAt   time.Time `gorm:"default:now()" json:"created_at"`
+
+	Debt *Debt `gorm:"foreignKey:DebtID" json:"debt,omitempty"`
+}
+
+func (DebtInterestAccrual) TableName() string {
+	return "debt_interest_accruals"
+}
+
+// DebtInterestBreakdown splits what was paid on a debt into interest and
+// principal. Payments cover accrued interest before principal.
+type DebtInterestBreakdown struct {
+	AccruedInterest int64
+	InterestPaid    int64
+	UnpaidInterest  int64
+	PrincipalPaid   int64
+}
+
+// HasInterest reports whether interest accrues on the debt
+func (d *Debt) HasInterest() bool {
+	return d.InterestRate != nil && *d.InterestRate > 0
+}
+
+// InterestSince returns the date interest starts accruing from
+func (d *Debt) InterestSince() time.Time {
+	if d.InterestStartDate != nil {
+		return startOfDay(*d.InterestStartDate)
+	}
+	return startOfDay(d.CreatedAt)
+}
+
+func (d *Debt) AccruedInterest() int64 {
+	var total int64
+	for _, a := range d.InterestAccruals {
+		total += a.Amount
+	}
+	return total
+}
+
+// LastAccrual returns the latest interest accrual, or nil if there is none
+func (d *Debt) LastAccrual() *DebtInterestAccrual {
+	var last *DebtInterestAccrual
+	for n := range d.InterestAccruals {
+		if last == nil || d.InterestAccruals[n].PeriodEnd.After(last.PeriodEnd) {
+			last = &d.InterestAccruals[n]
+		}
+	}
+	return last
+}
+
+// InterestBreakdown replays the accruals and payments in date order
+func (d *Debt) InterestBreakdown() DebtInterestBreakdown {
+	balance := d.balanceBefore(time.Time{})
+	return DebtInterestBreakdown{
+		AccruedInterest: d.AccruedInterest(),
+		InterestPaid:    balance.interestPaid,
+		UnpaidInterest:  balance.interest,
+		PrincipalPaid:   d.PaidAmount() - balance.interestPaid,
+	}
+}
+
+// AccrueInterest returns the interest accrued from start to end (inclusive) and
+// the average daily balance it was charged on. The monthly rate (APR / 12) is
+// charged on the average daily balance and prorated for part of a month; with
+// DAILY compounding the daily rate (APR / 365) is charged on a balance that
+// includes the interest of the days before. Both dates must be in one month.
+func (d *Debt) AccrueInterest(start, end time.Time) (amount, averageBalance int64) {
+	if !d.HasInterest() {
+		return 0, 0
+	}
+	start, end = startOfDay(start), startOfDay(end)
+
+	compounding := DebtInterestCompoundingMonthly
+	if d.InterestCompounding != nil {
+		compounding = *d.InterestCompounding
+	}
+
+	paid := make(map[time.Time]int64)
+	for _, p := range d.Payments {
+		if day := startOfDay(p.PaidAt); !day.Before(start) && !day.After(end) {
+			paid[day] += p.Amount
+		}
+	}
+
+	balance := d.balanceBefore(start)
+	dailyRate := *d.InterestRate / 100 / 365
+	var total, compounded float64
+	days := 0
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		balance.pay(paid[day])
+
+		var basis float64
+		switch compounding {
+		case DebtInterestCompoundingSimple:
+			basis = float64(balance.principal)
+		case DebtInterestCompoundingDaily:
+			basis = float64(balance.principal+balance.interest) + compounded
+		default:
+			basis = float64(balance.principal + balance.interest)
+		}
+		basis = math.Max(basis, 0)
+
+		total += basis
+		compounded += basis * dailyRate
+		days++
+	}
+	if days == 0 {
+		return 0, 0
+	}
+
+	averageBalance = int64(math.Round(total / float64(days)))
+	if compounding == DebtInterestCompoundingDaily {
+		return int64(math.Round(compounded)), averageBalance
+	}
+	daysInMonth := time.Date(start.Year(), start.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	return int64(math.Round(total / float64(daysInMonth) * *d.InterestRate / 100 / 12)), averageBalance
+}
+
+// debtBalance is the principal and unpaid interest of a debt
+type debtBalance struct {
+	principal    int64
+	interest     int64
+	interestPaid int64
+}
+
+// pay covers the unpaid interest first and the principal with the rest
+func (b *debtBalance) pay(amount int64) {
+	toInterest := max(min(amount, b.interest), 0)
+	b.interest -= toInterest
+	b.interestPaid += toInterest
+	b.principal -= amount - toInterest
+}
+
+// balanceBefore replays the accruals posted and the payments made before a
+// date, or all of them for the zero date. An accrual is posted at the end of
+// the last day of its period, after the payments made that day.
+func (d *Debt) balanceBefore(date time.Time) debtBalance {
+	type event struct {
+		at      time.Time
+		accrual int64
+		payment int64
+	}
+
+	var events []event
+	for _, a := range d.InterestAccruals {
+		events = append(events, event{at: startOfDay(a.PeriodEnd), accrual: a.Amount})
+	}
+	for _, p := range d.Payments {
+		events = append(events, event{at: startOfDay(p.PaidAt), payment: p.Amount})
+	}
+	sort.SliceStable(events, func(a, b int) bool {
+		if !events[a].at.Equal(events[b].at) {
+			return events[a].at.Before(events[b].at)
+		}
+		return events[a].accrual == 0 && events[b].accrual > 0
+	})
+
+	balance := debtBalance{principal: d.TotalToPay()}
+	for _, e := range events {
+		if !date.IsZero() && !e.at.Before(date) {
+			break
+		}
+		balance.interest += e.accrual
+		balance.pay(e.payment)
+	}
+	return balance
+}
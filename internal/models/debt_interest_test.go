@@ -0,0 +1,184 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type testDebtEvent struct {
+	on     time.Time
+	amount int64
+}
+
+// newTestDebt is 1.200.000 at 12% a year, so 12.000 a month while nothing is paid
+func newTestDebt(compounding DebtInterestCompounding, accruals, payments []testDebtEvent) *Debt {
+	rate := 12.0
+	debt := &Debt{
+		ID:                  uuid.New(),
+		PersonName:          "Budi",
+		ActualAmount:        1200000,
+		PaymentType:         DebtPaymentTypeOneTime,
+		Status:              DebtStatusActive,
+		InterestRate:        &rate,
+		InterestCompounding: &compounding,
+		InterestStartDate:   ptrTime(date(2026, time.March, 1)),
+	}
+	for _, a := range accruals {
+		debt.InterestAccruals = append(debt.InterestAccruals, DebtInterestAccrual{
+			ID:          uuid.New(),
+			DebtID:      debt.ID,
+			Period:      a.on.Format("2006-01"),
+			PeriodStart: time.Date(a.on.Year(), a.on.Month(), 1, 0, 0, 0, 0, time.UTC),
+			PeriodEnd:   a.on,
+			Amount:      a.amount,
+		})
+	}
+	for n, p := range payments {
+		debt.Payments = append(debt.Payments, DebtPayment{ID: uuid.New(), DebtID: debt.ID, PaymentNumber: n + 1, Amount: p.amount, PaidAt: p.on})
+	}
+	return debt
+}
+
+func TestDebtAccrueInterest(t *testing.T) {
+	marchInterest := []testDebtEvent{{on: date(2026, time.March, 31), amount: 12000}}
+	tests := []struct {
+		name        string
+		compounding DebtInterestCompounding
+		accruals    []testDebtEvent
+		payments    []testDebtEvent
+		start       time.Time
+		end         time.Time
+		wantAmount  int64
+		wantBalance int64
+	}{
+		{
+			name:        "full month",
+			compounding: DebtInterestCompoundingMonthly,
+			start:       date(2026, time.April, 1),
+			end:         date(2026, time.April, 30),
+			wantAmount:  12000,
+			wantBalance: 1200000,
+		},
+		{
+			name:        "part of a month is prorated",
+			compounding: DebtInterestCompoundingMonthly,
+			start:       date(2026, time.April, 16),
+			end:         date(2026, time.April, 30),
+			wantAmount:  6000,
+			wantBalance: 1200000,
+		},
+		{
+			name:        "payment in the middle of the month",
+			compounding: DebtInterestCompoundingMonthly,
+			payments:    []testDebtEvent{{on: date(2026, time.April, 16), amount: 600000}},
+			start:       date(2026, time.April, 1),
+			end:         date(2026, time.April, 30),
+			wantAmount:  9000,
+			wantBalance: 900000,
+		},
+		{
+			name:        "monthly compounding charges unpaid interest",
+			compounding: DebtInterestCompoundingMonthly,
+			accruals:    marchInterest,
+			start:       date(2026, time.April, 1),
+			end:         date(2026, time.April, 30),
+			wantAmount:  12120,
+			wantBalance: 1212000,
+		},
+		{
+			name:        "simple interest ignores unpaid interest",
+			compounding: DebtInterestCompoundingSimple,
+			accruals:    marchInterest,
+			start:       date(2026, time.April, 1),
+			end:         date(2026, time.April, 30),
+			wantAmount:  12000,
+			wantBalance: 1200000,
+		},
+		{
+			name:        "payments cover unpaid interest first",
+			compounding: DebtInterestCompoundingMonthly,
+			accruals:    marchInterest,
+			payments:    []testDebtEvent{{on: date(2026, time.April, 1), amount: 12000}},
+			start:       date(2026, time.April, 1),
+			end:         date(2026, time.April, 30),
+			wantAmount:  12000,
+			wantBalance: 1200000,
+		},
+		{
+			name:        "daily compounding",
+			compounding: DebtInterestCompoundingDaily,
+			start:       date(2026, time.April, 1),
+			end:         date(2026, time.April, 30),
+			wantAmount:  11892,
+			wantBalance: 1205738,
+		},
+		{
+			name:        "paid off",
+			compounding: DebtInterestCompoundingMonthly,
+			payments:    []testDebtEvent{{on: date(2026, time.March, 20), amount: 1200000}},
+			start:       date(2026, time.April, 1),
+			end:         date(2026, time.April, 30),
+			wantAmount:  0,
+			wantBalance: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			debt := newTestDebt(tt.compounding, tt.accruals, tt.payments)
+			amount, balance := debt.AccrueInterest(tt.start, tt.end)
+			if amount != tt.wantAmount {
+				t.Errorf("interest = %d, want %d", amount, tt.wantAmount)
+			}
+			if balance != tt.wantBalance {
+				t.Errorf("average balance = %d, want %d", balance, tt.wantBalance)
+			}
+		})
+	}
+}
+
+func TestDebtAccrueInterestWithoutRate(t *testing.T) {
+	debt := &Debt{ActualAmount: 1200000, PaymentType: DebtPaymentTypeOneTime}
+	if amount, balance := debt.AccrueInterest(date(2026, time.April, 1), date(2026, time.April, 30)); amount != 0 || balance != 0 {
+		t.Errorf("AccrueInterest = %d, %d, want 0, 0", amount, balance)
+	}
+}
+
+func TestDebtInterestBreakdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		accruals []testDebtEvent
+		payments []testDebtEvent
+		want     DebtInterestBreakdown
+	}{
+		{
+			name:     "payment covers the interest before it",
+			accruals: []testDebtEvent{{on: date(2026, time.March, 31), amount: 12000}, {on: date(2026, time.April, 30), amount: 12000}},
+			payments: []testDebtEvent{{on: date(2026, time.April, 5), amount: 100000}},
+			want:     DebtInterestBreakdown{AccruedInterest: 24000, InterestPaid: 12000, UnpaidInterest: 12000, PrincipalPaid: 88000},
+		},
+		{
+			name:     "interest is posted after payments made the same day",
+			accruals: []testDebtEvent{{on: date(2026, time.March, 31), amount: 12000}},
+			payments: []testDebtEvent{{on: date(2026, time.March, 31), amount: 5000}},
+			want:     DebtInterestBreakdown{AccruedInterest: 12000, InterestPaid: 0, UnpaidInterest: 12000, PrincipalPaid: 5000},
+		},
+		{
+			name:     "payment larger than the interest",
+			accruals: []testDebtEvent{{on: date(2026, time.March, 31), amount: 12000}},
+			payments: []testDebtEvent{{on: date(2026, time.April, 1), amount: 5000}, {on: date(2026, time.April, 2), amount: 50000}},
+			want:     DebtInterestBreakdown{AccruedInterest: 12000, InterestPaid: 12000, UnpaidInterest: 0, PrincipalPaid: 43000},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			debt := newTestDebt(DebtInterestCompoundingMonthly, tt.accruals, tt.payments)
+			if got := debt.InterestBreakdown(); got != tt.want {
+				t.Errorf("InterestBreakdown = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
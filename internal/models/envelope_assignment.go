@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EnvelopeAssignmentType string
+
+const (
+	EnvelopeAssignmentTypeAssign EnvelopeAssignmentType = "ASSIGN"
+	EnvelopeAssignmentTypeMove   EnvelopeAssignmentType = "MOVE"
+)
+
+// EnvelopeAssignment is one entry of the envelope budgeting ledger: money given
+// to (or taken back from) a category envelope for a month. A move between
+// envelopes is recorded as two MOVE entries sharing a TransferID. Entries are
+// never edited; corrections are new entries.
+type EnvelopeAssignment struct {
+	ID         uuid.UUID              `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID     uuid.UUID              `gorm:"type:uuid;not null;index:idx_envelope_assignments_user_month" json:"user_id"`
+	CategoryID uuid.UUID              `gorm:"type:uuid;not null" json:"category_id"`
+	Year       int                    `gorm:"not null;index:idx_envelope_assignments_user_month" json:"year"`
+	Month      int                    `gorm:"not null;index:idx_envelope_assignments_user_month" json:"month"`
+	Amount     int64                  `gorm:"not null" json:"amount"`
+	Type       EnvelopeAssignmentType `gorm:"type:varchar(10);not null" json:"type"`
+	TransferID *uuid.UUID             `gorm:"type:uuid" json:"transfer_id,omitempty"`
+	Notes      *string                `gorm:"type:text" json:"notes,omitempty"`
+	CreatedAt  time.Time              `gorm:"default:now()" json:"created_at"`
+
+	User     *User     `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Category *Category `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
+}
+
+func (EnvelopeAssignment) TableName() string {
+	return "envelope_assignments"
+}
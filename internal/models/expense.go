@@ -16,11 +16,18 @@ type Expense struct {
 	Notes       *string    `gorm:"type:text" json:"notes,omitempty"`
 	ExpenseDate *time.Time `gorm:"type:date" json:"expense_date,omitempty"`
 	PocketID    *uuid.UUID `gorm:"type:uuid" json:"pocket_id,omitempty"`
-	CreatedAt   time.Time  `gorm:"default:now()" json:"created_at"`
+	PayeeID     *uuid.UUID `gorm:"type:uuid" json:"payee_id,omitempty"`
+	// InstallmentID is set when the purchase was converted into an installment
+	// plan, which then pays for it instead of the pocket
+	InstallmentID *uuid.UUID `gorm:"type:uuid" json:"installment_id,omitempty"`
+	CreatedAt     time.Time  `gorm:"default:now()" json:"created_at"`
 
 	User     *User     `gorm:"foreignKey:UserID" json:"user,omitempty"`
 	Category *Category `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
 	Pocket   *Account  `gorm:"foreignKey:PocketID" json:"pocket,omitempty"`
+	Payee    *Payee    `gorm:"foreignKey:PayeeID" json:"payee,omitempty"`
+
+	Refunds []ExpenseRefund `gorm:"foreignKey:ExpenseID" json:"refunds,omitempty"`
 }
 
 func (Expense) TableName() string {
@@ -30,3 +37,16 @@ func (Expense) TableName() string {
 func (e *Expense) Total() int64 {
 	return e.UnitPrice * int64(e.Quantity)
 }
+
+func (e *Expense) RefundedAmount() int64 {
+	var total int64
+	for _, r := range e.Refunds {
+		total += r.Amount
+	}
+	return total
+}
+
+// NetTotal is the amount actually spent after refunds
+func (e *Expense) NetTotal() int64 {
+	return e.Total() - e.RefundedAmount()
+}
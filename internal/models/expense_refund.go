@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExpenseRefund represents a full or partial refund of an expense
+type ExpenseRefund struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	ExpenseID  uuid.UUID  `gorm:"type:uuid;not null" json:"expense_id"`
+	Amount     int64      `gorm:"not null" json:"amount"`
+	RefundDate time.Time  `gorm:"type:date;not null" json:"refund_date"`
+	PocketID   *uuid.UUID `gorm:"type:uuid" json:"pocket_id,omitempty"`
+	Notes      *string    `gorm:"type:text" json:"notes,omitempty"`
+	CreatedAt  time.Time  `gorm:"default:now()" json:"created_at"`
+
+	Expense *Expense `gorm:"foreignKey:ExpenseID" json:"expense,omitempty"`
+	Pocket  *Account `gorm:"foreignKey:PocketID" json:"pocket,omitempty"`
+}
+
+func (ExpenseRefund) TableName() string {
+	return "expense_refunds"
+}
@@ -15,6 +15,15 @@ type ExpenseTemplateGroup struct {
 	Notes        *string   `gorm:"type:text" json:"notes,omitempty"`
 	CreatedAt    time.Time `gorm:"default:now()" json:"created_at"`
 
+	// Recurrence takes precedence over RecurringDay when set
+	Recurrence Recurrence `gorm:"embedded;embeddedPrefix:recurrence_" json:"recurrence"`
+
+	// Auto-post creates the expenses on each occurrence; LastPostedDate records the
+	// latest occurrence already posted so an occurrence is never posted twice
+	AutoPost         bool       `gorm:"not null;default:false" json:"auto_post"`
+	NotifyOnAutoPost bool       `gorm:"not null;default:false" json:"notify_on_auto_post"`
+	LastPostedDate   *time.Time `gorm:"type:date" json:"last_posted_date,omitempty"`
+
 	User  *User                 `gorm:"foreignKey:UserID" json:"user,omitempty"`
 	Items []ExpenseTemplateItem `gorm:"foreignKey:GroupID" json:"items,omitempty"`
 }
@@ -23,6 +32,29 @@ func (ExpenseTemplateGroup) TableName() string {
 	return "expense_template_groups"
 }
 
+// Schedule returns the group's recurrence rule, falling back to a monthly rule on
+// RecurringDay. It returns nil when the group doesn't recur.
+func (g *ExpenseTemplateGroup) Schedule() *Recurrence {
+	if g.Recurrence.IsSet() {
+		return &g.Recurrence
+	}
+	if g.RecurringDay != nil {
+		rule := MonthlyOnDay(*g.RecurringDay)
+		return &rule
+	}
+	return nil
+}
+
+// Occurrences returns the group's scheduled dates between from and to, anchored
+// at the group's creation when the rule has no start date
+func (g *ExpenseTemplateGroup) Occurrences(from, to time.Time) []time.Time {
+	schedule := g.Schedule()
+	if schedule == nil {
+		return nil
+	}
+	return schedule.Occurrences(g.CreatedAt, from, to)
+}
+
 func (g *ExpenseTemplateGroup) Total() int64 {
 	var total int64
 	for _, item := range g.Items {
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Holiday is a user-defined non-business day used to shift recurring paydays
+type Holiday struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_holidays_user_date" json:"user_id"`
+	Date      time.Time `gorm:"type:date;not null;uniqueIndex:idx_holidays_user_date" json:"date"`
+	Name      string    `gorm:"type:varchar(255);not null" json:"name"`
+	CreatedAt time.Time `gorm:"default:now()" json:"created_at"`
+
+	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+func (Holiday) TableName() string {
+	return "holidays"
+}
@@ -16,11 +16,13 @@ type Income struct {
 	IsRecurring bool       `gorm:"not null;default:false" json:"is_recurring"`
 	Notes       *string    `gorm:"type:text" json:"notes,omitempty"`
 	PocketID    *uuid.UUID `gorm:"type:uuid" json:"pocket_id,omitempty"`
+	PayeeID     *uuid.UUID `gorm:"type:uuid" json:"payee_id,omitempty"`
 	CreatedAt   time.Time  `gorm:"default:now()" json:"created_at"`
 
 	User     *User           `gorm:"foreignKey:UserID" json:"user,omitempty"`
 	Category *IncomeCategory `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
 	Pocket   *Account        `gorm:"foreignKey:PocketID" json:"pocket,omitempty"`
+	Payee    *Payee          `gorm:"foreignKey:PayeeID" json:"payee,omitempty"`
 }
 
 func (Income) TableName() string {
@@ -7,12 +7,14 @@ import (
 )
 
 type IncomeCategory struct {
-	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
-	UserID    uuid.UUID `gorm:"type:uuid;not null" json:"user_id"`
-	Name      string    `gorm:"type:varchar(100);not null" json:"name"`
-	CreatedAt time.Time `gorm:"default:now()" json:"created_at"`
+	ID        uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null" json:"user_id"`
+	Name      string     `gorm:"type:varchar(100);not null" json:"name"`
+	ParentID  *uuid.UUID `gorm:"type:uuid" json:"parent_id,omitempty"`
+	CreatedAt time.Time  `gorm:"default:now()" json:"created_at"`
 
-	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	User   *User           `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Parent *IncomeCategory `gorm:"foreignKey:ParentID" json:"parent,omitempty"`
 }
 
 func (IncomeCategory) TableName() string {
@@ -13,24 +13,45 @@ const (
 	InstallmentStatusCompleted InstallmentStatus = "COMPLETED"
 )
 
+type InstallmentInterestMethod string
+
+const (
+	// InstallmentInterestMethodFlat charges interest on the original principal every period
+	InstallmentInterestMethodFlat InstallmentInterestMethod = "FLAT"
+	// InstallmentInterestMethodAnnuity keeps the payment fixed and charges interest
+	// (effective rate) on the remaining principal
+	InstallmentInterestMethodAnnuity InstallmentInterestMethod = "ANNUITY"
+	// InstallmentInterestMethodFixedPrincipal repays the same principal every period
+	// with interest on the remaining principal, so payments decrease
+	InstallmentInterestMethodFixedPrincipal InstallmentInterestMethod = "FIXED_PRINCIPAL"
+)
+
 type Installment struct {
-	ID             uuid.UUID         `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
-	UserID         uuid.UUID         `gorm:"type:uuid;not null" json:"user_id"`
-	Name           string            `gorm:"type:varchar(255);not null" json:"name"`
-	ActualAmount   int64             `gorm:"not null" json:"actual_amount"`
-	LoanAmount     int64             `gorm:"not null" json:"loan_amount"`
-	MonthlyPayment int64             `gorm:"not null" json:"monthly_payment"`
-	Tenor          int               `gorm:"not null" json:"tenor"`
-	StartDate      time.Time         `gorm:"type:date;not null" json:"start_date"`
-	DueDay         int               `gorm:"not null" json:"due_day"`
-	Status         InstallmentStatus `gorm:"type:varchar(20);not null;default:'ACTIVE'" json:"status"`
-	Icon           *string           `gorm:"type:varchar(50)" json:"icon,omitempty"`
-	CardBgColor    *string           `gorm:"type:varchar(50)" json:"card_bg_color,omitempty"`
-	Notes          *string           `gorm:"type:text" json:"notes,omitempty"`
-	CreatedAt      time.Time         `gorm:"default:now()" json:"created_at"`
-
-	User     *User                  `gorm:"foreignKey:UserID" json:"user,omitempty"`
-	Payments []InstallmentPayment `gorm:"foreignKey:InstallmentID" json:"payments,omitempty"`
+	ID             uuid.UUID                 `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID         uuid.UUID                 `gorm:"type:uuid;not null" json:"user_id"`
+	Name           string                    `gorm:"type:varchar(255);not null" json:"name"`
+	ActualAmount   int64                     `gorm:"not null" json:"actual_amount"`
+	LoanAmount     int64                     `gorm:"not null" json:"loan_amount"`
+	MonthlyPayment int64                     `gorm:"not null" json:"monthly_payment"`
+	Tenor          int                       `gorm:"not null" json:"tenor"`
+	StartDate      time.Time                 `gorm:"type:date;not null" json:"start_date"`
+	DueDay         int                       `gorm:"not null" json:"due_day"`
+	InterestMethod InstallmentInterestMethod `gorm:"type:varchar(20);not null;default:'FLAT'" json:"interest_method"`
+	InterestRate   *float64                  `gorm:"type:decimal(7,4)" json:"interest_rate,omitempty"`
+	Status         InstallmentStatus         `gorm:"type:varchar(20);not null;default:'ACTIVE'" json:"status"`
+	Icon           *string                   `gorm:"type:varchar(50)" json:"icon,omitempty"`
+	CardBgColor    *string                   `gorm:"type:varchar(50)" json:"card_bg_color,omitempty"`
+	Notes          *string                   `gorm:"type:text" json:"notes,omitempty"`
+	// ExpenseID is the purchase the installment was converted from
+	ExpenseID *uuid.UUID `gorm:"type:uuid" json:"expense_id,omitempty"`
+	// ConsolidationID is the consolidation that paid the installment off
+	ConsolidationID *uuid.UUID `gorm:"type:uuid" json:"consolidation_id,omitempty"`
+	CreatedAt       time.Time  `gorm:"default:now()" json:"created_at"`
+
+	User        *User                   `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Payments    []InstallmentPayment    `gorm:"foreignKey:InstallmentID" json:"payments,omitempty"`
+	Prepayments []InstallmentPrepayment `gorm:"foreignKey:InstallmentID" json:"prepayments,omitempty"`
+	Terms       []InstallmentTerm       `gorm:"foreignKey:InstallmentID" json:"terms,omitempty"`
 }
 
 func (Installment) TableName() string {
@@ -57,5 +78,15 @@ func (i *Installment) RemainingPayments() int {
 }
 
 func (i *Installment) RemainingAmount() int64 {
+	if i.InterestRate != nil && i.InterestMethod == InstallmentInterestMethodFixedPrincipal {
+		// Payments decrease over time, so add up what is left on the schedule
+		var remaining int64
+		for _, p := range i.AmortizationSchedule() {
+			if p.PaymentNumber > i.PaidCount() {
+				remaining += p.Payment
+			}
+		}
+		return remaining
+	}
 	return int64(i.RemainingPayments()) * i.MonthlyPayment
 }
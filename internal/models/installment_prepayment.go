@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PrepaymentStrategy string
+
+const (
+	// PrepaymentStrategyReduceTenor keeps the payment and shortens the loan
+	PrepaymentStrategyReduceTenor PrepaymentStrategy = "REDUCE_TENOR"
+	// PrepaymentStrategyReducePayment keeps the end date and lowers the payment
+	PrepaymentStrategyReducePayment PrepaymentStrategy = "REDUCE_PAYMENT"
+)
+
+// InstallmentPrepayment is an extra lump-sum payment on the principal, made
+// after AfterPaymentNumber regular payments. The Previous* fields hold the
+// installment terms it replaced so the schedule can be replayed.
+type InstallmentPrepayment struct {
+	ID                     uuid.UUID          `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	InstallmentID          uuid.UUID          `gorm:"type:uuid;not null;index" json:"installment_id"`
+	Amount                 int64              `gorm:"not null" json:"amount"`
+	PaidAt                 time.Time          `gorm:"type:date;not null" json:"paid_at"`
+	AfterPaymentNumber     int                `gorm:"not null" json:"after_payment_number"`
+	Strategy               PrepaymentStrategy `gorm:"type:varchar(20);not null" json:"strategy"`
+	PocketID               *uuid.UUID         `gorm:"type:uuid" json:"pocket_id,omitempty"`
+	PreviousTenor          int                `gorm:"not null" json:"previous_tenor"`
+	PreviousMonthlyPayment int64              `gorm:"not null" json:"previous_monthly_payment"`
+	PreviousLoanAmount     int64              `gorm:"not null" json:"previous_loan_amount"`
+	CreatedAt              time.Time          `gorm:"default:now()" json:"created_at"`
+
+	Installment *Installment `gorm:"foreignKey:InstallmentID" json:"installment,omitempty"`
+	Pocket      *Account     `gorm:"foreignKey:PocketID" json:"pocket,omitempty"`
+}
+
+func (InstallmentPrepayment) TableName() string {
+	return "installment_prepayments"
+}
+
+func (s PrepaymentStrategy) IsValid() bool {
+	return s == PrepaymentStrategyReduceTenor || s == PrepaymentStrategyReducePayment
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InstallmentTerm is a version of an installment's terms set by a restructure.
+// It governs the payments after AfterPaymentNumber, the first one due in the
+// month of EffectiveDate. Principal is the principal left at the restructure
+// plus Fee and InterestAdjustment, which are added to the loan. The Previous*
+// fields hold the terms it replaced so the schedule can be replayed.
+type InstallmentTerm struct {
+	ID                     uuid.UUID                 `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	InstallmentID          uuid.UUID                 `gorm:"type:uuid;not null;index" json:"installment_id"`
+	Version                int                       `gorm:"not null" json:"version"`
+	EffectiveDate          time.Time                 `gorm:"type:date;not null" json:"effective_date"`
+	AfterPaymentNumber     int                       `gorm:"not null" json:"after_payment_number"`
+	Principal              int64                     `gorm:"not null" json:"principal"`
+	Tenor                  int                       `gorm:"not null" json:"tenor"`
+	MonthlyPayment         int64                     `gorm:"not null" json:"monthly_payment"`
+	LoanAmount             int64                     `gorm:"not null" json:"loan_amount"`
+	InterestMethod         InstallmentInterestMethod `gorm:"type:varchar(20);not null;default:'FLAT'" json:"interest_method"`
+	InterestRate           *float64                  `gorm:"type:decimal(7,4)" json:"interest_rate,omitempty"`
+	DueDay                 int                       `gorm:"not null" json:"due_day"`
+	Fee                    int64                     `gorm:"not null;default:0" json:"fee"`
+	InterestAdjustment     int64                     `gorm:"not null;default:0" json:"interest_adjustment"`
+	CategoryID             *uuid.UUID                `gorm:"type:uuid" json:"category_id,omitempty"`
+	Notes                  *string                   `gorm:"type:text" json:"notes,omitempty"`
+	PreviousTenor          int                       `gorm:"not null" json:"previous_tenor"`
+	PreviousMonthlyPayment int64                     `gorm:"not null" json:"previous_monthly_payment"`
+	PreviousLoanAmount     int64                     `gorm:"not null" json:"previous_loan_amount"`
+	PreviousInterestMethod InstallmentInterestMethod `gorm:"type:varchar(20);not null;default:'FLAT'" json:"previous_interest_method"`
+	PreviousInterestRate   *float64                  `gorm:"type:decimal(7,4)" json:"previous_interest_rate,omitempty"`
+	PreviousDueDay         int                       `gorm:"not null" json:"previous_due_day"`
+	CreatedAt              time.Time                 `gorm:"default:now()" json:"created_at"`
+
+	Installment *Installment `gorm:"foreignKey:InstallmentID" json:"installment,omitempty"`
+	Category    *Category    `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
+}
+
+func (InstallmentTerm) TableName() string {
+	return "installment_terms"
+}
+
+// Capitalized returns the amount the restructure added to the loan
+func (t *InstallmentTerm) Capitalized() int64 {
+	return t.Fee + t.InterestAdjustment
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LoanKind string
+type LateFeeType string
+
+const (
+	LoanKindInstallment LoanKind = "INSTALLMENT"
+	LoanKindDebt        LoanKind = "DEBT"
+
+	LateFeeTypeFixed      LateFeeType = "FIXED"
+	LateFeeTypePercentage LateFeeType = "PERCENTAGE"
+)
+
+// IsValid reports whether k is a known loan kind
+func (k LoanKind) IsValid() bool {
+	return k == LoanKindInstallment || k == LoanKindDebt
+}
+
+// IsValid reports whether t is a known late fee type
+func (t LateFeeType) IsValid() bool {
+	return t == LateFeeTypeFixed || t == LateFeeTypePercentage
+}
+
+// LateFeeRule charges a fee for each period of a loan that stays unpaid past
+// its due date plus GraceDays. A rule without LoanKind applies to every
+// installment and debt, one with LoanID only to that loan. Fees are posted as
+// expenses in CategoryID.
+type LateFeeRule struct {
+	ID         uuid.UUID   `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID     uuid.UUID   `gorm:"type:uuid;not null" json:"user_id"`
+	Name       string      `gorm:"type:varchar(100);not null" json:"name"`
+	LoanKind   *LoanKind   `gorm:"type:varchar(20)" json:"loan_kind,omitempty"`
+	LoanID     *uuid.UUID  `gorm:"type:uuid" json:"loan_id,omitempty"`
+	FeeType    LateFeeType `gorm:"type:varchar(20);not null" json:"fee_type"`
+	Amount     int64       `gorm:"not null;default:0" json:"amount"`
+	Percentage float64     `gorm:"type:decimal(7,4);not null;default:0" json:"percentage"`
+	GraceDays  int         `gorm:"not null;default:0" json:"grace_days"`
+	CategoryID uuid.UUID   `gorm:"type:uuid;not null" json:"category_id"`
+	PocketID   *uuid.UUID  `gorm:"type:uuid" json:"pocket_id,omitempty"`
+	IsActive   bool        `gorm:"not null;default:true" json:"is_active"`
+	CreatedAt  time.Time   `gorm:"default:now()" json:"created_at"`
+
+	User     *User     `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Category *Category `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
+}
+
+func (LateFeeRule) TableName() string {
+	return "late_fee_rules"
+}
+
+// AppliesTo reports whether the rule covers the given loan
+func (r *LateFeeRule) AppliesTo(kind LoanKind, loanID uuid.UUID) bool {
+	if r.LoanKind != nil && *r.LoanKind != kind {
+		return false
+	}
+	return r.LoanID == nil || *r.LoanID == loanID
+}
+
+// FeeFor returns the fee for a missed period of the given amount
+func (r *LateFeeRule) FeeFor(periodAmount int64) int64 {
+	if r.FeeType == LateFeeTypePercentage {
+		return int64(math.Round(float64(periodAmount) * r.Percentage / 100))
+	}
+	return r.Amount
+}
+
+// LateFeeCharge records the fee a rule charged for one missed period, so the
+// same period is never charged twice
+type LateFeeCharge struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID        uuid.UUID  `gorm:"type:uuid;not null" json:"user_id"`
+	RuleID        uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_late_fee_charge_period" json:"rule_id"`
+	LoanKind      LoanKind   `gorm:"type:varchar(20);not null" json:"loan_kind"`
+	LoanID        uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_late_fee_charge_period" json:"loan_id"`
+	PaymentNumber int        `gorm:"not null;uniqueIndex:idx_late_fee_charge_period" json:"payment_number"`
+	DueDate       time.Time  `gorm:"type:date;not null" json:"due_date"`
+	Amount        int64      `gorm:"not null" json:"amount"`
+	ExpenseID     *uuid.UUID `gorm:"type:uuid" json:"expense_id,omitempty"`
+	CreatedAt     time.Time  `gorm:"default:now()" json:"created_at"`
+
+	Rule    *LateFeeRule `gorm:"foreignKey:RuleID" json:"rule,omitempty"`
+	Expense *Expense     `gorm:"foreignKey:ExpenseID" json:"expense,omitempty"`
+}
+
+func (LateFeeCharge) TableName() string {
+	return "late_fee_charges"
+}
@@ -12,6 +12,11 @@ const (
 	NotificationTypeInstallmentReminder NotificationType = "INSTALLMENT_REMINDER"
 	NotificationTypeDebtReminder        NotificationType = "DEBT_REMINDER"
 	NotificationTypeSavingsGoalReminder NotificationType = "SAVINGS_GOAL_REMINDER"
+	NotificationTypeTemplateAutoPost    NotificationType = "TEMPLATE_AUTO_POST"
+	NotificationTypeBudgetAlert         NotificationType = "BUDGET_ALERT"
+	NotificationTypeReceivableReminder  NotificationType = "RECEIVABLE_REMINDER"
+	NotificationTypeOverdueReminder     NotificationType = "OVERDUE_REMINDER"
+	NotificationTypeSavingsAutoSkipped  NotificationType = "SAVINGS_AUTO_SKIPPED"
 )
 
 type NotificationLog struct {
@@ -19,6 +24,7 @@ type NotificationLog struct {
 	UserID       uuid.UUID        `gorm:"type:uuid;not null" json:"user_id"`
 	Type         NotificationType `gorm:"type:varchar(50);not null" json:"type"`
 	ReferenceID  uuid.UUID        `gorm:"type:uuid;not null" json:"reference_id"`
+	PeriodKey    *string          `gorm:"type:varchar(50)" json:"period_key,omitempty"`
 	SentAt       time.Time        `gorm:"not null" json:"sent_at"`
 	EmailSubject *string          `gorm:"type:varchar(255)" json:"email_subject,omitempty"`
 	CreatedAt    time.Time        `gorm:"default:now()" json:"created_at"`
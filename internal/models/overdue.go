@@ -0,0 +1,127 @@
+package models
+
+import (
+	"time"
+)
+
+type OverdueStatus string
+
+const (
+	OverdueStatusCurrent OverdueStatus = "CURRENT"
+	OverdueStatusOverdue OverdueStatus = "OVERDUE"
+)
+
+// MissedPeriod is an expected payment whose due date passed without a
+// matching payment
+type MissedPeriod struct {
+	PaymentNumber int
+	DueDate       time.Time
+	Amount        int64
+}
+
+// OverdueInfo compares the payments a loan expects by a date with the ones
+// recorded. DaysLate counts from the oldest missed due date.
+type OverdueInfo struct {
+	Status        OverdueStatus
+	DaysLate      int
+	OverdueAmount int64
+	MissedPeriods []MissedPeriod
+}
+
+func newOverdueInfo(missed []MissedPeriod, today time.Time) OverdueInfo {
+	if len(missed) == 0 {
+		return OverdueInfo{Status: OverdueStatusCurrent, MissedPeriods: []MissedPeriod{}}
+	}
+	info := OverdueInfo{
+		Status:        OverdueStatusOverdue,
+		DaysLate:      int(today.Sub(missed[0].DueDate).Hours() / 24),
+		MissedPeriods: missed,
+	}
+	for _, p := range missed {
+		info.OverdueAmount += p.Amount
+	}
+	return info
+}
+
+// IsOverdue reports whether at least one period was missed
+func (o OverdueInfo) IsOverdue() bool {
+	return o.Status == OverdueStatusOverdue
+}
+
+// LastMissed is the most recently missed period, nil when none was missed
+func (o OverdueInfo) LastMissed() *MissedPeriod {
+	if len(o.MissedPeriods) == 0 {
+		return nil
+	}
+	return &o.MissedPeriods[len(o.MissedPeriods)-1]
+}
+
+// Overdue lists the periods due before asOf that have no payment. Payments are
+// matched to periods in order, so the first unpaid period is PaidCount + 1.
+func (i *Installment) Overdue(asOf time.Time) OverdueInfo {
+	today := startOfDay(asOf)
+	if i.Status != InstallmentStatusActive {
+		return newOverdueInfo(nil, today)
+	}
+
+	schedule := i.AmortizationSchedule()
+	var missed []MissedPeriod
+	for n := i.PaidCount() + 1; n <= i.Tenor; n++ {
+		due := i.DueDateFor(n)
+		if !due.Before(today) {
+			break
+		}
+		amount := i.MonthlyPayment
+		if n <= len(schedule) {
+			amount = schedule[n-1].Payment
+		}
+		missed = append(missed, MissedPeriod{PaymentNumber: n, DueDate: due, Amount: amount})
+	}
+	return newOverdueInfo(missed, today)
+}
+
+// Overdue lists the periods due before asOf that have no payment. A one-time
+// debt has a single period on its due date. An installment debt is due monthly
+// from its due date, one payment per period.
+func (d *Debt) Overdue(asOf time.Time) OverdueInfo {
+	today := startOfDay(asOf)
+	remaining := d.RemainingAmount()
+	if d.Status != DebtStatusActive || d.DueDate == nil || remaining <= 0 {
+		return newOverdueInfo(nil, today)
+	}
+
+	if !d.IsInstallment() || d.Tenor == nil || d.MonthlyPayment == nil {
+		if !d.DueDate.Before(today) {
+			return newOverdueInfo(nil, today)
+		}
+		return newOverdueInfo([]MissedPeriod{{PaymentNumber: 1, DueDate: *d.DueDate, Amount: remaining}}, today)
+	}
+
+	var missed []MissedPeriod
+	for n := d.PaidCount() + 1; n <= *d.Tenor && remaining > 0; n++ {
+		due := d.DueDateFor(n)
+		if !due.Before(today) {
+			break
+		}
+		amount := min(*d.MonthlyPayment, remaining)
+		remaining -= amount
+		missed = append(missed, MissedPeriod{PaymentNumber: n, DueDate: due, Amount: amount})
+	}
+	return newOverdueInfo(missed, today)
+}
+
+// DueDateFor returns the due date of an installment debt's payment, monthly
+// from DueDate
+func (d *Debt) DueDateFor(paymentNumber int) time.Time {
+	month := time.Date(d.DueDate.Year(), d.DueDate.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, paymentNumber-1, 0)
+	lastDay := month.AddDate(0, 1, -1).Day()
+	day := d.DueDate.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(month.Year(), month.Month(), day, 0, 0, 0, 0, time.UTC)
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
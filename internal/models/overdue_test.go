@@ -0,0 +1,190 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestInstallmentOverdue(t *testing.T) {
+	tests := []struct {
+		name         string
+		paid         int
+		status       InstallmentStatus
+		asOf         time.Time
+		wantStatus   OverdueStatus
+		wantNumbers  []int
+		wantDaysLate int
+		wantAmount   int64
+	}{
+		{name: "due today is not late", paid: 3, asOf: date(2026, time.April, 10), wantStatus: OverdueStatusCurrent},
+		{name: "a day past the due date", paid: 3, asOf: date(2026, time.April, 11), wantStatus: OverdueStatusOverdue, wantNumbers: []int{4}, wantDaysLate: 1, wantAmount: 100000},
+		{name: "late is counted from the oldest missed period", paid: 1, asOf: date(2026, time.April, 15), wantStatus: OverdueStatusOverdue, wantNumbers: []int{2, 3, 4}, wantDaysLate: 64, wantAmount: 300000},
+		{name: "time of day is ignored", paid: 3, asOf: time.Date(2026, time.April, 10, 23, 59, 0, 0, time.UTC), wantStatus: OverdueStatusCurrent},
+		{name: "fully paid", paid: 12, asOf: date(2027, time.June, 1), wantStatus: OverdueStatusCurrent},
+		{name: "completed", paid: 1, status: InstallmentStatusCompleted, asOf: date(2026, time.April, 15), wantStatus: OverdueStatusCurrent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			installment := newTestInstallment(tt.paid)
+			if tt.status != "" {
+				installment.Status = tt.status
+			}
+
+			info := installment.Overdue(tt.asOf)
+			checkOverdue(t, info, tt.wantStatus, tt.wantNumbers, tt.wantDaysLate, tt.wantAmount)
+		})
+	}
+}
+
+func TestDebtOverdue(t *testing.T) {
+	monthly := int64(100000)
+	tests := []struct {
+		name         string
+		debt         Debt
+		paid         []int64
+		asOf         time.Time
+		wantStatus   OverdueStatus
+		wantNumbers  []int
+		wantAmounts  []int64
+		wantDaysLate int
+	}{
+		{
+			name:       "one-time debt on its due date",
+			debt:       Debt{ActualAmount: 500000, PaymentType: DebtPaymentTypeOneTime, DueDate: ptrTime(date(2026, time.April, 10))},
+			asOf:       date(2026, time.April, 10),
+			wantStatus: OverdueStatusCurrent,
+		},
+		{
+			name:         "one-time debt past its due date owes what is left",
+			debt:         Debt{ActualAmount: 500000, PaymentType: DebtPaymentTypeOneTime, DueDate: ptrTime(date(2026, time.April, 10))},
+			paid:         []int64{200000},
+			asOf:         date(2026, time.April, 20),
+			wantStatus:   OverdueStatusOverdue,
+			wantNumbers:  []int{1},
+			wantAmounts:  []int64{300000},
+			wantDaysLate: 10,
+		},
+		{
+			name:         "installment debt due at the end of the month",
+			debt:         Debt{ActualAmount: 600000, PaymentType: DebtPaymentTypeInstallment, MonthlyPayment: &monthly, Tenor: ptrInt(6), DueDate: ptrTime(date(2026, time.January, 31))},
+			paid:         []int64{100000},
+			asOf:         date(2026, time.April, 1),
+			wantStatus:   OverdueStatusOverdue,
+			wantNumbers:  []int{2, 3},
+			wantAmounts:  []int64{100000, 100000},
+			wantDaysLate: 32,
+		},
+		{
+			name:         "last period is capped at what is left",
+			debt:         Debt{ActualAmount: 250000, PaymentType: DebtPaymentTypeInstallment, MonthlyPayment: &monthly, Tenor: ptrInt(3), DueDate: ptrTime(date(2026, time.January, 5))},
+			asOf:         date(2026, time.June, 1),
+			wantStatus:   OverdueStatusOverdue,
+			wantNumbers:  []int{1, 2, 3},
+			wantAmounts:  []int64{100000, 100000, 50000},
+			wantDaysLate: 147,
+		},
+		{
+			name:       "debt without a due date",
+			debt:       Debt{ActualAmount: 500000, PaymentType: DebtPaymentTypeOneTime},
+			asOf:       date(2026, time.April, 20),
+			wantStatus: OverdueStatusCurrent,
+		},
+		{
+			name:       "paid off",
+			debt:       Debt{ActualAmount: 500000, PaymentType: DebtPaymentTypeOneTime, DueDate: ptrTime(date(2026, time.April, 10))},
+			paid:       []int64{500000},
+			asOf:       date(2026, time.April, 20),
+			wantStatus: OverdueStatusCurrent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			debt := tt.debt
+			debt.ID = uuid.New()
+			debt.Status = DebtStatusActive
+			for n, amount := range tt.paid {
+				debt.Payments = append(debt.Payments, DebtPayment{ID: uuid.New(), DebtID: debt.ID, PaymentNumber: n + 1, Amount: amount, PaidAt: date(2026, time.January, 1)})
+			}
+
+			info := debt.Overdue(tt.asOf)
+			var wantAmount int64
+			for _, amount := range tt.wantAmounts {
+				wantAmount += amount
+			}
+			checkOverdue(t, info, tt.wantStatus, tt.wantNumbers, tt.wantDaysLate, wantAmount)
+			for n, period := range info.MissedPeriods {
+				if n < len(tt.wantAmounts) && period.Amount != tt.wantAmounts[n] {
+					t.Errorf("period %d amount = %d, want %d", period.PaymentNumber, period.Amount, tt.wantAmounts[n])
+				}
+			}
+		})
+	}
+}
+
+func checkOverdue(t *testing.T, info OverdueInfo, wantStatus OverdueStatus, wantNumbers []int, wantDaysLate int, wantAmount int64) {
+	t.Helper()
+	if info.Status != wantStatus {
+		t.Errorf("status = %s, want %s", info.Status, wantStatus)
+	}
+	var numbers []int
+	for _, period := range info.MissedPeriods {
+		numbers = append(numbers, period.PaymentNumber)
+	}
+	if len(numbers) != len(wantNumbers) {
+		t.Fatalf("missed periods = %v, want %v", numbers, wantNumbers)
+	}
+	for n := range numbers {
+		if numbers[n] != wantNumbers[n] {
+			t.Fatalf("missed periods = %v, want %v", numbers, wantNumbers)
+		}
+	}
+	if info.DaysLate != wantDaysLate {
+		t.Errorf("days late = %d, want %d", info.DaysLate, wantDaysLate)
+	}
+	if info.OverdueAmount != wantAmount {
+		t.Errorf("overdue amount = %d, want %d", info.OverdueAmount, wantAmount)
+	}
+}
+
+func TestLateFeeRule(t *testing.T) {
+	loanID := uuid.New()
+	debtKind := LoanKindDebt
+	tests := []struct {
+		name        string
+		rule        LateFeeRule
+		kind        LoanKind
+		loanID      uuid.UUID
+		period      int64
+		wantApplies bool
+		wantFee     int64
+	}{
+		{name: "fixed fee for every loan", rule: LateFeeRule{FeeType: LateFeeTypeFixed, Amount: 25000}, kind: LoanKindInstallment, loanID: loanID, period: 100000, wantApplies: true, wantFee: 25000},
+		{name: "percentage is rounded", rule: LateFeeRule{FeeType: LateFeeTypePercentage, Percentage: 5}, kind: LoanKindDebt, loanID: loanID, period: 333333, wantApplies: true, wantFee: 16667},
+		{name: "rule for debts skips installments", rule: LateFeeRule{LoanKind: &debtKind, FeeType: LateFeeTypeFixed, Amount: 25000}, kind: LoanKindInstallment, loanID: loanID, period: 100000, wantApplies: false, wantFee: 25000},
+		{name: "rule for one loan", rule: LateFeeRule{LoanKind: &debtKind, LoanID: &loanID, FeeType: LateFeeTypeFixed, Amount: 25000}, kind: LoanKindDebt, loanID: loanID, period: 100000, wantApplies: true, wantFee: 25000},
+		{name: "rule for another loan", rule: LateFeeRule{LoanKind: &debtKind, LoanID: &loanID, FeeType: LateFeeTypeFixed, Amount: 25000}, kind: LoanKindDebt, loanID: uuid.New(), period: 100000, wantApplies: false, wantFee: 25000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.AppliesTo(tt.kind, tt.loanID); got != tt.wantApplies {
+				t.Errorf("AppliesTo = %v, want %v", got, tt.wantApplies)
+			}
+			if got := tt.rule.FeeFor(tt.period); got != tt.wantFee {
+				t.Errorf("FeeFor(%d) = %d, want %d", tt.period, got, tt.wantFee)
+			}
+		})
+	}
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
+
+func ptrInt(n int) *int {
+	return &n
+}
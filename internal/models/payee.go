@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Payee represents a merchant or person the user transacts with
+type Payee struct {
+	ID                uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID            uuid.UUID  `gorm:"type:uuid;not null" json:"user_id"`
+	Name              string     `gorm:"type:varchar(255);not null" json:"name"`
+	DefaultCategoryID *uuid.UUID `gorm:"type:uuid" json:"default_category_id,omitempty"`
+	DefaultPocketID   *uuid.UUID `gorm:"type:uuid" json:"default_pocket_id,omitempty"`
+	Notes             *string    `gorm:"type:text" json:"notes,omitempty"`
+	CreatedAt         time.Time  `gorm:"default:now()" json:"created_at"`
+
+	User            *User        `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	DefaultCategory *Category    `gorm:"foreignKey:DefaultCategoryID" json:"default_category,omitempty"`
+	DefaultPocket   *Account     `gorm:"foreignKey:DefaultPocketID" json:"default_pocket,omitempty"`
+	Aliases         []PayeeAlias `gorm:"foreignKey:PayeeID" json:"aliases,omitempty"`
+
+	// Computed fields (not stored in DB)
+	ExpenseCount  int   `gorm:"-" json:"expense_count"`
+	LifetimeSpend int64 `gorm:"-" json:"lifetime_spend"`
+}
+
+func (Payee) TableName() string {
+	return "payees"
+}
+
+func (p *Payee) AliasNames() []string {
+	names := make([]string, len(p.Aliases))
+	for i, alias := range p.Aliases {
+		names[i] = alias.Alias
+	}
+	return names
+}
+
+// PayeeAlias is an alternative name used to match free-text entries to a payee
+type PayeeAlias struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	PayeeID   uuid.UUID `gorm:"type:uuid;not null" json:"payee_id"`
+	Alias     string    `gorm:"type:varchar(255);not null" json:"alias"`
+	CreatedAt time.Time `gorm:"default:now()" json:"created_at"`
+
+	Payee *Payee `gorm:"foreignKey:PayeeID" json:"payee,omitempty"`
+}
+
+func (PayeeAlias) TableName() string {
+	return "payee_aliases"
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PayoffStrategy string
+
+const (
+	// PayoffStrategySnowball pays off the smallest balance first
+	PayoffStrategySnowball PayoffStrategy = "SNOWBALL"
+	// PayoffStrategyAvalanche pays off the highest interest rate first
+	PayoffStrategyAvalanche PayoffStrategy = "AVALANCHE"
+	// PayoffStrategyCustomOrder follows an order picked by the user
+	PayoffStrategyCustomOrder PayoffStrategy = "CUSTOM_ORDER"
+)
+
+// PayoffPlan is a saved debt payoff simulation. CustomOrder and PayoffOrder hold
+// comma separated installment and debt IDs; Months keeps the planned totals so
+// the plan can be compared with the payments actually made.
+type PayoffPlan struct {
+	ID                 uuid.UUID      `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID             uuid.UUID      `gorm:"type:uuid;not null;index" json:"user_id"`
+	Name               string         `gorm:"type:varchar(255);not null" json:"name"`
+	Strategy           PayoffStrategy `gorm:"type:varchar(20);not null" json:"strategy"`
+	ExtraMonthlyBudget int64          `gorm:"not null;default:0" json:"extra_monthly_budget"`
+	CustomOrder        string         `gorm:"type:text;not null;default:''" json:"custom_order"`
+	PayoffOrder        string         `gorm:"type:text;not null;default:''" json:"payoff_order"`
+	StartDate          time.Time      `gorm:"type:date;not null" json:"start_date"`
+	DebtFreeDate       time.Time      `gorm:"type:date;not null" json:"debt_free_date"`
+	TotalInterest      int64          `gorm:"not null" json:"total_interest"`
+	TotalPaid          int64          `gorm:"not null" json:"total_paid"`
+	CreatedAt          time.Time      `gorm:"default:now()" json:"created_at"`
+
+	Months []PayoffPlanMonth `gorm:"foreignKey:PlanID" json:"months,omitempty"`
+}
+
+func (PayoffPlan) TableName() string {
+	return "payoff_plans"
+}
+
+type PayoffPlanMonth struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	PlanID         uuid.UUID `gorm:"type:uuid;not null;index" json:"plan_id"`
+	Year           int       `gorm:"not null" json:"year"`
+	Month          int       `gorm:"not null" json:"month"`
+	PlannedPayment int64     `gorm:"not null" json:"planned_payment"`
+	PlannedBalance int64     `gorm:"not null" json:"planned_balance"`
+}
+
+func (PayoffPlanMonth) TableName() string {
+	return "payoff_plan_months"
+}
+
+func (s PayoffStrategy) IsValid() bool {
+	switch s {
+	case PayoffStrategySnowball, PayoffStrategyAvalanche, PayoffStrategyCustomOrder:
+		return true
+	}
+	return false
+}
+
+// LoanIDs parses a comma separated ID list such as CustomOrder or PayoffOrder
+func LoanIDs(list string) []uuid.UUID {
+	var ids []uuid.UUID
+	for _, part := range strings.Split(list, ",") {
+		if id, err := uuid.Parse(strings.TrimSpace(part)); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// JoinLoanIDs is the inverse of LoanIDs
+func JoinLoanIDs(ids []uuid.UUID) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = id.String()
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,541 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type RecurrenceFrequency string
+
+const (
+	RecurrenceFrequencyDaily   RecurrenceFrequency = "DAILY"
+	RecurrenceFrequencyWeekly  RecurrenceFrequency = "WEEKLY"
+	RecurrenceFrequencyMonthly RecurrenceFrequency = "MONTHLY"
+	RecurrenceFrequencyYearly  RecurrenceFrequency = "YEARLY"
+)
+
+// maxRecurrencePeriods bounds occurrence generation so a bad rule can't loop forever
+const maxRecurrencePeriods = 100000
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// Recurrence is an RFC 5545 style repetition rule embedded in recurring records
+// (prefix recurrence_). A nil Frequency means no rule is set.
+//
+// ByWeekday, ByMonthDay and ByMonth hold comma separated lists, e.g. "MO,WE",
+// "1,15,-1" and "3,6,9,12". ByWeekday entries may carry an ordinal for monthly
+// and yearly rules ("1MO" first Monday, "-1FR" last Friday). Unlike RFC 5545,
+// month days past the end of a month are clamped to its last day, matching how
+// RecurringDay has always behaved.
+type Recurrence struct {
+	Frequency  *RecurrenceFrequency `gorm:"type:varchar(10)" json:"frequency,omitempty"`
+	Interval   int                  `gorm:"not null;default:1" json:"interval"`
+	ByWeekday  string               `gorm:"type:varchar(100)" json:"by_weekday,omitempty"`
+	ByMonthDay string               `gorm:"type:varchar(100)" json:"by_month_day,omitempty"`
+	ByMonth    string               `gorm:"type:varchar(50)" json:"by_month,omitempty"`
+	StartDate  *time.Time           `gorm:"type:date" json:"start_date,omitempty"`
+	EndDate    *time.Time           `gorm:"type:date" json:"end_date,omitempty"`
+	Count      *int                 `gorm:"type:int" json:"count,omitempty"`
+}
+
+// WeekdayRule is one BYDAY entry. Ordinal 0 means every such weekday in the period.
+type WeekdayRule struct {
+	Weekday time.Weekday
+	Ordinal int
+}
+
+// MonthlyOnDay returns the rule equivalent of the legacy RecurringDay field
+func MonthlyOnDay(day int) Recurrence {
+	frequency := RecurrenceFrequencyMonthly
+	return Recurrence{
+		Frequency:  &frequency,
+		Interval:   1,
+		ByMonthDay: strconv.Itoa(day),
+	}
+}
+
+func (r Recurrence) IsSet() bool {
+	return r.Frequency != nil
+}
+
+func (r Recurrence) interval() int {
+	if r.Interval < 1 {
+		return 1
+	}
+	return r.Interval
+}
+
+func (r Recurrence) Weekdays() ([]WeekdayRule, error) {
+	var rules []WeekdayRule
+	for _, part := range splitList(r.ByWeekday) {
+		part = strings.ToUpper(part)
+		if len(part) < 2 {
+			return nil, fmt.Errorf("invalid weekday %q", part)
+		}
+		code := part[len(part)-2:]
+		weekday, ok := weekdayCodes[code]
+		if !ok {
+			return nil, fmt.Errorf("invalid weekday %q", part)
+		}
+		ordinal := 0
+		if prefix := part[:len(part)-2]; prefix != "" {
+			n, err := strconv.Atoi(prefix)
+			if err != nil || n == 0 || n < -5 || n > 5 {
+				return nil, fmt.Errorf("invalid weekday ordinal %q", part)
+			}
+			ordinal = n
+		}
+		rules = append(rules, WeekdayRule{Weekday: weekday, Ordinal: ordinal})
+	}
+	return rules, nil
+}
+
+func (r Recurrence) MonthDays() ([]int, error) {
+	return parseIntList(r.ByMonthDay, -31, 31, "month day")
+}
+
+func (r Recurrence) Months() ([]int, error) {
+	return parseIntList(r.ByMonth, 1, 12, "month")
+}
+
+func (r Recurrence) Validate() error {
+	if r.Frequency == nil {
+		return errors.New("recurrence frequency is required")
+	}
+	switch *r.Frequency {
+	case RecurrenceFrequencyDaily, RecurrenceFrequencyWeekly, RecurrenceFrequencyMonthly, RecurrenceFrequencyYearly:
+	default:
+		return fmt.Errorf("invalid recurrence frequency %q", *r.Frequency)
+	}
+	if r.Interval < 1 {
+		return errors.New("recurrence interval must be at least 1")
+	}
+
+	weekdays, err := r.Weekdays()
+	if err != nil {
+		return err
+	}
+	if *r.Frequency == RecurrenceFrequencyDaily || *r.Frequency == RecurrenceFrequencyWeekly {
+		for _, w := range weekdays {
+			if w.Ordinal != 0 {
+				return errors.New("weekday ordinals are only allowed for monthly and yearly rules")
+			}
+		}
+	}
+	if _, err := r.MonthDays(); err != nil {
+		return err
+	}
+	if _, err := r.Months(); err != nil {
+		return err
+	}
+
+	if r.Count != nil {
+		if *r.Count < 1 {
+			return errors.New("recurrence count must be positive")
+		}
+		if r.StartDate == nil {
+			return errors.New("recurrence count requires a start date")
+		}
+		if r.EndDate != nil {
+			return errors.New("recurrence can't have both a count and an end date")
+		}
+	}
+	if r.StartDate != nil && r.EndDate != nil && r.EndDate.Before(*r.StartDate) {
+		return errors.New("recurrence end date must be after start date")
+	}
+	return nil
+}
+
+// Occurrences returns every occurrence between from and to (inclusive), in order.
+// anchor stands in for StartDate when the rule has none; it decides the phase of
+// intervals and the default weekday/day/month.
+func (r Recurrence) Occurrences(anchor, from, to time.Time) []time.Time {
+	if r.Frequency == nil {
+		return nil
+	}
+	weekdays, err := r.Weekdays()
+	if err != nil {
+		return nil
+	}
+	monthDays, err := r.MonthDays()
+	if err != nil {
+		return nil
+	}
+	months, err := r.Months()
+	if err != nil {
+		return nil
+	}
+
+	start := truncateDate(anchor)
+	if r.StartDate != nil {
+		start = truncateDate(*r.StartDate)
+	}
+	from = truncateDate(from)
+	to = truncateDate(to)
+	if r.EndDate != nil && truncateDate(*r.EndDate).Before(to) {
+		to = truncateDate(*r.EndDate)
+	}
+
+	var result []time.Time
+	seen := 0
+	for k := 0; k < maxRecurrencePeriods; k++ {
+		candidates, periodStart := r.periodCandidates(start, k, weekdays, monthDays, months)
+		if periodStart.After(to) {
+			break
+		}
+		for _, date := range candidates {
+			if date.Before(start) {
+				continue
+			}
+			if date.After(to) {
+				return result
+			}
+			seen++
+			if r.Count != nil && seen > *r.Count {
+				return result
+			}
+			if !date.Before(from) {
+				result = append(result, date)
+			}
+		}
+	}
+	return result
+}
+
+// Next returns the first occurrence strictly after the given date within the
+// next five years, if any
+func (r Recurrence) Next(anchor, after time.Time) *time.Time {
+	from := truncateDate(after).AddDate(0, 0, 1)
+	occurrences := r.Occurrences(anchor, from, from.AddDate(5, 0, 0))
+	if len(occurrences) == 0 {
+		return nil
+	}
+	return &occurrences[0]
+}
+
+// periodCandidates returns the sorted candidate dates of the k-th period and the
+// first day of that period
+func (r Recurrence) periodCandidates(start time.Time, k int, weekdays []WeekdayRule, monthDays, months []int) ([]time.Time, time.Time) {
+	step := k * r.interval()
+
+	switch *r.Frequency {
+	case RecurrenceFrequencyDaily:
+		date := start.AddDate(0, 0, step)
+		if matchesFilters(date, weekdays, monthDays, months) {
+			return []time.Time{date}, date
+		}
+		return nil, date
+
+	case RecurrenceFrequencyWeekly:
+		weekStart := start.AddDate(0, 0, -((int(start.Weekday())+6)%7)+step*7)
+		days := weekdays
+		if len(days) == 0 {
+			days = []WeekdayRule{{Weekday: start.Weekday()}}
+		}
+		var dates []time.Time
+		for _, w := range days {
+			date := weekStart.AddDate(0, 0, (int(w.Weekday)+6)%7)
+			if len(months) == 0 || containsInt(months, int(date.Month())) {
+				dates = append(dates, date)
+			}
+		}
+		return sortDates(dates), weekStart
+
+	case RecurrenceFrequencyMonthly:
+		monthStart := time.Date(start.Year(), start.Month()+time.Month(step), 1, 0, 0, 0, 0, time.UTC)
+		if len(months) > 0 && !containsInt(months, int(monthStart.Month())) {
+			return nil, monthStart
+		}
+		return monthCandidates(monthStart, start.Day(), weekdays, monthDays), monthStart
+
+	default: // yearly
+		yearStart := time.Date(start.Year()+step, 1, 1, 0, 0, 0, 0, time.UTC)
+		yearMonths := months
+		if len(yearMonths) == 0 {
+			yearMonths = []int{int(start.Month())}
+		}
+		var dates []time.Time
+		for _, m := range yearMonths {
+			monthStart := time.Date(yearStart.Year(), time.Month(m), 1, 0, 0, 0, 0, time.UTC)
+			dates = append(dates, monthCandidates(monthStart, start.Day(), weekdays, monthDays)...)
+		}
+		return sortDates(dates), yearStart
+	}
+}
+
+// monthCandidates expands BYMONTHDAY and BYDAY within one month. When both are
+// given only days matching both are kept; with neither, defaultDay is used.
+func monthCandidates(monthStart time.Time, defaultDay int, weekdays []WeekdayRule, monthDays []int) []time.Time {
+	lastDay := monthStart.AddDate(0, 1, -1).Day()
+
+	var byMonthDay []time.Time
+	for _, d := range monthDays {
+		day := d
+		if d < 0 {
+			day = lastDay + d + 1
+			if day < 1 {
+				continue
+			}
+		} else if d > lastDay {
+			day = lastDay
+		}
+		byMonthDay = append(byMonthDay, monthStart.AddDate(0, 0, day-1))
+	}
+
+	var byWeekday []time.Time
+	for _, w := range weekdays {
+		first := monthStart.AddDate(0, 0, (int(w.Weekday)-int(monthStart.Weekday())+7)%7)
+		var matches []time.Time
+		for date := first; date.Month() == monthStart.Month(); date = date.AddDate(0, 0, 7) {
+			matches = append(matches, date)
+		}
+		switch {
+		case w.Ordinal == 0:
+			byWeekday = append(byWeekday, matches...)
+		case w.Ordinal > 0 && w.Ordinal <= len(matches):
+			byWeekday = append(byWeekday, matches[w.Ordinal-1])
+		case w.Ordinal < 0 && -w.Ordinal <= len(matches):
+			byWeekday = append(byWeekday, matches[len(matches)+w.Ordinal])
+		}
+	}
+
+	switch {
+	case len(monthDays) > 0 && len(weekdays) > 0:
+		var both []time.Time
+		for _, date := range byMonthDay {
+			for _, other := range byWeekday {
+				if date.Equal(other) {
+					both = append(both, date)
+					break
+				}
+			}
+		}
+		return sortDates(both)
+	case len(monthDays) > 0:
+		return sortDates(byMonthDay)
+	case len(weekdays) > 0:
+		return sortDates(byWeekday)
+	default:
+		day := defaultDay
+		if day > lastDay {
+			day = lastDay
+		}
+		return []time.Time{monthStart.AddDate(0, 0, day-1)}
+	}
+}
+
+func matchesFilters(date time.Time, weekdays []WeekdayRule, monthDays, months []int) bool {
+	if len(months) > 0 && !containsInt(months, int(date.Month())) {
+		return false
+	}
+	if len(monthDays) > 0 {
+		lastDay := time.Date(date.Year(), date.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+		match := false
+		for _, d := range monthDays {
+			if d == date.Day() || (d < 0 && lastDay+d+1 == date.Day()) {
+				match = true
+				break
+			}
+		}
+		if !match {
+			return false
+		}
+	}
+	if len(weekdays) > 0 {
+		match := false
+		for _, w := range weekdays {
+			if w.Weekday == date.Weekday() {
+				match = true
+				break
+			}
+		}
+		if !match {
+			return false
+		}
+	}
+	return true
+}
+
+// RRule renders the rule as an RFC 5545 RRULE value (without DTSTART)
+func (r Recurrence) RRule() string {
+	if r.Frequency == nil {
+		return ""
+	}
+	parts := []string{"FREQ=" + string(*r.Frequency)}
+	if r.interval() > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.interval()))
+	}
+	if r.ByWeekday != "" {
+		parts = append(parts, "BYDAY="+strings.ToUpper(r.ByWeekday))
+	}
+	if r.ByMonthDay != "" {
+		parts = append(parts, "BYMONTHDAY="+r.ByMonthDay)
+	}
+	if r.ByMonth != "" {
+		parts = append(parts, "BYMONTH="+r.ByMonth)
+	}
+	if r.Count != nil {
+		parts = append(parts, "COUNT="+strconv.Itoa(*r.Count))
+	}
+	if r.EndDate != nil {
+		parts = append(parts, "UNTIL="+r.EndDate.Format("20060102"))
+	}
+	return strings.Join(parts, ";")
+}
+
+// ParseRRule imports an RFC 5545 rule. It accepts a bare rule ("FREQ=MONTHLY;..."),
+// an "RRULE:" line, and an optional "DTSTART:" line which becomes the start date.
+// Time-of-day parts (BYHOUR, BYMINUTE, ...) and BYSETPOS are not supported.
+func ParseRRule(value string) (*Recurrence, error) {
+	r := &Recurrence{Interval: 1}
+	found := false
+
+	for _, line := range strings.FieldsFunc(value, func(c rune) bool { return c == '\n' || c == '\r' }) {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		upper := strings.ToUpper(line)
+		if strings.HasPrefix(upper, "DTSTART") {
+			idx := strings.LastIndex(line, ":")
+			if idx < 0 {
+				return nil, fmt.Errorf("invalid DTSTART %q", line)
+			}
+			start, err := parseRRuleDate(line[idx+1:])
+			if err != nil {
+				return nil, err
+			}
+			r.StartDate = &start
+			continue
+		}
+		upper = strings.TrimPrefix(upper, "RRULE:")
+		if err := r.applyRRuleParts(upper); err != nil {
+			return nil, err
+		}
+		found = true
+	}
+
+	if !found {
+		return nil, errors.New("RRULE is empty")
+	}
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Recurrence) applyRRuleParts(rule string) error {
+	for _, part := range strings.Split(rule, ";") {
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("invalid RRULE part %q", part)
+		}
+		key, val := kv[0], kv[1]
+
+		switch key {
+		case "FREQ":
+			frequency := RecurrenceFrequency(val)
+			r.Frequency = &frequency
+		case "INTERVAL":
+			n, err := strconv.Atoi(val)
+			if err != nil {
+				return fmt.Errorf("invalid INTERVAL %q", val)
+			}
+			r.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(val)
+			if err != nil {
+				return fmt.Errorf("invalid COUNT %q", val)
+			}
+			r.Count = &n
+		case "UNTIL":
+			until, err := parseRRuleDate(val)
+			if err != nil {
+				return err
+			}
+			r.EndDate = &until
+		case "BYDAY":
+			r.ByWeekday = strings.ReplaceAll(val, "+", "")
+		case "BYMONTHDAY":
+			r.ByMonthDay = val
+		case "BYMONTH":
+			r.ByMonth = val
+		case "WKST":
+			// Weeks always start on Monday here
+		default:
+			return fmt.Errorf("unsupported RRULE part %s", key)
+		}
+	}
+	return nil
+}
+
+func parseRRuleDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if len(value) >= 8 {
+		if t, err := time.Parse("20060102", value[:8]); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid RRULE date %q", value)
+}
+
+func truncateDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func parseIntList(value string, min, max int, name string) ([]int, error) {
+	var result []int
+	for _, item := range splitList(value) {
+		n, err := strconv.Atoi(item)
+		if err != nil || n < min || n > max || n == 0 {
+			return nil, fmt.Errorf("invalid %s %q", name, item)
+		}
+		result = append(result, n)
+	}
+	return result, nil
+}
+
+func containsInt(values []int, n int) bool {
+	for _, v := range values {
+		if v == n {
+			return true
+		}
+	}
+	return false
+}
+
+func sortDates(dates []time.Time) []time.Time {
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	unique := dates[:0]
+	for i, date := range dates {
+		if i == 0 || !date.Equal(dates[i-1]) {
+			unique = append(unique, date)
+		}
+	}
+	return unique
+}
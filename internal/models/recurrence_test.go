@@ -0,0 +1,259 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func mustParseRRule(t *testing.T, value string) Recurrence {
+	t.Helper()
+	rule, err := ParseRRule(value)
+	if err != nil {
+		t.Fatalf("ParseRRule(%q): %v", value, err)
+	}
+	return *rule
+}
+
+func formatDates(dates []time.Time) string {
+	parts := make([]string, len(dates))
+	for i, d := range dates {
+		parts[i] = d.Format("2006-01-02")
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestRecurrenceOccurrences(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   string
+		anchor time.Time
+		from   time.Time
+		to     time.Time
+		want   string
+	}{
+		{
+			name:   "day 31 is clamped to the end of shorter months",
+			rule:   "FREQ=MONTHLY;BYMONTHDAY=31",
+			anchor: date(2026, time.January, 31),
+			from:   date(2026, time.January, 1),
+			to:     date(2026, time.May, 31),
+			want:   "2026-01-31 2026-02-28 2026-03-31 2026-04-30 2026-05-31",
+		},
+		{
+			name:   "anchor day is clamped when no month day is given",
+			rule:   "FREQ=MONTHLY",
+			anchor: date(2026, time.January, 31),
+			from:   date(2026, time.January, 1),
+			to:     date(2026, time.April, 30),
+			want:   "2026-01-31 2026-02-28 2026-03-31 2026-04-30",
+		},
+		{
+			name:   "leap year February",
+			rule:   "FREQ=MONTHLY;BYMONTHDAY=30",
+			anchor: date(2028, time.January, 30),
+			from:   date(2028, time.January, 1),
+			to:     date(2028, time.March, 31),
+			want:   "2028-01-30 2028-02-29 2028-03-30",
+		},
+		{
+			name:   "last day of the month",
+			rule:   "FREQ=MONTHLY;BYMONTHDAY=-1",
+			anchor: date(2026, time.January, 15),
+			from:   date(2026, time.January, 1),
+			to:     date(2026, time.April, 30),
+			want:   "2026-01-31 2026-02-28 2026-03-31 2026-04-30",
+		},
+		{
+			name:   "twice a month",
+			rule:   "FREQ=MONTHLY;BYMONTHDAY=1,15",
+			anchor: date(2026, time.January, 1),
+			from:   date(2026, time.January, 10),
+			to:     date(2026, time.February, 20),
+			want:   "2026-01-15 2026-02-01 2026-02-15",
+		},
+		{
+			name:   "every other month",
+			rule:   "FREQ=MONTHLY;INTERVAL=2;BYMONTHDAY=10",
+			anchor: date(2026, time.January, 10),
+			from:   date(2026, time.January, 1),
+			to:     date(2026, time.June, 30),
+			want:   "2026-01-10 2026-03-10 2026-05-10",
+		},
+		{
+			name:   "quarter ends",
+			rule:   "FREQ=MONTHLY;BYMONTH=3,6,9,12;BYMONTHDAY=-1",
+			anchor: date(2026, time.January, 1),
+			from:   date(2026, time.January, 1),
+			to:     date(2026, time.December, 31),
+			want:   "2026-03-31 2026-06-30 2026-09-30 2026-12-31",
+		},
+		{
+			name:   "last Friday of the month",
+			rule:   "FREQ=MONTHLY;BYDAY=-1FR",
+			anchor: date(2026, time.January, 1),
+			from:   date(2026, time.January, 1),
+			to:     date(2026, time.March, 31),
+			want:   "2026-01-30 2026-02-27 2026-03-27",
+		},
+		{
+			name:   "first Monday of the month",
+			rule:   "FREQ=MONTHLY;BYDAY=1MO",
+			anchor: date(2026, time.January, 1),
+			from:   date(2026, time.January, 1),
+			to:     date(2026, time.March, 31),
+			want:   "2026-01-05 2026-02-02 2026-03-02",
+		},
+		{
+			name:   "weekly on two days skips the days before the start",
+			rule:   "FREQ=WEEKLY;BYDAY=MO,WE",
+			anchor: date(2026, time.January, 1),
+			from:   date(2025, time.December, 1),
+			to:     date(2026, time.January, 14),
+			want:   "2026-01-05 2026-01-07 2026-01-12 2026-01-14",
+		},
+		{
+			name:   "every other week on the anchor weekday",
+			rule:   "FREQ=WEEKLY;INTERVAL=2",
+			anchor: date(2026, time.January, 1),
+			from:   date(2026, time.January, 1),
+			to:     date(2026, time.January, 31),
+			want:   "2026-01-01 2026-01-15 2026-01-29",
+		},
+		{
+			name:   "working days",
+			rule:   "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR",
+			anchor: date(2026, time.January, 1),
+			from:   date(2026, time.January, 1),
+			to:     date(2026, time.January, 6),
+			want:   "2026-01-01 2026-01-02 2026-01-05 2026-01-06",
+		},
+		{
+			name:   "yearly on February 29",
+			rule:   "FREQ=YEARLY",
+			anchor: date(2028, time.February, 29),
+			from:   date(2028, time.January, 1),
+			to:     date(2030, time.December, 31),
+			want:   "2028-02-29 2029-02-28 2030-02-28",
+		},
+		{
+			name:   "count includes occurrences before from",
+			rule:   "DTSTART:20260105\nRRULE:FREQ=MONTHLY;COUNT=3",
+			anchor: date(2025, time.June, 1),
+			from:   date(2026, time.February, 1),
+			to:     date(2026, time.December, 31),
+			want:   "2026-02-05 2026-03-05",
+		},
+		{
+			name:   "until ends the rule",
+			rule:   "FREQ=MONTHLY;BYMONTHDAY=5;UNTIL=20260320",
+			anchor: date(2026, time.January, 5),
+			from:   date(2026, time.January, 1),
+			to:     date(2026, time.December, 31),
+			want:   "2026-01-05 2026-02-05 2026-03-05",
+		},
+		{
+			name:   "catch-up from the day after the last post",
+			rule:   "FREQ=MONTHLY;BYMONTHDAY=25",
+			anchor: date(2026, time.January, 1),
+			from:   date(2026, time.January, 26),
+			to:     date(2026, time.April, 10),
+			want:   "2026-02-25 2026-03-25",
+		},
+		{
+			name:   "same day from and to",
+			rule:   "FREQ=MONTHLY;BYMONTHDAY=25",
+			anchor: date(2026, time.January, 1),
+			from:   date(2026, time.March, 25),
+			to:     date(2026, time.March, 25),
+			want:   "2026-03-25",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := mustParseRRule(t, tt.rule)
+			if got := formatDates(rule.Occurrences(tt.anchor, tt.from, tt.to)); got != tt.want {
+				t.Errorf("Occurrences = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecurrenceWithoutRule(t *testing.T) {
+	var rule Recurrence
+	if got := rule.Occurrences(date(2026, time.January, 1), date(2026, time.January, 1), date(2026, time.December, 31)); got != nil {
+		t.Errorf("Occurrences = %v, want none", got)
+	}
+	if got := rule.Next(date(2026, time.January, 1), date(2026, time.January, 1)); got != nil {
+		t.Errorf("Next = %v, want none", got)
+	}
+}
+
+func TestRecurrenceNext(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   string
+		anchor time.Time
+		after  time.Time
+		want   string
+	}{
+		{name: "later the same month", rule: "FREQ=MONTHLY;BYMONTHDAY=25", anchor: date(2026, time.January, 1), after: date(2026, time.March, 10), want: "2026-03-25"},
+		{name: "strictly after an occurrence", rule: "FREQ=MONTHLY;BYMONTHDAY=25", anchor: date(2026, time.January, 1), after: date(2026, time.March, 25), want: "2026-04-25"},
+		{name: "into a shorter month", rule: "FREQ=MONTHLY;BYMONTHDAY=31", anchor: date(2026, time.January, 31), after: date(2026, time.January, 31), want: "2026-02-28"},
+		{name: "after the last counted occurrence", rule: "DTSTART:20260105\nRRULE:FREQ=MONTHLY;COUNT=2", anchor: date(2026, time.January, 5), after: date(2026, time.February, 5), want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := mustParseRRule(t, tt.rule)
+			got := ""
+			if next := rule.Next(tt.anchor, tt.after); next != nil {
+				got = next.Format("2006-01-02")
+			}
+			if got != tt.want {
+				t.Errorf("Next = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr string
+	}{
+		{name: "bare rule", value: "FREQ=MONTHLY;BYMONTHDAY=1,15", want: "FREQ=MONTHLY;BYMONTHDAY=1,15"},
+		{name: "RRULE line with a start date", value: "DTSTART:20260105T090000Z\nRRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;COUNT=4", want: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;COUNT=4"},
+		{name: "lower case with a plus ordinal", value: "rrule:freq=monthly;byday=+1mo;wkst=mo", want: "FREQ=MONTHLY;BYDAY=1MO"},
+		{name: "until", value: "FREQ=YEARLY;BYMONTH=2;UNTIL=20301231", want: "FREQ=YEARLY;BYMONTH=2;UNTIL=20301231"},
+		{name: "empty", value: "DTSTART:20260105", wantErr: "RRULE is empty"},
+		{name: "unknown frequency", value: "FREQ=HOURLY", wantErr: "invalid recurrence frequency"},
+		{name: "unsupported part", value: "FREQ=MONTHLY;BYSETPOS=-1", wantErr: "unsupported RRULE part BYSETPOS"},
+		{name: "ordinal on a weekly rule", value: "FREQ=WEEKLY;BYDAY=1MO", wantErr: "only allowed for monthly and yearly"},
+		{name: "count without a start date", value: "FREQ=MONTHLY;COUNT=3", wantErr: "requires a start date"},
+		{name: "count and until", value: "DTSTART:20260105\nRRULE:FREQ=MONTHLY;COUNT=3;UNTIL=20261231", wantErr: "both a count and an end date"},
+		{name: "month day out of range", value: "FREQ=MONTHLY;BYMONTHDAY=32", wantErr: "invalid month day"},
+		{name: "zero interval", value: "FREQ=DAILY;INTERVAL=0", wantErr: "interval must be at least 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRRule(tt.value)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseRRule error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRRule: %v", err)
+			}
+			if got := rule.RRule(); got != tt.want {
+				t.Errorf("RRule = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
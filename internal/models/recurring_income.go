@@ -16,14 +16,49 @@ type RecurringIncomeGroup struct {
 	Notes        *string   `gorm:"type:text" json:"notes,omitempty"`
 	CreatedAt    time.Time `gorm:"default:now()" json:"created_at"`
 
-	User  *User                 `gorm:"foreignKey:UserID" json:"user,omitempty"`
-	Items []RecurringIncomeItem `gorm:"foreignKey:GroupID" json:"items,omitempty"`
+	// Recurrence takes precedence over RecurringDay when set
+	Recurrence Recurrence `gorm:"embedded;embeddedPrefix:recurrence_" json:"recurrence"`
+
+	// Auto-post creates the incomes on each occurrence into TargetPocketID (or the
+	// default pocket). LastPostedDate, the latest scheduled occurrence already
+	// posted, guards against double posting.
+	AutoPost           bool       `gorm:"not null;default:false" json:"auto_post"`
+	TargetPocketID     *uuid.UUID `gorm:"type:uuid" json:"target_pocket_id,omitempty"`
+	ShiftToBusinessDay bool       `gorm:"not null;default:false" json:"shift_to_business_day"`
+	LastPostedDate     *time.Time `gorm:"type:date" json:"last_posted_date,omitempty"`
+
+	User         *User                 `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	TargetPocket *Account              `gorm:"foreignKey:TargetPocketID" json:"target_pocket,omitempty"`
+	Items        []RecurringIncomeItem `gorm:"foreignKey:GroupID" json:"items,omitempty"`
 }
 
 func (RecurringIncomeGroup) TableName() string {
 	return "recurring_income_groups"
 }
 
+// Schedule returns the group's recurrence rule, falling back to a monthly rule on
+// RecurringDay. It returns nil when the group doesn't recur.
+func (g *RecurringIncomeGroup) Schedule() *Recurrence {
+	if g.Recurrence.IsSet() {
+		return &g.Recurrence
+	}
+	if g.RecurringDay != nil {
+		rule := MonthlyOnDay(*g.RecurringDay)
+		return &rule
+	}
+	return nil
+}
+
+// Occurrences returns the group's scheduled dates between from and to, anchored
+// at the group's creation when the rule has no start date
+func (g *RecurringIncomeGroup) Occurrences(from, to time.Time) []time.Time {
+	schedule := g.Schedule()
+	if schedule == nil {
+		return nil
+	}
+	return schedule.Occurrences(g.CreatedAt, from, to)
+}
+
 func (g *RecurringIncomeGroup) Total() int64 {
 	var total int64
 	for _, item := range g.Items {
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RecurringIncomeRunStatus string
+
+const (
+	RecurringIncomeRunStatusSuccess RecurringIncomeRunStatus = "SUCCESS"
+	RecurringIncomeRunStatusPartial RecurringIncomeRunStatus = "PARTIAL"
+	RecurringIncomeRunStatusFailed  RecurringIncomeRunStatus = "FAILED"
+)
+
+// RecurringIncomeRun logs one automatic posting of a recurring income group.
+// ScheduledDate is the occurrence the run posted; PostedDate can be earlier when
+// a payday is moved back to the previous business day.
+type RecurringIncomeRun struct {
+	ID            uuid.UUID                `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID        uuid.UUID                `gorm:"type:uuid;not null" json:"user_id"`
+	GroupID       uuid.UUID                `gorm:"type:uuid;not null;uniqueIndex:idx_recurring_income_runs_group_date" json:"group_id"`
+	ScheduledDate time.Time                `gorm:"type:date;not null;uniqueIndex:idx_recurring_income_runs_group_date" json:"scheduled_date"`
+	PostedDate    time.Time                `gorm:"type:date;not null" json:"posted_date"`
+	Status        RecurringIncomeRunStatus `gorm:"type:varchar(20);not null" json:"status"`
+	IncomeCount   int                      `gorm:"not null;default:0" json:"income_count"`
+	TotalAmount   int64                    `gorm:"not null;default:0" json:"total_amount"`
+	Error         *string                  `gorm:"type:text" json:"error,omitempty"`
+	CreatedAt     time.Time                `gorm:"default:now()" json:"created_at"`
+
+	Group *RecurringIncomeGroup `gorm:"foreignKey:GroupID" json:"group,omitempty"`
+}
+
+func (RecurringIncomeRun) TableName() string {
+	return "recurring_income_runs"
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RoundUpMode string
+
+const (
+	RoundUpModePerExpense RoundUpMode = "PER_EXPENSE"
+	RoundUpModeDaily      RoundUpMode = "DAILY"
+)
+
+// RoundUpIncrements are the amounts an expense total can be rounded up to
+var RoundUpIncrements = []int64{1000, 5000, 10000}
+
+// RoundUpSetting is a user's round-up configuration. Each expense paid from a
+// pocket sets aside the difference to the next Increment for SavingsGoalID,
+// either right away or batched once a day.
+type RoundUpSetting struct {
+	ID            uuid.UUID   `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID        uuid.UUID   `gorm:"type:uuid;not null;uniqueIndex" json:"user_id"`
+	IsEnabled     bool        `gorm:"not null;default:false" json:"is_enabled"`
+	Increment     int64       `gorm:"not null;default:1000" json:"increment"`
+	SavingsGoalID *uuid.UUID  `gorm:"type:uuid" json:"savings_goal_id,omitempty"`
+	Mode          RoundUpMode `gorm:"type:varchar(20);not null;default:'PER_EXPENSE'" json:"mode"`
+	CreatedAt     time.Time   `gorm:"default:now()" json:"created_at"`
+	UpdatedAt     *time.Time  `json:"updated_at,omitempty"`
+
+	SavingsGoal *SavingsGoal `gorm:"foreignKey:SavingsGoalID" json:"savings_goal,omitempty"`
+}
+
+func (RoundUpSetting) TableName() string {
+	return "round_up_settings"
+}
+
+// RoundUp is the amount set aside for one expense. ContributionID is nil while
+// it waits for the daily batch; several round-ups can share one contribution.
+type RoundUp struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID         uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	ExpenseID      uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex" json:"expense_id"`
+	SavingsGoalID  uuid.UUID  `gorm:"type:uuid;not null" json:"savings_goal_id"`
+	PocketID       uuid.UUID  `gorm:"type:uuid;not null" json:"pocket_id"`
+	Increment      int64      `gorm:"not null" json:"increment"`
+	Amount         int64      `gorm:"not null" json:"amount"`
+	Date           time.Time  `gorm:"type:date;not null" json:"date"`
+	ContributionID *uuid.UUID `gorm:"type:uuid;index" json:"contribution_id,omitempty"`
+	CreatedAt      time.Time  `gorm:"default:now()" json:"created_at"`
+
+	SavingsGoal *SavingsGoal `gorm:"foreignKey:SavingsGoalID" json:"savings_goal,omitempty"`
+}
+
+func (RoundUp) TableName() string {
+	return "round_ups"
+}
+
+// RoundUpAmount is the difference between total and the next multiple of increment
+func RoundUpAmount(total, increment int64) int64 {
+	if increment <= 0 || total <= 0 {
+		return 0
+	}
+	remainder := total % increment
+	if remainder == 0 {
+		return 0
+	}
+	return increment - remainder
+}
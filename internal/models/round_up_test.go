@@ -0,0 +1,30 @@
+package models
+
+import "testing"
+
+func TestRoundUpAmount(t *testing.T) {
+	tests := []struct {
+		name      string
+		total     int64
+		increment int64
+		want      int64
+	}{
+		{name: "rounds up to the next thousand", total: 23500, increment: 1000, want: 500},
+		{name: "rounds up to the next five thousand", total: 23500, increment: 5000, want: 1500},
+		{name: "rounds up to the next ten thousand", total: 23500, increment: 10000, want: 6500},
+		{name: "one rupiah over", total: 10001, increment: 10000, want: 9999},
+		{name: "exact multiple", total: 25000, increment: 5000, want: 0},
+		{name: "smaller than the increment", total: 300, increment: 1000, want: 700},
+		{name: "zero total", total: 0, increment: 1000, want: 0},
+		{name: "fully refunded", total: -500, increment: 1000, want: 0},
+		{name: "no increment", total: 23500, increment: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RoundUpAmount(tt.total, tt.increment); got != tt.want {
+				t.Errorf("RoundUpAmount(%d, %d) = %d, want %d", tt.total, tt.increment, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SavingsAutoRuleType string
+
+const (
+	SavingsAutoRuleTypeFixed            SavingsAutoRuleType = "FIXED"
+	SavingsAutoRuleTypeIncomePercentage SavingsAutoRuleType = "INCOME_PERCENTAGE"
+)
+
+// Reasons a rule was paused by the scheduler rather than by the user
+const (
+	SavingsAutoRulePausedGoalReached  = "GOAL_REACHED"
+	SavingsAutoRulePausedGoalInactive = "GOAL_INACTIVE"
+)
+
+// SavingsAutoRule contributes to a savings goal from SourcePocketID on every
+// occurrence of its recurrence. A FIXED rule moves Amount; an INCOME_PERCENTAGE
+// rule moves Percentage of the incomes received since the previous occurrence.
+// LastRunDate, the latest occurrence already executed, guards against running
+// an occurrence twice.
+type SavingsAutoRule struct {
+	ID             uuid.UUID           `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID         uuid.UUID           `gorm:"type:uuid;not null" json:"user_id"`
+	SavingsGoalID  uuid.UUID           `gorm:"type:uuid;not null;index" json:"savings_goal_id"`
+	Type           SavingsAutoRuleType `gorm:"type:varchar(20);not null" json:"type"`
+	Amount         *int64              `json:"amount,omitempty"`
+	Percentage     *float64            `gorm:"type:decimal(5,2)" json:"percentage,omitempty"`
+	SourcePocketID uuid.UUID           `gorm:"type:uuid;not null" json:"source_pocket_id"`
+	Recurrence     Recurrence          `gorm:"embedded;embeddedPrefix:recurrence_" json:"recurrence"`
+	IsActive       bool                `gorm:"not null;default:true" json:"is_active"`
+	PausedReason   *string             `gorm:"type:varchar(50)" json:"paused_reason,omitempty"`
+	LastRunDate    *time.Time          `gorm:"type:date" json:"last_run_date,omitempty"`
+	CreatedAt      time.Time           `gorm:"default:now()" json:"created_at"`
+
+	SavingsGoal  *SavingsGoal `gorm:"foreignKey:SavingsGoalID" json:"savings_goal,omitempty"`
+	SourcePocket *Account     `gorm:"foreignKey:SourcePocketID" json:"source_pocket,omitempty"`
+}
+
+func (SavingsAutoRule) TableName() string {
+	return "savings_auto_rules"
+}
+
+// Occurrences returns the rule's scheduled dates between from and to, anchored
+// at the rule's creation when the recurrence has no start date
+func (r *SavingsAutoRule) Occurrences(from, to time.Time) []time.Time {
+	return r.Recurrence.Occurrences(r.CreatedAt, from, to)
+}
+
+type SavingsAutoRunStatus string
+
+const (
+	SavingsAutoRunStatusSuccess           SavingsAutoRunStatus = "SUCCESS"
+	SavingsAutoRunStatusSkipped           SavingsAutoRunStatus = "SKIPPED"
+	SavingsAutoRunStatusInsufficientFunds SavingsAutoRunStatus = "INSUFFICIENT_FUNDS"
+	SavingsAutoRunStatusFailed            SavingsAutoRunStatus = "FAILED"
+)
+
+// SavingsAutoRun logs one execution of a savings auto rule. Amount is what the
+// rule tried to contribute; ContributionID is only set on success.
+type SavingsAutoRun struct {
+	ID             uuid.UUID            `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID         uuid.UUID            `gorm:"type:uuid;not null" json:"user_id"`
+	RuleID         uuid.UUID            `gorm:"type:uuid;not null;uniqueIndex:idx_savings_auto_runs_rule_date" json:"rule_id"`
+	SavingsGoalID  uuid.UUID            `gorm:"type:uuid;not null" json:"savings_goal_id"`
+	ScheduledDate  time.Time            `gorm:"type:date;not null;uniqueIndex:idx_savings_auto_runs_rule_date" json:"scheduled_date"`
+	Status         SavingsAutoRunStatus `gorm:"type:varchar(20);not null" json:"status"`
+	Amount         int64                `gorm:"not null;default:0" json:"amount"`
+	ContributionID *uuid.UUID           `gorm:"type:uuid" json:"contribution_id,omitempty"`
+	Reason         *string              `gorm:"type:text" json:"reason,omitempty"`
+	CreatedAt      time.Time            `gorm:"default:now()" json:"created_at"`
+
+	Rule *SavingsAutoRule `gorm:"foreignKey:RuleID" json:"rule,omitempty"`
+}
+
+func (SavingsAutoRun) TableName() string {
+	return "savings_auto_runs"
+}
@@ -30,6 +30,7 @@ type SavingsGoal struct {
 
 	User          *User                 `gorm:"foreignKey:UserID" json:"user,omitempty"`
 	Contributions []SavingsContribution `gorm:"foreignKey:SavingsGoalID" json:"contributions,omitempty"`
+	Withdrawals   []SavingsWithdrawal   `gorm:"foreignKey:SavingsGoalID" json:"withdrawals,omitempty"`
 }
 
 func (SavingsGoal) TableName() string {
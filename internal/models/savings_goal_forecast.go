@@ -0,0 +1,154 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+type SavingsGoalPace string
+
+const (
+	SavingsGoalPaceAhead   SavingsGoalPace = "AHEAD"
+	SavingsGoalPaceOnTrack SavingsGoalPace = "ON_TRACK"
+	SavingsGoalPaceBehind  SavingsGoalPace = "BEHIND"
+)
+
+const (
+	// forecastWindowMonths is how many trailing months of contributions the forecast averages
+	forecastWindowMonths = 6
+	// forecastTrendMinMonths is the history needed before the trend is trusted
+	forecastTrendMinMonths = 3
+	// maxForecastMonths bounds the projection so a tiny pace can't loop forever
+	maxForecastMonths = 600
+)
+
+// SavingsGoalForecast projects when a goal will be reached from its own
+// contribution history
+type SavingsGoalForecast struct {
+	// AverageMonthly is the mean contribution per month over the trailing window
+	AverageMonthly int64
+	// Trend is the change in monthly contributions per month (least squares)
+	Trend int64
+	// ProjectedCompletionDate is nil when the current pace never reaches the target
+	ProjectedCompletionDate *time.Time
+	Pace                    SavingsGoalPace
+	// RequiredMonthly is what must be saved each month from now to finish by TargetDate
+	RequiredMonthly int64
+}
+
+// Forecast projects the goal's completion as of now. The history is split into
+// one-month buckets counting back from now (at most forecastWindowMonths, and
+// no more than the goal's age). Future months continue the average along the
+// trend, clamped between half and double the average so a noisy trend can't
+// stall or explode the projection.
+func (s *SavingsGoal) Forecast(now time.Time) SavingsGoalForecast {
+	forecast := SavingsGoalForecast{RequiredMonthly: s.MonthlyTarget()}
+
+	if s.RemainingAmount() == 0 {
+		completed := s.lastContributionDate()
+		if completed == nil {
+			completed = &now
+		}
+		forecast.ProjectedCompletionDate = completed
+		forecast.Pace = SavingsGoalPaceOnTrack
+		if completed.Before(s.TargetDate.AddDate(0, -1, 0)) {
+			forecast.Pace = SavingsGoalPaceAhead
+		}
+		return forecast
+	}
+
+	buckets := s.contributionBuckets(now)
+	var total int64
+	for _, amount := range buckets {
+		total += amount
+	}
+	average := float64(total) / float64(len(buckets))
+	var slope float64
+	if len(buckets) >= forecastTrendMinMonths {
+		slope = trendSlope(buckets)
+	}
+	forecast.AverageMonthly = int64(math.Round(average))
+	forecast.Trend = int64(math.Round(slope))
+
+	if average > 0 {
+		remaining := float64(s.RemainingAmount())
+		for month := 1; month <= maxForecastMonths; month++ {
+			rate := math.Min(math.Max(average+slope*float64(month), average/2), average*2)
+			if rate >= remaining {
+				days := int(math.Ceil(remaining / rate * 30))
+				projected := now.AddDate(0, month-1, days)
+				projected = time.Date(projected.Year(), projected.Month(), projected.Day(), 0, 0, 0, 0, time.UTC)
+				forecast.ProjectedCompletionDate = &projected
+				break
+			}
+			remaining -= rate
+		}
+	}
+
+	switch {
+	case forecast.ProjectedCompletionDate == nil || forecast.ProjectedCompletionDate.After(s.TargetDate):
+		forecast.Pace = SavingsGoalPaceBehind
+	case forecast.ProjectedCompletionDate.Before(s.TargetDate.AddDate(0, -1, 0)):
+		forecast.Pace = SavingsGoalPaceAhead
+	default:
+		forecast.Pace = SavingsGoalPaceOnTrack
+	}
+
+	return forecast
+}
+
+// contributionBuckets sums net contributions (less withdrawals) into one-month
+// buckets ending at now, oldest first
+func (s *SavingsGoal) contributionBuckets(now time.Time) []int64 {
+	count := monthsBetween(s.CreatedAt, now)
+	count = max(min(count, forecastWindowMonths), 1)
+
+	buckets := make([]int64, count)
+	add := func(date time.Time, amount int64) {
+		for i := range buckets {
+			end := now.AddDate(0, -(count - 1 - i), 0)
+			start := end.AddDate(0, -1, 0)
+			if date.After(start) && !date.After(end) {
+				buckets[i] += amount
+				return
+			}
+		}
+	}
+	for _, contribution := range s.Contributions {
+		add(contribution.ContributionDate, contribution.Amount)
+	}
+	for _, withdrawal := range s.Withdrawals {
+		add(withdrawal.WithdrawalDate, -withdrawal.Amount)
+	}
+	return buckets
+}
+
+func (s *SavingsGoal) lastContributionDate() *time.Time {
+	var last *time.Time
+	for i := range s.Contributions {
+		date := s.Contributions[i].ContributionDate
+		if last == nil || date.After(*last) {
+			last = &date
+		}
+	}
+	return last
+}
+
+// trendSlope is the least squares slope of values against their index
+func trendSlope(values []int64) float64 {
+	n := float64(len(values))
+	var sumX, sumY, sumXY, sumXX float64
+	for i, v := range values {
+		x := float64(i)
+		y := float64(v)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denominator
+}
@@ -0,0 +1,163 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestSavingsGoalForecast(t *testing.T) {
+	now := date(2026, time.June, 15)
+	monthly := func(amounts ...int64) []SavingsContribution {
+		var contributions []SavingsContribution
+		for n, amount := range amounts {
+			contributions = append(contributions, SavingsContribution{ID: uuid.New(), Amount: amount, ContributionDate: date(2026, time.Month(7-len(amounts)+n), 1)})
+		}
+		return contributions
+	}
+
+	tests := []struct {
+		name          string
+		createdAt     time.Time
+		target        int64
+		current       int64
+		targetDate    time.Time
+		contributions []SavingsContribution
+		withdrawals   []SavingsWithdrawal
+		wantAverage   int64
+		wantTrend     int64
+		wantDate      string
+		wantPace      SavingsGoalPace
+	}{
+		{
+			name:          "steady pace finishing near the target date",
+			createdAt:     date(2026, time.January, 1),
+			target:        1200000,
+			current:       600000,
+			targetDate:    date(2026, time.December, 31),
+			contributions: monthly(100000, 100000, 100000, 100000, 100000, 100000),
+			wantAverage:   100000,
+			wantDate:      "2026-12-15",
+			wantPace:      SavingsGoalPaceOnTrack,
+		},
+		{
+			name:          "steady pace well before the target date",
+			createdAt:     date(2026, time.January, 1),
+			target:        1200000,
+			current:       600000,
+			targetDate:    date(2027, time.June, 30),
+			contributions: monthly(100000, 100000, 100000, 100000, 100000, 100000),
+			wantAverage:   100000,
+			wantDate:      "2026-12-15",
+			wantPace:      SavingsGoalPaceAhead,
+		},
+		{
+			name:          "steady pace past the target date",
+			createdAt:     date(2026, time.January, 1),
+			target:        1200000,
+			current:       600000,
+			targetDate:    date(2026, time.September, 30),
+			contributions: monthly(100000, 100000, 100000, 100000, 100000, 100000),
+			wantAverage:   100000,
+			wantDate:      "2026-12-15",
+			wantPace:      SavingsGoalPaceBehind,
+		},
+		{
+			name:          "rising contributions follow the trend",
+			createdAt:     date(2026, time.January, 1),
+			target:        1200000,
+			current:       675000,
+			targetDate:    date(2026, time.December, 31),
+			contributions: monthly(50000, 75000, 100000, 125000, 150000, 175000),
+			wantAverage:   112500,
+			wantTrend:     25000,
+			wantDate:      "2026-09-21",
+			wantPace:      SavingsGoalPaceAhead,
+		},
+		{
+			name:          "trend is ignored for a short history",
+			createdAt:     date(2026, time.May, 1),
+			target:        400000,
+			current:       200000,
+			targetDate:    date(2026, time.December, 31),
+			contributions: monthly(50000, 150000),
+			wantAverage:   100000,
+			wantDate:      "2026-08-14",
+			wantPace:      SavingsGoalPaceAhead,
+		},
+		{
+			name:          "a falling trend is clamped to half the average",
+			createdAt:     date(2026, time.January, 1),
+			target:        1200000,
+			current:       300000,
+			targetDate:    date(2026, time.December, 31),
+			contributions: monthly(100000, 100000, 100000, 100000, 100000, 100000),
+			withdrawals:   []SavingsWithdrawal{{ID: uuid.New(), Amount: 300000, WithdrawalDate: date(2026, time.June, 10)}},
+			wantAverage:   50000,
+			wantTrend:     -42857,
+			wantDate:      "2029-06-14",
+			wantPace:      SavingsGoalPaceBehind,
+		},
+		{
+			name:       "no contributions never finishes",
+			createdAt:  date(2026, time.January, 1),
+			target:     1200000,
+			targetDate: date(2026, time.December, 31),
+			wantPace:   SavingsGoalPaceBehind,
+		},
+		{
+			name:          "reached on the last contribution",
+			createdAt:     date(2026, time.January, 1),
+			target:        600000,
+			current:       600000,
+			targetDate:    date(2026, time.December, 31),
+			contributions: monthly(100000, 100000, 100000, 100000, 100000, 100000),
+			wantDate:      "2026-06-01",
+			wantPace:      SavingsGoalPaceAhead,
+		},
+		{
+			name:       "reached without contributions",
+			createdAt:  date(2026, time.January, 1),
+			target:     600000,
+			current:    600000,
+			targetDate: date(2026, time.July, 1),
+			wantDate:   "2026-06-15",
+			wantPace:   SavingsGoalPaceOnTrack,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			goal := SavingsGoal{
+				ID:            uuid.New(),
+				Name:          "Liburan",
+				TargetAmount:  tt.target,
+				CurrentAmount: tt.current,
+				TargetDate:    tt.targetDate,
+				Status:        SavingsGoalStatusActive,
+				CreatedAt:     tt.createdAt,
+				Contributions: tt.contributions,
+				Withdrawals:   tt.withdrawals,
+			}
+
+			forecast := goal.Forecast(now)
+			if forecast.AverageMonthly != tt.wantAverage {
+				t.Errorf("average monthly = %d, want %d", forecast.AverageMonthly, tt.wantAverage)
+			}
+			if forecast.Trend != tt.wantTrend {
+				t.Errorf("trend = %d, want %d", forecast.Trend, tt.wantTrend)
+			}
+			got := ""
+			if forecast.ProjectedCompletionDate != nil {
+				got = forecast.ProjectedCompletionDate.Format("2006-01-02")
+			}
+			if got != tt.wantDate {
+				t.Errorf("projected completion = %q, want %q", got, tt.wantDate)
+			}
+			if forecast.Pace != tt.wantPace {
+				t.Errorf("pace = %s, want %s", forecast.Pace, tt.wantPace)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SavingsWithdrawal records money taken out of a savings goal into a pocket.
+// Unlike withdrawing a contribution, it keeps the goal's history intact.
+type SavingsWithdrawal struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	SavingsGoalID  uuid.UUID  `gorm:"type:uuid;not null;index" json:"savings_goal_id"`
+	Amount         int64      `gorm:"not null" json:"amount"`
+	WithdrawalDate time.Time  `gorm:"type:date;not null" json:"withdrawal_date"`
+	PocketID       *uuid.UUID `gorm:"type:uuid" json:"pocket_id,omitempty"`
+	Reason         *string    `gorm:"type:text" json:"reason,omitempty"`
+	CreatedAt      time.Time  `gorm:"default:now()" json:"created_at"`
+
+	SavingsGoal *SavingsGoal `gorm:"foreignKey:SavingsGoalID" json:"savings_goal,omitempty"`
+	Pocket      *Account     `gorm:"foreignKey:PocketID" json:"pocket,omitempty"`
+}
+
+func (SavingsWithdrawal) TableName() string {
+	return "savings_withdrawals"
+}
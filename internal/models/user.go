@@ -16,6 +16,8 @@ type User struct {
 	NotifyInstallment bool       `gorm:"default:true" json:"notify_installment"`
 	NotifyDebt        bool       `gorm:"default:true" json:"notify_debt"`
 	NotifySavingsGoal bool       `gorm:"default:true" json:"notify_savings_goal"`
+	NotifyBudget      bool       `gorm:"default:true" json:"notify_budget"`
+	NotifyReceivable  bool       `gorm:"default:true" json:"notify_receivable"`
 	NotifyDaysBefore  int        `gorm:"default:3" json:"notify_days_before"`
 	CreatedAt         time.Time  `gorm:"default:now()" json:"created_at"`
 	UpdatedAt         *time.Time `json:"updated_at,omitempty"`
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+)
+
+type budgetRepository struct {
+	db *gorm.DB
+}
+
+func NewBudgetRepository(db *gorm.DB) BudgetRepository {
+	return &budgetRepository{db: db}
+}
+
+func (r *budgetRepository) Create(budget *models.Budget) error {
+	return r.db.Create(budget).Error
+}
+
+func (r *budgetRepository) GetByID(id uuid.UUID) (*models.Budget, error) {
+	var budget models.Budget
+	err := r.db.Preload("Category").Preload("Overrides").First(&budget, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &budget, nil
+}
+
+func (r *budgetRepository) GetByUserID(userID uuid.UUID) ([]models.Budget, error) {
+	var budgets []models.Budget
+	err := r.db.Preload("Category").Preload("Overrides").
+		Where("user_id = ?", userID).
+		Order("category_id IS NOT NULL, created_at ASC").
+		Find(&budgets).Error
+	return budgets, err
+}
+
+func (r *budgetRepository) GetByUserIDAndCategoryID(userID uuid.UUID, categoryID *uuid.UUID) (*models.Budget, error) {
+	var budget models.Budget
+	query := r.db.Where("user_id = ?", userID)
+	if categoryID != nil {
+		query = query.Where("category_id = ?", *categoryID)
+	} else {
+		query = query.Where("category_id IS NULL")
+	}
+	if err := query.First(&budget).Error; err != nil {
+		return nil, err
+	}
+	return &budget, nil
+}
+
+func (r *budgetRepository) Update(budget *models.Budget) error {
+	return r.db.Omit("Category", "Overrides").Save(budget).Error
+}
+
+func (r *budgetRepository) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.BudgetOverride{}, "budget_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Budget{}, "id = ?", id).Error
+	})
+}
+
+// SaveOverride creates the override of a month or replaces its amount
+func (r *budgetRepository) SaveOverride(override *models.BudgetOverride) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var existing models.BudgetOverride
+		err := tx.Where("budget_id = ? AND month = ? AND year = ?", override.BudgetID, override.Month, override.Year).
+			First(&existing).Error
+		if err == nil {
+			override.ID = existing.ID
+			override.CreatedAt = existing.CreatedAt
+			return tx.Model(&existing).Update("amount", override.Amount).Error
+		}
+		if err != gorm.ErrRecordNotFound {
+			return err
+		}
+		return tx.Create(override).Error
+	})
+}
+
+func (r *budgetRepository) DeleteOverride(budgetID uuid.UUID, month, year int) error {
+	return r.db.Delete(&models.BudgetOverride{}, "budget_id = ? AND month = ? AND year = ?", budgetID, month, year).Error
+}
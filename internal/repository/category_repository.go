@@ -39,7 +39,7 @@ func (r *categoryRepository) GetByUserIDWithStats(userID uuid.UUID) ([]models.Ca
 	err := r.db.Raw(`
 		SELECT c.*, 
 			   COUNT(e.id) as expense_count, 
-			   COALESCE(SUM(e.unit_price * e.quantity), 0) as total_spent
+			   COALESCE(SUM(e.unit_price * e.quantity - COALESCE((SELECT SUM(r.amount) FROM expense_refunds r WHERE r.expense_id = e.id), 0)), 0) as total_spent
 		FROM categories c
 		LEFT JOIN expenses e ON e.category_id = c.id
 		WHERE c.user_id = ?
@@ -53,6 +53,163 @@ func (r *categoryRepository) Update(category *models.Category) error {
 	return r.db.Save(category).Error
 }
 
+func (r *categoryRepository) ReparentChildren(parentID uuid.UUID, newParentID *uuid.UUID) error {
+	return r.db.Model(&models.Category{}).Where("parent_id = ?", parentID).Update("parent_id", newParentID).Error
+}
+
+func (r *categoryRepository) HasLateFeeRules(id uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.LateFeeRule{}).Where("category_id = ?", id).Count(&count).Error
+	return count > 0, err
+}
+
 func (r *categoryRepository) Delete(id uuid.UUID) error {
-	return r.db.Delete(&models.Category{}, "id = ?", id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Budgets of the category go with it
+		if err := tx.Exec("DELETE FROM budget_overrides WHERE budget_id IN (SELECT id FROM budgets WHERE category_id = ?)", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM budgets WHERE category_id = ?", id).Error; err != nil {
+			return err
+		}
+		// Money assigned to the envelope returns to "to be budgeted"
+		if err := tx.Exec("DELETE FROM envelope_assignments WHERE category_id = ?", id).Error; err != nil {
+			return err
+		}
+		// Payees fall back to having no default category
+		if err := tx.Exec("UPDATE payees SET default_category_id = NULL WHERE default_category_id = ?", id).Error; err != nil {
+			return err
+		}
+		// Restructure and consolidation fees already posted stay on the loan
+		if err := tx.Exec("UPDATE installment_terms SET category_id = NULL WHERE category_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("UPDATE debt_consolidations SET fee_category_id = NULL WHERE fee_category_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Category{}, "id = ?", id).Error
+	})
+}
+
+// Merge moves all history of the source categories into the target and deletes
+// the sources together with their linked expense accounts, in one transaction
+func (r *categoryRepository) Merge(sourceIDs []uuid.UUID, target *models.Category) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Reassign records that reference the source categories
+		if err := tx.Exec("UPDATE expenses SET category_id = ? WHERE category_id IN ?", target.ID, sourceIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("UPDATE expense_template_items SET category_id = ? WHERE category_id IN ?", target.ID, sourceIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("UPDATE payees SET default_category_id = ? WHERE default_category_id IN ?", target.ID, sourceIDs).Error; err != nil {
+			return err
+		}
+		if err := mergeBudgets(tx, sourceIDs, target.ID); err != nil {
+			return err
+		}
+		if err := tx.Exec("UPDATE envelope_assignments SET category_id = ? WHERE category_id IN ?", target.ID, sourceIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("UPDATE late_fee_rules SET category_id = ? WHERE category_id IN ?", target.ID, sourceIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("UPDATE installment_terms SET category_id = ? WHERE category_id IN ?", target.ID, sourceIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("UPDATE debt_consolidations SET fee_category_id = ? WHERE fee_category_id IN ?", target.ID, sourceIDs).Error; err != nil {
+			return err
+		}
+
+		// Children of the sources move under the target
+		if err := tx.Model(&models.Category{}).Where("id = ?", target.ID).Update("parent_id", target.ParentID).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("UPDATE categories SET parent_id = ? WHERE parent_id IN ? AND id <> ?", target.ID, sourceIDs, target.ID).Error; err != nil {
+			return err
+		}
+
+		if err := mergeLinkedAccounts(tx, sourceIDs, target.ID, "category", "debit - credit"); err != nil {
+			return err
+		}
+
+		return tx.Exec("DELETE FROM categories WHERE id IN ?", sourceIDs).Error
+	})
+}
+
+// mergeBudgets moves the source categories' budgets to the target. When the
+// target already has a budget, or several sources have one, the amounts are
+// added up into a single budget; overrides of merged budgets are dropped.
+func mergeBudgets(tx *gorm.DB, sourceIDs []uuid.UUID, targetID uuid.UUID) error {
+	var budgets []models.Budget
+	if err := tx.Where("category_id IN ? OR category_id = ?", sourceIDs, targetID).
+		Order("created_at ASC").
+		Find(&budgets).Error; err != nil {
+		return err
+	}
+	if len(budgets) == 0 {
+		return nil
+	}
+
+	keep := budgets[0]
+	for _, b := range budgets {
+		if b.CategoryID != nil && *b.CategoryID == targetID {
+			keep = b
+			break
+		}
+	}
+	if len(budgets) > 1 {
+		var total int64
+		var dropIDs []uuid.UUID
+		for _, b := range budgets {
+			total += b.Amount
+			if b.ID != keep.ID {
+				dropIDs = append(dropIDs, b.ID)
+			}
+		}
+		if err := tx.Exec("DELETE FROM budget_overrides WHERE budget_id IN ?", append(dropIDs, keep.ID)).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM budgets WHERE id IN ?", dropIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Budget{}).Where("id = ?", keep.ID).Update("amount", total).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Model(&models.Budget{}).Where("id = ?", keep.ID).Update("category_id", targetID).Error
+}
+
+// mergeLinkedAccounts re-points ledger entries from the source accounts to the
+// target account, recalculates the target balance and deletes the source accounts.
+// balanceExpr is the balance formula of the account type, e.g. "debit - credit".
+func mergeLinkedAccounts(tx *gorm.DB, sourceRefIDs []uuid.UUID, targetRefID uuid.UUID, referenceType, balanceExpr string) error {
+	var target models.Account
+	if err := tx.Where("reference_id = ? AND reference_type = ?", targetRefID, referenceType).First(&target).Error; err != nil {
+		return err
+	}
+
+	var sourceAccountIDs []uuid.UUID
+	if err := tx.Model(&models.Account{}).
+		Where("reference_id IN ? AND reference_type = ?", sourceRefIDs, referenceType).
+		Pluck("id", &sourceAccountIDs).Error; err != nil {
+		return err
+	}
+	if len(sourceAccountIDs) == 0 {
+		return nil
+	}
+
+	if err := tx.Exec("UPDATE transaction_entries SET account_id = ? WHERE account_id IN ?", target.ID, sourceAccountIDs).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Exec(`
+		UPDATE accounts SET current_balance = (
+			SELECT COALESCE(SUM(`+balanceExpr+`), 0) FROM transaction_entries WHERE account_id = ?
+		) WHERE id = ?
+	`, target.ID, target.ID).Error; err != nil {
+		return err
+	}
+
+	return tx.Exec("DELETE FROM accounts WHERE id IN ?", sourceAccountIDs).Error
 }
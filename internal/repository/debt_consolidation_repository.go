@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+)
+
+type debtConsolidationRepository struct {
+	db *gorm.DB
+}
+
+func NewDebtConsolidationRepository(db *gorm.DB) DebtConsolidationRepository {
+	return &debtConsolidationRepository{db: db}
+}
+
+func (r *debtConsolidationRepository) Create(consolidation *models.DebtConsolidation) error {
+	return r.db.Create(consolidation).Error
+}
+
+func (r *debtConsolidationRepository) GetByID(id uuid.UUID) (*models.DebtConsolidation, error) {
+	var consolidation models.DebtConsolidation
+	err := r.db.First(&consolidation, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &consolidation, nil
+}
+
+// GetByLoanID returns the consolidation funded by an installment or debt
+func (r *debtConsolidationRepository) GetByLoanID(loanID uuid.UUID) (*models.DebtConsolidation, error) {
+	var consolidation models.DebtConsolidation
+	err := r.db.Where("installment_id = ? OR debt_id = ?", loanID, loanID).First(&consolidation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &consolidation, nil
+}
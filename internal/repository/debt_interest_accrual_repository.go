@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+)
+
+type debtInterestAccrualRepository struct {
+	db *gorm.DB
+}
+
+func NewDebtInterestAccrualRepository(db *gorm.DB) DebtInterestAccrualRepository {
+	return &debtInterestAccrualRepository{db: db}
+}
+
+func (r *debtInterestAccrualRepository) Create(accrual *models.DebtInterestAccrual) error {
+	return r.db.Create(accrual).Error
+}
+
+func (r *debtInterestAccrualRepository) GetByDebtID(debtID uuid.UUID) ([]models.DebtInterestAccrual, error) {
+	var accruals []models.DebtInterestAccrual
+	err := r.db.Where("debt_id = ?", debtID).Order("period_start ASC").Find(&accruals).Error
+	return accruals, err
+}
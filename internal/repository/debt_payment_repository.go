@@ -51,3 +51,11 @@ func (r *debtPaymentRepository) GetLastPaymentNumber(debtID uuid.UUID) (int, err
 	}
 	return payment.PaymentNumber, nil
 }
+
+func (r *debtPaymentRepository) Update(payment *models.DebtPayment) error {
+	return r.db.Omit("Debt", "Pocket").Save(payment).Error
+}
+
+func (r *debtPaymentRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.DebtPayment{}, "id = ?", id).Error
+}
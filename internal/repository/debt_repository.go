@@ -21,7 +21,7 @@ func (r *debtRepository) Create(debt *models.Debt) error {
 
 func (r *debtRepository) GetByID(id uuid.UUID) (*models.Debt, error) {
 	var debt models.Debt
-	err := r.db.Preload("Payments").First(&debt, "id = ?", id).Error
+	err := r.db.Preload("Payments").Preload("InterestAccruals").First(&debt, "id = ?", id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -30,7 +30,7 @@ func (r *debtRepository) GetByID(id uuid.UUID) (*models.Debt, error) {
 
 func (r *debtRepository) GetByUserID(userID uuid.UUID, status *models.DebtStatus) ([]models.Debt, error) {
 	var debts []models.Debt
-	query := r.db.Preload("Payments").Where("user_id = ?", userID)
+	query := r.db.Preload("Payments").Preload("InterestAccruals").Where("user_id = ?", userID)
 
 	if status != nil {
 		query = query.Where("status = ?", *status)
@@ -42,16 +42,30 @@ func (r *debtRepository) GetByUserID(userID uuid.UUID, status *models.DebtStatus
 
 func (r *debtRepository) GetByDueDateRange(startDate, endDate string, status models.DebtStatus) ([]models.Debt, error) {
 	var debts []models.Debt
-	err := r.db.Preload("Payments").Preload("User").
+	err := r.db.Preload("Payments").Preload("InterestAccruals").Preload("User").
 		Where("due_date >= ? AND due_date <= ? AND status = ?", startDate, endDate, status).
 		Find(&debts).Error
 	return debts, err
 }
 
+// GetWithInterest returns the debts with an interest rate in a status
+func (r *debtRepository) GetWithInterest(status models.DebtStatus) ([]models.Debt, error) {
+	var debts []models.Debt
+	err := r.db.Preload("Payments").Preload("InterestAccruals").
+		Where("interest_rate > 0 AND status = ?", status).
+		Find(&debts).Error
+	return debts, err
+}
+
 func (r *debtRepository) Update(debt *models.Debt) error {
 	return r.db.Save(debt).Error
 }
 
 func (r *debtRepository) Delete(id uuid.UUID) error {
-	return r.db.Delete(&models.Debt{}, "id = ?", id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.DebtInterestAccrual{}, "debt_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Debt{}, "id = ?", id).Error
+	})
 }
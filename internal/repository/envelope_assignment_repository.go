@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+)
+
+type envelopeAssignmentRepository struct {
+	db *gorm.DB
+}
+
+func NewEnvelopeAssignmentRepository(db *gorm.DB) EnvelopeAssignmentRepository {
+	return &envelopeAssignmentRepository{db: db}
+}
+
+func (r *envelopeAssignmentRepository) Create(assignment *models.EnvelopeAssignment) error {
+	return r.db.Create(assignment).Error
+}
+
+func (r *envelopeAssignmentRepository) CreateBatch(assignments []models.EnvelopeAssignment) error {
+	return r.db.Create(&assignments).Error
+}
+
+func (r *envelopeAssignmentRepository) GetByUserIDAndMonth(userID uuid.UUID, month, year int) ([]models.EnvelopeAssignment, error) {
+	var assignments []models.EnvelopeAssignment
+	err := r.db.Preload("Category").
+		Where("user_id = ? AND year = ? AND month = ?", userID, year, month).
+		Order("created_at DESC").
+		Find(&assignments).Error
+	return assignments, err
+}
+
+// GetByUserIDUpTo returns all entries up to and including the month, oldest first
+func (r *envelopeAssignmentRepository) GetByUserIDUpTo(userID uuid.UUID, month, year int) ([]models.EnvelopeAssignment, error) {
+	var assignments []models.EnvelopeAssignment
+	err := r.db.Where("user_id = ? AND (year < ? OR (year = ? AND month <= ?))", userID, year, year, month).
+		Order("year ASC, month ASC, created_at ASC").
+		Find(&assignments).Error
+	return assignments, err
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+)
+
+type expenseRefundRepository struct {
+	db *gorm.DB
+}
+
+func NewExpenseRefundRepository(db *gorm.DB) ExpenseRefundRepository {
+	return &expenseRefundRepository{db: db}
+}
+
+func (r *expenseRefundRepository) Create(refund *models.ExpenseRefund) error {
+	return r.db.Create(refund).Error
+}
+
+func (r *expenseRefundRepository) GetByID(id uuid.UUID) (*models.ExpenseRefund, error) {
+	var refund models.ExpenseRefund
+	err := r.db.Preload("Expense").First(&refund, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &refund, nil
+}
+
+func (r *expenseRefundRepository) GetByExpenseID(expenseID uuid.UUID) ([]models.ExpenseRefund, error) {
+	var refunds []models.ExpenseRefund
+	err := r.db.Where("expense_id = ?", expenseID).Order("refund_date ASC").Find(&refunds).Error
+	return refunds, err
+}
+
+func (r *expenseRefundRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.ExpenseRefund{}, "id = ?", id).Error
+}
+
+func (r *expenseRefundRepository) DeleteByExpenseID(expenseID uuid.UUID) error {
+	return r.db.Delete(&models.ExpenseRefund{}, "expense_id = ?", expenseID).Error
+}
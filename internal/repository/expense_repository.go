@@ -21,7 +21,7 @@ func (r *expenseRepository) Create(expense *models.Expense) error {
 
 func (r *expenseRepository) GetByID(id uuid.UUID) (*models.Expense, error) {
 	var expense models.Expense
-	err := r.db.Preload("Category").First(&expense, "id = ?", id).Error
+	err := r.db.Preload("Category").Preload("Refunds").First(&expense, "id = ?", id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -30,12 +30,15 @@ func (r *expenseRepository) GetByID(id uuid.UUID) (*models.Expense, error) {
 
 func (r *expenseRepository) GetByUserID(userID uuid.UUID, filter *ExpenseFilter) ([]models.Expense, error) {
 	var expenses []models.Expense
-	query := r.db.Preload("Category").Where("user_id = ?", userID)
+	query := r.db.Preload("Category").Preload("Refunds").Where("user_id = ?", userID)
 
 	if filter != nil {
 		if filter.CategoryID != nil {
 			query = query.Where("category_id = ?", *filter.CategoryID)
 		}
+		if filter.PayeeID != nil {
+			query = query.Where("payee_id = ?", *filter.PayeeID)
+		}
 		if filter.StartDate != nil {
 			query = query.Where("expense_date >= ?", *filter.StartDate)
 		}
@@ -50,7 +53,7 @@ func (r *expenseRepository) GetByUserID(userID uuid.UUID, filter *ExpenseFilter)
 
 func (r *expenseRepository) GetByUserIDAndDateRange(userID uuid.UUID, startDate, endDate string) ([]models.Expense, error) {
 	var expenses []models.Expense
-	err := r.db.Preload("Category").
+	err := r.db.Preload("Category").Preload("Refunds").
 		Where("user_id = ? AND expense_date >= ? AND expense_date <= ?", userID, startDate, endDate).
 		Find(&expenses).Error
 	return expenses, err
@@ -58,7 +61,7 @@ func (r *expenseRepository) GetByUserIDAndDateRange(userID uuid.UUID, startDate,
 
 func (r *expenseRepository) GetRecentByUserID(userID uuid.UUID, limit int) ([]models.Expense, error) {
 	var expenses []models.Expense
-	err := r.db.Preload("Category").
+	err := r.db.Preload("Category").Preload("Refunds").
 		Where("user_id = ?", userID).
 		Order("created_at DESC").
 		Limit(limit).
@@ -66,6 +69,16 @@ func (r *expenseRepository) GetRecentByUserID(userID uuid.UUID, limit int) ([]mo
 	return expenses, err
 }
 
+func (r *expenseRepository) GetRecentByPayeeID(payeeID uuid.UUID, limit int) ([]models.Expense, error) {
+	var expenses []models.Expense
+	err := r.db.Preload("Category").Preload("Refunds").
+		Where("payee_id = ?", payeeID).
+		Order("expense_date DESC, created_at DESC").
+		Limit(limit).
+		Find(&expenses).Error
+	return expenses, err
+}
+
 func (r *expenseRepository) Update(expense *models.Expense) error {
 	return r.db.Save(expense).Error
 }
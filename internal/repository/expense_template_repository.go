@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 
@@ -58,6 +60,27 @@ func (r *expenseTemplateGroupRepository) DeleteItem(itemID uuid.UUID) error {
 	return r.db.Delete(&models.ExpenseTemplateItem{}, "id = ?", itemID).Error
 }
 
+// GetAutoPostGroups returns all groups with auto-post enabled and a schedule set
+func (r *expenseTemplateGroupRepository) GetAutoPostGroups() ([]models.ExpenseTemplateGroup, error) {
+	var groups []models.ExpenseTemplateGroup
+	err := r.db.Preload("Items").Preload("Items.Category").
+		Where("auto_post = ? AND (recurring_day IS NOT NULL OR recurrence_frequency IS NOT NULL)", true).
+		Find(&groups).Error
+	return groups, err
+}
+
+// MarkPosted records the occurrence date as posted. It only moves forward and
+// returns false when that occurrence (or a later one) was already posted.
+func (r *expenseTemplateGroupRepository) MarkPosted(id uuid.UUID, date time.Time) (bool, error) {
+	result := r.db.Model(&models.ExpenseTemplateGroup{}).
+		Where("id = ? AND (last_posted_date IS NULL OR last_posted_date < ?)", id, date).
+		Update("last_posted_date", date)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
 func (r *expenseTemplateGroupRepository) GetItemByID(itemID uuid.UUID) (*models.ExpenseTemplateItem, error) {
 	var item models.ExpenseTemplateItem
 	err := r.db.Preload("Category").First(&item, "id = ?", itemID).Error
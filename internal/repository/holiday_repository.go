@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+)
+
+type holidayRepository struct {
+	db *gorm.DB
+}
+
+func NewHolidayRepository(db *gorm.DB) HolidayRepository {
+	return &holidayRepository{db: db}
+}
+
+func (r *holidayRepository) Create(holiday *models.Holiday) error {
+	return r.db.Create(holiday).Error
+}
+
+func (r *holidayRepository) GetByID(id uuid.UUID) (*models.Holiday, error) {
+	var holiday models.Holiday
+	err := r.db.First(&holiday, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &holiday, nil
+}
+
+func (r *holidayRepository) GetByUserIDAndDateRange(userID uuid.UUID, startDate, endDate string) ([]models.Holiday, error) {
+	var holidays []models.Holiday
+	err := r.db.Where("user_id = ? AND date >= ? AND date <= ?", userID, startDate, endDate).
+		Order("date ASC").
+		Find(&holidays).Error
+	return holidays, err
+}
+
+func (r *holidayRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.Holiday{}, "id = ?", id).Error
+}
@@ -38,6 +38,38 @@ func (r *incomeCategoryRepository) Update(category *models.IncomeCategory) error
 	return r.db.Save(category).Error
 }
 
+func (r *incomeCategoryRepository) ReparentChildren(parentID uuid.UUID, newParentID *uuid.UUID) error {
+	return r.db.Model(&models.IncomeCategory{}).Where("parent_id = ?", parentID).Update("parent_id", newParentID).Error
+}
+
 func (r *incomeCategoryRepository) Delete(id uuid.UUID) error {
 	return r.db.Delete(&models.IncomeCategory{}, "id = ?", id).Error
 }
+
+// Merge moves all history of the source income categories into the target and
+// deletes the sources together with their linked income accounts, in one transaction
+func (r *incomeCategoryRepository) Merge(sourceIDs []uuid.UUID, target *models.IncomeCategory) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Reassign records that reference the source categories
+		if err := tx.Exec("UPDATE incomes SET category_id = ? WHERE category_id IN ?", target.ID, sourceIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("UPDATE recurring_income_items SET category_id = ? WHERE category_id IN ?", target.ID, sourceIDs).Error; err != nil {
+			return err
+		}
+
+		// Children of the sources move under the target
+		if err := tx.Model(&models.IncomeCategory{}).Where("id = ?", target.ID).Update("parent_id", target.ParentID).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("UPDATE income_categories SET parent_id = ? WHERE parent_id IN ? AND id <> ?", target.ID, sourceIDs, target.ID).Error; err != nil {
+			return err
+		}
+
+		if err := mergeLinkedAccounts(tx, sourceIDs, target.ID, "income_category", "credit - debit"); err != nil {
+			return err
+		}
+
+		return tx.Exec("DELETE FROM income_categories WHERE id IN ?", sourceIDs).Error
+	})
+}
@@ -51,3 +51,11 @@ func (r *installmentPaymentRepository) GetLastPaymentNumber(installmentID uuid.U
 	}
 	return payment.PaymentNumber, nil
 }
+
+func (r *installmentPaymentRepository) Update(payment *models.InstallmentPayment) error {
+	return r.db.Omit("Installment", "Pocket").Save(payment).Error
+}
+
+func (r *installmentPaymentRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.InstallmentPayment{}, "id = ?", id).Error
+}
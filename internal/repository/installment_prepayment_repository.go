@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+)
+
+type installmentPrepaymentRepository struct {
+	db *gorm.DB
+}
+
+func NewInstallmentPrepaymentRepository(db *gorm.DB) InstallmentPrepaymentRepository {
+	return &installmentPrepaymentRepository{db: db}
+}
+
+func (r *installmentPrepaymentRepository) Create(prepayment *models.InstallmentPrepayment) error {
+	return r.db.Create(prepayment).Error
+}
+
+func (r *installmentPrepaymentRepository) GetByInstallmentID(installmentID uuid.UUID) ([]models.InstallmentPrepayment, error) {
+	var prepayments []models.InstallmentPrepayment
+	err := r.db.Where("installment_id = ?", installmentID).Order("after_payment_number ASC, paid_at ASC").Find(&prepayments).Error
+	return prepayments, err
+}
@@ -21,7 +21,7 @@ func (r *installmentRepository) Create(installment *models.Installment) error {
 
 func (r *installmentRepository) GetByID(id uuid.UUID) (*models.Installment, error) {
 	var installment models.Installment
-	err := r.db.Preload("Payments").First(&installment, "id = ?", id).Error
+	err := r.db.Preload("Payments").Preload("Prepayments").Preload("Terms").First(&installment, "id = ?", id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -30,7 +30,7 @@ func (r *installmentRepository) GetByID(id uuid.UUID) (*models.Installment, erro
 
 func (r *installmentRepository) GetByUserID(userID uuid.UUID, status *models.InstallmentStatus) ([]models.Installment, error) {
 	var installments []models.Installment
-	query := r.db.Preload("Payments").Where("user_id = ?", userID)
+	query := r.db.Preload("Payments").Preload("Prepayments").Preload("Terms").Where("user_id = ?", userID)
 
 	if status != nil {
 		query = query.Where("status = ?", *status)
@@ -42,7 +42,7 @@ func (r *installmentRepository) GetByUserID(userID uuid.UUID, status *models.Ins
 
 func (r *installmentRepository) GetByDueDay(dueDay int, status models.InstallmentStatus) ([]models.Installment, error) {
 	var installments []models.Installment
-	err := r.db.Preload("Payments").Preload("User").
+	err := r.db.Preload("Payments").Preload("Prepayments").Preload("Terms").Preload("User").
 		Where("due_day = ? AND status = ?", dueDay, status).
 		Find(&installments).Error
 	return installments, err
@@ -53,5 +53,13 @@ func (r *installmentRepository) Update(installment *models.Installment) error {
 }
 
 func (r *installmentRepository) Delete(id uuid.UUID) error {
-	return r.db.Delete(&models.Installment{}, "id = ?", id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.InstallmentPrepayment{}, "installment_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.InstallmentTerm{}, "installment_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Installment{}, "id = ?", id).Error
+	})
 }
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+)
+
+type installmentTermRepository struct {
+	db *gorm.DB
+}
+
+func NewInstallmentTermRepository(db *gorm.DB) InstallmentTermRepository {
+	return &installmentTermRepository{db: db}
+}
+
+func (r *installmentTermRepository) Create(term *models.InstallmentTerm) error {
+	return r.db.Create(term).Error
+}
+
+func (r *installmentTermRepository) GetByInstallmentID(installmentID uuid.UUID) ([]models.InstallmentTerm, error) {
+	var terms []models.InstallmentTerm
+	err := r.db.Where("installment_id = ?", installmentID).Order("version ASC").Find(&terms).Error
+	return terms, err
+}
+
+func (r *installmentTermRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.InstallmentTerm{}, "id = ?", id).Error
+}
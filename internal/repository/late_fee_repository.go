@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+)
+
+type lateFeeRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewLateFeeRuleRepository(db *gorm.DB) LateFeeRuleRepository {
+	return &lateFeeRuleRepository{db: db}
+}
+
+func (r *lateFeeRuleRepository) Create(rule *models.LateFeeRule) error {
+	return r.db.Create(rule).Error
+}
+
+func (r *lateFeeRuleRepository) GetByID(id uuid.UUID) (*models.LateFeeRule, error) {
+	var rule models.LateFeeRule
+	err := r.db.Preload("Category").First(&rule, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *lateFeeRuleRepository) GetByUserID(userID uuid.UUID) ([]models.LateFeeRule, error) {
+	var rules []models.LateFeeRule
+	err := r.db.Preload("Category").Where("user_id = ?", userID).Order("created_at ASC").Find(&rules).Error
+	return rules, err
+}
+
+func (r *lateFeeRuleRepository) GetActive() ([]models.LateFeeRule, error) {
+	var rules []models.LateFeeRule
+	err := r.db.Where("is_active = ?", true).Order("user_id, created_at ASC").Find(&rules).Error
+	return rules, err
+}
+
+func (r *lateFeeRuleRepository) Update(rule *models.LateFeeRule) error {
+	return r.db.Omit("Category").Save(rule).Error
+}
+
+func (r *lateFeeRuleRepository) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.LateFeeCharge{}, "rule_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.LateFeeRule{}, "id = ?", id).Error
+	})
+}
+
+type lateFeeChargeRepository struct {
+	db *gorm.DB
+}
+
+func NewLateFeeChargeRepository(db *gorm.DB) LateFeeChargeRepository {
+	return &lateFeeChargeRepository{db: db}
+}
+
+func (r *lateFeeChargeRepository) Create(charge *models.LateFeeCharge) error {
+	return r.db.Create(charge).Error
+}
+
+func (r *lateFeeChargeRepository) GetByUserID(userID uuid.UUID, loanID *uuid.UUID) ([]models.LateFeeCharge, error) {
+	var charges []models.LateFeeCharge
+	query := r.db.Where("user_id = ?", userID)
+	if loanID != nil {
+		query = query.Where("loan_id = ?", *loanID)
+	}
+	err := query.Order("due_date DESC, created_at DESC").Find(&charges).Error
+	return charges, err
+}
+
+func (r *lateFeeChargeRepository) Exists(ruleID, loanID uuid.UUID, paymentNumber int) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.LateFeeCharge{}).
+		Where("rule_id = ? AND loan_id = ? AND payment_number = ?", ruleID, loanID, paymentNumber).
+		Count(&count).Error
+	return count > 0, err
+}
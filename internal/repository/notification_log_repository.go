@@ -35,3 +35,13 @@ func (r *notificationLogRepository) ExistsForToday(userID, referenceID uuid.UUID
 		Count(&count).Error
 	return count > 0, err
 }
+
+// ExistsForPeriod checks for a notification already sent for a period key, for
+// notifications that go out once per period rather than once per day
+func (r *notificationLogRepository) ExistsForPeriod(userID, referenceID uuid.UUID, notificationType models.NotificationType, periodKey string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.NotificationLog{}).
+		Where("user_id = ? AND reference_id = ? AND type = ? AND period_key = ?", userID, referenceID, notificationType, periodKey).
+		Count(&count).Error
+	return count > 0, err
+}
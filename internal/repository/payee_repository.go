@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+)
+
+type payeeRepository struct {
+	db *gorm.DB
+}
+
+func NewPayeeRepository(db *gorm.DB) PayeeRepository {
+	return &payeeRepository{db: db}
+}
+
+func (r *payeeRepository) Create(payee *models.Payee) error {
+	return r.db.Create(payee).Error
+}
+
+func (r *payeeRepository) GetByID(id uuid.UUID) (*models.Payee, error) {
+	var payee models.Payee
+	err := r.db.Preload("Aliases").Preload("DefaultCategory").First(&payee, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &payee, nil
+}
+
+func (r *payeeRepository) GetByUserID(userID uuid.UUID) ([]models.Payee, error) {
+	var payees []models.Payee
+	err := r.db.Preload("Aliases").Preload("DefaultCategory").
+		Where("user_id = ?", userID).
+		Order("name ASC").
+		Find(&payees).Error
+	return payees, err
+}
+
+func (r *payeeRepository) GetByUserIDWithStats(userID uuid.UUID) ([]models.Payee, error) {
+	payees, err := r.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats []struct {
+		PayeeID       uuid.UUID
+		ExpenseCount  int
+		LifetimeSpend int64
+	}
+	err = r.db.Raw(`
+		SELECT e.payee_id,
+			   COUNT(e.id) as expense_count,
+			   COALESCE(SUM(e.unit_price * e.quantity - COALESCE((SELECT SUM(r.amount) FROM expense_refunds r WHERE r.expense_id = e.id), 0)), 0) as lifetime_spend
+		FROM expenses e
+		WHERE e.user_id = ? AND e.payee_id IS NOT NULL
+		GROUP BY e.payee_id
+	`, userID).Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+
+	byPayee := make(map[uuid.UUID]int)
+	for i, p := range payees {
+		byPayee[p.ID] = i
+	}
+	for _, stat := range stats {
+		if i, ok := byPayee[stat.PayeeID]; ok {
+			payees[i].ExpenseCount = stat.ExpenseCount
+			payees[i].LifetimeSpend = stat.LifetimeSpend
+		}
+	}
+
+	return payees, nil
+}
+
+// FindByName matches a payee by its name or one of its aliases, case-insensitively
+func (r *payeeRepository) FindByName(userID uuid.UUID, name string) (*models.Payee, error) {
+	normalized := strings.ToLower(strings.TrimSpace(name))
+
+	var payee models.Payee
+	err := r.db.
+		Where("user_id = ?", userID).
+		Where("LOWER(name) = ? OR id IN (SELECT payee_id FROM payee_aliases WHERE LOWER(alias) = ?)", normalized, normalized).
+		First(&payee).Error
+	if err != nil {
+		return nil, err
+	}
+	return &payee, nil
+}
+
+func (r *payeeRepository) GetSpendingStats(payeeID uuid.UUID) (count int, total int64, err error) {
+	var result struct {
+		ExpenseCount  int
+		LifetimeSpend int64
+	}
+	err = r.db.Raw(`
+		SELECT COUNT(e.id) as expense_count,
+			   COALESCE(SUM(e.unit_price * e.quantity - COALESCE((SELECT SUM(r.amount) FROM expense_refunds r WHERE r.expense_id = e.id), 0)), 0) as lifetime_spend
+		FROM expenses e
+		WHERE e.payee_id = ?
+	`, payeeID).Scan(&result).Error
+	return result.ExpenseCount, result.LifetimeSpend, err
+}
+
+func (r *payeeRepository) GetMonthlySpending(payeeID uuid.UUID, startDate string) ([]PayeeMonthlySpending, error) {
+	var months []PayeeMonthlySpending
+	err := r.db.Raw(`
+		SELECT TO_CHAR(e.expense_date, 'YYYY-MM') as month,
+			   COALESCE(SUM(e.unit_price * e.quantity - COALESCE((SELECT SUM(r.amount) FROM expense_refunds r WHERE r.expense_id = e.id), 0)), 0) as total,
+			   COUNT(e.id) as count
+		FROM expenses e
+		WHERE e.payee_id = ? AND e.expense_date >= ?
+		GROUP BY TO_CHAR(e.expense_date, 'YYYY-MM')
+		ORDER BY month ASC
+	`, payeeID, startDate).Scan(&months).Error
+	return months, err
+}
+
+func (r *payeeRepository) Update(payee *models.Payee) error {
+	return r.db.Omit("Aliases", "DefaultCategory", "DefaultPocket").Save(payee).Error
+}
+
+func (r *payeeRepository) ReplaceAliases(payeeID uuid.UUID, aliases []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.PayeeAlias{}, "payee_id = ?", payeeID).Error; err != nil {
+			return err
+		}
+		for _, alias := range aliases {
+			if err := tx.Create(&models.PayeeAlias{ID: uuid.New(), PayeeID: payeeID, Alias: alias}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *payeeRepository) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Unlink transactions instead of deleting them
+		for _, table := range []string{"expenses", "incomes", "debts"} {
+			if err := tx.Exec("UPDATE "+table+" SET payee_id = NULL WHERE payee_id = ?", id).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Delete(&models.PayeeAlias{}, "payee_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Payee{}, "id = ?", id).Error
+	})
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+)
+
+type payoffPlanRepository struct {
+	db *gorm.DB
+}
+
+func NewPayoffPlanRepository(db *gorm.DB) PayoffPlanRepository {
+	return &payoffPlanRepository{db: db}
+}
+
+// Create saves the plan together with its months
+func (r *payoffPlanRepository) Create(plan *models.PayoffPlan) error {
+	return r.db.Create(plan).Error
+}
+
+func (r *payoffPlanRepository) GetByID(id uuid.UUID) (*models.PayoffPlan, error) {
+	var plan models.PayoffPlan
+	err := r.db.Preload("Months", func(db *gorm.DB) *gorm.DB {
+		return db.Order("year ASC, month ASC")
+	}).First(&plan, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+func (r *payoffPlanRepository) GetByUserID(userID uuid.UUID) ([]models.PayoffPlan, error) {
+	var plans []models.PayoffPlan
+	err := r.db.Preload("Months", func(db *gorm.DB) *gorm.DB {
+		return db.Order("year ASC, month ASC")
+	}).Where("user_id = ?", userID).Order("created_at DESC").Find(&plans).Error
+	return plans, err
+}
+
+func (r *payoffPlanRepository) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.PayoffPlanMonth{}, "plan_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.PayoffPlan{}, "id = ?", id).Error
+	})
+}
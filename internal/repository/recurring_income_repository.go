@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 
@@ -45,10 +47,13 @@ func (r *recurringIncomeGroupRepository) Update(group *models.RecurringIncomeGro
 }
 
 func (r *recurringIncomeGroupRepository) Delete(id uuid.UUID) error {
-	// Delete items first, then delete group
+	// Delete items and run logs first, then delete group
 	if err := r.db.Delete(&models.RecurringIncomeItem{}, "group_id = ?", id).Error; err != nil {
 		return err
 	}
+	if err := r.db.Delete(&models.RecurringIncomeRun{}, "group_id = ?", id).Error; err != nil {
+		return err
+	}
 	return r.db.Delete(&models.RecurringIncomeGroup{}, "id = ?", id).Error
 }
 
@@ -64,6 +69,27 @@ func (r *recurringIncomeGroupRepository) DeleteItem(itemID uuid.UUID) error {
 	return r.db.Delete(&models.RecurringIncomeItem{}, "id = ?", itemID).Error
 }
 
+// GetAutoPostGroups returns all active groups with auto-post enabled and a schedule set
+func (r *recurringIncomeGroupRepository) GetAutoPostGroups() ([]models.RecurringIncomeGroup, error) {
+	var groups []models.RecurringIncomeGroup
+	err := r.db.Preload("Items").Preload("Items.Category").
+		Where("is_active = ? AND auto_post = ? AND (recurring_day IS NOT NULL OR recurrence_frequency IS NOT NULL)", true, true).
+		Find(&groups).Error
+	return groups, err
+}
+
+// MarkPosted records the occurrence date as posted. It only moves forward and
+// returns false when that occurrence (or a later one) was already posted.
+func (r *recurringIncomeGroupRepository) MarkPosted(id uuid.UUID, date time.Time) (bool, error) {
+	result := r.db.Model(&models.RecurringIncomeGroup{}).
+		Where("id = ? AND (last_posted_date IS NULL OR last_posted_date < ?)", id, date).
+		Update("last_posted_date", date)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
 func (r *recurringIncomeGroupRepository) GetItemByID(itemID uuid.UUID) (*models.RecurringIncomeItem, error) {
 	var item models.RecurringIncomeItem
 	err := r.db.Preload("Category").First(&item, "id = ?", itemID).Error
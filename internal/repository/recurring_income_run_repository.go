@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+)
+
+type recurringIncomeRunRepository struct {
+	db *gorm.DB
+}
+
+func NewRecurringIncomeRunRepository(db *gorm.DB) RecurringIncomeRunRepository {
+	return &recurringIncomeRunRepository{db: db}
+}
+
+func (r *recurringIncomeRunRepository) Create(run *models.RecurringIncomeRun) error {
+	return r.db.Create(run).Error
+}
+
+func (r *recurringIncomeRunRepository) GetByUserID(userID uuid.UUID, groupID *uuid.UUID, limit int) ([]models.RecurringIncomeRun, error) {
+	var runs []models.RecurringIncomeRun
+	query := r.db.Preload("Group").Where("user_id = ?", userID)
+
+	if groupID != nil {
+		query = query.Where("group_id = ?", *groupID)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	err := query.Order("posted_date DESC, created_at DESC").Find(&runs).Error
+	return runs, err
+}
+
+func (r *recurringIncomeRunRepository) DeleteByGroupID(groupID uuid.UUID) error {
+	return r.db.Delete(&models.RecurringIncomeRun{}, "group_id = ?", groupID).Error
+}
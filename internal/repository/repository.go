@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 
@@ -8,52 +10,88 @@ import (
 )
 
 type Repositories struct {
-	DB                   *gorm.DB
-	User                 UserRepository
-	Category             CategoryRepository
-	Expense              ExpenseRepository
-	ExpenseTemplateGroup ExpenseTemplateGroupRepository
-	Installment          InstallmentRepository
-	InstallmentPayment   InstallmentPaymentRepository
-	Debt                 DebtRepository
-	DebtPayment          DebtPaymentRepository
-	NotificationLog      NotificationLogRepository
-	IncomeCategory       IncomeCategoryRepository
-	Income               IncomeRepository
-	RecurringIncomeGroup RecurringIncomeGroupRepository
-	PasswordResetToken   PasswordResetTokenRepository
-	TwoFACode            TwoFACodeRepository
-	Account              AccountRepository
-	Transaction          TransactionRepository
-	TransactionEntry     TransactionEntryRepository
-	SavingsGoal          SavingsGoalRepository
-	SavingsContribution  SavingsContributionRepository
-	RefreshToken         RefreshTokenRepository
+	DB                    *gorm.DB
+	User                  UserRepository
+	Category              CategoryRepository
+	Expense               ExpenseRepository
+	ExpenseTemplateGroup  ExpenseTemplateGroupRepository
+	Installment           InstallmentRepository
+	InstallmentPayment    InstallmentPaymentRepository
+	InstallmentPrepayment InstallmentPrepaymentRepository
+	InstallmentTerm       InstallmentTermRepository
+	Debt                  DebtRepository
+	DebtPayment           DebtPaymentRepository
+	DebtInterestAccrual   DebtInterestAccrualRepository
+	DebtConsolidation     DebtConsolidationRepository
+	NotificationLog       NotificationLogRepository
+	IncomeCategory        IncomeCategoryRepository
+	Income                IncomeRepository
+	RecurringIncomeGroup  RecurringIncomeGroupRepository
+	PasswordResetToken    PasswordResetTokenRepository
+	TwoFACode             TwoFACodeRepository
+	Account               AccountRepository
+	Transaction           TransactionRepository
+	TransactionEntry      TransactionEntryRepository
+	SavingsGoal           SavingsGoalRepository
+	SavingsContribution   SavingsContributionRepository
+	SavingsWithdrawal     SavingsWithdrawalRepository
+	SavingsAutoRule       SavingsAutoRuleRepository
+	SavingsAutoRun        SavingsAutoRunRepository
+	RoundUpSetting        RoundUpSettingRepository
+	RoundUp               RoundUpRepository
+	RefreshToken          RefreshTokenRepository
+	Payee                 PayeeRepository
+	ExpenseRefund         ExpenseRefundRepository
+	Holiday               HolidayRepository
+	RecurringIncomeRun    RecurringIncomeRunRepository
+	Budget                BudgetRepository
+	EnvelopeAssignment    EnvelopeAssignmentRepository
+	PayoffPlan            PayoffPlanRepository
+	LateFeeRule           LateFeeRuleRepository
+	LateFeeCharge         LateFeeChargeRepository
 }
 
 func NewRepositories(db *gorm.DB) *Repositories {
 	return &Repositories{
-		DB:                   db,
-		User:                 NewUserRepository(db),
-		Category:             NewCategoryRepository(db),
-		Expense:              NewExpenseRepository(db),
-		ExpenseTemplateGroup: NewExpenseTemplateGroupRepository(db),
-		Installment:          NewInstallmentRepository(db),
-		InstallmentPayment:   NewInstallmentPaymentRepository(db),
-		Debt:                 NewDebtRepository(db),
-		DebtPayment:          NewDebtPaymentRepository(db),
-		NotificationLog:      NewNotificationLogRepository(db),
-		IncomeCategory:       NewIncomeCategoryRepository(db),
-		Income:               NewIncomeRepository(db),
-		RecurringIncomeGroup: NewRecurringIncomeGroupRepository(db),
-		PasswordResetToken:   NewPasswordResetTokenRepository(db),
-		TwoFACode:            NewTwoFACodeRepository(db),
-		Account:              NewAccountRepository(db),
-		Transaction:          NewTransactionRepository(db),
-		TransactionEntry:     NewTransactionEntryRepository(db),
-		SavingsGoal:          NewSavingsGoalRepository(db),
-		SavingsContribution:  NewSavingsContributionRepository(db),
-		RefreshToken:         NewRefreshTokenRepository(db),
+		DB:                    db,
+		User:                  NewUserRepository(db),
+		Category:              NewCategoryRepository(db),
+		Expense:               NewExpenseRepository(db),
+		ExpenseTemplateGroup:  NewExpenseTemplateGroupRepository(db),
+		Installment:           NewInstallmentRepository(db),
+		InstallmentPayment:    NewInstallmentPaymentRepository(db),
+		InstallmentPrepayment: NewInstallmentPrepaymentRepository(db),
+		InstallmentTerm:       NewInstallmentTermRepository(db),
+		Debt:                  NewDebtRepository(db),
+		DebtPayment:           NewDebtPaymentRepository(db),
+		DebtInterestAccrual:   NewDebtInterestAccrualRepository(db),
+		DebtConsolidation:     NewDebtConsolidationRepository(db),
+		NotificationLog:       NewNotificationLogRepository(db),
+		IncomeCategory:        NewIncomeCategoryRepository(db),
+		Income:                NewIncomeRepository(db),
+		RecurringIncomeGroup:  NewRecurringIncomeGroupRepository(db),
+		PasswordResetToken:    NewPasswordResetTokenRepository(db),
+		TwoFACode:             NewTwoFACodeRepository(db),
+		Account:               NewAccountRepository(db),
+		Transaction:           NewTransactionRepository(db),
+		TransactionEntry:      NewTransactionEntryRepository(db),
+		SavingsGoal:           NewSavingsGoalRepository(db),
+		SavingsContribution:   NewSavingsContributionRepository(db),
+		SavingsWithdrawal:     NewSavingsWithdrawalRepository(db),
+		SavingsAutoRule:       NewSavingsAutoRuleRepository(db),
+		SavingsAutoRun:        NewSavingsAutoRunRepository(db),
+		RoundUpSetting:        NewRoundUpSettingRepository(db),
+		RoundUp:               NewRoundUpRepository(db),
+		RefreshToken:          NewRefreshTokenRepository(db),
+		Payee:                 NewPayeeRepository(db),
+		ExpenseRefund:         NewExpenseRefundRepository(db),
+		Holiday:               NewHolidayRepository(db),
+		RecurringIncomeRun:    NewRecurringIncomeRunRepository(db),
+		Budget:                NewBudgetRepository(db),
+		EnvelopeAssignment:    NewEnvelopeAssignmentRepository(db),
+		PayoffPlan:            NewPayoffPlanRepository(db),
+		LateFeeRule:           NewLateFeeRuleRepository(db),
+		LateFeeCharge:         NewLateFeeChargeRepository(db),
 	}
 }
 
@@ -73,7 +111,10 @@ type CategoryRepository interface {
 	GetByUserID(userID uuid.UUID) ([]models.Category, error)
 	GetByUserIDWithStats(userID uuid.UUID) ([]models.Category, error)
 	Update(category *models.Category) error
+	ReparentChildren(parentID uuid.UUID, newParentID *uuid.UUID) error
+	HasLateFeeRules(id uuid.UUID) (bool, error)
 	Delete(id uuid.UUID) error
+	Merge(sourceIDs []uuid.UUID, target *models.Category) error
 }
 
 type ExpenseRepository interface {
@@ -82,16 +123,26 @@ type ExpenseRepository interface {
 	GetByUserID(userID uuid.UUID, filter *ExpenseFilter) ([]models.Expense, error)
 	GetByUserIDAndDateRange(userID uuid.UUID, startDate, endDate string) ([]models.Expense, error)
 	GetRecentByUserID(userID uuid.UUID, limit int) ([]models.Expense, error)
+	GetRecentByPayeeID(payeeID uuid.UUID, limit int) ([]models.Expense, error)
 	Update(expense *models.Expense) error
 	Delete(id uuid.UUID) error
 }
 
 type ExpenseFilter struct {
 	CategoryID *uuid.UUID
+	PayeeID    *uuid.UUID
 	StartDate  *string
 	EndDate    *string
 }
 
+type ExpenseRefundRepository interface {
+	Create(refund *models.ExpenseRefund) error
+	GetByID(id uuid.UUID) (*models.ExpenseRefund, error)
+	GetByExpenseID(expenseID uuid.UUID) ([]models.ExpenseRefund, error)
+	Delete(id uuid.UUID) error
+	DeleteByExpenseID(expenseID uuid.UUID) error
+}
+
 type ExpenseTemplateGroupRepository interface {
 	Create(group *models.ExpenseTemplateGroup) error
 	GetByID(id uuid.UUID) (*models.ExpenseTemplateGroup, error)
@@ -102,6 +153,8 @@ type ExpenseTemplateGroupRepository interface {
 	UpdateItem(item *models.ExpenseTemplateItem) error
 	DeleteItem(itemID uuid.UUID) error
 	GetItemByID(itemID uuid.UUID) (*models.ExpenseTemplateItem, error)
+	GetAutoPostGroups() ([]models.ExpenseTemplateGroup, error)
+	MarkPosted(id uuid.UUID, date time.Time) (bool, error)
 }
 
 type InstallmentRepository interface {
@@ -119,6 +172,19 @@ type InstallmentPaymentRepository interface {
 	GetByIDs(ids []uuid.UUID) ([]models.InstallmentPayment, error)
 	GetByInstallmentID(installmentID uuid.UUID) ([]models.InstallmentPayment, error)
 	GetLastPaymentNumber(installmentID uuid.UUID) (int, error)
+	Update(payment *models.InstallmentPayment) error
+	Delete(id uuid.UUID) error
+}
+
+type InstallmentPrepaymentRepository interface {
+	Create(prepayment *models.InstallmentPrepayment) error
+	GetByInstallmentID(installmentID uuid.UUID) ([]models.InstallmentPrepayment, error)
+}
+
+type InstallmentTermRepository interface {
+	Create(term *models.InstallmentTerm) error
+	GetByInstallmentID(installmentID uuid.UUID) ([]models.InstallmentTerm, error)
+	Delete(id uuid.UUID) error
 }
 
 type DebtRepository interface {
@@ -126,22 +192,37 @@ type DebtRepository interface {
 	GetByID(id uuid.UUID) (*models.Debt, error)
 	GetByUserID(userID uuid.UUID, status *models.DebtStatus) ([]models.Debt, error)
 	GetByDueDateRange(startDate, endDate string, status models.DebtStatus) ([]models.Debt, error)
+	GetWithInterest(status models.DebtStatus) ([]models.Debt, error)
 	Update(debt *models.Debt) error
 	Delete(id uuid.UUID) error
 }
 
+type DebtInterestAccrualRepository interface {
+	Create(accrual *models.DebtInterestAccrual) error
+	GetByDebtID(debtID uuid.UUID) ([]models.DebtInterestAccrual, error)
+}
+
+type DebtConsolidationRepository interface {
+	Create(consolidation *models.DebtConsolidation) error
+	GetByID(id uuid.UUID) (*models.DebtConsolidation, error)
+	GetByLoanID(loanID uuid.UUID) (*models.DebtConsolidation, error)
+}
+
 type DebtPaymentRepository interface {
 	Create(payment *models.DebtPayment) error
 	GetByID(id uuid.UUID) (*models.DebtPayment, error)
 	GetByIDs(ids []uuid.UUID) ([]models.DebtPayment, error)
 	GetByDebtID(debtID uuid.UUID) ([]models.DebtPayment, error)
 	GetLastPaymentNumber(debtID uuid.UUID) (int, error)
+	Update(payment *models.DebtPayment) error
+	Delete(id uuid.UUID) error
 }
 
 type NotificationLogRepository interface {
 	Create(log *models.NotificationLog) error
 	GetByUserID(userID uuid.UUID) ([]models.NotificationLog, error)
 	ExistsForToday(userID, referenceID uuid.UUID, notificationType models.NotificationType) (bool, error)
+	ExistsForPeriod(userID, referenceID uuid.UUID, notificationType models.NotificationType, periodKey string) (bool, error)
 }
 
 type IncomeCategoryRepository interface {
@@ -149,7 +230,9 @@ type IncomeCategoryRepository interface {
 	GetByID(id uuid.UUID) (*models.IncomeCategory, error)
 	GetByUserID(userID uuid.UUID) ([]models.IncomeCategory, error)
 	Update(category *models.IncomeCategory) error
+	ReparentChildren(parentID uuid.UUID, newParentID *uuid.UUID) error
 	Delete(id uuid.UUID) error
+	Merge(sourceIDs []uuid.UUID, target *models.IncomeCategory) error
 }
 
 type IncomeFilter struct {
@@ -177,6 +260,61 @@ type RecurringIncomeGroupRepository interface {
 	UpdateItem(item *models.RecurringIncomeItem) error
 	DeleteItem(itemID uuid.UUID) error
 	GetItemByID(itemID uuid.UUID) (*models.RecurringIncomeItem, error)
+	GetAutoPostGroups() ([]models.RecurringIncomeGroup, error)
+	MarkPosted(id uuid.UUID, date time.Time) (bool, error)
+}
+
+type HolidayRepository interface {
+	Create(holiday *models.Holiday) error
+	GetByID(id uuid.UUID) (*models.Holiday, error)
+	GetByUserIDAndDateRange(userID uuid.UUID, startDate, endDate string) ([]models.Holiday, error)
+	Delete(id uuid.UUID) error
+}
+
+type RecurringIncomeRunRepository interface {
+	Create(run *models.RecurringIncomeRun) error
+	GetByUserID(userID uuid.UUID, groupID *uuid.UUID, limit int) ([]models.RecurringIncomeRun, error)
+	DeleteByGroupID(groupID uuid.UUID) error
+}
+
+type BudgetRepository interface {
+	Create(budget *models.Budget) error
+	GetByID(id uuid.UUID) (*models.Budget, error)
+	GetByUserID(userID uuid.UUID) ([]models.Budget, error)
+	GetByUserIDAndCategoryID(userID uuid.UUID, categoryID *uuid.UUID) (*models.Budget, error)
+	Update(budget *models.Budget) error
+	Delete(id uuid.UUID) error
+	SaveOverride(override *models.BudgetOverride) error
+	DeleteOverride(budgetID uuid.UUID, month, year int) error
+}
+
+type EnvelopeAssignmentRepository interface {
+	Create(assignment *models.EnvelopeAssignment) error
+	CreateBatch(assignments []models.EnvelopeAssignment) error
+	GetByUserIDAndMonth(userID uuid.UUID, month, year int) ([]models.EnvelopeAssignment, error)
+	GetByUserIDUpTo(userID uuid.UUID, month, year int) ([]models.EnvelopeAssignment, error)
+}
+
+type PayoffPlanRepository interface {
+	Create(plan *models.PayoffPlan) error
+	GetByID(id uuid.UUID) (*models.PayoffPlan, error)
+	GetByUserID(userID uuid.UUID) ([]models.PayoffPlan, error)
+	Delete(id uuid.UUID) error
+}
+
+type LateFeeRuleRepository interface {
+	Create(rule *models.LateFeeRule) error
+	GetByID(id uuid.UUID) (*models.LateFeeRule, error)
+	GetByUserID(userID uuid.UUID) ([]models.LateFeeRule, error)
+	GetActive() ([]models.LateFeeRule, error)
+	Update(rule *models.LateFeeRule) error
+	Delete(id uuid.UUID) error
+}
+
+type LateFeeChargeRepository interface {
+	Create(charge *models.LateFeeCharge) error
+	GetByUserID(userID uuid.UUID, loanID *uuid.UUID) ([]models.LateFeeCharge, error)
+	Exists(ruleID, loanID uuid.UUID, paymentNumber int) (bool, error)
 }
 
 type PasswordResetTokenRepository interface {
@@ -233,6 +371,13 @@ type TransactionEntryRepository interface {
 	DeleteByTransactionID(transactionID uuid.UUID) error
 	SumByAccountID(accountID uuid.UUID) (debit int64, credit int64, err error)
 	SumByAccountIDAndDateRange(accountID uuid.UUID, startDate, endDate string) (debit int64, credit int64, err error)
+	SumExpenseByCategory(userID uuid.UUID, startDate, endDate string) ([]CategorySpending, error)
+}
+
+// CategorySpending is the net amount booked on a category's expense account
+type CategorySpending struct {
+	CategoryID uuid.UUID
+	Total      int64
 }
 
 type SavingsGoalRepository interface {
@@ -251,6 +396,48 @@ type SavingsContributionRepository interface {
 	GetBySavingsGoalID(goalID uuid.UUID) ([]models.SavingsContribution, error)
 	Delete(id uuid.UUID) error
 	GetTotalByUserIDAndDateRange(userID uuid.UUID, startDate, endDate string) (int64, error)
+	Update(contribution *models.SavingsContribution) error
+}
+
+type SavingsWithdrawalRepository interface {
+	Create(withdrawal *models.SavingsWithdrawal) error
+	GetByID(id uuid.UUID) (*models.SavingsWithdrawal, error)
+	GetBySavingsGoalID(goalID uuid.UUID) ([]models.SavingsWithdrawal, error)
+	DeleteBySavingsGoalID(goalID uuid.UUID) error
+}
+
+type SavingsAutoRuleRepository interface {
+	Create(rule *models.SavingsAutoRule) error
+	GetByID(id uuid.UUID) (*models.SavingsAutoRule, error)
+	GetBySavingsGoalID(goalID uuid.UUID) ([]models.SavingsAutoRule, error)
+	GetActive() ([]models.SavingsAutoRule, error)
+	Update(rule *models.SavingsAutoRule) error
+	MarkRun(id uuid.UUID, date time.Time) (bool, error)
+	Delete(id uuid.UUID) error
+	DeleteBySavingsGoalID(goalID uuid.UUID) error
+}
+
+type RoundUpSettingRepository interface {
+	GetByUserID(userID uuid.UUID) (*models.RoundUpSetting, error)
+	Save(setting *models.RoundUpSetting) error
+}
+
+type RoundUpRepository interface {
+	Create(roundUp *models.RoundUp) error
+	GetByExpenseID(expenseID uuid.UUID) (*models.RoundUp, error)
+	GetPending() ([]models.RoundUp, error)
+	GetByUserIDAndDateRange(userID uuid.UUID, startDate, endDate string) ([]models.RoundUp, error)
+	Update(roundUp *models.RoundUp) error
+	SetContribution(ids []uuid.UUID, contributionID uuid.UUID) error
+	Delete(id uuid.UUID) error
+	DeleteBySavingsGoalID(goalID uuid.UUID) error
+}
+
+type SavingsAutoRunRepository interface {
+	Create(run *models.SavingsAutoRun) error
+	GetByUserID(userID uuid.UUID, ruleID *uuid.UUID, limit int) ([]models.SavingsAutoRun, error)
+	DeleteByRuleID(ruleID uuid.UUID) error
+	DeleteBySavingsGoalID(goalID uuid.UUID) error
 }
 
 type RefreshTokenRepository interface {
@@ -261,3 +448,22 @@ type RefreshTokenRepository interface {
 	DeleteByUserID(userID uuid.UUID) error
 	DeleteExpired() error
 }
+
+type PayeeRepository interface {
+	Create(payee *models.Payee) error
+	GetByID(id uuid.UUID) (*models.Payee, error)
+	GetByUserID(userID uuid.UUID) ([]models.Payee, error)
+	GetByUserIDWithStats(userID uuid.UUID) ([]models.Payee, error)
+	FindByName(userID uuid.UUID, name string) (*models.Payee, error)
+	GetSpendingStats(payeeID uuid.UUID) (count int, total int64, err error)
+	GetMonthlySpending(payeeID uuid.UUID, startDate string) ([]PayeeMonthlySpending, error)
+	Update(payee *models.Payee) error
+	ReplaceAliases(payeeID uuid.UUID, aliases []string) error
+	Delete(id uuid.UUID) error
+}
+
+type PayeeMonthlySpending struct {
+	Month string
+	Total int64
+	Count int
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+)
+
+type roundUpSettingRepository struct {
+	db *gorm.DB
+}
+
+func NewRoundUpSettingRepository(db *gorm.DB) RoundUpSettingRepository {
+	return &roundUpSettingRepository{db: db}
+}
+
+// GetByUserID returns nil when the user never configured round-ups
+func (r *roundUpSettingRepository) GetByUserID(userID uuid.UUID) (*models.RoundUpSetting, error) {
+	var setting models.RoundUpSetting
+	err := r.db.Preload("SavingsGoal").Where("user_id = ?", userID).First(&setting).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &setting, nil
+}
+
+func (r *roundUpSettingRepository) Save(setting *models.RoundUpSetting) error {
+	return r.db.Omit("SavingsGoal").Save(setting).Error
+}
+
+type roundUpRepository struct {
+	db *gorm.DB
+}
+
+func NewRoundUpRepository(db *gorm.DB) RoundUpRepository {
+	return &roundUpRepository{db: db}
+}
+
+func (r *roundUpRepository) Create(roundUp *models.RoundUp) error {
+	return r.db.Omit("SavingsGoal").Create(roundUp).Error
+}
+
+// GetByExpenseID returns nil when the expense wasn't rounded up
+func (r *roundUpRepository) GetByExpenseID(expenseID uuid.UUID) (*models.RoundUp, error) {
+	var roundUp models.RoundUp
+	err := r.db.Where("expense_id = ?", expenseID).First(&roundUp).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &roundUp, nil
+}
+
+// GetPending returns the round-ups still waiting for the daily batch
+func (r *roundUpRepository) GetPending() ([]models.RoundUp, error) {
+	var roundUps []models.RoundUp
+	err := r.db.Where("contribution_id IS NULL").Order("date ASC").Find(&roundUps).Error
+	return roundUps, err
+}
+
+func (r *roundUpRepository) GetByUserIDAndDateRange(userID uuid.UUID, startDate, endDate string) ([]models.RoundUp, error) {
+	var roundUps []models.RoundUp
+	err := r.db.Preload("SavingsGoal").
+		Where("user_id = ? AND date >= ? AND date <= ?", userID, startDate, endDate).
+		Order("date DESC").
+		Find(&roundUps).Error
+	return roundUps, err
+}
+
+func (r *roundUpRepository) Update(roundUp *models.RoundUp) error {
+	return r.db.Omit("SavingsGoal").Save(roundUp).Error
+}
+
+func (r *roundUpRepository) SetContribution(ids []uuid.UUID, contributionID uuid.UUID) error {
+	return r.db.Model(&models.RoundUp{}).Where("id IN ?", ids).Update("contribution_id", contributionID).Error
+}
+
+func (r *roundUpRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.RoundUp{}, "id = ?", id).Error
+}
+
+func (r *roundUpRepository) DeleteBySavingsGoalID(goalID uuid.UUID) error {
+	return r.db.Delete(&models.RoundUp{}, "savings_goal_id = ?", goalID).Error
+}
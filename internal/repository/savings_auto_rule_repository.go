@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+)
+
+type savingsAutoRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewSavingsAutoRuleRepository(db *gorm.DB) SavingsAutoRuleRepository {
+	return &savingsAutoRuleRepository{db: db}
+}
+
+func (r *savingsAutoRuleRepository) Create(rule *models.SavingsAutoRule) error {
+	return r.db.Create(rule).Error
+}
+
+func (r *savingsAutoRuleRepository) GetByID(id uuid.UUID) (*models.SavingsAutoRule, error) {
+	var rule models.SavingsAutoRule
+	err := r.db.Preload("SavingsGoal").Preload("SourcePocket").First(&rule, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *savingsAutoRuleRepository) GetBySavingsGoalID(goalID uuid.UUID) ([]models.SavingsAutoRule, error) {
+	var rules []models.SavingsAutoRule
+	err := r.db.Preload("SourcePocket").
+		Where("savings_goal_id = ?", goalID).
+		Order("created_at ASC").
+		Find(&rules).Error
+	return rules, err
+}
+
+func (r *savingsAutoRuleRepository) GetActive() ([]models.SavingsAutoRule, error) {
+	var rules []models.SavingsAutoRule
+	err := r.db.Preload("SavingsGoal").Preload("SourcePocket").
+		Where("is_active = ?", true).
+		Find(&rules).Error
+	return rules, err
+}
+
+func (r *savingsAutoRuleRepository) Update(rule *models.SavingsAutoRule) error {
+	return r.db.Omit("SavingsGoal", "SourcePocket").Save(rule).Error
+}
+
+// MarkRun records the occurrence date as executed. It only moves forward and
+// returns false when that occurrence (or a later one) was already executed.
+func (r *savingsAutoRuleRepository) MarkRun(id uuid.UUID, date time.Time) (bool, error) {
+	result := r.db.Model(&models.SavingsAutoRule{}).
+		Where("id = ? AND (last_run_date IS NULL OR last_run_date < ?)", id, date).
+		Update("last_run_date", date)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *savingsAutoRuleRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.SavingsAutoRule{}, "id = ?", id).Error
+}
+
+func (r *savingsAutoRuleRepository) DeleteBySavingsGoalID(goalID uuid.UUID) error {
+	return r.db.Delete(&models.SavingsAutoRule{}, "savings_goal_id = ?", goalID).Error
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+)
+
+type savingsAutoRunRepository struct {
+	db *gorm.DB
+}
+
+func NewSavingsAutoRunRepository(db *gorm.DB) SavingsAutoRunRepository {
+	return &savingsAutoRunRepository{db: db}
+}
+
+func (r *savingsAutoRunRepository) Create(run *models.SavingsAutoRun) error {
+	return r.db.Omit("Rule").Create(run).Error
+}
+
+func (r *savingsAutoRunRepository) GetByUserID(userID uuid.UUID, ruleID *uuid.UUID, limit int) ([]models.SavingsAutoRun, error) {
+	var runs []models.SavingsAutoRun
+	query := r.db.Where("user_id = ?", userID)
+
+	if ruleID != nil {
+		query = query.Where("rule_id = ?", *ruleID)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	err := query.Order("scheduled_date DESC, created_at DESC").Find(&runs).Error
+	return runs, err
+}
+
+func (r *savingsAutoRunRepository) DeleteByRuleID(ruleID uuid.UUID) error {
+	return r.db.Delete(&models.SavingsAutoRun{}, "rule_id = ?", ruleID).Error
+}
+
+func (r *savingsAutoRunRepository) DeleteBySavingsGoalID(goalID uuid.UUID) error {
+	return r.db.Delete(&models.SavingsAutoRun{}, "savings_goal_id = ?", goalID).Error
+}
@@ -34,6 +34,10 @@ func (r *savingsContributionRepository) GetBySavingsGoalID(goalID uuid.UUID) ([]
 	return contributions, err
 }
 
+func (r *savingsContributionRepository) Update(contribution *models.SavingsContribution) error {
+	return r.db.Omit("SavingsGoal", "Pocket").Save(contribution).Error
+}
+
 func (r *savingsContributionRepository) Delete(id uuid.UUID) error {
 	return r.db.Delete(&models.SavingsContribution{}, "id = ?", id).Error
 }
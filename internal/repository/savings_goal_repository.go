@@ -21,7 +21,7 @@ func (r *savingsGoalRepository) Create(goal *models.SavingsGoal) error {
 
 func (r *savingsGoalRepository) GetByID(id uuid.UUID) (*models.SavingsGoal, error) {
 	var goal models.SavingsGoal
-	err := r.db.Preload("Contributions").First(&goal, "id = ?", id).Error
+	err := r.db.Preload("Contributions").Preload("Withdrawals").First(&goal, "id = ?", id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -30,7 +30,7 @@ func (r *savingsGoalRepository) GetByID(id uuid.UUID) (*models.SavingsGoal, erro
 
 func (r *savingsGoalRepository) GetByUserID(userID uuid.UUID, status *models.SavingsGoalStatus) ([]models.SavingsGoal, error) {
 	var goals []models.SavingsGoal
-	query := r.db.Preload("Contributions").Where("user_id = ?", userID)
+	query := r.db.Preload("Contributions").Preload("Withdrawals").Where("user_id = ?", userID)
 
 	if status != nil {
 		query = query.Where("status = ?", *status)
@@ -42,7 +42,7 @@ func (r *savingsGoalRepository) GetByUserID(userID uuid.UUID, status *models.Sav
 
 func (r *savingsGoalRepository) GetActiveByUserID(userID uuid.UUID) ([]models.SavingsGoal, error) {
 	var goals []models.SavingsGoal
-	err := r.db.Preload("Contributions").
+	err := r.db.Preload("Contributions").Preload("Withdrawals").
 		Where("user_id = ? AND status = ?", userID, models.SavingsGoalStatusActive).
 		Order("target_date ASC").Find(&goals).Error
 	return goals, err
@@ -50,7 +50,7 @@ func (r *savingsGoalRepository) GetActiveByUserID(userID uuid.UUID) ([]models.Sa
 
 func (r *savingsGoalRepository) GetByTargetDateRange(startDate, endDate string, status models.SavingsGoalStatus) ([]models.SavingsGoal, error) {
 	var goals []models.SavingsGoal
-	err := r.db.Preload("Contributions").Preload("User").
+	err := r.db.Preload("Contributions").Preload("Withdrawals").Preload("User").
 		Where("target_date BETWEEN ? AND ? AND status = ?", startDate, endDate, status).
 		Find(&goals).Error
 	return goals, err
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+)
+
+type savingsWithdrawalRepository struct {
+	db *gorm.DB
+}
+
+func NewSavingsWithdrawalRepository(db *gorm.DB) SavingsWithdrawalRepository {
+	return &savingsWithdrawalRepository{db: db}
+}
+
+func (r *savingsWithdrawalRepository) Create(withdrawal *models.SavingsWithdrawal) error {
+	return r.db.Create(withdrawal).Error
+}
+
+func (r *savingsWithdrawalRepository) GetByID(id uuid.UUID) (*models.SavingsWithdrawal, error) {
+	var withdrawal models.SavingsWithdrawal
+	err := r.db.Preload("SavingsGoal").First(&withdrawal, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &withdrawal, nil
+}
+
+func (r *savingsWithdrawalRepository) GetBySavingsGoalID(goalID uuid.UUID) ([]models.SavingsWithdrawal, error) {
+	var withdrawals []models.SavingsWithdrawal
+	err := r.db.Where("savings_goal_id = ?", goalID).Order("withdrawal_date DESC").Find(&withdrawals).Error
+	return withdrawals, err
+}
+
+func (r *savingsWithdrawalRepository) DeleteBySavingsGoalID(goalID uuid.UUID) error {
+	return r.db.Delete(&models.SavingsWithdrawal{}, "savings_goal_id = ?", goalID).Error
+}
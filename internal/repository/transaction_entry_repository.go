@@ -87,3 +87,18 @@ func (r *transactionEntryRepository) SumByAccountIDAndDateRange(accountID uuid.U
 		Scan(&result).Error
 	return result.TotalDebit, result.TotalCredit, err
 }
+
+// SumExpenseByCategory returns the net debit of every category expense account
+// in the date range; refunds credit the account and reduce the total
+func (r *transactionEntryRepository) SumExpenseByCategory(userID uuid.UUID, startDate, endDate string) ([]CategorySpending, error) {
+	var spending []CategorySpending
+	err := r.db.Model(&models.TransactionEntry{}).
+		Select("accounts.reference_id as category_id, COALESCE(SUM(transaction_entries.debit - transaction_entries.credit), 0) as total").
+		Joins("JOIN transactions ON transactions.id = transaction_entries.transaction_id").
+		Joins("JOIN accounts ON accounts.id = transaction_entries.account_id").
+		Where("transactions.user_id = ? AND transactions.transaction_date BETWEEN ? AND ?", userID, startDate, endDate).
+		Where("accounts.account_type = ? AND accounts.reference_type = ?", models.AccountTypeExpense, "category").
+		Group("accounts.reference_id").
+		Scan(&spending).Error
+	return spending, err
+}
@@ -39,7 +39,7 @@ func (r *userRepository) GetByEmail(email string) (*models.User, error) {
 
 func (r *userRepository) GetAllWithNotificationsEnabled() ([]models.User, error) {
 	var users []models.User
-	err := r.db.Where("notify_installment = ? OR notify_debt = ?", true, true).Find(&users).Error
+	err := r.db.Where("notify_installment = ? OR notify_debt = ? OR notify_budget = ? OR notify_receivable = ?", true, true, true, true).Find(&users).Error
 	if err != nil {
 		return nil, err
 	}
@@ -72,11 +72,39 @@ func (r *userRepository) DeleteAllUserData(userID uuid.UUID) error {
 		if err := tx.Exec("DELETE FROM installment_payments WHERE installment_id IN (SELECT id FROM installments WHERE user_id = ?)", userID).Error; err != nil {
 			return err
 		}
+		if err := tx.Exec("DELETE FROM installment_prepayments WHERE installment_id IN (SELECT id FROM installments WHERE user_id = ?)", userID).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM installment_terms WHERE installment_id IN (SELECT id FROM installments WHERE user_id = ?)", userID).Error; err != nil {
+			return err
+		}
 		if err := tx.Exec("DELETE FROM debt_payments WHERE debt_id IN (SELECT id FROM debts WHERE user_id = ?)", userID).Error; err != nil {
 			return err
 		}
+		if err := tx.Exec("DELETE FROM debt_interest_accruals WHERE debt_id IN (SELECT id FROM debts WHERE user_id = ?)", userID).Error; err != nil {
+			return err
+		}
+
+		// Delete round-ups and their settings (reference expenses and savings_goals)
+		if err := tx.Exec("DELETE FROM round_ups WHERE user_id = ?", userID).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM round_up_settings WHERE user_id = ?", userID).Error; err != nil {
+			return err
+		}
 
-		// Delete savings contributions (references savings_goals)
+		// Delete savings auto rules and their runs (reference savings_goals)
+		if err := tx.Exec("DELETE FROM savings_auto_runs WHERE user_id = ?", userID).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM savings_auto_rules WHERE user_id = ?", userID).Error; err != nil {
+			return err
+		}
+
+		// Delete savings contributions and withdrawals (reference savings_goals)
+		if err := tx.Exec("DELETE FROM savings_withdrawals WHERE savings_goal_id IN (SELECT id FROM savings_goals WHERE user_id = ?)", userID).Error; err != nil {
+			return err
+		}
 		if err := tx.Exec("DELETE FROM savings_contributions WHERE savings_goal_id IN (SELECT id FROM savings_goals WHERE user_id = ?)", userID).Error; err != nil {
 			return err
 		}
@@ -85,6 +113,9 @@ func (r *userRepository) DeleteAllUserData(userID uuid.UUID) error {
 		}
 
 		// Delete main records
+		if err := tx.Exec("DELETE FROM expense_refunds WHERE expense_id IN (SELECT id FROM expenses WHERE user_id = ?)", userID).Error; err != nil {
+			return err
+		}
 		if err := tx.Exec("DELETE FROM expenses WHERE user_id = ?", userID).Error; err != nil {
 			return err
 		}
@@ -95,6 +126,10 @@ func (r *userRepository) DeleteAllUserData(userID uuid.UUID) error {
 		if err := tx.Exec("DELETE FROM expense_template_groups WHERE user_id = ?", userID).Error; err != nil {
 			return err
 		}
+		// Delete consolidations (reference the installments and debts they fund)
+		if err := tx.Exec("DELETE FROM debt_consolidations WHERE user_id = ?", userID).Error; err != nil {
+			return err
+		}
 		if err := tx.Exec("DELETE FROM installments WHERE user_id = ?", userID).Error; err != nil {
 			return err
 		}
@@ -104,13 +139,50 @@ func (r *userRepository) DeleteAllUserData(userID uuid.UUID) error {
 		if err := tx.Exec("DELETE FROM incomes WHERE user_id = ?", userID).Error; err != nil {
 			return err
 		}
-		// Delete recurring income items then groups
+		// Delete recurring income items and run logs then groups
 		if err := tx.Exec("DELETE FROM recurring_income_items WHERE group_id IN (SELECT id FROM recurring_income_groups WHERE user_id = ?)", userID).Error; err != nil {
 			return err
 		}
+		if err := tx.Exec("DELETE FROM recurring_income_runs WHERE user_id = ?", userID).Error; err != nil {
+			return err
+		}
 		if err := tx.Exec("DELETE FROM recurring_income_groups WHERE user_id = ?", userID).Error; err != nil {
 			return err
 		}
+		if err := tx.Exec("DELETE FROM holidays WHERE user_id = ?", userID).Error; err != nil {
+			return err
+		}
+		// Delete budget overrides then budgets (references categories)
+		if err := tx.Exec("DELETE FROM budget_overrides WHERE budget_id IN (SELECT id FROM budgets WHERE user_id = ?)", userID).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM budgets WHERE user_id = ?", userID).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM envelope_assignments WHERE user_id = ?", userID).Error; err != nil {
+			return err
+		}
+		// Delete late fee charges then rules (references categories)
+		if err := tx.Exec("DELETE FROM late_fee_charges WHERE user_id = ?", userID).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM late_fee_rules WHERE user_id = ?", userID).Error; err != nil {
+			return err
+		}
+		// Delete payoff plan months then plans
+		if err := tx.Exec("DELETE FROM payoff_plan_months WHERE plan_id IN (SELECT id FROM payoff_plans WHERE user_id = ?)", userID).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM payoff_plans WHERE user_id = ?", userID).Error; err != nil {
+			return err
+		}
+		// Delete payee aliases then payees (referenced by expenses, incomes and debts)
+		if err := tx.Exec("DELETE FROM payee_aliases WHERE payee_id IN (SELECT id FROM payees WHERE user_id = ?)", userID).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM payees WHERE user_id = ?", userID).Error; err != nil {
+			return err
+		}
 		if err := tx.Exec("DELETE FROM notification_logs WHERE user_id = ?", userID).Error; err != nil {
 			return err
 		}
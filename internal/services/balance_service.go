@@ -32,9 +32,10 @@ const (
 )
 
 type BalanceFilterInput struct {
-	Period    BalancePeriod
-	StartDate *time.Time
-	EndDate   *time.Time
+	Period           BalancePeriod
+	StartDate        *time.Time
+	EndDate          *time.Time
+	CategoryGrouping CategoryGrouping
 }
 
 type IncomeCategorySummary struct {
@@ -93,6 +94,11 @@ func (s *BalanceService) GetBalance(userID uuid.UUID, filter BalanceFilterInput)
 		return nil, err
 	}
 
+	incomeRoots, err := loadIncomeCategoryRoots(s.repos.IncomeCategory, userID, filter.CategoryGrouping)
+	if err != nil {
+		return nil, err
+	}
+
 	incomeCategoryMap := make(map[uuid.UUID]*IncomeCategorySummary)
 
 	for _, inc := range incomes {
@@ -100,13 +106,14 @@ func (s *BalanceService) GetBalance(userID uuid.UUID, filter BalanceFilterInput)
 		report.Income.Count++
 
 		// By category
-		if _, exists := incomeCategoryMap[inc.CategoryID]; !exists {
-			incomeCategoryMap[inc.CategoryID] = &IncomeCategorySummary{
-				Category: *inc.Category,
+		category := reportIncomeCategory(incomeRoots, *inc.Category)
+		if _, exists := incomeCategoryMap[category.ID]; !exists {
+			incomeCategoryMap[category.ID] = &IncomeCategorySummary{
+				Category: category,
 			}
 		}
-		incomeCategoryMap[inc.CategoryID].TotalAmount += inc.Amount
-		incomeCategoryMap[inc.CategoryID].IncomeCount++
+		incomeCategoryMap[category.ID].TotalAmount += inc.Amount
+		incomeCategoryMap[category.ID].IncomeCount++
 	}
 
 	for _, summary := range incomeCategoryMap {
@@ -123,35 +130,47 @@ func (s *BalanceService) GetBalance(userID uuid.UUID, filter BalanceFilterInput)
 		return nil, err
 	}
 
+	expenseRoots, err := loadCategoryRoots(s.repos.Category, userID, filter.CategoryGrouping)
+	if err != nil {
+		return nil, err
+	}
+
 	expenseCategoryMap := make(map[uuid.UUID]*CategorySummary)
 	for _, exp := range expenses {
-		report.Expense.Total += exp.Total()
+		// A purchase paid by an installment is counted through its payments
+		if exp.InstallmentID == nil {
+			report.Expense.Total += exp.NetTotal()
+		}
 		report.Expense.Count++
 
-		if _, exists := expenseCategoryMap[exp.CategoryID]; !exists {
-			expenseCategoryMap[exp.CategoryID] = &CategorySummary{
-				Category: *exp.Category,
+		category := reportCategory(expenseRoots, *exp.Category)
+		if _, exists := expenseCategoryMap[category.ID]; !exists {
+			expenseCategoryMap[category.ID] = &CategorySummary{
+				Category: category,
 			}
 		}
-		expenseCategoryMap[exp.CategoryID].TotalAmount += exp.Total()
-		expenseCategoryMap[exp.CategoryID].ExpenseCount++
+		expenseCategoryMap[category.ID].TotalAmount += exp.NetTotal()
+		expenseCategoryMap[category.ID].ExpenseCount++
 	}
 
 	for _, summary := range expenseCategoryMap {
 		report.Expense.ByCategory = append(report.Expense.ByCategory, *summary)
 	}
 
-	// Get actual liability payments from ledger for the period (for installments)
-	actualLiabilityPayments, err := s.ledgerService.GetActualPaymentsByDateRange(
-		userID,
-		startDate.Format("2006-01-02"),
-		endDate.Format("2006-01-02"),
-		models.AccountTypeLiability,
-	)
-	if err != nil {
-		actualLiabilityPayments = 0
+	// Get installment payments from transactions for the period. A consolidation
+	// payoff only moves the balance to the new loan, so it is not counted.
+	for _, referenceType := range []string{"installment_payment", "installment_prepayment"} {
+		installmentPayments, err := s.ledgerService.GetMonthlyObligationByReferenceType(
+			userID,
+			startDate.Format("2006-01-02"),
+			endDate.Format("2006-01-02"),
+			referenceType,
+		)
+		if err != nil {
+			installmentPayments = 0
+		}
+		report.Installment.Total += installmentPayments
 	}
-	report.Installment.Total = actualLiabilityPayments
 
 	// Get debt payments from transactions for the period
 	debtPayments, err := s.ledgerService.GetMonthlyObligationByReferenceType(
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+	"github.com/azzamdhx/moneybro/backend/internal/repository"
+)
+
+type fakeIncomeRepo struct {
+	repository.IncomeRepository
+	incomes []models.Income
+}
+
+func (r *fakeIncomeRepo) GetByUserIDAndDateRange(userID uuid.UUID, startDate, endDate string) ([]models.Income, error) {
+	var incomes []models.Income
+	for _, income := range r.incomes {
+		if date := income.IncomeDate.Format("2006-01-02"); income.UserID == userID && date >= startDate && date <= endDate {
+			incomes = append(incomes, income)
+		}
+	}
+	return incomes, nil
+}
+
+type fakeExpenseRepo struct {
+	repository.ExpenseRepository
+}
+
+func (r *fakeExpenseRepo) GetByUserIDAndDateRange(userID uuid.UUID, startDate, endDate string) ([]models.Expense, error) {
+	return nil, nil
+}
+
+func TestBalanceInstallmentTotalAfterConsolidation(t *testing.T) {
+	tests := []struct {
+		name string
+		// payment is paid on the new loan after the consolidation
+		payment         int64
+		wantInstallment int64
+		wantNet         int64
+		wantStatus      BalanceStatus
+	}{
+		{name: "payoff alone", wantStatus: BalanceStatusBalanced},
+		{name: "payoff and a payment of the new loan", payment: 140000, wantInstallment: 140000, wantNet: -140000, wantStatus: BalanceStatusDeficit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ct := newConsolidationTest(t)
+			result, err := ct.service.Consolidate(ct.userID, ConsolidationInput{
+				InstallmentIDs: []uuid.UUID{ct.installmentID},
+				DebtIDs:        []uuid.UUID{ct.debtID},
+				NewLoan: ConsolidationLoanInput{
+					Kind:   PayoffLoanKindInstallment,
+					Name:   "Pinjaman Konsolidasi",
+					Date:   time.Date(2026, time.April, 15, 0, 0, 0, 0, time.UTC),
+					Tenor:  10,
+					DueDay: 5,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Consolidate: %v", err)
+			}
+			if tt.payment > 0 {
+				pocket := newPocket(t, ct.accounts, ct.userID, tt.payment, true)
+				newAccount, err := ct.accounts.GetByReference(result.Installment.ID, "installment")
+				if err != nil {
+					t.Fatalf("get new loan account: %v", err)
+				}
+				paymentID := uuid.New()
+				if _, err := ct.ledger.CreateJournalEntry(ct.userID, time.Date(2026, time.April, 25, 0, 0, 0, 0, time.UTC), "Installment Payment",
+					[]LedgerEntry{
+						{AccountID: newAccount.ID, Debit: tt.payment},
+						{AccountID: pocket.ID, Credit: tt.payment},
+					}, &paymentID, "installment_payment"); err != nil {
+					t.Fatalf("record payment: %v", err)
+				}
+			}
+
+			startDate := time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC)
+			endDate := time.Date(2026, time.April, 30, 0, 0, 0, 0, time.UTC)
+			service := NewBalanceService(&repository.Repositories{Income: &fakeIncomeRepo{}, Expense: &fakeExpenseRepo{}}, ct.ledger)
+			report, err := service.GetBalance(ct.userID, BalanceFilterInput{Period: BalancePeriodCustom, StartDate: &startDate, EndDate: &endDate})
+			if err != nil {
+				t.Fatalf("GetBalance: %v", err)
+			}
+
+			if report.Installment.Total != tt.wantInstallment {
+				t.Errorf("installment total = %d, want %d", report.Installment.Total, tt.wantInstallment)
+			}
+			if report.NetBalance != tt.wantNet {
+				t.Errorf("net balance = %d, want %d", report.NetBalance, tt.wantNet)
+			}
+			if report.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", report.Status, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,293 @@
+package services
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+	"github.com/azzamdhx/moneybro/backend/internal/repository"
+)
+
+type BudgetService struct {
+	budgetRepo   repository.BudgetRepository
+	categoryRepo repository.CategoryRepository
+	entryRepo    repository.TransactionEntryRepository
+}
+
+func NewBudgetService(
+	budgetRepo repository.BudgetRepository,
+	categoryRepo repository.CategoryRepository,
+	entryRepo repository.TransactionEntryRepository,
+) *BudgetService {
+	return &BudgetService{
+		budgetRepo:   budgetRepo,
+		categoryRepo: categoryRepo,
+		entryRepo:    entryRepo,
+	}
+}
+
+// CreateBudgetInput.AlertThresholds are the spending percentages that trigger an
+// alert email; nil keeps the default (80 and 100), an empty list disables alerts
+type CreateBudgetInput struct {
+	CategoryID      *uuid.UUID
+	Amount          int64
+	AlertThresholds []int
+}
+
+type UpdateBudgetInput struct {
+	Amount          *int64
+	AlertThresholds []int
+}
+
+// BudgetProgress compares one budget with the month's spending. ProjectedSpend
+// extrapolates the current month at its pace so far; past months project
+// their actual spending.
+type BudgetProgress struct {
+	Budget         models.Budget
+	Budgeted       int64
+	Spent          int64
+	Remaining      int64
+	Percentage     float64
+	ProjectedSpend int64
+}
+
+type BudgetStatusReport struct {
+	Month       int
+	Year        int
+	DaysElapsed int
+	DaysInMonth int
+	Items       []BudgetProgress
+}
+
+func (s *BudgetService) Create(userID uuid.UUID, input CreateBudgetInput) (*models.Budget, error) {
+	if input.Amount <= 0 {
+		return nil, errors.New("budget amount must be greater than 0")
+	}
+	if input.CategoryID != nil {
+		category, err := s.categoryRepo.GetByID(*input.CategoryID)
+		if err != nil || category.UserID != userID {
+			return nil, errors.New("category not found")
+		}
+	}
+	if _, err := s.budgetRepo.GetByUserIDAndCategoryID(userID, input.CategoryID); err == nil {
+		return nil, errors.New("a budget already exists for this category")
+	}
+
+	budget := &models.Budget{
+		ID:              uuid.New(),
+		UserID:          userID,
+		CategoryID:      input.CategoryID,
+		Amount:          input.Amount,
+		AlertThresholds: "80,100",
+	}
+	if input.AlertThresholds != nil {
+		thresholds, err := formatAlertThresholds(input.AlertThresholds)
+		if err != nil {
+			return nil, err
+		}
+		budget.AlertThresholds = thresholds
+	}
+	if err := s.budgetRepo.Create(budget); err != nil {
+		return nil, err
+	}
+
+	return s.budgetRepo.GetByID(budget.ID)
+}
+
+func (s *BudgetService) GetByUserID(userID uuid.UUID) ([]models.Budget, error) {
+	return s.budgetRepo.GetByUserID(userID)
+}
+
+func (s *BudgetService) Update(userID, id uuid.UUID, input UpdateBudgetInput) (*models.Budget, error) {
+	budget, err := s.getOwned(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Amount != nil {
+		if *input.Amount <= 0 {
+			return nil, errors.New("budget amount must be greater than 0")
+		}
+		budget.Amount = *input.Amount
+	}
+	if input.AlertThresholds != nil {
+		thresholds, err := formatAlertThresholds(input.AlertThresholds)
+		if err != nil {
+			return nil, err
+		}
+		budget.AlertThresholds = thresholds
+	}
+
+	if err := s.budgetRepo.Update(budget); err != nil {
+		return nil, err
+	}
+
+	return s.budgetRepo.GetByID(budget.ID)
+}
+
+func (s *BudgetService) Delete(userID, id uuid.UUID) error {
+	if _, err := s.getOwned(userID, id); err != nil {
+		return err
+	}
+	return s.budgetRepo.Delete(id)
+}
+
+// SetOverride replaces the budget amount for a single month
+func (s *BudgetService) SetOverride(userID, id uuid.UUID, month, year int, amount int64) (*models.Budget, error) {
+	if _, err := s.getOwned(userID, id); err != nil {
+		return nil, err
+	}
+	if month < 1 || month > 12 {
+		return nil, errors.New("month must be between 1 and 12")
+	}
+	if amount < 0 {
+		return nil, errors.New("budget amount cannot be negative")
+	}
+
+	override := &models.BudgetOverride{
+		ID:       uuid.New(),
+		BudgetID: id,
+		Month:    month,
+		Year:     year,
+		Amount:   amount,
+	}
+	if err := s.budgetRepo.SaveOverride(override); err != nil {
+		return nil, err
+	}
+
+	return s.budgetRepo.GetByID(id)
+}
+
+func (s *BudgetService) DeleteOverride(userID, id uuid.UUID, month, year int) (*models.Budget, error) {
+	if _, err := s.getOwned(userID, id); err != nil {
+		return nil, err
+	}
+	if err := s.budgetRepo.DeleteOverride(id, month, year); err != nil {
+		return nil, err
+	}
+	return s.budgetRepo.GetByID(id)
+}
+
+// GetStatus compares every budget with the spending booked on the ledger
+// expense accounts in the month. A category budget includes the spending of
+// its child categories; the total budget includes all categories.
+func (s *BudgetService) GetStatus(userID uuid.UUID, month, year int) (*BudgetStatusReport, error) {
+	if month < 1 || month > 12 {
+		return nil, errors.New("month must be between 1 and 12")
+	}
+
+	startDate, endDate := monthDateRange(month, year)
+	monthStart := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	daysInMonth := monthStart.AddDate(0, 1, -1).Day()
+
+	report := &BudgetStatusReport{
+		Month:       month,
+		Year:        year,
+		DaysInMonth: daysInMonth,
+		Items:       []BudgetProgress{},
+	}
+
+	now := time.Now()
+	switch current := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC); {
+	case monthStart.Equal(current):
+		report.DaysElapsed = now.Day()
+	case monthStart.Before(current):
+		report.DaysElapsed = daysInMonth
+	}
+
+	budgets, err := s.budgetRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(budgets) == 0 {
+		return report, nil
+	}
+
+	spending, err := s.entryRepo.SumExpenseByCategory(userID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.categoryRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	parents := make(map[uuid.UUID]*uuid.UUID, len(categories))
+	for _, c := range categories {
+		parents[c.ID] = c.ParentID
+	}
+
+	for _, budget := range budgets {
+		var spent int64
+		for _, cs := range spending {
+			if budget.CategoryID == nil || isCategoryWithin(parents, cs.CategoryID, *budget.CategoryID) {
+				spent += cs.Total
+			}
+		}
+
+		budgeted := budget.AmountFor(month, year)
+		progress := BudgetProgress{
+			Budget:         budget,
+			Budgeted:       budgeted,
+			Spent:          spent,
+			Remaining:      budgeted - spent,
+			ProjectedSpend: spent,
+		}
+		if budgeted > 0 {
+			progress.Percentage = float64(spent) / float64(budgeted) * 100
+		}
+		if report.DaysElapsed > 0 && report.DaysElapsed < daysInMonth {
+			progress.ProjectedSpend = spent * int64(daysInMonth) / int64(report.DaysElapsed)
+		}
+		report.Items = append(report.Items, progress)
+	}
+
+	return report, nil
+}
+
+func (s *BudgetService) getOwned(userID, id uuid.UUID) (*models.Budget, error) {
+	budget, err := s.budgetRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if budget.UserID != userID {
+		return nil, errors.New("budget not found")
+	}
+	return budget, nil
+}
+
+// formatAlertThresholds validates alert percentages and stores them sorted and
+// without duplicates
+func formatAlertThresholds(thresholds []int) (string, error) {
+	seen := make(map[int]bool)
+	var values []int
+	for _, t := range thresholds {
+		if t < 1 || t > 1000 {
+			return "", errors.New("alert threshold must be between 1 and 1000 percent")
+		}
+		if !seen[t] {
+			seen[t] = true
+			values = append(values, t)
+		}
+	}
+	sort.Ints(values)
+	return joinInts(values), nil
+}
+
+// isCategoryWithin reports whether categoryID is ancestorID or one of its descendants
+func isCategoryWithin(parents map[uuid.UUID]*uuid.UUID, categoryID, ancestorID uuid.UUID) bool {
+	current := categoryID
+	for i := 0; i <= len(parents); i++ {
+		if current == ancestorID {
+			return true
+		}
+		parent, ok := parents[current]
+		if !ok || parent == nil {
+			return false
+		}
+		current = *parent
+	}
+	return false
+}
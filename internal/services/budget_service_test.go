@@ -0,0 +1,172 @@
+package services
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+	"github.com/azzamdhx/moneybro/backend/internal/repository"
+)
+
+type fakeBudgetRepo struct {
+	repository.BudgetRepository
+	budgets []models.Budget
+}
+
+func (r *fakeBudgetRepo) GetByUserID(userID uuid.UUID) ([]models.Budget, error) {
+	var budgets []models.Budget
+	for _, budget := range r.budgets {
+		if budget.UserID == userID {
+			budgets = append(budgets, budget)
+		}
+	}
+	return budgets, nil
+}
+
+func TestBudgetGetStatus(t *testing.T) {
+	userID := uuid.New()
+	food := models.Category{ID: uuid.New(), UserID: userID, Name: "Makan"}
+	groceries := models.Category{ID: uuid.New(), UserID: userID, Name: "Belanja", ParentID: &food.ID}
+	transport := models.Category{ID: uuid.New(), UserID: userID, Name: "Transport"}
+	spending := &fakeSpendingRepo{spending: map[string][]repository.CategorySpending{
+		"2026-03": {
+			{CategoryID: food.ID, Total: 100000},
+			{CategoryID: groceries.ID, Total: 200000},
+			{CategoryID: transport.ID, Total: 50000},
+		},
+	}}
+
+	tests := []struct {
+		name          string
+		budget        models.Budget
+		wantBudgeted  int64
+		wantSpent     int64
+		wantRemaining int64
+		wantPercent   float64
+	}{
+		{
+			name:          "category budget includes its children",
+			budget:        models.Budget{CategoryID: &food.ID, Amount: 400000},
+			wantBudgeted:  400000,
+			wantSpent:     300000,
+			wantRemaining: 100000,
+			wantPercent:   75,
+		},
+		{
+			name:          "child category budget leaves out its parent",
+			budget:        models.Budget{CategoryID: &groceries.ID, Amount: 400000},
+			wantBudgeted:  400000,
+			wantSpent:     200000,
+			wantRemaining: 200000,
+			wantPercent:   50,
+		},
+		{
+			name:          "total budget includes every category",
+			budget:        models.Budget{Amount: 280000},
+			wantBudgeted:  280000,
+			wantSpent:     350000,
+			wantRemaining: -70000,
+			wantPercent:   125,
+		},
+		{
+			name: "override replaces the amount for its month",
+			budget: models.Budget{CategoryID: &food.ID, Amount: 400000, Overrides: []models.BudgetOverride{
+				{Month: 2, Year: 2026, Amount: 100000},
+				{Month: 3, Year: 2026, Amount: 250000},
+			}},
+			wantBudgeted:  250000,
+			wantSpent:     300000,
+			wantRemaining: -50000,
+			wantPercent:   120,
+		},
+		{
+			name:          "nothing budgeted has no percentage",
+			budget:        models.Budget{CategoryID: &transport.ID},
+			wantSpent:     50000,
+			wantRemaining: -50000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			budget := tt.budget
+			budget.ID = uuid.New()
+			budget.UserID = userID
+			service := NewBudgetService(
+				&fakeBudgetRepo{budgets: []models.Budget{budget}},
+				&fakeCategoryRepo{categories: []models.Category{food, groceries, transport}},
+				spending,
+			)
+
+			report, err := service.GetStatus(userID, 3, 2026)
+			if err != nil {
+				t.Fatalf("GetStatus: %v", err)
+			}
+			if report.DaysInMonth != 31 || report.DaysElapsed != 31 {
+				t.Errorf("days = %d of %d, want 31 of 31", report.DaysElapsed, report.DaysInMonth)
+			}
+			if len(report.Items) != 1 {
+				t.Fatalf("got %d items, want 1", len(report.Items))
+			}
+			item := report.Items[0]
+			if item.Budgeted != tt.wantBudgeted {
+				t.Errorf("budgeted = %d, want %d", item.Budgeted, tt.wantBudgeted)
+			}
+			if item.Spent != tt.wantSpent {
+				t.Errorf("spent = %d, want %d", item.Spent, tt.wantSpent)
+			}
+			if item.Remaining != tt.wantRemaining {
+				t.Errorf("remaining = %d, want %d", item.Remaining, tt.wantRemaining)
+			}
+			if math.Abs(item.Percentage-tt.wantPercent) > 0.01 {
+				t.Errorf("percentage = %.2f, want %.2f", item.Percentage, tt.wantPercent)
+			}
+			// A past month projects what was actually spent
+			if item.ProjectedSpend != tt.wantSpent {
+				t.Errorf("projected spend = %d, want %d", item.ProjectedSpend, tt.wantSpent)
+			}
+		})
+	}
+}
+
+func TestBudgetGetStatusInvalidMonth(t *testing.T) {
+	service := NewBudgetService(&fakeBudgetRepo{}, &fakeCategoryRepo{}, &fakeSpendingRepo{})
+	if _, err := service.GetStatus(uuid.New(), 13, 2026); !errContains(err, "month must be between 1 and 12") {
+		t.Errorf("GetStatus error = %v", err)
+	}
+}
+
+func TestFormatAlertThresholds(t *testing.T) {
+	tests := []struct {
+		name       string
+		thresholds []int
+		want       string
+		wantErr    string
+	}{
+		{name: "sorted without duplicates", thresholds: []int{100, 80, 100, 50}, want: "50,80,100"},
+		{name: "no alerts", thresholds: []int{}, want: ""},
+		{name: "over budget alert", thresholds: []int{150}, want: "150"},
+		{name: "zero percent", thresholds: []int{0, 80}, wantErr: "between 1 and 1000 percent"},
+		{name: "too high", thresholds: []int{1001}, wantErr: "between 1 and 1000 percent"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatAlertThresholds(tt.thresholds)
+			if !errContains(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("formatAlertThresholds = %q, want %q", got, tt.want)
+			}
+			if tt.wantErr == "" {
+				budget := models.Budget{AlertThresholds: got}
+				if formatted, _ := formatAlertThresholds(budget.Thresholds()); formatted != got {
+					t.Errorf("Thresholds round trip = %q, want %q", formatted, got)
+				}
+			}
+		})
+	}
+}
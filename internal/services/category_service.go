@@ -21,7 +21,16 @@ func NewCategoryService(categoryRepo repository.CategoryRepository, accountServi
 	}
 }
 
-func (s *CategoryService) Create(userID uuid.UUID, name string) (*models.Category, error) {
+// CategoryGrouping controls whether category reports use leaf categories or
+// roll child categories up into their top-level parent
+type CategoryGrouping string
+
+const (
+	CategoryGroupingLeaf   CategoryGrouping = "LEAF"
+	CategoryGroupingRollup CategoryGrouping = "ROLLUP"
+)
+
+func (s *CategoryService) Create(userID uuid.UUID, name string, parentID *uuid.UUID) (*models.Category, error) {
 	if name == "" {
 		return nil, errors.New("category name is required")
 	}
@@ -32,6 +41,13 @@ func (s *CategoryService) Create(userID uuid.UUID, name string) (*models.Categor
 		Name:   name,
 	}
 
+	if parentID != nil {
+		if err := s.validateParent(category, *parentID); err != nil {
+			return nil, err
+		}
+		category.ParentID = parentID
+	}
+
 	if err := s.categoryRepo.Create(category); err != nil {
 		return nil, err
 	}
@@ -52,7 +68,16 @@ func (s *CategoryService) GetByUserID(userID uuid.UUID) ([]models.Category, erro
 	return s.categoryRepo.GetByUserIDWithStats(userID)
 }
 
-func (s *CategoryService) Update(id uuid.UUID, name string) (*models.Category, error) {
+// GetRootMap maps every category of the user to its top-level ancestor
+func (s *CategoryService) GetRootMap(userID uuid.UUID) (map[uuid.UUID]models.Category, error) {
+	categories, err := s.categoryRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return rootCategoryMap(categories), nil
+}
+
+func (s *CategoryService) Update(id uuid.UUID, name string, parentID *uuid.UUID, clearParent bool) (*models.Category, error) {
 	category, err := s.categoryRepo.GetByID(id)
 	if err != nil {
 		return nil, err
@@ -64,6 +89,15 @@ func (s *CategoryService) Update(id uuid.UUID, name string) (*models.Category, e
 
 	category.Name = name
 
+	if clearParent {
+		category.ParentID = nil
+	} else if parentID != nil {
+		if err := s.validateParent(category, *parentID); err != nil {
+			return nil, err
+		}
+		category.ParentID = parentID
+	}
+
 	if err := s.categoryRepo.Update(category); err != nil {
 		return nil, err
 	}
@@ -71,10 +105,138 @@ func (s *CategoryService) Update(id uuid.UUID, name string) (*models.Category, e
 	return category, nil
 }
 
+// Delete removes the category. Its children move up to the deleted category's
+// parent (or become top-level) and keep their own ledger accounts.
 func (s *CategoryService) Delete(id uuid.UUID) error {
+	category, err := s.categoryRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	// Late fees need a category to be posted in
+	hasRules, err := s.categoryRepo.HasLateFeeRules(id)
+	if err != nil {
+		return err
+	}
+	if hasRules {
+		return errors.New("category is used by a late fee rule, move the rule to another category first")
+	}
+
+	if err := s.categoryRepo.ReparentChildren(id, category.ParentID); err != nil {
+		return err
+	}
+
 	// Delete linked account first
 	if err := s.accountService.DeleteAccountByReference(id, "category"); err != nil {
 		return err
 	}
 	return s.categoryRepo.Delete(id)
 }
+
+// Merge consolidates the source categories into the target. Expenses, template
+// items and ledger history move to the target; the sources are deleted.
+func (s *CategoryService) Merge(userID uuid.UUID, sourceIDs []uuid.UUID, targetID uuid.UUID) (*models.Category, error) {
+	target, err := s.categoryRepo.GetByID(targetID)
+	if err != nil || target.UserID != userID {
+		return nil, errors.New("target category not found")
+	}
+
+	sources := make(map[uuid.UUID]bool)
+	for _, id := range sourceIDs {
+		if id == targetID {
+			return nil, errors.New("target category cannot be one of the sources")
+		}
+		source, err := s.categoryRepo.GetByID(id)
+		if err != nil || source.UserID != userID {
+			return nil, errors.New("source category not found")
+		}
+		sources[id] = true
+	}
+	if len(sources) == 0 {
+		return nil, errors.New("at least one source category is required")
+	}
+
+	// If the target sits below a source, attach it to the nearest surviving ancestor
+	for target.ParentID != nil && sources[*target.ParentID] {
+		parent, err := s.categoryRepo.GetByID(*target.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		target.ParentID = parent.ParentID
+	}
+
+	ids := make([]uuid.UUID, 0, len(sources))
+	for id := range sources {
+		ids = append(ids, id)
+	}
+	if err := s.categoryRepo.Merge(ids, target); err != nil {
+		return nil, err
+	}
+
+	return s.categoryRepo.GetByID(targetID)
+}
+
+func (s *CategoryService) validateParent(category *models.Category, parentID uuid.UUID) error {
+	parent, err := s.categoryRepo.GetByID(parentID)
+	if err != nil || parent.UserID != category.UserID {
+		return errors.New("parent category not found")
+	}
+
+	// Walk up the ancestors to prevent cycles
+	for current := parent; current != nil; {
+		if current.ID == category.ID {
+			return errors.New("category cannot be its own ancestor")
+		}
+		if current.ParentID == nil {
+			break
+		}
+		current, err = s.categoryRepo.GetByID(*current.ParentID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadCategoryRoots returns the top-level ancestor of every category when
+// rolling up, or nil when reporting leaf categories
+func loadCategoryRoots(repo repository.CategoryRepository, userID uuid.UUID, grouping CategoryGrouping) (map[uuid.UUID]models.Category, error) {
+	if grouping != CategoryGroupingRollup {
+		return nil, nil
+	}
+	categories, err := repo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return rootCategoryMap(categories), nil
+}
+
+// reportCategory returns the category an amount is reported under
+func reportCategory(roots map[uuid.UUID]models.Category, category models.Category) models.Category {
+	if root, ok := roots[category.ID]; ok {
+		return root
+	}
+	return category
+}
+
+// rootCategoryMap maps every category to its top-level ancestor
+func rootCategoryMap(categories []models.Category) map[uuid.UUID]models.Category {
+	byID := make(map[uuid.UUID]models.Category, len(categories))
+	for _, c := range categories {
+		byID[c.ID] = c
+	}
+
+	roots := make(map[uuid.UUID]models.Category, len(categories))
+	for _, c := range categories {
+		root := c
+		for i := 0; root.ParentID != nil && i < len(categories); i++ {
+			parent, ok := byID[*root.ParentID]
+			if !ok {
+				break
+			}
+			root = parent
+		}
+		roots[c.ID] = root
+	}
+	return roots
+}
@@ -0,0 +1,550 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+	"github.com/azzamdhx/moneybro/backend/internal/repository"
+)
+
+type ConsolidationService struct {
+	consolidationRepo  repository.DebtConsolidationRepository
+	installmentRepo    repository.InstallmentRepository
+	prepaymentRepo     repository.InstallmentPrepaymentRepository
+	debtRepo           repository.DebtRepository
+	debtPaymentRepo    repository.DebtPaymentRepository
+	accountRepo        repository.AccountRepository
+	installmentService *InstallmentService
+	debtService        *DebtService
+	ledgerService      *LedgerService
+}
+
+func NewConsolidationService(
+	consolidationRepo repository.DebtConsolidationRepository,
+	installmentRepo repository.InstallmentRepository,
+	prepaymentRepo repository.InstallmentPrepaymentRepository,
+	debtRepo repository.DebtRepository,
+	debtPaymentRepo repository.DebtPaymentRepository,
+	accountRepo repository.AccountRepository,
+	installmentService *InstallmentService,
+	debtService *DebtService,
+	ledgerService *LedgerService,
+) *ConsolidationService {
+	return &ConsolidationService{
+		consolidationRepo:  consolidationRepo,
+		installmentRepo:    installmentRepo,
+		prepaymentRepo:     prepaymentRepo,
+		debtRepo:           debtRepo,
+		debtPaymentRepo:    debtPaymentRepo,
+		accountRepo:        accountRepo,
+		installmentService: installmentService,
+		debtService:        debtService,
+		ledgerService:      ledgerService,
+	}
+}
+
+type ConsolidationInput struct {
+	DebtIDs        []uuid.UUID
+	InstallmentIDs []uuid.UUID
+	NewLoan        ConsolidationLoanInput
+}
+
+// ConsolidationLoanInput describes the loan that pays off the others. Date is
+// when the old loans are paid off; the first payment is due on DueDay in the
+// month of StartDate, by default the month after Date.
+type ConsolidationLoanInput struct {
+	Kind      PayoffLoanKind
+	Name      string
+	Date      time.Time
+	Tenor     int
+	DueDay    int
+	StartDate *time.Time
+	// MonthlyPayment is used by an installment without an InterestRate and
+	// defaults to the balance and fee spread over the tenor
+	MonthlyPayment *int64
+	InterestMethod models.InstallmentInterestMethod
+	// InterestRate is an annual percentage; for a debt it accrues monthly on
+	// the balance left
+	InterestRate *float64
+	// Fee is an origination fee added to the new loan and booked on
+	// FeeCategoryID
+	Fee           int64
+	FeeCategoryID *uuid.UUID
+	Notes         *string
+}
+
+// ConsolidatedLoan is one of the loans being paid off. PayoffAmount is carried
+// over to the new loan: the principal left on an installment or the amount
+// left to pay on a debt, including accrued interest. RemainingCost is what the
+// loan would still cost if it were kept.
+type ConsolidatedLoan struct {
+	ID             uuid.UUID
+	Kind           PayoffLoanKind
+	Name           string
+	PayoffAmount   int64
+	MonthlyPayment int64
+	RemainingCost  int64
+	EndDate        *time.Time
+}
+
+// ConsolidationPreview compares the loans being paid off with the new loan.
+// Principal is the payoff amount plus the fee; CostDifference is positive when
+// the new loan costs less in total.
+type ConsolidationPreview struct {
+	Loans             []ConsolidatedLoan
+	PayoffAmount      int64
+	Fee               int64
+	Principal         int64
+	OldMonthlyPayment int64
+	NewMonthlyPayment int64
+	OldTotalCost      int64
+	NewTotalCost      int64
+	CostDifference    int64
+	OldEndDate        *time.Time
+	NewEndDate        time.Time
+}
+
+// ConsolidationResult is a consolidation with the new loan and the loans it
+// paid off
+type ConsolidationResult struct {
+	Consolidation            *models.DebtConsolidation
+	Installment              *models.Installment
+	Debt                     *models.Debt
+	ConsolidatedInstallments []models.Installment
+	ConsolidatedDebts        []models.Debt
+}
+
+// consolidationPlan is a validated consolidation ready to be saved
+type consolidationPlan struct {
+	preview         *ConsolidationPreview
+	installments    []*models.Installment
+	debts           []*models.Debt
+	installmentLoan CreateInstallmentInput
+	debtLoan        CreateDebtInput
+}
+
+// Preview shows the old and new total cost without saving anything
+func (s *ConsolidationService) Preview(userID uuid.UUID, input ConsolidationInput) (*ConsolidationPreview, error) {
+	plan, err := s.plan(userID, input)
+	if err != nil {
+		return nil, err
+	}
+	return plan.preview, nil
+}
+
+// Consolidate creates the new loan and pays off the selected installments and
+// debts with it: DEBIT each old Liability Account, CREDIT the new one. The old
+// loans are completed and linked to the consolidation. The fee is booked as
+// DEBIT Expense Account, CREDIT the new Liability Account.
+func (s *ConsolidationService) Consolidate(userID uuid.UUID, input ConsolidationInput) (*ConsolidationResult, error) {
+	plan, err := s.plan(userID, input)
+	if err != nil {
+		return nil, err
+	}
+	loanInput := input.NewLoan
+
+	consolidation := &models.DebtConsolidation{
+		ID:            uuid.New(),
+		UserID:        userID,
+		Date:          loanInput.Date,
+		PayoffAmount:  plan.preview.PayoffAmount,
+		Fee:           plan.preview.Fee,
+		OldTotalCost:  plan.preview.OldTotalCost,
+		NewTotalCost:  plan.preview.NewTotalCost,
+		FeeCategoryID: loanInput.FeeCategoryID,
+	}
+
+	var newAccount *models.Account
+	if loanInput.Kind == PayoffLoanKindDebt {
+		debt, err := s.debtService.Create(userID, plan.debtLoan)
+		if err != nil {
+			return nil, err
+		}
+		consolidation.DebtID = &debt.ID
+		newAccount, err = s.accountRepo.GetByReference(debt.ID, "debt")
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		installment, err := s.installmentService.Create(userID, plan.installmentLoan)
+		if err != nil {
+			return nil, err
+		}
+		consolidation.InstallmentID = &installment.ID
+		newAccount, err = s.accountRepo.GetByReference(installment.ID, "installment")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.consolidationRepo.Create(consolidation); err != nil {
+		return nil, err
+	}
+
+	for _, installment := range plan.installments {
+		if err := s.payOffInstallment(consolidation, installment, newAccount); err != nil {
+			return nil, err
+		}
+	}
+	for _, debt := range plan.debts {
+		if err := s.payOffDebt(consolidation, debt, newAccount); err != nil {
+			return nil, err
+		}
+	}
+
+	if consolidation.Fee > 0 {
+		feeAccount, err := s.accountRepo.GetByReference(*consolidation.FeeCategoryID, "category")
+		if err != nil {
+			return nil, err
+		}
+		if err := s.createTransferLedgerEntry(userID, consolidation.Date, "Consolidation Fee: "+loanInput.Name,
+			feeAccount, newAccount, consolidation.Fee, consolidation.ID, "consolidation_fee"); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.GetByID(userID, consolidation.ID)
+}
+
+// GetByID returns one of the user's consolidations with its loans
+func (s *ConsolidationService) GetByID(userID, id uuid.UUID) (*ConsolidationResult, error) {
+	consolidation, err := s.consolidationRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if consolidation.UserID != userID {
+		return nil, errors.New("consolidation not found")
+	}
+
+	result := &ConsolidationResult{Consolidation: consolidation}
+	if consolidation.InstallmentID != nil {
+		if result.Installment, err = s.installmentRepo.GetByID(*consolidation.InstallmentID); err != nil {
+			return nil, err
+		}
+	}
+	if consolidation.DebtID != nil {
+		if result.Debt, err = s.debtRepo.GetByID(*consolidation.DebtID); err != nil {
+			return nil, err
+		}
+	}
+
+	installmentStatus := models.InstallmentStatusCompleted
+	installments, err := s.installmentRepo.GetByUserID(userID, &installmentStatus)
+	if err != nil {
+		return nil, err
+	}
+	for _, installment := range installments {
+		if installment.ConsolidationID != nil && *installment.ConsolidationID == id {
+			result.ConsolidatedInstallments = append(result.ConsolidatedInstallments, installment)
+		}
+	}
+	debtStatus := models.DebtStatusCompleted
+	debts, err := s.debtRepo.GetByUserID(userID, &debtStatus)
+	if err != nil {
+		return nil, err
+	}
+	for _, debt := range debts {
+		if debt.ConsolidationID != nil && *debt.ConsolidationID == id {
+			result.ConsolidatedDebts = append(result.ConsolidatedDebts, debt)
+		}
+	}
+	return result, nil
+}
+
+// plan validates the loans to pay off and works out the new loan
+func (s *ConsolidationService) plan(userID uuid.UUID, input ConsolidationInput) (*consolidationPlan, error) {
+	loanInput := input.NewLoan
+	if len(input.InstallmentIDs)+len(input.DebtIDs) == 0 {
+		return nil, errors.New("select at least one installment or debt")
+	}
+	if loanInput.Kind != PayoffLoanKindInstallment && loanInput.Kind != PayoffLoanKindDebt {
+		return nil, errors.New("invalid loan kind")
+	}
+	if loanInput.Name == "" {
+		return nil, errors.New("name is required")
+	}
+	if loanInput.Tenor <= 0 {
+		return nil, errors.New("tenor must be positive")
+	}
+	if loanInput.DueDay < 1 || loanInput.DueDay > 31 {
+		return nil, errors.New("due day must be between 1 and 31")
+	}
+	if loanInput.Fee < 0 {
+		return nil, errors.New("fee cannot be negative")
+	}
+	if loanInput.Fee > 0 {
+		if loanInput.FeeCategoryID == nil {
+			return nil, errors.New("category is required for a fee")
+		}
+		account, err := s.accountRepo.GetByReference(*loanInput.FeeCategoryID, "category")
+		if err != nil || account.UserID != userID {
+			return nil, errors.New("category not found")
+		}
+	}
+
+	start := time.Date(loanInput.Date.Year(), loanInput.Date.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 1, 0)
+	if loanInput.StartDate != nil {
+		start = *loanInput.StartDate
+	}
+
+	plan := &consolidationPlan{preview: &ConsolidationPreview{Loans: []ConsolidatedLoan{}, Fee: loanInput.Fee}}
+	seen := make(map[uuid.UUID]bool)
+	for _, id := range input.InstallmentIDs {
+		if seen[id] {
+			return nil, errors.New("a loan is selected more than once")
+		}
+		seen[id] = true
+
+		installment, err := s.installmentRepo.GetByID(id)
+		if err != nil || installment.UserID != userID {
+			return nil, errors.New("installment not found")
+		}
+		if installment.Status != models.InstallmentStatusActive {
+			return nil, errors.New("installment is not active")
+		}
+		loan := installmentPayoffLoan(installment)
+		if loan.Balance <= 0 {
+			return nil, errors.New("installment is already paid off")
+		}
+
+		consolidated := ConsolidatedLoan{
+			ID:             installment.ID,
+			Kind:           PayoffLoanKindInstallment,
+			Name:           installment.Name,
+			PayoffAmount:   loan.Balance,
+			MonthlyPayment: loan.MinimumPayment,
+			RemainingCost:  installment.RemainingAmount(),
+		}
+		consolidated.EndDate = scheduleEndDate(installment.AmortizationSchedule())
+		plan.preview.add(consolidated)
+		plan.installments = append(plan.installments, installment)
+	}
+	for _, id := range input.DebtIDs {
+		if seen[id] {
+			return nil, errors.New("a loan is selected more than once")
+		}
+		seen[id] = true
+
+		debt, err := s.debtRepo.GetByID(id)
+		if err != nil || debt.UserID != userID {
+			return nil, errors.New("debt not found")
+		}
+		if debt.IsReceivable() {
+			return nil, errors.New("money lent to others cannot be consolidated")
+		}
+		if debt.Status != models.DebtStatusActive {
+			return nil, errors.New("debt is not active")
+		}
+		loan := debtPayoffLoan(debt)
+		if loan.Balance <= 0 {
+			return nil, errors.New("debt is already paid off")
+		}
+
+		// Debts are run through the payoff simulation so the interest that
+		// would still accrue on them counts towards their cost
+		_, paidOff, err := runPayoff([]PayoffLoan{loan}, 0, start, false)
+		if err != nil {
+			return nil, err
+		}
+		plan.preview.add(ConsolidatedLoan{
+			ID:             debt.ID,
+			Kind:           PayoffLoanKindDebt,
+			Name:           debt.PersonName,
+			PayoffAmount:   loan.Balance,
+			MonthlyPayment: loan.MinimumPayment,
+			RemainingCost:  paidOff[0].TotalPaid,
+			EndDate:        paidOff[0].PayoffDate,
+		})
+		plan.debts = append(plan.debts, debt)
+	}
+
+	preview := plan.preview
+	preview.Principal = preview.PayoffAmount + preview.Fee
+	if loanInput.Kind == PayoffLoanKindDebt {
+		if err := plan.planDebtLoan(loanInput, start); err != nil {
+			return nil, err
+		}
+	} else if err := plan.planInstallmentLoan(loanInput, start); err != nil {
+		return nil, err
+	}
+	preview.CostDifference = preview.OldTotalCost - preview.NewTotalCost
+	return plan, nil
+}
+
+// add counts a loan being paid off towards the old totals
+func (p *ConsolidationPreview) add(loan ConsolidatedLoan) {
+	p.Loans = append(p.Loans, loan)
+	p.PayoffAmount += loan.PayoffAmount
+	p.OldMonthlyPayment += loan.MonthlyPayment
+	p.OldTotalCost += loan.RemainingCost
+	if loan.EndDate != nil && (p.OldEndDate == nil || loan.EndDate.After(*p.OldEndDate)) {
+		p.OldEndDate = loan.EndDate
+	}
+}
+
+// planInstallmentLoan works out the new installment the way CreateInstallment
+// would, from a principal of the payoff amount plus the fee
+func (p *consolidationPlan) planInstallmentLoan(loanInput ConsolidationLoanInput, start time.Time) error {
+	principal := p.preview.Principal
+	input := CreateInstallmentInput{
+		Name:           loanInput.Name,
+		ActualAmount:   principal,
+		LoanAmount:     principal,
+		MonthlyPayment: principal / int64(loanInput.Tenor),
+		Tenor:          loanInput.Tenor,
+		StartDate:      start,
+		DueDay:         loanInput.DueDay,
+		InterestMethod: loanInput.InterestMethod,
+		InterestRate:   loanInput.InterestRate,
+		Notes:          loanInput.Notes,
+	}
+	if loanInput.MonthlyPayment != nil && loanInput.InterestRate == nil {
+		input.MonthlyPayment = *loanInput.MonthlyPayment
+		input.LoanAmount = input.MonthlyPayment * int64(input.Tenor)
+		if input.LoanAmount < principal {
+			return errors.New("monthly payments do not cover the balance and fee")
+		}
+	}
+	if err := applyInterestRate(&input); err != nil {
+		return err
+	}
+
+	planned := models.Installment{
+		ActualAmount:   input.ActualAmount,
+		LoanAmount:     input.LoanAmount,
+		MonthlyPayment: input.MonthlyPayment,
+		Tenor:          input.Tenor,
+		StartDate:      input.StartDate,
+		DueDay:         input.DueDay,
+		InterestMethod: input.InterestMethod,
+		InterestRate:   input.InterestRate,
+	}
+	schedule := planned.AmortizationSchedule()
+	p.preview.NewMonthlyPayment = schedule[0].Payment
+	p.preview.NewTotalCost = input.LoanAmount
+	p.preview.NewEndDate = schedule[len(schedule)-1].DueDate
+	p.installmentLoan = input
+	return nil
+}
+
+// planDebtLoan works out the new installment debt. Its monthly payment is the
+// principal over the tenor, and any interest rate accrues on the balance left.
+func (p *consolidationPlan) planDebtLoan(loanInput ConsolidationLoanInput, start time.Time) error {
+	principal := p.preview.Principal
+	monthly := principal / int64(loanInput.Tenor)
+	dueDate := calculateDueDate(loanInput.DueDay, int(start.Month()), start.Year())
+	input := CreateDebtInput{
+		PersonName:        loanInput.Name,
+		ActualAmount:      principal,
+		PaymentType:       models.DebtPaymentTypeInstallment,
+		Direction:         models.DebtDirectionPayable,
+		MonthlyPayment:    &monthly,
+		Tenor:             &loanInput.Tenor,
+		DueDate:           &dueDate,
+		Notes:             loanInput.Notes,
+		InterestRate:      loanInput.InterestRate,
+		InterestStartDate: &loanInput.Date,
+	}
+	if err := validateDebtInterest(&input); err != nil {
+		return err
+	}
+
+	loan := PayoffLoan{Kind: PayoffLoanKindDebt, Balance: principal, MinimumPayment: monthly}
+	if input.InterestRate != nil {
+		loan.AnnualRate = *input.InterestRate
+	}
+	_, paidOff, err := runPayoff([]PayoffLoan{loan}, 0, start, false)
+	if err != nil {
+		return err
+	}
+	p.preview.NewMonthlyPayment = monthly
+	p.preview.NewTotalCost = paidOff[0].TotalPaid
+	p.preview.NewEndDate = calculateDueDate(loanInput.DueDay, int(paidOff[0].PayoffDate.Month()), paidOff[0].PayoffDate.Year())
+	p.debtLoan = input
+	return nil
+}
+
+// payOffInstallment prepays the principal left, which completes the installment
+func (s *ConsolidationService) payOffInstallment(consolidation *models.DebtConsolidation, installment *models.Installment, newAccount *models.Account) error {
+	result, prepayment, err := planInstallmentPrepayment(installment, PrepaymentInput{
+		ExtraAmount: installment.RemainingPrincipal(),
+		Date:        consolidation.Date,
+		Strategy:    models.PrepaymentStrategyReduceTenor,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.prepaymentRepo.Create(prepayment); err != nil {
+		return err
+	}
+
+	liabilityAccount, err := s.accountRepo.GetByReference(installment.ID, "installment")
+	if err != nil {
+		return err
+	}
+	if err := s.createTransferLedgerEntry(consolidation.UserID, consolidation.Date, "Consolidation Payoff: "+installment.Name,
+		liabilityAccount, newAccount, prepayment.Amount, prepayment.ID, "consolidation_payoff"); err != nil {
+		return err
+	}
+
+	paidOff := result.Installment
+	paidOff.Status = models.InstallmentStatusCompleted
+	paidOff.ConsolidationID = &consolidation.ID
+	return s.installmentRepo.Update(paidOff)
+}
+
+// payOffDebt records a payment of the amount left, which completes the debt
+func (s *ConsolidationService) payOffDebt(consolidation *models.DebtConsolidation, debt *models.Debt, newAccount *models.Account) error {
+	lastNumber, err := s.debtPaymentRepo.GetLastPaymentNumber(debt.ID)
+	if err != nil {
+		return err
+	}
+
+	payment := &models.DebtPayment{
+		ID:            uuid.New(),
+		DebtID:        debt.ID,
+		PaymentNumber: lastNumber + 1,
+		Amount:        debt.RemainingAmount(),
+		PaidAt:        consolidation.Date,
+	}
+	if err := s.debtPaymentRepo.Create(payment); err != nil {
+		return err
+	}
+
+	liabilityAccount, err := s.accountRepo.GetByReference(debt.ID, "debt")
+	if err != nil {
+		return err
+	}
+	if err := s.createTransferLedgerEntry(consolidation.UserID, consolidation.Date, "Consolidation Payoff: "+debt.PersonName,
+		liabilityAccount, newAccount, payment.Amount, payment.ID, "consolidation_payoff"); err != nil {
+		return err
+	}
+
+	debt.Payments = append(debt.Payments, *payment)
+	debt.Status = models.DebtStatusCompleted
+	debt.ConsolidationID = &consolidation.ID
+	return s.debtRepo.Update(debt)
+}
+
+// createTransferLedgerEntry moves an amount between two accounts: DEBIT from,
+// CREDIT to
+func (s *ConsolidationService) createTransferLedgerEntry(userID uuid.UUID, date time.Time, description string, from, to *models.Account, amount int64, referenceID uuid.UUID, referenceType string) error {
+	entries := []LedgerEntry{
+		{AccountID: from.ID, Debit: amount, Credit: 0},
+		{AccountID: to.ID, Debit: 0, Credit: amount},
+	}
+
+	_, err := s.ledgerService.CreateJournalEntry(
+		userID,
+		date,
+		description,
+		entries,
+		&referenceID,
+		referenceType,
+	)
+	return err
+}
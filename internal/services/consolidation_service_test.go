@@ -0,0 +1,464 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+	"github.com/azzamdhx/moneybro/backend/internal/repository"
+)
+
+type fakeInstallmentRepo struct {
+	repository.InstallmentRepository
+	installments map[uuid.UUID]models.Installment
+}
+
+func (r *fakeInstallmentRepo) Create(installment *models.Installment) error {
+	r.installments[installment.ID] = *installment
+	return nil
+}
+
+func (r *fakeInstallmentRepo) GetByID(id uuid.UUID) (*models.Installment, error) {
+	installment, ok := r.installments[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &installment, nil
+}
+
+func (r *fakeInstallmentRepo) GetByUserID(userID uuid.UUID, status *models.InstallmentStatus) ([]models.Installment, error) {
+	var installments []models.Installment
+	for _, installment := range r.installments {
+		if installment.UserID == userID && (status == nil || installment.Status == *status) {
+			installments = append(installments, installment)
+		}
+	}
+	return installments, nil
+}
+
+func (r *fakeInstallmentRepo) Update(installment *models.Installment) error {
+	r.installments[installment.ID] = *installment
+	return nil
+}
+
+func (r *fakeInstallmentRepo) Delete(id uuid.UUID) error {
+	delete(r.installments, id)
+	return nil
+}
+
+type fakeInstallmentPrepaymentRepo struct {
+	repository.InstallmentPrepaymentRepository
+}
+
+func (r *fakeInstallmentPrepaymentRepo) Create(prepayment *models.InstallmentPrepayment) error {
+	return nil
+}
+
+// fakeDebtRepo loads a debt's payments from payments when it is set, as the
+// repository preloads them
+type fakeDebtRepo struct {
+	repository.DebtRepository
+	debts    map[uuid.UUID]models.Debt
+	payments *fakeDebtPaymentRepo
+}
+
+func (r *fakeDebtRepo) Create(debt *models.Debt) error {
+	r.debts[debt.ID] = *debt
+	return nil
+}
+
+func (r *fakeDebtRepo) GetByID(id uuid.UUID) (*models.Debt, error) {
+	debt, ok := r.debts[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	if r.payments != nil {
+		debt.Payments, _ = r.payments.GetByDebtID(id)
+	}
+	return &debt, nil
+}
+
+func (r *fakeDebtRepo) GetByUserID(userID uuid.UUID, status *models.DebtStatus) ([]models.Debt, error) {
+	var debts []models.Debt
+	for _, debt := range r.debts {
+		if debt.UserID == userID && (status == nil || debt.Status == *status) {
+			debts = append(debts, debt)
+		}
+	}
+	return debts, nil
+}
+
+func (r *fakeDebtRepo) Update(debt *models.Debt) error {
+	r.debts[debt.ID] = *debt
+	return nil
+}
+
+func (r *fakeDebtRepo) Delete(id uuid.UUID) error {
+	delete(r.debts, id)
+	return nil
+}
+
+// fakeDebtPaymentRepo preloads a payment's debt from debts when it is set
+type fakeDebtPaymentRepo struct {
+	repository.DebtPaymentRepository
+	payments []models.DebtPayment
+	debts    map[uuid.UUID]models.Debt
+}
+
+func (r *fakeDebtPaymentRepo) Create(payment *models.DebtPayment) error {
+	r.payments = append(r.payments, *payment)
+	return nil
+}
+
+func (r *fakeDebtPaymentRepo) GetByID(id uuid.UUID) (*models.DebtPayment, error) {
+	for _, payment := range r.payments {
+		if payment.ID == id {
+			if debt, ok := r.debts[payment.DebtID]; ok {
+				payment.Debt = &debt
+			}
+			return &payment, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeDebtPaymentRepo) Update(payment *models.DebtPayment) error {
+	for i := range r.payments {
+		if r.payments[i].ID == payment.ID {
+			r.payments[i] = *payment
+			r.payments[i].Debt = nil
+		}
+	}
+	return nil
+}
+
+func (r *fakeDebtPaymentRepo) Delete(id uuid.UUID) error {
+	for i := range r.payments {
+		if r.payments[i].ID == id {
+			r.payments = append(r.payments[:i], r.payments[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (r *fakeDebtPaymentRepo) GetByDebtID(debtID uuid.UUID) ([]models.DebtPayment, error) {
+	var payments []models.DebtPayment
+	for _, payment := range r.payments {
+		if payment.DebtID == debtID {
+			payments = append(payments, payment)
+		}
+	}
+	return payments, nil
+}
+
+func (r *fakeDebtPaymentRepo) GetLastPaymentNumber(debtID uuid.UUID) (int, error) {
+	var last int
+	for _, payment := range r.payments {
+		if payment.DebtID == debtID {
+			last = max(last, payment.PaymentNumber)
+		}
+	}
+	return last, nil
+}
+
+type fakeConsolidationRepo struct {
+	repository.DebtConsolidationRepository
+	consolidations map[uuid.UUID]models.DebtConsolidation
+}
+
+func (r *fakeConsolidationRepo) Create(consolidation *models.DebtConsolidation) error {
+	r.consolidations[consolidation.ID] = *consolidation
+	return nil
+}
+
+func (r *fakeConsolidationRepo) GetByID(id uuid.UUID) (*models.DebtConsolidation, error) {
+	consolidation, ok := r.consolidations[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &consolidation, nil
+}
+
+func (r *fakeConsolidationRepo) GetByLoanID(loanID uuid.UUID) (*models.DebtConsolidation, error) {
+	for _, consolidation := range r.consolidations {
+		if consolidation.InstallmentID != nil && *consolidation.InstallmentID == loanID ||
+			consolidation.DebtID != nil && *consolidation.DebtID == loanID {
+			return &consolidation, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+type fakePayeeRepo struct {
+	repository.PayeeRepository
+}
+
+func (r *fakePayeeRepo) FindByName(userID uuid.UUID, name string) (*models.Payee, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+// consolidationTest has an installment with 900.000 of principal left after 3
+// of 12 payments, a debt of 500.000 and a fee category. Their linked accounts
+// start at 0, as they are opened in production.
+type consolidationTest struct {
+	service            *ConsolidationService
+	installmentService *InstallmentService
+	debtService        *DebtService
+	accountService     *AccountService
+	ledger             *LedgerService
+	store              *memoryStore
+	accounts           *fakeAccountRepo
+	installments       *fakeInstallmentRepo
+	debts              *fakeDebtRepo
+	userID             uuid.UUID
+	installmentID      uuid.UUID
+	debtID             uuid.UUID
+	feeCategoryID      uuid.UUID
+}
+
+func newConsolidationTest(t *testing.T) *consolidationTest {
+	t.Helper()
+	ledger, accounts, store := newTestLedger(t)
+	accountService := NewAccountService(accounts)
+	consolidationRepo := &fakeConsolidationRepo{consolidations: make(map[uuid.UUID]models.DebtConsolidation)}
+	installments := &fakeInstallmentRepo{installments: make(map[uuid.UUID]models.Installment)}
+	prepayments := &fakeInstallmentPrepaymentRepo{}
+	debts := &fakeDebtRepo{debts: make(map[uuid.UUID]models.Debt)}
+	debtPayments := &fakeDebtPaymentRepo{}
+
+	installmentService := NewInstallmentService(installments, nil, prepayments, nil, consolidationRepo, accounts, accountService, ledger, nil)
+	debtService := NewDebtService(debts, debtPayments, nil, consolidationRepo, accounts, &fakePayeeRepo{}, accountService, ledger)
+
+	ct := &consolidationTest{
+		service:            NewConsolidationService(consolidationRepo, installments, prepayments, debts, debtPayments, accounts, installmentService, debtService, ledger),
+		installmentService: installmentService,
+		debtService:        debtService,
+		accountService:     accountService,
+		ledger:             ledger,
+		store:              store,
+		accounts:           accounts,
+		installments:       installments,
+		debts:              debts,
+		userID:             uuid.New(),
+		feeCategoryID:      uuid.New(),
+	}
+
+	installment := models.Installment{
+		ID:             uuid.New(),
+		UserID:         ct.userID,
+		Name:           "Laptop",
+		ActualAmount:   1200000,
+		LoanAmount:     1200000,
+		MonthlyPayment: 100000,
+		Tenor:          12,
+		StartDate:      time.Date(2026, time.January, 10, 0, 0, 0, 0, time.UTC),
+		DueDay:         10,
+		InterestMethod: models.InstallmentInterestMethodFlat,
+		Status:         models.InstallmentStatusActive,
+	}
+	for n := 1; n <= 3; n++ {
+		installment.Payments = append(installment.Payments, models.InstallmentPayment{
+			ID:            uuid.New(),
+			InstallmentID: installment.ID,
+			PaymentNumber: n,
+			Amount:        100000,
+			PaidAt:        installment.DueDateFor(n),
+		})
+	}
+	installments.installments[installment.ID] = installment
+	ct.installmentID = installment.ID
+	ct.linkedAccount(t, installment.Name, installment.ID, "installment", models.AccountTypeLiability)
+
+	dueDate := time.Date(2026, time.December, 1, 0, 0, 0, 0, time.UTC)
+	debt := models.Debt{
+		ID:           uuid.New(),
+		UserID:       ct.userID,
+		PersonName:   "Budi",
+		ActualAmount: 500000,
+		PaymentType:  models.DebtPaymentTypeOneTime,
+		Direction:    models.DebtDirectionPayable,
+		DueDate:      &dueDate,
+		Status:       models.DebtStatusActive,
+	}
+	debts.debts[debt.ID] = debt
+	ct.debtID = debt.ID
+	ct.linkedAccount(t, debt.PersonName, debt.ID, "debt", models.AccountTypeLiability)
+
+	ct.linkedAccount(t, "Biaya Admin", ct.feeCategoryID, "category", models.AccountTypeExpense)
+	return ct
+}
+
+func (ct *consolidationTest) linkedAccount(t *testing.T, name string, referenceID uuid.UUID, referenceType string, accountType models.AccountType) {
+	t.Helper()
+	if _, err := ct.accountService.CreateLinkedAccount(ct.userID, name, accountType, referenceID, referenceType); err != nil {
+		t.Fatalf("create %s account: %v", referenceType, err)
+	}
+}
+
+func (ct *consolidationTest) balance(t *testing.T, referenceID uuid.UUID, referenceType string) int64 {
+	t.Helper()
+	account, err := ct.accounts.GetByReference(referenceID, referenceType)
+	if err != nil {
+		t.Fatalf("get %s account: %v", referenceType, err)
+	}
+	return ct.store.balance(account.ID)
+}
+
+func TestConsolidate(t *testing.T) {
+	tests := []struct {
+		name          string
+		kind          PayoffLoanKind
+		fee           int64
+		wantPayoff    int64
+		wantPrincipal int64
+	}{
+		{name: "into an installment", kind: PayoffLoanKindInstallment, wantPayoff: 1400000, wantPrincipal: 1400000},
+		{name: "into an installment with a fee", kind: PayoffLoanKindInstallment, fee: 50000, wantPayoff: 1400000, wantPrincipal: 1450000},
+		{name: "into a debt", kind: PayoffLoanKindDebt, wantPayoff: 1400000, wantPrincipal: 1400000},
+		{name: "into a debt with a fee", kind: PayoffLoanKindDebt, fee: 50000, wantPayoff: 1400000, wantPrincipal: 1450000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ct := newConsolidationTest(t)
+			input := ConsolidationInput{
+				InstallmentIDs: []uuid.UUID{ct.installmentID},
+				DebtIDs:        []uuid.UUID{ct.debtID},
+				NewLoan: ConsolidationLoanInput{
+					Kind:   tt.kind,
+					Name:   "Pinjaman Konsolidasi",
+					Date:   time.Date(2026, time.April, 15, 0, 0, 0, 0, time.UTC),
+					Tenor:  10,
+					DueDay: 5,
+					Fee:    tt.fee,
+				},
+			}
+			if tt.fee > 0 {
+				input.NewLoan.FeeCategoryID = &ct.feeCategoryID
+			}
+
+			result, err := ct.service.Consolidate(ct.userID, input)
+			if err != nil {
+				t.Fatalf("Consolidate: %v", err)
+			}
+			consolidation := result.Consolidation
+			if consolidation.PayoffAmount != tt.wantPayoff {
+				t.Errorf("payoff amount = %d, want %d", consolidation.PayoffAmount, tt.wantPayoff)
+			}
+
+			// The new loan is opened for the payoff plus the fee
+			var newLoanID uuid.UUID
+			var newLoanType string
+			if tt.kind == PayoffLoanKindDebt {
+				if result.Debt == nil || result.Debt.ActualAmount != tt.wantPrincipal {
+					t.Fatalf("new debt = %+v, want an amount of %d", result.Debt, tt.wantPrincipal)
+				}
+				newLoanID, newLoanType = result.Debt.ID, "debt"
+			} else {
+				if result.Installment == nil || result.Installment.ActualAmount != tt.wantPrincipal {
+					t.Fatalf("new installment = %+v, want an amount of %d", result.Installment, tt.wantPrincipal)
+				}
+				newLoanID, newLoanType = result.Installment.ID, "installment"
+			}
+			if got := ct.balance(t, newLoanID, newLoanType); got != tt.wantPrincipal {
+				t.Errorf("new loan balance = %d, want %d", got, tt.wantPrincipal)
+			}
+			if got := ct.balance(t, ct.feeCategoryID, "category"); got != tt.fee {
+				t.Errorf("fee category balance = %d, want %d", got, tt.fee)
+			}
+
+			// The old loans are paid off and point at the consolidation
+			if len(result.ConsolidatedInstallments) != 1 || len(result.ConsolidatedDebts) != 1 {
+				t.Fatalf("consolidated %d installments and %d debts, want 1 and 1", len(result.ConsolidatedInstallments), len(result.ConsolidatedDebts))
+			}
+			installment := result.ConsolidatedInstallments[0]
+			if installment.Status != models.InstallmentStatusCompleted || installment.RemainingPrincipal() != 0 {
+				t.Errorf("old installment status = %s with %d principal left", installment.Status, installment.RemainingPrincipal())
+			}
+			debt := result.ConsolidatedDebts[0]
+			if debt.Status != models.DebtStatusCompleted || debt.RemainingAmount() != 0 {
+				t.Errorf("old debt status = %s with %d left", debt.Status, debt.RemainingAmount())
+			}
+			// The payoff debits what was left of each old loan
+			if got := ct.balance(t, ct.installmentID, "installment"); got != -900000 {
+				t.Errorf("old installment balance = %d, want -900000", got)
+			}
+			if got := ct.balance(t, ct.debtID, "debt"); got != -500000 {
+				t.Errorf("old debt balance = %d, want -500000", got)
+			}
+			if got := ct.store.countByReference("consolidation_payoff"); got != 2 {
+				t.Errorf("payoff journal entries = %d, want 2", got)
+			}
+		})
+	}
+}
+
+func TestDeleteConsolidatedLoans(t *testing.T) {
+	tests := []struct {
+		name    string
+		delete  func(ct *consolidationTest, result *ConsolidationResult) error
+		wantErr string
+	}{
+		{
+			name: "installment paid off by the consolidation",
+			delete: func(ct *consolidationTest, result *ConsolidationResult) error {
+				return ct.installmentService.Delete(ct.installmentID)
+			},
+			wantErr: "installment was paid off by a consolidation",
+		},
+		{
+			name: "debt paid off by the consolidation",
+			delete: func(ct *consolidationTest, result *ConsolidationResult) error {
+				return ct.debtService.Delete(ct.debtID)
+			},
+			wantErr: "debt was paid off by a consolidation",
+		},
+		{
+			name: "new loan of the consolidation",
+			delete: func(ct *consolidationTest, result *ConsolidationResult) error {
+				return ct.installmentService.Delete(result.Installment.ID)
+			},
+			wantErr: "installment pays off a consolidation",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ct := newConsolidationTest(t)
+			result, err := ct.service.Consolidate(ct.userID, ConsolidationInput{
+				InstallmentIDs: []uuid.UUID{ct.installmentID},
+				DebtIDs:        []uuid.UUID{ct.debtID},
+				NewLoan: ConsolidationLoanInput{
+					Kind:   PayoffLoanKindInstallment,
+					Name:   "Pinjaman Konsolidasi",
+					Date:   time.Date(2026, time.April, 15, 0, 0, 0, 0, time.UTC),
+					Tenor:  10,
+					DueDay: 5,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Consolidate: %v", err)
+			}
+
+			err = tt.delete(ct, result)
+			if !errContains(err, tt.wantErr) {
+				t.Fatalf("delete error = %v, want %q", err, tt.wantErr)
+			}
+
+			// Nothing was removed, so the ledger still balances
+			if len(ct.installments.installments) != 2 || len(ct.debts.debts) != 1 {
+				t.Errorf("left %d installments and %d debts, want 2 and 1", len(ct.installments.installments), len(ct.debts.debts))
+			}
+			if got := ct.store.countByReference("consolidation_payoff"); got != 2 {
+				t.Errorf("payoff journal entries = %d, want 2", got)
+			}
+			if got := ct.balance(t, result.Installment.ID, "installment"); got != 1400000 {
+				t.Errorf("new loan balance = %d, want 1400000", got)
+			}
+		})
+	}
+}
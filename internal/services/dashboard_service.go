@@ -16,13 +16,15 @@ type DashboardService struct {
 	repos         *repository.Repositories
 	redis         *redis.Client
 	ledgerService *LedgerService
+	budgetService *BudgetService
 }
 
-func NewDashboardService(repos *repository.Repositories, redis *redis.Client, ledgerService *LedgerService) *DashboardService {
+func NewDashboardService(repos *repository.Repositories, redis *redis.Client, ledgerService *LedgerService, budgetService *BudgetService) *DashboardService {
 	return &DashboardService{
 		repos:         repos,
 		redis:         redis,
 		ledgerService: ledgerService,
+		budgetService: budgetService,
 	}
 }
 
@@ -45,6 +47,7 @@ type BalanceSummary struct {
 
 type Dashboard struct {
 	TotalActiveDebt                   int64
+	TotalReceivable                   int64
 	TotalActiveInstallment            int64
 	TotalExpenseThisMonth             int64
 	TotalIncomeThisMonth              int64
@@ -53,6 +56,7 @@ type Dashboard struct {
 	ActiveSavingsGoals                []models.SavingsGoal
 	ExpensesByCategory                []CategorySummary
 	RecentExpenses                    []models.Expense
+	BudgetProgress                    []BudgetProgress
 }
 
 type CategorySummary struct {
@@ -61,7 +65,7 @@ type CategorySummary struct {
 	ExpenseCount int
 }
 
-func (s *DashboardService) GetDashboard(userID uuid.UUID) (*Dashboard, error) {
+func (s *DashboardService) GetDashboard(userID uuid.UUID, grouping CategoryGrouping) (*Dashboard, error) {
 	dashboard := &Dashboard{}
 
 	now := time.Now()
@@ -78,19 +82,24 @@ func (s *DashboardService) GetDashboard(userID uuid.UUID) (*Dashboard, error) {
 
 	var g errgroup.Group
 
-	// 1. Active debts
+	// 1. Active debts and money owed to the user
 	g.Go(func() error {
 		activeStatus := models.DebtStatusActive
 		debts, err := s.repos.Debt.GetByUserID(userID, &activeStatus)
 		if err != nil {
 			return err
 		}
-		var total int64
+		var total, receivable int64
 		for _, debt := range debts {
-			total += debt.RemainingAmount()
+			if debt.IsReceivable() {
+				receivable += debt.RemainingAmount()
+			} else {
+				total += debt.RemainingAmount()
+			}
 		}
 		mu.Lock()
 		dashboard.TotalActiveDebt = total
+		dashboard.TotalReceivable = receivable
 		mu.Unlock()
 		return nil
 	})
@@ -142,16 +151,24 @@ func (s *DashboardService) GetDashboard(userID uuid.UUID) (*Dashboard, error) {
 		if err != nil {
 			return err
 		}
+		roots, err := loadCategoryRoots(s.repos.Category, userID, grouping)
+		if err != nil {
+			return err
+		}
 		var totalExpense int64
 		categoryMap := make(map[uuid.UUID]*CategorySummary)
 		for _, exp := range expenses {
-			totalExpense += exp.Total()
+			// A purchase paid by an installment is counted through its payments
+			if exp.InstallmentID == nil {
+				totalExpense += exp.NetTotal()
+			}
 			if exp.Category != nil {
-				if _, exists := categoryMap[exp.CategoryID]; !exists {
-					categoryMap[exp.CategoryID] = &CategorySummary{Category: *exp.Category}
+				category := reportCategory(roots, *exp.Category)
+				if _, exists := categoryMap[category.ID]; !exists {
+					categoryMap[category.ID] = &CategorySummary{Category: category}
 				}
-				categoryMap[exp.CategoryID].TotalAmount += exp.Total()
-				categoryMap[exp.CategoryID].ExpenseCount++
+				categoryMap[category.ID].TotalAmount += exp.NetTotal()
+				categoryMap[category.ID].ExpenseCount++
 			}
 		}
 		var byCategory []CategorySummary
@@ -217,6 +234,18 @@ func (s *DashboardService) GetDashboard(userID uuid.UUID) (*Dashboard, error) {
 		return nil
 	})
 
+	// 10. Budget progress this month
+	g.Go(func() error {
+		status, err := s.budgetService.GetStatus(userID, int(now.Month()), now.Year())
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		dashboard.BudgetProgress = status.Items
+		mu.Unlock()
+		return nil
+	})
+
 	if err := g.Wait(); err != nil {
 		return nil, err
 	}
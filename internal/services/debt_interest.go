@@ -0,0 +1,181 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+)
+
+// validateDebtInterest checks the interest terms of a debt and defaults the
+// compounding period to MONTHLY when a rate is set
+func validateDebtInterest(input *CreateDebtInput) error {
+	if input.InterestRate == nil {
+		input.InterestCompounding = nil
+		input.InterestStartDate = nil
+		return nil
+	}
+	if *input.InterestRate < 0 || *input.InterestRate > 999 {
+		return errors.New("interest rate must be between 0 and 999 percent")
+	}
+	if input.InterestCompounding == nil {
+		compounding := models.DebtInterestCompoundingMonthly
+		input.InterestCompounding = &compounding
+	}
+	if !input.InterestCompounding.IsValid() {
+		return errors.New("invalid interest compounding")
+	}
+	return nil
+}
+
+// AccrueInterest posts the interest of every month that ended since the last
+// accrual on all active debts with an interest rate. Errors are logged per
+// debt so one debt doesn't hold up the others.
+func (s *DebtService) AccrueInterest(asOf time.Time) ([]models.DebtInterestAccrual, error) {
+	debts, err := s.debtRepo.GetWithInterest(models.DebtStatusActive)
+	if err != nil {
+		return nil, err
+	}
+
+	s.accrualMu.Lock()
+	defer s.accrualMu.Unlock()
+
+	var accruals []models.DebtInterestAccrual
+	for i := range debts {
+		debtAccruals, err := s.accrueDebtInterest(&debts[i], asOf)
+		accruals = append(accruals, debtAccruals...)
+		if err != nil {
+			log.Printf("Error accruing interest for debt %s: %v", debts[i].ID, err)
+		}
+	}
+	return accruals, nil
+}
+
+// AccrueInterestForUser runs the accrual for the user's debts now instead of
+// waiting for the monthly job
+func (s *DebtService) AccrueInterestForUser(userID uuid.UUID, asOf time.Time) ([]models.DebtInterestAccrual, error) {
+	status := models.DebtStatusActive
+	debts, err := s.debtRepo.GetByUserID(userID, &status)
+	if err != nil {
+		return nil, err
+	}
+
+	s.accrualMu.Lock()
+	defer s.accrualMu.Unlock()
+
+	var accruals []models.DebtInterestAccrual
+	for i := range debts {
+		if !debts[i].HasInterest() {
+			continue
+		}
+		debtAccruals, err := s.accrueDebtInterest(&debts[i], asOf)
+		accruals = append(accruals, debtAccruals...)
+		if err != nil {
+			return accruals, err
+		}
+	}
+	return accruals, nil
+}
+
+// GetInterestAccruals lists the interest accrued on one of the user's debts
+func (s *DebtService) GetInterestAccruals(userID, debtID uuid.UUID) ([]models.DebtInterestAccrual, error) {
+	if _, err := s.getOwned(userID, debtID); err != nil {
+		return nil, err
+	}
+	return s.accrualRepo.GetByDebtID(debtID)
+}
+
+// accrueDebtInterest accrues month by month from the day after the last
+// accrual, or from the interest start date, up to the end of the month before
+// asOf. A month without interest is still recorded so it isn't accrued again.
+func (s *DebtService) accrueDebtInterest(debt *models.Debt, asOf time.Time) ([]models.DebtInterestAccrual, error) {
+	start := debt.InterestSince()
+	if last := debt.LastAccrual(); last != nil {
+		start = time.Date(last.PeriodEnd.Year(), last.PeriodEnd.Month(), last.PeriodEnd.Day()+1, 0, 0, 0, 0, time.UTC)
+	}
+	currentMonth := time.Date(asOf.Year(), asOf.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	var accruals []models.DebtInterestAccrual
+	for start.Before(currentMonth) {
+		end := time.Date(start.Year(), start.Month()+1, 0, 0, 0, 0, 0, time.UTC)
+		amount, balance := debt.AccrueInterest(start, end)
+
+		accrual := models.DebtInterestAccrual{
+			ID:          uuid.New(),
+			DebtID:      debt.ID,
+			Period:      monthKey(start),
+			PeriodStart: start,
+			PeriodEnd:   end,
+			Balance:     balance,
+			Amount:      amount,
+		}
+		if amount > 0 {
+			if err := s.createInterestLedgerEntry(debt, &accrual); err != nil {
+				return accruals, err
+			}
+		}
+		if err := s.accrualRepo.Create(&accrual); err != nil {
+			return accruals, err
+		}
+
+		debt.InterestAccruals = append(debt.InterestAccruals, accrual)
+		accruals = append(accruals, accrual)
+		start = end.AddDate(0, 0, 1)
+	}
+	return accruals, nil
+}
+
+// createInterestLedgerEntry posts accrued interest: DEBIT Interest Expense,
+// CREDIT Liability Account, or DEBIT Receivable, CREDIT Interest Income when
+// the money was lent by the user
+func (s *DebtService) createInterestLedgerEntry(debt *models.Debt, accrual *models.DebtInterestAccrual) error {
+	debtAccount, err := s.accountRepo.GetByReference(debt.ID, "debt")
+	if err != nil {
+		return err
+	}
+	interestAccount, err := s.interestAccount(debt)
+	if err != nil {
+		return err
+	}
+
+	entries := []LedgerEntry{
+		{AccountID: interestAccount.ID, Debit: accrual.Amount, Credit: 0},
+		{AccountID: debtAccount.ID, Debit: 0, Credit: accrual.Amount},
+	}
+	description := "Debt Interest: " + debt.PersonName
+	if debt.IsReceivable() {
+		entries = []LedgerEntry{
+			{AccountID: debtAccount.ID, Debit: accrual.Amount, Credit: 0},
+			{AccountID: interestAccount.ID, Debit: 0, Credit: accrual.Amount},
+		}
+		description = "Receivable Interest: " + debt.PersonName
+	}
+
+	_, err = s.ledgerService.CreateJournalEntry(
+		debt.UserID,
+		accrual.PeriodEnd,
+		description,
+		entries,
+		&accrual.ID,
+		"debt_interest",
+	)
+	return err
+}
+
+// interestAccount returns the EXPENSE account interest on a debt is booked
+// on, or the INCOME account for a receivable, creating it on first use
+func (s *DebtService) interestAccount(debt *models.Debt) (*models.Account, error) {
+	account, err := s.accountRepo.GetByReference(debt.ID, "debt_interest")
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return account, err
+	}
+
+	if debt.IsReceivable() {
+		return s.accountService.CreateLinkedAccount(debt.UserID, "Bunga Piutang: "+debt.PersonName, models.AccountTypeIncome, debt.ID, "debt_interest")
+	}
+	return s.accountService.CreateLinkedAccount(debt.UserID, "Bunga: "+debt.PersonName, models.AccountTypeExpense, debt.ID, "debt_interest")
+}
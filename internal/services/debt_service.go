@@ -2,35 +2,49 @@ package services
 
 import (
 	"errors"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 
 	"github.com/azzamdhx/moneybro/backend/internal/models"
 	"github.com/azzamdhx/moneybro/backend/internal/repository"
 )
 
 type DebtService struct {
-	debtRepo       repository.DebtRepository
-	paymentRepo    repository.DebtPaymentRepository
-	accountRepo    repository.AccountRepository
-	accountService *AccountService
-	ledgerService  *LedgerService
+	debtRepo          repository.DebtRepository
+	paymentRepo       repository.DebtPaymentRepository
+	accrualRepo       repository.DebtInterestAccrualRepository
+	consolidationRepo repository.DebtConsolidationRepository
+	accountRepo       repository.AccountRepository
+	payeeRepo         repository.PayeeRepository
+	accountService    *AccountService
+	ledgerService     *LedgerService
+	// accrualMu keeps the cron job and a manual run from accruing a month twice
+	accrualMu sync.Mutex
 }
 
 func NewDebtService(
 	debtRepo repository.DebtRepository,
 	paymentRepo repository.DebtPaymentRepository,
+	accrualRepo repository.DebtInterestAccrualRepository,
+	consolidationRepo repository.DebtConsolidationRepository,
 	accountRepo repository.AccountRepository,
+	payeeRepo repository.PayeeRepository,
 	accountService *AccountService,
 	ledgerService *LedgerService,
 ) *DebtService {
 	return &DebtService{
-		debtRepo:       debtRepo,
-		paymentRepo:    paymentRepo,
-		accountRepo:    accountRepo,
-		accountService: accountService,
-		ledgerService:  ledgerService,
+		debtRepo:          debtRepo,
+		paymentRepo:       paymentRepo,
+		accrualRepo:       accrualRepo,
+		consolidationRepo: consolidationRepo,
+		accountRepo:       accountRepo,
+		payeeRepo:         payeeRepo,
+		accountService:    accountService,
+		ledgerService:     ledgerService,
 	}
 }
 
@@ -39,12 +53,20 @@ type CreateDebtInput struct {
 	ActualAmount   int64
 	LoanAmount     *int64
 	PaymentType    models.DebtPaymentType
+	Direction      models.DebtDirection
 	MonthlyPayment *int64
 	Tenor          *int
 	DueDate        *time.Time
 	Icon           *string
 	CardBgColor    *string
 	Notes          *string
+	PayeeID        *uuid.UUID
+	// InterestRate is an annual percentage accrued monthly on the balance left
+	InterestRate        *float64
+	InterestCompounding *models.DebtInterestCompounding
+	// InterestStartDate defaults to the creation date, or to today when a rate
+	// is added to an existing debt
+	InterestStartDate *time.Time
 }
 
 func (s *DebtService) Create(userID uuid.UUID, input CreateDebtInput) (*models.Debt, error) {
@@ -54,6 +76,24 @@ func (s *DebtService) Create(userID uuid.UUID, input CreateDebtInput) (*models.D
 	if input.ActualAmount <= 0 {
 		return nil, errors.New("actual amount must be positive")
 	}
+	if input.Direction == "" {
+		input.Direction = models.DebtDirectionPayable
+	}
+	if !input.Direction.IsValid() {
+		return nil, errors.New("invalid debt direction")
+	}
+	if err := validateDebtInterest(&input); err != nil {
+		return nil, err
+	}
+
+	// Resolve payee: use provided or match by person name
+	payee, err := matchPayee(s.payeeRepo, userID, input.PayeeID, input.PersonName)
+	if err != nil {
+		return nil, err
+	}
+	if payee != nil {
+		input.PayeeID = &payee.ID
+	}
 
 	// Validate and calculate for INSTALLMENT payment type
 	if input.PaymentType == models.DebtPaymentTypeInstallment {
@@ -70,28 +110,37 @@ func (s *DebtService) Create(userID uuid.UUID, input CreateDebtInput) (*models.D
 	}
 
 	debt := &models.Debt{
-		ID:             uuid.New(),
-		UserID:         userID,
-		PersonName:     input.PersonName,
-		ActualAmount:   input.ActualAmount,
-		LoanAmount:     input.LoanAmount,
-		PaymentType:    input.PaymentType,
-		MonthlyPayment: input.MonthlyPayment,
-		Tenor:          input.Tenor,
-		DueDate:        input.DueDate,
-		Status:         models.DebtStatusActive,
-		Icon:           input.Icon,
-		CardBgColor:    input.CardBgColor,
-		Notes:          input.Notes,
+		ID:                  uuid.New(),
+		UserID:              userID,
+		PersonName:          input.PersonName,
+		ActualAmount:        input.ActualAmount,
+		LoanAmount:          input.LoanAmount,
+		PaymentType:         input.PaymentType,
+		Direction:           input.Direction,
+		MonthlyPayment:      input.MonthlyPayment,
+		Tenor:               input.Tenor,
+		DueDate:             input.DueDate,
+		Status:              models.DebtStatusActive,
+		Icon:                input.Icon,
+		CardBgColor:         input.CardBgColor,
+		Notes:               input.Notes,
+		PayeeID:             input.PayeeID,
+		InterestRate:        input.InterestRate,
+		InterestCompounding: input.InterestCompounding,
+		InterestStartDate:   input.InterestStartDate,
 	}
 
 	if err := s.debtRepo.Create(debt); err != nil {
 		return nil, err
 	}
 
-	// Create linked LIABILITY account for this debt
-	accountName := "Hutang: " + input.PersonName
-	if _, err := s.accountService.CreateLinkedAccount(userID, accountName, models.AccountTypeLiability, debt.ID, "debt"); err != nil {
+	// Create linked LIABILITY account for this debt, or an ASSET account for
+	// money owed to the user
+	accountName, accountType := "Hutang: "+input.PersonName, models.AccountTypeLiability
+	if debt.IsReceivable() {
+		accountName, accountType = "Piutang: "+input.PersonName, models.AccountTypeAsset
+	}
+	if _, err := s.accountService.CreateLinkedAccount(userID, accountName, accountType, debt.ID, "debt"); err != nil {
 		return nil, err
 	}
 
@@ -102,8 +151,18 @@ func (s *DebtService) GetByID(id uuid.UUID) (*models.Debt, error) {
 	return s.debtRepo.GetByID(id)
 }
 
-func (s *DebtService) GetByUserID(userID uuid.UUID, status *models.DebtStatus) ([]models.Debt, error) {
-	return s.debtRepo.GetByUserID(userID, status)
+func (s *DebtService) GetByUserID(userID uuid.UUID, status *models.DebtStatus, direction *models.DebtDirection) ([]models.Debt, error) {
+	debts, err := s.debtRepo.GetByUserID(userID, status)
+	if err != nil || direction == nil {
+		return debts, err
+	}
+	filtered := make([]models.Debt, 0, len(debts))
+	for _, debt := range debts {
+		if debt.Direction == *direction {
+			filtered = append(filtered, debt)
+		}
+	}
+	return filtered, nil
 }
 
 func (s *DebtService) Update(id uuid.UUID, input CreateDebtInput, status *models.DebtStatus) (*models.Debt, error) {
@@ -112,8 +171,12 @@ func (s *DebtService) Update(id uuid.UUID, input CreateDebtInput, status *models
 		return nil, err
 	}
 
-	// Validate and calculate for INSTALLMENT payment type
-	if input.PaymentType == models.DebtPaymentTypeInstallment {
+	// Validate and calculate for INSTALLMENT payment type. A prepayment may have
+	// adjusted the payment, so it's kept as long as the terms don't change.
+	termsUnchanged := debt.PaymentType == input.PaymentType && debt.ActualAmount == input.ActualAmount &&
+		debt.Tenor != nil && input.Tenor != nil && *debt.Tenor == *input.Tenor &&
+		debt.MonthlyPayment != nil && input.MonthlyPayment != nil && *debt.MonthlyPayment == *input.MonthlyPayment
+	if input.PaymentType == models.DebtPaymentTypeInstallment && !termsUnchanged {
 		if input.Tenor == nil || *input.Tenor <= 0 {
 			return nil, errors.New("tenor is required for installment debt")
 		}
@@ -126,6 +189,17 @@ func (s *DebtService) Update(id uuid.UUID, input CreateDebtInput, status *models
 		input.MonthlyPayment = &calculatedMonthly
 	}
 
+	if err := validateDebtInterest(&input); err != nil {
+		return nil, err
+	}
+	if input.InterestRate != nil && input.InterestStartDate == nil {
+		input.InterestStartDate = debt.InterestStartDate
+		if !debt.HasInterest() {
+			today := time.Now()
+			input.InterestStartDate = &today
+		}
+	}
+
 	debt.PersonName = input.PersonName
 	debt.ActualAmount = input.ActualAmount
 	debt.LoanAmount = input.LoanAmount
@@ -136,6 +210,15 @@ func (s *DebtService) Update(id uuid.UUID, input CreateDebtInput, status *models
 	debt.Icon = input.Icon
 	debt.CardBgColor = input.CardBgColor
 	debt.Notes = input.Notes
+	if input.PayeeID != nil {
+		if _, err := matchPayee(s.payeeRepo, debt.UserID, input.PayeeID, ""); err != nil {
+			return nil, err
+		}
+	}
+	debt.PayeeID = input.PayeeID
+	debt.InterestRate = input.InterestRate
+	debt.InterestCompounding = input.InterestCompounding
+	debt.InterestStartDate = input.InterestStartDate
 
 	if status != nil {
 		debt.Status = *status
@@ -154,16 +237,28 @@ func (s *DebtService) Delete(id uuid.UUID) error {
 	if err != nil {
 		return err
 	}
+	if _, err := s.consolidationRepo.GetByLoanID(id); err == nil {
+		return errors.New("debt pays off a consolidation and cannot be deleted")
+	}
+	if debt.ConsolidationID != nil {
+		return errors.New("debt was paid off by a consolidation and cannot be deleted")
+	}
 
 	// Delete all payment transactions first (before CASCADE deletes payments)
 	for _, payment := range debt.Payments {
-		_ = s.ledgerService.DeleteByReference(payment.ID, "debt_payment")
+		_ = s.ledgerService.DeleteByReference(payment.ID, debtPaymentReferenceType(debt))
+	}
+	for _, accrual := range debt.InterestAccruals {
+		_ = s.ledgerService.DeleteByReference(accrual.ID, "debt_interest")
 	}
 
-	// Delete linked account
+	// Delete linked accounts
 	if err := s.accountService.DeleteAccountByReference(id, "debt"); err != nil {
 		return err
 	}
+	if err := s.accountService.DeleteAccountByReference(id, "debt_interest"); err != nil {
+		return err
+	}
 
 	return s.debtRepo.Delete(id)
 }
@@ -192,7 +287,8 @@ func (s *DebtService) RecordPayment(debtID uuid.UUID, amount int64, paidAt time.
 		return nil, err
 	}
 
-	// Create ledger entry: DEBIT Liability Account, CREDIT Cash Account
+	// Create ledger entry: DEBIT Liability Account, CREDIT Cash Account, or the
+	// other way round when the money is paid back to the user
 	if err := s.createPaymentLedgerEntry(debt.UserID, debt, payment); err != nil {
 		return nil, err
 	}
@@ -208,6 +304,107 @@ func (s *DebtService) RecordPayment(debtID uuid.UUID, amount int64, paidAt time.
 	return payment, nil
 }
 
+// UpdatePayment corrects a recorded payment and its ledger entry. The debt is
+// completed or reopened to match the new remaining amount.
+func (s *DebtService) UpdatePayment(userID, id uuid.UUID, input UpdatePaymentInput) (*models.DebtPayment, error) {
+	payment, err := s.getOwnedPayment(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := input.apply(&payment.Amount, &payment.PaidAt, &payment.PocketID); err != nil {
+		return nil, err
+	}
+
+	if err := s.paymentRepo.Update(payment); err != nil {
+		return nil, err
+	}
+	if err := s.updatePaymentLedgerEntry(payment.Debt.UserID, payment.Debt, payment); err != nil {
+		return nil, err
+	}
+	if err := s.renumberPayments(payment.DebtID); err != nil {
+		return nil, err
+	}
+	if err := s.syncStatus(payment.DebtID); err != nil {
+		return nil, err
+	}
+
+	return s.paymentRepo.GetByID(id)
+}
+
+// DeletePayment removes a recorded payment and its ledger entry, reopening the
+// debt if it was completed
+func (s *DebtService) DeletePayment(userID, id uuid.UUID) error {
+	payment, err := s.getOwnedPayment(userID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.ledgerService.DeleteByReference(payment.ID, debtPaymentReferenceType(payment.Debt)); err != nil {
+		return err
+	}
+	if err := s.paymentRepo.Delete(id); err != nil {
+		return err
+	}
+	if err := s.renumberPayments(payment.DebtID); err != nil {
+		return err
+	}
+	return s.syncStatus(payment.DebtID)
+}
+
+// renumberPayments numbers the debt's payments 1..n in date order
+func (s *DebtService) renumberPayments(debtID uuid.UUID) error {
+	payments, err := s.paymentRepo.GetByDebtID(debtID)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(payments, func(i, j int) bool { return payments[i].PaidAt.Before(payments[j].PaidAt) })
+
+	for i := range payments {
+		if payments[i].PaymentNumber == i+1 {
+			continue
+		}
+		payments[i].PaymentNumber = i + 1
+		if err := s.paymentRepo.Update(&payments[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncStatus completes a debt that is paid off and reopens a completed one
+// that has an amount left to pay
+func (s *DebtService) syncStatus(debtID uuid.UUID) error {
+	debt, err := s.debtRepo.GetByID(debtID)
+	if err != nil {
+		return err
+	}
+
+	paidOff := debt.RemainingAmount() <= 0
+	switch {
+	case paidOff && debt.Status == models.DebtStatusActive:
+		debt.Status = models.DebtStatusCompleted
+	case !paidOff && debt.Status == models.DebtStatusCompleted:
+		debt.Status = models.DebtStatusActive
+	default:
+		return nil
+	}
+	return s.debtRepo.Update(debt)
+}
+
+func (s *DebtService) getOwnedPayment(userID, id uuid.UUID) (*models.DebtPayment, error) {
+	payment, err := s.paymentRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if payment.Debt == nil || payment.Debt.UserID != userID {
+		return nil, errors.New("debt payment not found")
+	}
+	if payment.Debt.ConsolidationID != nil {
+		return nil, errors.New("debt was paid off by a consolidation")
+	}
+	return payment, nil
+}
+
 func (s *DebtService) MarkComplete(id uuid.UUID) (*models.Debt, error) {
 	debt, err := s.debtRepo.GetByID(id)
 	if err != nil {
@@ -222,13 +419,196 @@ func (s *DebtService) MarkComplete(id uuid.UUID) (*models.Debt, error) {
 	return s.debtRepo.GetByID(id)
 }
 
+// SimulatePrepayment shows the effect of an extra payment without saving anything
+func (s *DebtService) SimulatePrepayment(userID, id uuid.UUID, input PrepaymentInput) (*PrepaymentResult, error) {
+	debt, err := s.getOwned(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return planDebtPrepayment(debt, input)
+}
+
+// ApplyPrepayment records the extra payment as a debt payment and updates the
+// debt's tenor or monthly payment
+func (s *DebtService) ApplyPrepayment(userID, id uuid.UUID, input PrepaymentInput) (*PrepaymentResult, error) {
+	debt, err := s.getOwned(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	result, err := planDebtPrepayment(debt, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.RecordPayment(id, input.ExtraAmount, input.Date, input.PocketID); err != nil {
+		return nil, err
+	}
+
+	debt, err = s.debtRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if debt.Status == models.DebtStatusActive {
+		debt.Tenor = result.Debt.Tenor
+		debt.MonthlyPayment = result.Debt.MonthlyPayment
+		if err := s.debtRepo.Update(debt); err != nil {
+			return nil, err
+		}
+	}
+
+	result.Debt, err = s.debtRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// planDebtPrepayment works out the new tenor and payment of an installment
+// debt. Debts have a fixed total to pay, so prepaying saves no interest. The
+// extra payment is a debt payment of its own and counts towards the tenor.
+func planDebtPrepayment(debt *models.Debt, input PrepaymentInput) (*PrepaymentResult, error) {
+	if debt.Status != models.DebtStatusActive {
+		return nil, errors.New("debt is not active")
+	}
+	if !debt.IsInstallment() || debt.Tenor == nil || debt.MonthlyPayment == nil || *debt.MonthlyPayment <= 0 {
+		return nil, errors.New("prepayment is only available for installment debts")
+	}
+	if input.ExtraAmount <= 0 {
+		return nil, errors.New("extra amount must be positive")
+	}
+	if !input.Strategy.IsValid() {
+		return nil, errors.New("invalid prepayment strategy")
+	}
+
+	remaining := debt.RemainingAmount()
+	if input.ExtraAmount > remaining {
+		return nil, errors.New("extra amount exceeds the remaining amount")
+	}
+
+	monthly := *debt.MonthlyPayment
+	periodsBefore := debt.RemainingPayments()
+	if periodsBefore <= 0 {
+		periodsBefore = int((remaining + monthly - 1) / monthly)
+	}
+
+	left := remaining - input.ExtraAmount
+	periodsAfter := periodsBefore
+	newMonthly := monthly
+	switch {
+	case left == 0:
+		periodsAfter = 0
+	case input.Strategy == models.PrepaymentStrategyReduceTenor:
+		periodsAfter = int((left + monthly - 1) / monthly)
+	default:
+		newMonthly = (left + int64(periodsAfter) - 1) / int64(periodsAfter)
+	}
+
+	// Debts only keep their next due date, later ones follow monthly
+	firstDue := input.Date.AddDate(0, 1, 0)
+	if debt.DueDate != nil && debt.DueDate.After(input.Date) {
+		firstDue = *debt.DueDate
+	}
+	firstNumber := debt.PaidCount() + 1
+	before := debtSchedule(remaining, monthly, periodsBefore, firstDue, firstNumber)
+	after := debtSchedule(left, newMonthly, periodsAfter, firstDue, firstNumber+1)
+
+	planned := *debt
+	tenor := debt.PaidCount() + 1 + periodsAfter
+	planned.Tenor = &tenor
+	planned.MonthlyPayment = &newMonthly
+
+	return &PrepaymentResult{
+		Strategy:                input.Strategy,
+		ExtraAmount:             input.ExtraAmount,
+		RemainingBefore:         remaining,
+		RemainingAfter:          left,
+		MonthlyPaymentBefore:    monthly,
+		MonthlyPaymentAfter:     newMonthly,
+		RemainingPaymentsBefore: periodsBefore,
+		RemainingPaymentsAfter:  periodsAfter,
+		EndDateBefore:           scheduleEndDate(before),
+		EndDateAfter:            scheduleEndDate(after),
+		Schedule:                after,
+		Debt:                    &planned,
+	}, nil
+}
+
+// debtSchedule spreads an amount over monthly payments, the last one taking
+// what is left
+func debtSchedule(amount, monthly int64, periods int, firstDue time.Time, firstNumber int) []models.AmortizationPeriod {
+	schedule := make([]models.AmortizationPeriod, 0, periods)
+	for n := 0; n < periods && amount > 0; n++ {
+		payment := monthly
+		if n == periods-1 || payment > amount {
+			payment = amount
+		}
+		amount -= payment
+		schedule = append(schedule, models.AmortizationPeriod{
+			PaymentNumber:      firstNumber + n,
+			DueDate:            calculateDueDate(firstDue.Day(), int(firstDue.Month())+n, firstDue.Year()),
+			Payment:            payment,
+			Principal:          payment,
+			RemainingPrincipal: amount,
+		})
+	}
+	return schedule
+}
+
+func (s *DebtService) getOwned(userID, id uuid.UUID) (*models.Debt, error) {
+	debt, err := s.debtRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if debt.UserID != userID {
+		return nil, errors.New("debt not found")
+	}
+	return debt, nil
+}
+
 func (s *DebtService) createPaymentLedgerEntry(userID uuid.UUID, debt *models.Debt, payment *models.DebtPayment) error {
-	// Get liability account (linked to debt)
-	liabilityAccount, err := s.accountRepo.GetByReference(debt.ID, "debt")
+	entries, description, err := s.paymentLedgerEntries(userID, debt, payment)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.ledgerService.CreateJournalEntry(
+		userID,
+		payment.PaidAt,
+		description,
+		entries,
+		&payment.ID,
+		debtPaymentReferenceType(debt),
+	)
+	return err
+}
+
+func (s *DebtService) updatePaymentLedgerEntry(userID uuid.UUID, debt *models.Debt, payment *models.DebtPayment) error {
+	tx, err := s.ledgerService.GetTransactionByReference(payment.ID, debtPaymentReferenceType(debt))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return s.createPaymentLedgerEntry(userID, debt, payment)
+	}
 	if err != nil {
 		return err
 	}
 
+	entries, description, err := s.paymentLedgerEntries(userID, debt, payment)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.ledgerService.UpdateJournalEntry(tx.ID, payment.PaidAt, description, entries)
+	return err
+}
+
+// paymentLedgerEntries debits the liability account and credits the pocket, or
+// the other way round when the money is paid back to the user
+func (s *DebtService) paymentLedgerEntries(userID uuid.UUID, debt *models.Debt, payment *models.DebtPayment) ([]LedgerEntry, string, error) {
+	// Get the liability or receivable account linked to the debt
+	debtAccount, err := s.accountRepo.GetByReference(debt.ID, "debt")
+	if err != nil {
+		return nil, "", err
+	}
+
 	// Get pocket account
 	var pocketAccount *models.Account
 	if payment.PocketID != nil {
@@ -237,21 +617,29 @@ func (s *DebtService) createPaymentLedgerEntry(userID uuid.UUID, debt *models.De
 		pocketAccount, err = s.accountRepo.GetDefaultByUserID(userID)
 	}
 	if err != nil {
-		return err
+		return nil, "", err
 	}
 
 	entries := []LedgerEntry{
-		{AccountID: liabilityAccount.ID, Debit: payment.Amount, Credit: 0},
+		{AccountID: debtAccount.ID, Debit: payment.Amount, Credit: 0},
 		{AccountID: pocketAccount.ID, Debit: 0, Credit: payment.Amount},
 	}
+	description := "Debt Payment: " + debt.PersonName
+	if debt.IsReceivable() {
+		entries = []LedgerEntry{
+			{AccountID: pocketAccount.ID, Debit: payment.Amount, Credit: 0},
+			{AccountID: debtAccount.ID, Debit: 0, Credit: payment.Amount},
+		}
+		description = "Receivable Payment: " + debt.PersonName
+	}
+	return entries, description, nil
+}
 
-	_, err = s.ledgerService.CreateJournalEntry(
-		userID,
-		payment.PaidAt,
-		"Debt Payment: "+debt.PersonName,
-		entries,
-		&payment.ID,
-		"debt_payment",
-	)
-	return err
+// debtPaymentReferenceType keeps repayments received apart from the user's own
+// debt payments in the ledger
+func debtPaymentReferenceType(debt *models.Debt) string {
+	if debt.IsReceivable() {
+		return "receivable_payment"
+	}
+	return "debt_payment"
 }
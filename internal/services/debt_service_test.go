@@ -0,0 +1,379 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+)
+
+// debtTest records debts against a default pocket that starts at 1.000.000
+type debtTest struct {
+	service  *DebtService
+	store    *memoryStore
+	accounts *fakeAccountRepo
+	debts    *fakeDebtRepo
+	payments *fakeDebtPaymentRepo
+	userID   uuid.UUID
+	pocket   *models.Account
+}
+
+func newDebtTest(t *testing.T) *debtTest {
+	t.Helper()
+	ledger, accounts, store := newTestLedger(t)
+	payments := &fakeDebtPaymentRepo{debts: make(map[uuid.UUID]models.Debt)}
+	debts := &fakeDebtRepo{debts: payments.debts, payments: payments}
+	userID := uuid.New()
+	return &debtTest{
+		service:  NewDebtService(debts, payments, nil, nil, accounts, &fakePayeeRepo{}, NewAccountService(accounts), ledger),
+		store:    store,
+		accounts: accounts,
+		debts:    debts,
+		payments: payments,
+		userID:   userID,
+		pocket:   newPocket(t, accounts, userID, 1000000, true),
+	}
+}
+
+func TestDebtDirection(t *testing.T) {
+	tests := []struct {
+		name          string
+		direction     models.DebtDirection
+		wantErr       string
+		wantAccount   models.AccountType
+		wantReference string
+		wantPocket    int64
+	}{
+		{
+			name:          "payable by default",
+			wantAccount:   models.AccountTypeLiability,
+			wantReference: "debt_payment",
+			wantPocket:    700000,
+		},
+		{
+			name:          "receivable is paid back into the pocket",
+			direction:     models.DebtDirectionReceivable,
+			wantAccount:   models.AccountTypeAsset,
+			wantReference: "receivable_payment",
+			wantPocket:    1300000,
+		},
+		{
+			name:      "unknown direction",
+			direction: "SIDEWAYS",
+			wantErr:   "invalid debt direction",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dt := newDebtTest(t)
+			debt, err := dt.service.Create(dt.userID, CreateDebtInput{
+				PersonName:   "Budi",
+				ActualAmount: 300000,
+				PaymentType:  models.DebtPaymentTypeOneTime,
+				Direction:    tt.direction,
+			})
+			if !errContains(err, tt.wantErr) {
+				t.Fatalf("Create error = %v, want %q", err, tt.wantErr)
+			}
+			if tt.wantErr != "" {
+				return
+			}
+
+			account, err := dt.accounts.GetByReference(debt.ID, "debt")
+			if err != nil {
+				t.Fatalf("linked account: %v", err)
+			}
+			if account.AccountType != tt.wantAccount {
+				t.Errorf("linked account type = %s, want %s", account.AccountType, tt.wantAccount)
+			}
+
+			if _, err := dt.service.RecordPayment(debt.ID, 300000, time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC), nil); err != nil {
+				t.Fatalf("RecordPayment: %v", err)
+			}
+			if got := dt.store.countByReference(tt.wantReference); got != 1 {
+				t.Errorf("%s journal entries = %d, want 1", tt.wantReference, got)
+			}
+			if got := dt.store.balance(dt.pocket.ID); got != tt.wantPocket {
+				t.Errorf("pocket balance = %d, want %d", got, tt.wantPocket)
+			}
+			// Either way the payment reduces what is owed on the linked account
+			if got := dt.store.balance(account.ID); got != -300000 {
+				t.Errorf("linked account balance = %d, want -300000", got)
+			}
+			if status := dt.debts.debts[debt.ID].Status; status != models.DebtStatusCompleted {
+				t.Errorf("status = %s, want %s", status, models.DebtStatusCompleted)
+			}
+		})
+	}
+}
+
+func TestDebtGetByUserIDDirection(t *testing.T) {
+	dt := newDebtTest(t)
+	for _, direction := range []models.DebtDirection{models.DebtDirectionPayable, models.DebtDirectionReceivable, models.DebtDirectionReceivable} {
+		if _, err := dt.service.Create(dt.userID, CreateDebtInput{PersonName: "Budi", ActualAmount: 100000, PaymentType: models.DebtPaymentTypeOneTime, Direction: direction}); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	receivable := models.DebtDirectionReceivable
+	tests := []struct {
+		name      string
+		direction *models.DebtDirection
+		want      int
+	}{
+		{name: "every direction", want: 3},
+		{name: "receivables only", direction: &receivable, want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			debts, err := dt.service.GetByUserID(dt.userID, nil, tt.direction)
+			if err != nil {
+				t.Fatalf("GetByUserID: %v", err)
+			}
+			if len(debts) != tt.want {
+				t.Errorf("got %d debts, want %d", len(debts), tt.want)
+			}
+		})
+	}
+}
+
+// paidDebt is a 300.000 debt paid off with 100.000 on March 1 and 200.000 on
+// April 1, leaving the pocket at 700.000
+func (dt *debtTest) paidDebt(t *testing.T) (*models.Debt, *models.DebtPayment, *models.DebtPayment) {
+	t.Helper()
+	debt, err := dt.service.Create(dt.userID, CreateDebtInput{PersonName: "Budi", ActualAmount: 300000, PaymentType: models.DebtPaymentTypeOneTime})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	first, err := dt.service.RecordPayment(debt.ID, 100000, time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC), nil)
+	if err != nil {
+		t.Fatalf("RecordPayment: %v", err)
+	}
+	second, err := dt.service.RecordPayment(debt.ID, 200000, time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC), nil)
+	if err != nil {
+		t.Fatalf("RecordPayment: %v", err)
+	}
+	return debt, first, second
+}
+
+// paymentNumber returns the stored number of a payment
+func (dt *debtTest) paymentNumber(id uuid.UUID) int {
+	for _, payment := range dt.payments.payments {
+		if payment.ID == id {
+			return payment.PaymentNumber
+		}
+	}
+	return 0
+}
+
+func TestDebtUpdatePayment(t *testing.T) {
+	amount := func(v int64) *int64 { return &v }
+	february := time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		input       UpdatePaymentInput
+		otherUser   bool
+		wantErr     string
+		wantStatus  models.DebtStatus
+		wantPocket  int64
+		wantNumbers [2]int
+	}{
+		{
+			name:        "a lower amount reopens the debt",
+			input:       UpdatePaymentInput{Amount: amount(150000)},
+			wantStatus:  models.DebtStatusActive,
+			wantPocket:  750000,
+			wantNumbers: [2]int{1, 2},
+		},
+		{
+			name:        "an earlier date renumbers the payments",
+			input:       UpdatePaymentInput{PaidAt: &february},
+			wantStatus:  models.DebtStatusCompleted,
+			wantPocket:  700000,
+			wantNumbers: [2]int{2, 1},
+		},
+		{
+			name:    "zero amount",
+			input:   UpdatePaymentInput{Amount: amount(0)},
+			wantErr: "amount must be positive",
+		},
+		{
+			name:      "another user's payment",
+			input:     UpdatePaymentInput{Amount: amount(150000)},
+			otherUser: true,
+			wantErr:   "debt payment not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dt := newDebtTest(t)
+			debt, first, second := dt.paidDebt(t)
+			userID := dt.userID
+			if tt.otherUser {
+				userID = uuid.New()
+			}
+
+			_, err := dt.service.UpdatePayment(userID, second.ID, tt.input)
+			if !errContains(err, tt.wantErr) {
+				t.Fatalf("UpdatePayment error = %v, want %q", err, tt.wantErr)
+			}
+			if tt.wantErr != "" {
+				if got := dt.store.balance(dt.pocket.ID); got != 700000 {
+					t.Errorf("pocket balance = %d after a failed update, want 700000", got)
+				}
+				return
+			}
+			if status := dt.debts.debts[debt.ID].Status; status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", status, tt.wantStatus)
+			}
+			if got := dt.store.balance(dt.pocket.ID); got != tt.wantPocket {
+				t.Errorf("pocket balance = %d, want %d", got, tt.wantPocket)
+			}
+			if got := [2]int{dt.paymentNumber(first.ID), dt.paymentNumber(second.ID)}; got != tt.wantNumbers {
+				t.Errorf("payment numbers = %v, want %v", got, tt.wantNumbers)
+			}
+			if got := dt.store.countByReference("debt_payment"); got != 2 {
+				t.Errorf("debt_payment journal entries = %d, want 2", got)
+			}
+		})
+	}
+}
+
+func TestDebtDeletePayment(t *testing.T) {
+	tests := []struct {
+		name      string
+		otherUser bool
+		wantErr   string
+	}{
+		{name: "deleting the first payment"},
+		{name: "another user's payment", otherUser: true, wantErr: "debt payment not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dt := newDebtTest(t)
+			debt, first, second := dt.paidDebt(t)
+			userID := dt.userID
+			if tt.otherUser {
+				userID = uuid.New()
+			}
+
+			err := dt.service.DeletePayment(userID, first.ID)
+			if !errContains(err, tt.wantErr) {
+				t.Fatalf("DeletePayment error = %v, want %q", err, tt.wantErr)
+			}
+			if tt.wantErr != "" {
+				if len(dt.payments.payments) != 2 {
+					t.Errorf("a failed delete left %d payments, want 2", len(dt.payments.payments))
+				}
+				return
+			}
+			if len(dt.payments.payments) != 1 || dt.paymentNumber(second.ID) != 1 {
+				t.Errorf("payments = %+v, want only the second one numbered 1", dt.payments.payments)
+			}
+			if got := dt.store.countByReference("debt_payment"); got != 1 {
+				t.Errorf("debt_payment journal entries = %d, want 1", got)
+			}
+			if got := dt.store.balance(dt.pocket.ID); got != 800000 {
+				t.Errorf("pocket balance = %d, want 800000", got)
+			}
+			if status := dt.debts.debts[debt.ID].Status; status != models.DebtStatusActive {
+				t.Errorf("status = %s, want the debt reopened", status)
+			}
+		})
+	}
+}
+
+func TestPlanDebtPrepayment(t *testing.T) {
+	// 1.200.000 over 12 months with two payments made leaves 1.000.000 over 10
+	newDebt := func() *models.Debt {
+		tenor, monthly := 12, int64(100000)
+		debt := &models.Debt{
+			ID:             uuid.New(),
+			ActualAmount:   1200000,
+			PaymentType:    models.DebtPaymentTypeInstallment,
+			Tenor:          &tenor,
+			MonthlyPayment: &monthly,
+			Status:         models.DebtStatusActive,
+		}
+		for range 2 {
+			debt.Payments = append(debt.Payments, models.DebtPayment{ID: uuid.New(), DebtID: debt.ID, Amount: monthly})
+		}
+		return debt
+	}
+
+	tests := []struct {
+		name        string
+		modify      func(debt *models.Debt)
+		extra       int64
+		strategy    models.PrepaymentStrategy
+		wantErr     string
+		wantMonthly int64
+		wantPeriods int
+		wantTenor   int
+	}{
+		{name: "reduce tenor", extra: 250000, strategy: models.PrepaymentStrategyReduceTenor, wantMonthly: 100000, wantPeriods: 8, wantTenor: 11},
+		{name: "reduce payment", extra: 250000, strategy: models.PrepaymentStrategyReducePayment, wantMonthly: 75000, wantPeriods: 10, wantTenor: 13},
+		{name: "pays the debt off", extra: 1000000, strategy: models.PrepaymentStrategyReducePayment, wantMonthly: 100000, wantTenor: 3},
+		{name: "more than is left", extra: 1000001, strategy: models.PrepaymentStrategyReduceTenor, wantErr: "exceeds the remaining amount"},
+		{name: "nothing extra", strategy: models.PrepaymentStrategyReduceTenor, wantErr: "must be positive"},
+		{name: "unknown strategy", extra: 250000, strategy: "REDUCE_EVERYTHING", wantErr: "invalid prepayment strategy"},
+		{
+			name:     "one-time debt",
+			modify:   func(debt *models.Debt) { debt.PaymentType = models.DebtPaymentTypeOneTime },
+			extra:    250000,
+			strategy: models.PrepaymentStrategyReduceTenor,
+			wantErr:  "only available for installment debts",
+		},
+		{
+			name:     "completed debt",
+			modify:   func(debt *models.Debt) { debt.Status = models.DebtStatusCompleted },
+			extra:    250000,
+			strategy: models.PrepaymentStrategyReduceTenor,
+			wantErr:  "debt is not active",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			debt := newDebt()
+			if tt.modify != nil {
+				tt.modify(debt)
+			}
+
+			result, err := planDebtPrepayment(debt, PrepaymentInput{ExtraAmount: tt.extra, Date: time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC), Strategy: tt.strategy})
+			if !errContains(err, tt.wantErr) {
+				t.Fatalf("planDebtPrepayment error = %v, want %q", err, tt.wantErr)
+			}
+			if tt.wantErr != "" {
+				return
+			}
+			if result.RemainingBefore != 1000000 || result.RemainingPaymentsBefore != 10 {
+				t.Errorf("before = %d over %d payments, want 1000000 over 10", result.RemainingBefore, result.RemainingPaymentsBefore)
+			}
+			if result.RemainingAfter != 1000000-tt.extra {
+				t.Errorf("remaining after = %d, want %d", result.RemainingAfter, 1000000-tt.extra)
+			}
+			if result.MonthlyPaymentAfter != tt.wantMonthly {
+				t.Errorf("monthly payment after = %d, want %d", result.MonthlyPaymentAfter, tt.wantMonthly)
+			}
+			if result.RemainingPaymentsAfter != tt.wantPeriods {
+				t.Errorf("remaining payments after = %d, want %d", result.RemainingPaymentsAfter, tt.wantPeriods)
+			}
+			if *debt.Tenor != 12 {
+				t.Errorf("planning changed the debt's tenor to %d", *debt.Tenor)
+			}
+			if tenor := *result.Debt.Tenor; tenor != tt.wantTenor {
+				t.Errorf("planned tenor = %d, want %d", tenor, tt.wantTenor)
+			}
+			if len(result.Schedule) != tt.wantPeriods {
+				t.Errorf("schedule has %d payments, want %d", len(result.Schedule), tt.wantPeriods)
+			}
+		})
+	}
+}
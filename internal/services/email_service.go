@@ -3,12 +3,17 @@ package services
 import (
 	"context"
 	"fmt"
+	"html"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/resend/resend-go/v3"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
 )
 
 type EmailService struct {
@@ -129,6 +134,48 @@ func (s *EmailService) SendDebtReminder(ctx context.Context, to, personName stri
 	})
 }
 
+func (s *EmailService) SendReceivableReminder(ctx context.Context, to, personName string, daysUntil int, amount int64) error {
+	template, err := s.loadTemplate("receivable_reminder.html")
+	if err != nil {
+		return err
+	}
+
+	html := s.renderTemplate(template, map[string]interface{}{
+		"person_name": personName,
+		"days_until":  daysUntil,
+		"amount":      amount,
+	})
+
+	return s.Send(ctx, EmailParams{
+		To:      to,
+		Subject: fmt.Sprintf("Reminder: Piutang dari %s jatuh tempo dalam %d hari", personName, daysUntil),
+		HTML:    html,
+	})
+}
+
+// SendOverdueReminder tells the user a payment is past its due date. label
+// names the kind of loan, e.g. "Cicilan" or "Hutang ke".
+func (s *EmailService) SendOverdueReminder(ctx context.Context, to, label, name string, daysLate, missedPeriods int, amount int64) error {
+	template, err := s.loadTemplate("overdue_reminder.html")
+	if err != nil {
+		return err
+	}
+
+	html := s.renderTemplate(template, map[string]interface{}{
+		"label":          label,
+		"name":           name,
+		"days_late":      daysLate,
+		"missed_periods": missedPeriods,
+		"amount":         amount,
+	})
+
+	return s.Send(ctx, EmailParams{
+		To:      to,
+		Subject: fmt.Sprintf("Terlambat: %s %s sudah lewat jatuh tempo %d hari", label, name, daysLate),
+		HTML:    html,
+	})
+}
+
 func (s *EmailService) SendPasswordResetEmail(ctx context.Context, to, name, resetLink string) error {
 	template, err := s.loadTemplate("password_reset.html")
 	if err != nil {
@@ -147,18 +194,32 @@ func (s *EmailService) SendPasswordResetEmail(ctx context.Context, to, name, res
 	})
 }
 
-func (s *EmailService) SendSavingsGoalReminder(ctx context.Context, to, name string, daysUntil int, remainingAmount, currentAmount int64, progress float64) error {
+var savingsGoalPaceLabels = map[models.SavingsGoalPace]string{
+	models.SavingsGoalPaceAhead:   "Lebih cepat dari target",
+	models.SavingsGoalPaceOnTrack: "Sesuai jadwal",
+	models.SavingsGoalPaceBehind:  "Tertinggal dari target",
+}
+
+func (s *EmailService) SendSavingsGoalReminder(ctx context.Context, to, name string, daysUntil int, remainingAmount, currentAmount int64, progress float64, forecast models.SavingsGoalForecast) error {
 	template, err := s.loadTemplate("savings_goal_reminder.html")
 	if err != nil {
 		return err
 	}
 
+	projectedDate := "Belum dapat diperkirakan"
+	if forecast.ProjectedCompletionDate != nil {
+		projectedDate = forecast.ProjectedCompletionDate.Format("02 Jan 2006")
+	}
+
 	html := s.renderTemplate(template, map[string]interface{}{
 		"name":             name,
 		"days_until":       daysUntil,
 		"remaining_amount": remainingAmount,
 		"current_amount":   currentAmount,
 		"progress":         int(progress),
+		"pace":             savingsGoalPaceLabels[forecast.Pace],
+		"projected_date":   projectedDate,
+		"required_monthly": forecast.RequiredMonthly,
 	})
 
 	return s.Send(ctx, EmailParams{
@@ -168,6 +229,88 @@ func (s *EmailService) SendSavingsGoalReminder(ctx context.Context, to, name str
 	})
 }
 
+func (s *EmailService) SendSavingsAutoSkipped(ctx context.Context, to, goalName, pocketName string, scheduledDate time.Time, amount, pocketBalance int64) error {
+	template, err := s.loadTemplate("savings_auto_skipped.html")
+	if err != nil {
+		return err
+	}
+
+	html := s.renderTemplate(template, map[string]interface{}{
+		"goal_name":      goalName,
+		"pocket_name":    pocketName,
+		"scheduled_date": scheduledDate.Format("02 Jan 2006"),
+		"amount":         amount,
+		"pocket_balance": pocketBalance,
+	})
+
+	return s.Send(ctx, EmailParams{
+		To:      to,
+		Subject: fmt.Sprintf("Tabungan otomatis %s dilewati: saldo tidak cukup", goalName),
+		HTML:    html,
+	})
+}
+
+func (s *EmailService) SendTemplateAutoPostSummary(ctx context.Context, to, groupName string, postDate time.Time, expenses []models.Expense) error {
+	template, err := s.loadTemplate("template_auto_post.html")
+	if err != nil {
+		return err
+	}
+
+	var rows strings.Builder
+	var total int64
+	for _, exp := range expenses {
+		total += exp.Total()
+		fmt.Fprintf(&rows,
+			`<tr><td class="card-value" style="padding: 6px 0; color: #0a0a0a; font-size: 14px;">%s</td><td class="card-value" style="padding: 6px 0; color: #0a0a0a; font-size: 14px; text-align: right;">Rp %s</td></tr>`,
+			html.EscapeString(exp.ItemName), formatCurrency(exp.Total()))
+	}
+
+	body := s.renderTemplate(template, map[string]interface{}{
+		"group_name": html.EscapeString(groupName),
+		"post_date":  postDate.Format("02 Jan 2006"),
+		"item_count": len(expenses),
+		"total":      total,
+		"items":      rows.String(),
+	})
+
+	return s.Send(ctx, EmailParams{
+		To:      to,
+		Subject: fmt.Sprintf("Pengeluaran rutin %s telah dicatat", groupName),
+		HTML:    body,
+	})
+}
+
+func (s *EmailService) SendBudgetAlert(ctx context.Context, to, budgetName string, threshold int, percentage float64, budgeted, spent int64, period string) error {
+	template, err := s.loadTemplate("budget_alert.html")
+	if err != nil {
+		return err
+	}
+
+	remainingLabel := "Sisa Anggaran"
+	remaining := budgeted - spent
+	if remaining < 0 {
+		remainingLabel = "Melebihi Anggaran"
+		remaining = -remaining
+	}
+
+	body := s.renderTemplate(template, map[string]interface{}{
+		"budget_name":     html.EscapeString(budgetName),
+		"threshold":       threshold,
+		"percentage":      int(percentage),
+		"budgeted":        budgeted,
+		"spent":           spent,
+		"remaining":       remaining,
+		"remaining_label": remainingLabel,
+		"period":          period,
+	})
+
+	return s.Send(ctx, EmailParams{
+		To:      to,
+		Subject: fmt.Sprintf("Peringatan: Anggaran %s sudah terpakai %d%%", budgetName, threshold),
+		HTML:    body,
+	})
+}
+
 func (s *EmailService) Send2FACodeEmail(ctx context.Context, to, name, code string) error {
 	template, err := s.loadTemplate("2fa_code.html")
 	if err != nil {
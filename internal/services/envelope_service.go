@@ -0,0 +1,367 @@
+package services
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+	"github.com/azzamdhx/moneybro/backend/internal/repository"
+)
+
+// EnvelopeService implements zero-based budgeting on top of pocket balances.
+// Money in pockets is either assigned to a category envelope or still "to be
+// budgeted". An envelope's available amount rolls over from month to month,
+// including overspending, which is carried forward as a negative amount.
+type EnvelopeService struct {
+	assignmentRepo repository.EnvelopeAssignmentRepository
+	categoryRepo   repository.CategoryRepository
+	accountRepo    repository.AccountRepository
+	entryRepo      repository.TransactionEntryRepository
+	incomeRepo     repository.IncomeRepository
+}
+
+func NewEnvelopeService(
+	assignmentRepo repository.EnvelopeAssignmentRepository,
+	categoryRepo repository.CategoryRepository,
+	accountRepo repository.AccountRepository,
+	entryRepo repository.TransactionEntryRepository,
+	incomeRepo repository.IncomeRepository,
+) *EnvelopeService {
+	return &EnvelopeService{
+		assignmentRepo: assignmentRepo,
+		categoryRepo:   categoryRepo,
+		accountRepo:    accountRepo,
+		entryRepo:      entryRepo,
+		incomeRepo:     incomeRepo,
+	}
+}
+
+type AssignToEnvelopeInput struct {
+	CategoryID uuid.UUID
+	Month      int
+	Year       int
+	Amount     int64
+	Notes      *string
+}
+
+type MoveEnvelopeMoneyInput struct {
+	FromCategoryID uuid.UUID
+	ToCategoryID   uuid.UUID
+	Month          int
+	Year           int
+	Amount         int64
+	Notes          *string
+}
+
+type EnvelopeLine struct {
+	Category    models.Category
+	CarriedOver int64
+	Assigned    int64
+	Activity    int64
+	Available   int64
+}
+
+type EnvelopeReport struct {
+	Month          int
+	Year           int
+	Income         int64
+	PocketBalance  int64
+	TotalAssigned  int64
+	TotalActivity  int64
+	TotalAvailable int64
+	ToBeBudgeted   int64
+	Envelopes      []EnvelopeLine
+}
+
+// Assign gives money to an envelope for a month. A negative amount takes money
+// back to "to be budgeted", up to what is still available in the envelope.
+func (s *EnvelopeService) Assign(userID uuid.UUID, input AssignToEnvelopeInput) (*EnvelopeReport, error) {
+	if err := validateEnvelopeMonth(input.Month); err != nil {
+		return nil, err
+	}
+	if input.Amount == 0 {
+		return nil, errors.New("amount must not be 0")
+	}
+	if err := s.validateCategory(userID, input.CategoryID); err != nil {
+		return nil, err
+	}
+
+	if input.Amount < 0 {
+		report, err := s.GetReport(userID, input.Month, input.Year)
+		if err != nil {
+			return nil, err
+		}
+		if envelopeAvailable(report, input.CategoryID) < -input.Amount {
+			return nil, errors.New("cannot unassign more than the envelope's available amount")
+		}
+	}
+
+	assignment := &models.EnvelopeAssignment{
+		ID:         uuid.New(),
+		UserID:     userID,
+		CategoryID: input.CategoryID,
+		Month:      input.Month,
+		Year:       input.Year,
+		Amount:     input.Amount,
+		Type:       models.EnvelopeAssignmentTypeAssign,
+		Notes:      input.Notes,
+	}
+	if err := s.assignmentRepo.Create(assignment); err != nil {
+		return nil, err
+	}
+
+	return s.GetReport(userID, input.Month, input.Year)
+}
+
+// Move shifts available money from one envelope to another within a month
+func (s *EnvelopeService) Move(userID uuid.UUID, input MoveEnvelopeMoneyInput) (*EnvelopeReport, error) {
+	if err := validateEnvelopeMonth(input.Month); err != nil {
+		return nil, err
+	}
+	if input.Amount <= 0 {
+		return nil, errors.New("amount must be greater than 0")
+	}
+	if input.FromCategoryID == input.ToCategoryID {
+		return nil, errors.New("cannot move money to the same envelope")
+	}
+	if err := s.validateCategory(userID, input.FromCategoryID); err != nil {
+		return nil, err
+	}
+	if err := s.validateCategory(userID, input.ToCategoryID); err != nil {
+		return nil, err
+	}
+
+	report, err := s.GetReport(userID, input.Month, input.Year)
+	if err != nil {
+		return nil, err
+	}
+	if envelopeAvailable(report, input.FromCategoryID) < input.Amount {
+		return nil, errors.New("insufficient funds in envelope")
+	}
+
+	transferID := uuid.New()
+	if err := s.assignmentRepo.CreateBatch([]models.EnvelopeAssignment{
+		{
+			ID:         uuid.New(),
+			UserID:     userID,
+			CategoryID: input.FromCategoryID,
+			Month:      input.Month,
+			Year:       input.Year,
+			Amount:     -input.Amount,
+			Type:       models.EnvelopeAssignmentTypeMove,
+			TransferID: &transferID,
+			Notes:      input.Notes,
+		},
+		{
+			ID:         uuid.New(),
+			UserID:     userID,
+			CategoryID: input.ToCategoryID,
+			Month:      input.Month,
+			Year:       input.Year,
+			Amount:     input.Amount,
+			Type:       models.EnvelopeAssignmentTypeMove,
+			TransferID: &transferID,
+			Notes:      input.Notes,
+		},
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.GetReport(userID, input.Month, input.Year)
+}
+
+func (s *EnvelopeService) GetAssignments(userID uuid.UUID, month, year int) ([]models.EnvelopeAssignment, error) {
+	if err := validateEnvelopeMonth(month); err != nil {
+		return nil, err
+	}
+	return s.assignmentRepo.GetByUserIDAndMonth(userID, month, year)
+}
+
+// GetReport builds the envelope report of a month. Envelope balances are
+// replayed from the first month with an assignment; spending comes from the
+// category expense accounts of the ledger and is booked on the nearest envelope
+// up the category tree. To be budgeted is the pocket balance at month end minus
+// what all envelopes still hold.
+func (s *EnvelopeService) GetReport(userID uuid.UUID, month, year int) (*EnvelopeReport, error) {
+	if err := validateEnvelopeMonth(month); err != nil {
+		return nil, err
+	}
+
+	assignments, err := s.assignmentRepo.GetByUserIDUpTo(userID, month, year)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.categoryRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]models.Category, len(categories))
+	for _, c := range categories {
+		byID[c.ID] = c
+	}
+
+	target := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	start := target
+	envelopes := make(map[uuid.UUID]bool)
+	assigned := make(map[time.Time]map[uuid.UUID]int64)
+	for _, a := range assignments {
+		period := time.Date(a.Year, time.Month(a.Month), 1, 0, 0, 0, 0, time.UTC)
+		if period.Before(start) {
+			start = period
+		}
+		envelopes[a.CategoryID] = true
+		if assigned[period] == nil {
+			assigned[period] = make(map[uuid.UUID]int64)
+		}
+		assigned[period][a.CategoryID] += a.Amount
+	}
+
+	lines := make(map[uuid.UUID]*EnvelopeLine)
+	line := func(id uuid.UUID) *EnvelopeLine {
+		if l, ok := lines[id]; ok {
+			return l
+		}
+		l := &EnvelopeLine{Category: byID[id]}
+		lines[id] = l
+		return l
+	}
+
+	for period := start; !period.After(target); period = period.AddDate(0, 1, 0) {
+		// Roll last month's available over
+		for _, l := range lines {
+			l.CarriedOver = l.Available
+			l.Assigned = 0
+			l.Activity = 0
+		}
+
+		for id, amount := range assigned[period] {
+			line(id).Assigned += amount
+		}
+
+		startDate, endDate := monthDateRange(int(period.Month()), period.Year())
+		spending, err := s.entryRepo.SumExpenseByCategory(userID, startDate, endDate)
+		if err != nil {
+			return nil, err
+		}
+		for _, cs := range spending {
+			if cs.Total == 0 {
+				continue
+			}
+			line(envelopeFor(byID, envelopes, cs.CategoryID)).Activity += cs.Total
+		}
+
+		for _, l := range lines {
+			l.Available = l.CarriedOver + l.Assigned - l.Activity
+		}
+	}
+
+	report := &EnvelopeReport{
+		Month:     month,
+		Year:      year,
+		Envelopes: []EnvelopeLine{},
+	}
+
+	for _, l := range lines {
+		if l.Category.ID == uuid.Nil {
+			// Category no longer exists
+			continue
+		}
+		if !envelopes[l.Category.ID] && l.CarriedOver == 0 && l.Activity == 0 {
+			continue
+		}
+		report.TotalAssigned += l.Assigned
+		report.TotalActivity += l.Activity
+		report.TotalAvailable += l.Available
+		report.Envelopes = append(report.Envelopes, *l)
+	}
+	sort.Slice(report.Envelopes, func(i, j int) bool {
+		return strings.ToLower(report.Envelopes[i].Category.Name) < strings.ToLower(report.Envelopes[j].Category.Name)
+	})
+
+	startDate, endDate := monthDateRange(month, year)
+	incomes, err := s.incomeRepo.GetByUserIDAndDateRange(userID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	for _, inc := range incomes {
+		report.Income += inc.Amount
+	}
+
+	pocketBalance, err := s.pocketBalanceAt(userID, endDate)
+	if err != nil {
+		return nil, err
+	}
+	report.PocketBalance = pocketBalance
+	report.ToBeBudgeted = pocketBalance - report.TotalAvailable
+
+	return report, nil
+}
+
+// pocketBalanceAt returns the total pocket balance at the end of the given day,
+// by taking ledger movements after that day off the current balances
+func (s *EnvelopeService) pocketBalanceAt(userID uuid.UUID, date string) (int64, error) {
+	pockets, err := s.accountRepo.GetPocketsByUserID(userID)
+	if err != nil {
+		return 0, err
+	}
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return 0, err
+	}
+	after := day.AddDate(0, 0, 1).Format("2006-01-02")
+
+	var total int64
+	for _, pocket := range pockets {
+		debit, credit, err := s.entryRepo.SumByAccountIDAndDateRange(pocket.ID, after, "9999-12-31")
+		if err != nil {
+			return 0, err
+		}
+		total += pocket.CurrentBalance - (debit - credit)
+	}
+	return total, nil
+}
+
+func (s *EnvelopeService) validateCategory(userID, categoryID uuid.UUID) error {
+	category, err := s.categoryRepo.GetByID(categoryID)
+	if err != nil || category.UserID != userID {
+		return errors.New("category not found")
+	}
+	return nil
+}
+
+func validateEnvelopeMonth(month int) error {
+	if month < 1 || month > 12 {
+		return errors.New("month must be between 1 and 12")
+	}
+	return nil
+}
+
+// envelopeFor returns the nearest category up the tree (itself included) that
+// is an envelope, or the category itself when none is
+func envelopeFor(byID map[uuid.UUID]models.Category, envelopes map[uuid.UUID]bool, categoryID uuid.UUID) uuid.UUID {
+	current := categoryID
+	for i := 0; i <= len(byID); i++ {
+		if envelopes[current] {
+			return current
+		}
+		category, ok := byID[current]
+		if !ok || category.ParentID == nil {
+			break
+		}
+		current = *category.ParentID
+	}
+	return categoryID
+}
+
+func envelopeAvailable(report *EnvelopeReport, categoryID uuid.UUID) int64 {
+	for _, e := range report.Envelopes {
+		if e.Category.ID == categoryID {
+			return e.Available
+		}
+	}
+	return 0
+}
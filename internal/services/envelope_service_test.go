@@ -0,0 +1,260 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+	"github.com/azzamdhx/moneybro/backend/internal/repository"
+)
+
+type fakeEnvelopeAssignmentRepo struct {
+	repository.EnvelopeAssignmentRepository
+	assignments []models.EnvelopeAssignment
+}
+
+func (r *fakeEnvelopeAssignmentRepo) Create(assignment *models.EnvelopeAssignment) error {
+	r.assignments = append(r.assignments, *assignment)
+	return nil
+}
+
+func (r *fakeEnvelopeAssignmentRepo) CreateBatch(assignments []models.EnvelopeAssignment) error {
+	r.assignments = append(r.assignments, assignments...)
+	return nil
+}
+
+func (r *fakeEnvelopeAssignmentRepo) GetByUserIDUpTo(userID uuid.UUID, month, year int) ([]models.EnvelopeAssignment, error) {
+	var assignments []models.EnvelopeAssignment
+	for _, a := range r.assignments {
+		if a.UserID == userID && (a.Year < year || a.Year == year && a.Month <= month) {
+			assignments = append(assignments, a)
+		}
+	}
+	return assignments, nil
+}
+
+type fakePocketRepo struct {
+	repository.AccountRepository
+	pockets []models.Account
+}
+
+func (r *fakePocketRepo) GetPocketsByUserID(userID uuid.UUID) ([]models.Account, error) {
+	return r.pockets, nil
+}
+
+// envelopeFixture has envelopes for food (spent through its groceries child)
+// and transport, and an untracked category:
+//
+//	January:  food gets 300.000, groceries spend 350.000 -> food -50.000
+//	February: food gets 100.000 and spends 20.000 -> 30.000;
+//	          transport gets 50.000 and spends 60.000 -> -10.000
+//	March:    nothing assigned, 5.000 spent on the untracked category
+type envelopeFixture struct {
+	userID      uuid.UUID
+	food        models.Category
+	groceries   models.Category
+	transport   models.Category
+	other       models.Category
+	assignments *fakeEnvelopeAssignmentRepo
+	service     *EnvelopeService
+}
+
+func newEnvelopeFixture() *envelopeFixture {
+	f := &envelopeFixture{userID: uuid.New()}
+	f.food = models.Category{ID: uuid.New(), UserID: f.userID, Name: "Makan"}
+	f.groceries = models.Category{ID: uuid.New(), UserID: f.userID, Name: "Belanja", ParentID: &f.food.ID}
+	f.transport = models.Category{ID: uuid.New(), UserID: f.userID, Name: "Transport"}
+	f.other = models.Category{ID: uuid.New(), UserID: f.userID, Name: "Lainnya"}
+
+	assign := func(category models.Category, month int, amount int64) models.EnvelopeAssignment {
+		return models.EnvelopeAssignment{ID: uuid.New(), UserID: f.userID, CategoryID: category.ID, Month: month, Year: 2026, Amount: amount, Type: models.EnvelopeAssignmentTypeAssign}
+	}
+	f.assignments = &fakeEnvelopeAssignmentRepo{assignments: []models.EnvelopeAssignment{
+		assign(f.food, 1, 300000),
+		assign(f.food, 2, 100000),
+		assign(f.transport, 2, 50000),
+	}}
+	spending := &fakeSpendingRepo{
+		spending: map[string][]repository.CategorySpending{
+			"2026-01": {{CategoryID: f.groceries.ID, Total: 350000}},
+			"2026-02": {{CategoryID: f.food.ID, Total: 20000}, {CategoryID: f.transport.ID, Total: 60000}},
+			"2026-03": {{CategoryID: f.other.ID, Total: 5000}, {CategoryID: f.transport.ID, Total: 0}},
+		},
+		movements: make(map[uuid.UUID]int64),
+	}
+	pocket := models.Account{ID: uuid.New(), UserID: f.userID, Name: "Dompet", CurrentBalance: 500000, IsPocket: true}
+	// 100.000 came into the pocket after March
+	spending.movements[pocket.ID] = 100000
+
+	f.service = NewEnvelopeService(
+		f.assignments,
+		&fakeCategoryRepo{categories: []models.Category{f.food, f.groceries, f.transport, f.other}},
+		&fakePocketRepo{pockets: []models.Account{pocket}},
+		spending,
+		&fakeIncomeRepo{},
+	)
+	return f
+}
+
+// availableByName maps envelope names to their available amount
+func availableByName(report *EnvelopeReport) map[string]int64 {
+	available := make(map[string]int64)
+	for _, e := range report.Envelopes {
+		available[e.Category.Name] = e.Available
+	}
+	return available
+}
+
+func TestEnvelopeGetReport(t *testing.T) {
+	f := newEnvelopeFixture()
+
+	report, err := f.service.GetReport(f.userID, 3, 2026)
+	if err != nil {
+		t.Fatalf("GetReport: %v", err)
+	}
+
+	want := []struct {
+		name        string
+		carriedOver int64
+		activity    int64
+		available   int64
+	}{
+		{name: "Lainnya", activity: 5000, available: -5000},
+		{name: "Makan", carriedOver: 30000, available: 30000},
+		{name: "Transport", carriedOver: -10000, available: -10000},
+	}
+	if len(report.Envelopes) != len(want) {
+		t.Fatalf("got %d envelopes, want %d", len(report.Envelopes), len(want))
+	}
+	for i, w := range want {
+		e := report.Envelopes[i]
+		if e.Category.Name != w.name {
+			t.Errorf("envelope %d = %s, want %s", i, e.Category.Name, w.name)
+			continue
+		}
+		if e.CarriedOver != w.carriedOver || e.Activity != w.activity || e.Available != w.available {
+			t.Errorf("%s: carried over %d, activity %d, available %d; want %d, %d, %d",
+				w.name, e.CarriedOver, e.Activity, e.Available, w.carriedOver, w.activity, w.available)
+		}
+	}
+	if report.TotalAvailable != 15000 {
+		t.Errorf("total available = %d, want 15000", report.TotalAvailable)
+	}
+	if report.PocketBalance != 400000 {
+		t.Errorf("pocket balance = %d, want 400000", report.PocketBalance)
+	}
+	if report.ToBeBudgeted != 385000 {
+		t.Errorf("to be budgeted = %d, want 385000", report.ToBeBudgeted)
+	}
+}
+
+func TestEnvelopeGetReportOverspentMonth(t *testing.T) {
+	f := newEnvelopeFixture()
+
+	report, err := f.service.GetReport(f.userID, 1, 2026)
+	if err != nil {
+		t.Fatalf("GetReport: %v", err)
+	}
+	available := availableByName(report)
+	if len(available) != 1 || available["Makan"] != -50000 {
+		t.Errorf("available = %v, want only Makan at -50000", available)
+	}
+}
+
+func TestEnvelopeMove(t *testing.T) {
+	tests := []struct {
+		name          string
+		amount        int64
+		to            func(f *envelopeFixture) uuid.UUID
+		wantErr       string
+		wantFood      int64
+		wantTransport int64
+	}{
+		{name: "within what is available", amount: 20000, wantFood: 10000, wantTransport: 10000},
+		{name: "everything available", amount: 30000, wantFood: 0, wantTransport: 20000},
+		{name: "more than is available", amount: 30001, wantErr: "insufficient funds in envelope"},
+		{name: "nothing", amount: 0, wantErr: "greater than 0"},
+		{name: "to the same envelope", amount: 10000, to: func(f *envelopeFixture) uuid.UUID { return f.food.ID }, wantErr: "same envelope"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newEnvelopeFixture()
+			to := f.transport.ID
+			if tt.to != nil {
+				to = tt.to(f)
+			}
+			before := len(f.assignments.assignments)
+
+			report, err := f.service.Move(f.userID, MoveEnvelopeMoneyInput{FromCategoryID: f.food.ID, ToCategoryID: to, Month: 3, Year: 2026, Amount: tt.amount})
+			if !errContains(err, tt.wantErr) {
+				t.Fatalf("Move error = %v, want %q", err, tt.wantErr)
+			}
+			if tt.wantErr != "" {
+				if len(f.assignments.assignments) != before {
+					t.Errorf("a failed move recorded %d assignments", len(f.assignments.assignments)-before)
+				}
+				return
+			}
+			available := availableByName(report)
+			if available["Makan"] != tt.wantFood || available["Transport"] != tt.wantTransport {
+				t.Errorf("available = %v, want Makan %d and Transport %d", available, tt.wantFood, tt.wantTransport)
+			}
+			if report.TotalAvailable != 15000 {
+				t.Errorf("total available = %d, a move must not change it", report.TotalAvailable)
+			}
+		})
+	}
+}
+
+func TestEnvelopeAssign(t *testing.T) {
+	tests := []struct {
+		name         string
+		amount       int64
+		wantErr      string
+		wantFood     int64
+		wantBudgeted int64
+		wantAssigned int64
+		wantRecorded bool
+	}{
+		{name: "assign", amount: 70000, wantFood: 100000, wantBudgeted: 315000, wantAssigned: 70000, wantRecorded: true},
+		{name: "take back what is available", amount: -30000, wantFood: 0, wantBudgeted: 415000, wantAssigned: -30000, wantRecorded: true},
+		{name: "take back more than is available", amount: -30001, wantErr: "more than the envelope's available amount"},
+		{name: "zero", amount: 0, wantErr: "must not be 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newEnvelopeFixture()
+			before := len(f.assignments.assignments)
+
+			report, err := f.service.Assign(f.userID, AssignToEnvelopeInput{CategoryID: f.food.ID, Month: 3, Year: 2026, Amount: tt.amount})
+			if !errContains(err, tt.wantErr) {
+				t.Fatalf("Assign error = %v, want %q", err, tt.wantErr)
+			}
+			if recorded := len(f.assignments.assignments) > before; recorded != tt.wantRecorded {
+				t.Fatalf("recorded an assignment: %v, want %v", recorded, tt.wantRecorded)
+			}
+			if tt.wantErr != "" {
+				return
+			}
+			if got := availableByName(report)["Makan"]; got != tt.wantFood {
+				t.Errorf("Makan available = %d, want %d", got, tt.wantFood)
+			}
+			if report.TotalAssigned != tt.wantAssigned {
+				t.Errorf("total assigned = %d, want %d", report.TotalAssigned, tt.wantAssigned)
+			}
+			if report.ToBeBudgeted != tt.wantBudgeted {
+				t.Errorf("to be budgeted = %d, want %d", report.ToBeBudgeted, tt.wantBudgeted)
+			}
+		})
+	}
+}
+
+func TestEnvelopeAssignUnknownCategory(t *testing.T) {
+	f := newEnvelopeFixture()
+	if _, err := f.service.Assign(uuid.New(), AssignToEnvelopeInput{CategoryID: f.food.ID, Month: 3, Year: 2026, Amount: 10000}); !errContains(err, "category not found") {
+		t.Errorf("Assign for another user's category error = %v", err)
+	}
+}
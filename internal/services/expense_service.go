@@ -1,7 +1,9 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"log"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,21 +14,42 @@ import (
 
 type ExpenseService struct {
 	expenseRepo   repository.ExpenseRepository
+	refundRepo    repository.ExpenseRefundRepository
 	categoryRepo  repository.CategoryRepository
 	accountRepo   repository.AccountRepository
+	payeeRepo     repository.PayeeRepository
 	ledgerService *LedgerService
+	budgetAlerts  budgetAlertChecker
+	roundUps      roundUpTracker
+}
+
+// budgetAlertChecker is notified after an expense is recorded so budget alerts
+// go out without waiting for the daily run
+type budgetAlertChecker interface {
+	CheckBudgetAlerts(ctx context.Context, userID uuid.UUID)
+}
+
+// roundUpTracker keeps an expense's round-up in step with the expense
+type roundUpTracker interface {
+	Apply(expense *models.Expense) error
+	Sync(expense *models.Expense) error
+	Remove(expense *models.Expense) error
 }
 
 func NewExpenseService(
 	expenseRepo repository.ExpenseRepository,
+	refundRepo repository.ExpenseRefundRepository,
 	categoryRepo repository.CategoryRepository,
 	accountRepo repository.AccountRepository,
+	payeeRepo repository.PayeeRepository,
 	ledgerService *LedgerService,
 ) *ExpenseService {
 	return &ExpenseService{
 		expenseRepo:   expenseRepo,
+		refundRepo:    refundRepo,
 		categoryRepo:  categoryRepo,
 		accountRepo:   accountRepo,
+		payeeRepo:     payeeRepo,
 		ledgerService: ledgerService,
 	}
 }
@@ -39,6 +62,7 @@ type CreateExpenseInput struct {
 	Notes       *string
 	ExpenseDate *time.Time
 	PocketID    *uuid.UUID
+	PayeeID     *uuid.UUID
 }
 
 func (s *ExpenseService) Create(userID uuid.UUID, input CreateExpenseInput) (*models.Expense, error) {
@@ -52,6 +76,24 @@ func (s *ExpenseService) Create(userID uuid.UUID, input CreateExpenseInput) (*mo
 		input.Quantity = 1
 	}
 
+	// Resolve payee: use provided or match by item name, then apply its defaults
+	payee, err := matchPayee(s.payeeRepo, userID, input.PayeeID, input.ItemName)
+	if err != nil {
+		return nil, err
+	}
+	if payee != nil {
+		input.PayeeID = &payee.ID
+		if input.CategoryID == uuid.Nil && payee.DefaultCategoryID != nil {
+			input.CategoryID = *payee.DefaultCategoryID
+		}
+		if input.PocketID == nil && payee.DefaultPocketID != nil {
+			input.PocketID = payee.DefaultPocketID
+		}
+	}
+	if input.CategoryID == uuid.Nil {
+		return nil, errors.New("category is required")
+	}
+
 	// Resolve pocket: use provided or fall back to default
 	pocketID := input.PocketID
 	if pocketID == nil {
@@ -72,6 +114,7 @@ func (s *ExpenseService) Create(userID uuid.UUID, input CreateExpenseInput) (*mo
 		Notes:       input.Notes,
 		ExpenseDate: input.ExpenseDate,
 		PocketID:    pocketID,
+		PayeeID:     input.PayeeID,
 	}
 
 	if err := s.expenseRepo.Create(expense); err != nil {
@@ -83,6 +126,17 @@ func (s *ExpenseService) Create(userID uuid.UUID, input CreateExpenseInput) (*mo
 		return nil, err
 	}
 
+	// A failed round-up shouldn't fail the expense itself
+	if s.roundUps != nil {
+		if err := s.roundUps.Apply(expense); err != nil {
+			log.Printf("Error rounding up expense %s: %v", expense.ID, err)
+		}
+	}
+
+	if s.budgetAlerts != nil {
+		go s.budgetAlerts.CheckBudgetAlerts(context.Background(), userID)
+	}
+
 	return s.expenseRepo.GetByID(expense.ID)
 }
 
@@ -102,6 +156,7 @@ type UpdateExpenseInput struct {
 	Notes       *string
 	ExpenseDate *time.Time
 	PocketID    *uuid.UUID
+	PayeeID     *uuid.UUID
 }
 
 func (s *ExpenseService) Update(id uuid.UUID, input UpdateExpenseInput) (*models.Expense, error) {
@@ -109,6 +164,7 @@ func (s *ExpenseService) Update(id uuid.UUID, input UpdateExpenseInput) (*models
 	if err != nil {
 		return nil, err
 	}
+	total := expense.Total()
 
 	if input.CategoryID != nil {
 		expense.CategoryID = *input.CategoryID
@@ -131,16 +187,50 @@ func (s *ExpenseService) Update(id uuid.UUID, input UpdateExpenseInput) (*models
 	if input.PocketID != nil {
 		expense.PocketID = input.PocketID
 	}
+	if input.PayeeID != nil {
+		payee, err := matchPayee(s.payeeRepo, expense.UserID, input.PayeeID, "")
+		if err != nil {
+			return nil, err
+		}
+		expense.PayeeID = &payee.ID
+	}
+
+	if expense.Total() < expense.RefundedAmount() {
+		return nil, errors.New("expense total cannot be less than refunded amount")
+	}
+	if expense.InstallmentID != nil && expense.Total() != total {
+		return nil, errors.New("amount of an expense paid by installment cannot be changed")
+	}
 
+	// Clear preloaded associations so Save only writes the expense row
+	refunds := expense.Refunds
+	expense.Refunds = nil
+	expense.Category = nil
 	if err := s.expenseRepo.Update(expense); err != nil {
 		return nil, err
 	}
+	expense.Refunds = refunds
 
 	// Update ledger entry
 	if err := s.updateLedgerEntry(expense); err != nil {
 		return nil, err
 	}
 
+	// Refunds follow the expense's category account
+	if input.CategoryID != nil {
+		for i := range expense.Refunds {
+			if err := s.updateRefundLedgerEntry(expense, &expense.Refunds[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if s.roundUps != nil {
+		if err := s.roundUps.Sync(expense); err != nil {
+			return nil, err
+		}
+	}
+
 	return s.expenseRepo.GetByID(expense.ID)
 }
 
@@ -149,6 +239,25 @@ func (s *ExpenseService) Delete(id uuid.UUID) error {
 	if err != nil {
 		return err
 	}
+	if expense.InstallmentID != nil {
+		return errors.New("expense is paid by an installment, delete the installment first")
+	}
+
+	// Delete refunds and their ledger entries first
+	for _, refund := range expense.Refunds {
+		if err := s.ledgerService.DeleteByReference(refund.ID, "expense_refund"); err != nil {
+			return err
+		}
+	}
+	if err := s.refundRepo.DeleteByExpenseID(expense.ID); err != nil {
+		return err
+	}
+
+	if s.roundUps != nil {
+		if err := s.roundUps.Remove(expense); err != nil {
+			return err
+		}
+	}
 
 	// Delete ledger entry first
 	if err := s.ledgerService.DeleteByReference(expense.ID, "expense"); err != nil {
@@ -158,6 +267,80 @@ func (s *ExpenseService) Delete(id uuid.UUID) error {
 	return s.expenseRepo.Delete(id)
 }
 
+type RefundExpenseInput struct {
+	Amount     int64
+	RefundDate time.Time
+	PocketID   *uuid.UUID
+	Notes      *string
+}
+
+// Refund records a full or partial refund of an expense. The refund credits the
+// category's expense account so category totals show net spend.
+func (s *ExpenseService) Refund(userID, expenseID uuid.UUID, input RefundExpenseInput) (*models.Expense, error) {
+	expense, err := s.expenseRepo.GetByID(expenseID)
+	if err != nil {
+		return nil, err
+	}
+	if expense.UserID != userID {
+		return nil, errors.New("expense not found")
+	}
+	if input.Amount <= 0 {
+		return nil, errors.New("refund amount must be positive")
+	}
+	if input.Amount > expense.NetTotal() {
+		return nil, errors.New("refund amount exceeds remaining expense amount")
+	}
+
+	// Resolve pocket: use provided, the expense's pocket, or the default
+	pocketID := input.PocketID
+	if pocketID == nil {
+		pocketID = expense.PocketID
+	}
+	if pocketID == nil {
+		defaultAccount, err := s.accountRepo.GetDefaultByUserID(userID)
+		if err != nil {
+			return nil, errors.New("no default pocket found")
+		}
+		pocketID = &defaultAccount.ID
+	}
+
+	refund := &models.ExpenseRefund{
+		ID:         uuid.New(),
+		ExpenseID:  expense.ID,
+		Amount:     input.Amount,
+		RefundDate: input.RefundDate,
+		PocketID:   pocketID,
+		Notes:      input.Notes,
+	}
+
+	if err := s.refundRepo.Create(refund); err != nil {
+		return nil, err
+	}
+
+	// Create ledger entry: DEBIT Cash Account, CREDIT Expense Account
+	if err := s.createRefundLedgerEntry(expense, refund); err != nil {
+		return nil, err
+	}
+
+	return s.expenseRepo.GetByID(expense.ID)
+}
+
+func (s *ExpenseService) DeleteRefund(userID, refundID uuid.UUID) error {
+	refund, err := s.refundRepo.GetByID(refundID)
+	if err != nil {
+		return err
+	}
+	if refund.Expense == nil || refund.Expense.UserID != userID {
+		return errors.New("refund not found")
+	}
+
+	if err := s.ledgerService.DeleteByReference(refund.ID, "expense_refund"); err != nil {
+		return err
+	}
+
+	return s.refundRepo.Delete(refund.ID)
+}
+
 func (s *ExpenseService) createLedgerEntry(userID uuid.UUID, expense *models.Expense) error {
 	// Get expense account (linked to category)
 	expenseAccount, err := s.accountRepo.GetByReference(expense.CategoryID, "category")
@@ -165,13 +348,8 @@ func (s *ExpenseService) createLedgerEntry(userID uuid.UUID, expense *models.Exp
 		return err
 	}
 
-	// Get pocket account
-	var pocketAccount *models.Account
-	if expense.PocketID != nil {
-		pocketAccount, err = s.accountRepo.GetByID(*expense.PocketID)
-	} else {
-		pocketAccount, err = s.accountRepo.GetDefaultByUserID(userID)
-	}
+	// Get the pocket, or the installment's liability account once converted
+	pocketAccount, err := s.paidFromAccount(expense)
 	if err != nil {
 		return err
 	}
@@ -209,13 +387,8 @@ func (s *ExpenseService) updateLedgerEntry(expense *models.Expense) error {
 		return err
 	}
 
-	// Get pocket account
-	var pocketAccount *models.Account
-	if expense.PocketID != nil {
-		pocketAccount, err = s.accountRepo.GetByID(*expense.PocketID)
-	} else {
-		pocketAccount, err = s.accountRepo.GetDefaultByUserID(expense.UserID)
-	}
+	// Get the pocket, or the installment's liability account once converted
+	pocketAccount, err := s.paidFromAccount(expense)
 	if err != nil {
 		return err
 	}
@@ -234,3 +407,94 @@ func (s *ExpenseService) updateLedgerEntry(expense *models.Expense) error {
 	_, err = s.ledgerService.UpdateJournalEntry(tx.ID, expenseDate, "Expense: "+expense.ItemName, entries)
 	return err
 }
+
+// SetInstallment moves an expense onto the installment it was converted into,
+// or back to its pocket for nil, and reroutes its ledger entry
+func (s *ExpenseService) SetInstallment(expense *models.Expense, installmentID *uuid.UUID) error {
+	expense.InstallmentID = installmentID
+
+	// Clear preloaded associations so Save only writes the expense row
+	refunds := expense.Refunds
+	expense.Refunds = nil
+	expense.Category = nil
+	if err := s.expenseRepo.Update(expense); err != nil {
+		return err
+	}
+	expense.Refunds = refunds
+
+	if err := s.updateLedgerEntry(expense); err != nil {
+		return err
+	}
+
+	// The installment pays instead of the pocket, so there's nothing to round up
+	if s.roundUps != nil {
+		return s.roundUps.Sync(expense)
+	}
+	return nil
+}
+
+// paidFromAccount returns the account credited for an expense: the liability
+// account of the installment it was converted into, or its pocket
+func (s *ExpenseService) paidFromAccount(expense *models.Expense) (*models.Account, error) {
+	if expense.InstallmentID != nil {
+		return s.accountRepo.GetByReference(*expense.InstallmentID, "installment")
+	}
+	if expense.PocketID != nil {
+		return s.accountRepo.GetByID(*expense.PocketID)
+	}
+	return s.accountRepo.GetDefaultByUserID(expense.UserID)
+}
+
+func (s *ExpenseService) refundLedgerEntries(expense *models.Expense, refund *models.ExpenseRefund) ([]LedgerEntry, error) {
+	expenseAccount, err := s.accountRepo.GetByReference(expense.CategoryID, "category")
+	if err != nil {
+		return nil, err
+	}
+
+	var pocketAccount *models.Account
+	if refund.PocketID != nil {
+		pocketAccount, err = s.accountRepo.GetByID(*refund.PocketID)
+	} else {
+		pocketAccount, err = s.accountRepo.GetDefaultByUserID(expense.UserID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return []LedgerEntry{
+		{AccountID: pocketAccount.ID, Debit: refund.Amount, Credit: 0},
+		{AccountID: expenseAccount.ID, Debit: 0, Credit: refund.Amount},
+	}, nil
+}
+
+func (s *ExpenseService) createRefundLedgerEntry(expense *models.Expense, refund *models.ExpenseRefund) error {
+	entries, err := s.refundLedgerEntries(expense, refund)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.ledgerService.CreateJournalEntry(
+		expense.UserID,
+		refund.RefundDate,
+		"Refund: "+expense.ItemName,
+		entries,
+		&refund.ID,
+		"expense_refund",
+	)
+	return err
+}
+
+func (s *ExpenseService) updateRefundLedgerEntry(expense *models.Expense, refund *models.ExpenseRefund) error {
+	tx, err := s.ledgerService.GetTransactionByReference(refund.ID, "expense_refund")
+	if err != nil {
+		return err
+	}
+
+	entries, err := s.refundLedgerEntries(expense, refund)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.ledgerService.UpdateJournalEntry(tx.ID, refund.RefundDate, "Refund: "+expense.ItemName, entries)
+	return err
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"errors"
+	"log"
 	"time"
 
 	"github.com/google/uuid"
@@ -29,10 +30,13 @@ func NewExpenseTemplateGroupService(
 }
 
 type CreateExpenseTemplateGroupInput struct {
-	Name         string
-	RecurringDay *int
-	Notes        *string
-	Items        []CreateExpenseTemplateItemInput
+	Name             string
+	RecurringDay     *int
+	Recurrence       *RecurrenceInput
+	Notes            *string
+	AutoPost         bool
+	NotifyOnAutoPost bool
+	Items            []CreateExpenseTemplateItemInput
 }
 
 type CreateExpenseTemplateItemInput struct {
@@ -54,11 +58,26 @@ func (s *ExpenseTemplateGroupService) Create(userID uuid.UUID, input CreateExpen
 	}
 
 	group := &models.ExpenseTemplateGroup{
-		ID:           uuid.New(),
-		UserID:       userID,
-		Name:         input.Name,
-		RecurringDay: input.RecurringDay,
-		Notes:        input.Notes,
+		ID:               uuid.New(),
+		UserID:           userID,
+		Name:             input.Name,
+		RecurringDay:     input.RecurringDay,
+		Notes:            input.Notes,
+		AutoPost:         input.AutoPost,
+		NotifyOnAutoPost: input.NotifyOnAutoPost,
+	}
+	if input.Recurrence != nil {
+		rule, err := buildRecurrence(*input.Recurrence)
+		if err != nil {
+			return nil, err
+		}
+		group.Recurrence = rule
+	}
+	if group.AutoPost {
+		if group.Schedule() == nil {
+			return nil, errors.New("recurring day or recurrence is required for auto-post")
+		}
+		skipPastTemplateOccurrences(group, time.Now())
 	}
 
 	if err := s.groupRepo.Create(group); err != nil {
@@ -104,9 +123,13 @@ func (s *ExpenseTemplateGroupService) GetByUserID(userID uuid.UUID) ([]models.Ex
 }
 
 type UpdateExpenseTemplateGroupInput struct {
-	Name         *string
-	RecurringDay *int
-	Notes        *string
+	Name             *string
+	RecurringDay     *int
+	Recurrence       *RecurrenceInput
+	ClearRecurrence  bool
+	Notes            *string
+	AutoPost         *bool
+	NotifyOnAutoPost *bool
 }
 
 func (s *ExpenseTemplateGroupService) Update(id uuid.UUID, input UpdateExpenseTemplateGroupInput) (*models.ExpenseTemplateGroup, error) {
@@ -119,11 +142,36 @@ func (s *ExpenseTemplateGroupService) Update(id uuid.UUID, input UpdateExpenseTe
 		group.Name = *input.Name
 	}
 	if input.RecurringDay != nil {
+		if *input.RecurringDay < 1 || *input.RecurringDay > 31 {
+			return nil, errors.New("recurring day must be between 1 and 31")
+		}
 		group.RecurringDay = input.RecurringDay
 	}
 	if input.Notes != nil {
 		group.Notes = input.Notes
 	}
+	if input.ClearRecurrence {
+		group.Recurrence = models.Recurrence{Interval: 1}
+	} else if input.Recurrence != nil {
+		rule, err := buildRecurrence(*input.Recurrence)
+		if err != nil {
+			return nil, err
+		}
+		group.Recurrence = rule
+	}
+	if input.AutoPost != nil {
+		// Enabling auto-post starts from the next occurrence, not a past one
+		if *input.AutoPost && !group.AutoPost {
+			skipPastTemplateOccurrences(group, time.Now())
+		}
+		group.AutoPost = *input.AutoPost
+	}
+	if input.NotifyOnAutoPost != nil {
+		group.NotifyOnAutoPost = *input.NotifyOnAutoPost
+	}
+	if group.AutoPost && group.Schedule() == nil {
+		return nil, errors.New("recurring day or recurrence is required for auto-post")
+	}
 
 	if err := s.groupRepo.Update(group); err != nil {
 		return nil, err
@@ -231,5 +279,96 @@ func (s *ExpenseTemplateGroupService) CreateExpensesFromGroup(userID uuid.UUID,
 		expenses = append(expenses, *expense)
 	}
 
+	// A manual post stands in for the nearest occurrence so the auto-post job won't repeat it
+	if group.Schedule() != nil {
+		postedAt := time.Now()
+		if expenseDate != nil {
+			postedAt = *expenseDate
+		}
+		if _, err := s.groupRepo.MarkPosted(group.ID, manualPostOccurrence(group.Occurrences, postedAt)); err != nil {
+			return expenses, err
+		}
+	}
+
 	return expenses, nil
 }
+
+// AutoPostResult describes the expenses created for one template group by the auto-post job
+type AutoPostResult struct {
+	Group    models.ExpenseTemplateGroup
+	PostDate time.Time
+	Expenses []models.Expense
+}
+
+// AutoPostDueGroups creates expenses for every occurrence of an auto-post group
+// that has been reached since its last posted occurrence. Occurrences missed while
+// the job wasn't running are caught up in order.
+func (s *ExpenseTemplateGroupService) AutoPostDueGroups(now time.Time) ([]AutoPostResult, error) {
+	groups, err := s.groupRepo.GetAutoPostGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	var results []AutoPostResult
+	for _, group := range groups {
+		from := today
+		if group.LastPostedDate != nil {
+			from = group.LastPostedDate.AddDate(0, 0, 1)
+		}
+
+		for _, postDate := range group.Occurrences(from, today) {
+			result, ok := s.autoPostOccurrence(group, postDate)
+			if ok {
+				results = append(results, result)
+			}
+		}
+	}
+
+	return results, nil
+}
+
+func (s *ExpenseTemplateGroupService) autoPostOccurrence(group models.ExpenseTemplateGroup, postDate time.Time) (AutoPostResult, bool) {
+	// Claim the occurrence before posting so concurrent runs can't double-post
+	claimed, err := s.groupRepo.MarkPosted(group.ID, postDate)
+	if err != nil {
+		log.Printf("Error marking template group %s as posted: %v", group.ID, err)
+		return AutoPostResult{}, false
+	}
+	if !claimed {
+		return AutoPostResult{}, false
+	}
+
+	var expenses []models.Expense
+	for _, item := range group.Items {
+		expense, err := s.expenseService.Create(group.UserID, CreateExpenseInput{
+			CategoryID:  item.CategoryID,
+			ItemName:    item.ItemName,
+			UnitPrice:   item.UnitPrice,
+			Quantity:    item.Quantity,
+			Notes:       group.Notes,
+			ExpenseDate: &postDate,
+		})
+		if err != nil {
+			log.Printf("Error auto-posting %s from template group %s: %v", item.ItemName, group.ID, err)
+			continue
+		}
+		expenses = append(expenses, *expense)
+	}
+
+	return AutoPostResult{
+		Group:    group,
+		PostDate: postDate,
+		Expenses: expenses,
+	}, true
+}
+
+// skipPastTemplateOccurrences marks everything up to today as posted, so enabling
+// auto-post doesn't backfill occurrences the user handled by hand
+func skipPastTemplateOccurrences(group *models.ExpenseTemplateGroup, now time.Time) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if group.LastPostedDate == nil || group.LastPostedDate.Before(today) {
+		group.LastPostedDate = &today
+	}
+}
@@ -0,0 +1,235 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+	"github.com/azzamdhx/moneybro/backend/internal/repository"
+)
+
+// fakeExpenseTemplateGroupRepo keeps the last posted date column apart from the
+// groups it lists, so a test can move it as a concurrent run would
+type fakeExpenseTemplateGroupRepo struct {
+	repository.ExpenseTemplateGroupRepository
+	groups     map[uuid.UUID]models.ExpenseTemplateGroup
+	lastPosted map[uuid.UUID]time.Time
+}
+
+func newFakeExpenseTemplateGroupRepo(group models.ExpenseTemplateGroup) *fakeExpenseTemplateGroupRepo {
+	r := &fakeExpenseTemplateGroupRepo{
+		groups:     map[uuid.UUID]models.ExpenseTemplateGroup{group.ID: group},
+		lastPosted: make(map[uuid.UUID]time.Time),
+	}
+	if group.LastPostedDate != nil {
+		r.lastPosted[group.ID] = *group.LastPostedDate
+	}
+	return r
+}
+
+func (r *fakeExpenseTemplateGroupRepo) GetByID(id uuid.UUID) (*models.ExpenseTemplateGroup, error) {
+	group, ok := r.groups[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &group, nil
+}
+
+func (r *fakeExpenseTemplateGroupRepo) GetAutoPostGroups() ([]models.ExpenseTemplateGroup, error) {
+	var groups []models.ExpenseTemplateGroup
+	for _, group := range r.groups {
+		if group.AutoPost {
+			groups = append(groups, group)
+		}
+	}
+	return groups, nil
+}
+
+func (r *fakeExpenseTemplateGroupRepo) MarkPosted(id uuid.UUID, date time.Time) (bool, error) {
+	if last, ok := r.lastPosted[id]; ok && !last.Before(date) {
+		return false, nil
+	}
+	r.lastPosted[id] = date
+	return true, nil
+}
+
+func TestExpenseTemplateAutoPostDueGroups(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+		now  time.Time
+		// listed is the last posted date the job reads; stored is the one the
+		// database holds when the job claims an occurrence
+		listed     *time.Time
+		stored     *time.Time
+		wantPosted string
+		wantLast   string
+	}{
+		{
+			name:       "first run posts today only",
+			rule:       "FREQ=MONTHLY;BYMONTHDAY=10",
+			now:        time.Date(2026, time.April, 10, 8, 0, 0, 0, time.UTC),
+			wantPosted: "2026-04-10",
+			wantLast:   "2026-04-10",
+		},
+		{
+			name:       "missed months are caught up in order",
+			rule:       "FREQ=MONTHLY;BYMONTHDAY=10",
+			now:        time.Date(2026, time.April, 10, 8, 0, 0, 0, time.UTC),
+			listed:     ptrDate(2026, time.January, 10),
+			wantPosted: "2026-02-10 2026-03-10 2026-04-10",
+			wantLast:   "2026-04-10",
+		},
+		{
+			name:     "already posted today",
+			rule:     "FREQ=MONTHLY;BYMONTHDAY=10",
+			now:      time.Date(2026, time.April, 10, 20, 0, 0, 0, time.UTC),
+			listed:   ptrDate(2026, time.April, 10),
+			wantLast: "2026-04-10",
+		},
+		{
+			name:     "not due yet",
+			rule:     "FREQ=MONTHLY;BYMONTHDAY=10",
+			now:      time.Date(2026, time.April, 9, 8, 0, 0, 0, time.UTC),
+			listed:   ptrDate(2026, time.March, 10),
+			wantLast: "2026-03-10",
+		},
+		{
+			name:       "catch-up across the end of February",
+			rule:       "FREQ=MONTHLY;BYMONTHDAY=31",
+			now:        time.Date(2026, time.March, 31, 8, 0, 0, 0, time.UTC),
+			listed:     ptrDate(2026, time.January, 31),
+			wantPosted: "2026-02-28 2026-03-31",
+			wantLast:   "2026-03-31",
+		},
+		{
+			name:       "occurrences claimed by another run are skipped",
+			rule:       "FREQ=MONTHLY;BYMONTHDAY=10",
+			now:        time.Date(2026, time.April, 10, 8, 0, 0, 0, time.UTC),
+			listed:     ptrDate(2026, time.January, 10),
+			stored:     ptrDate(2026, time.March, 10),
+			wantPosted: "2026-04-10",
+			wantLast:   "2026-04-10",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := models.ParseRRule(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRRule: %v", err)
+			}
+			group := models.ExpenseTemplateGroup{
+				ID:             uuid.New(),
+				UserID:         uuid.New(),
+				Name:           "Tagihan",
+				CreatedAt:      time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC),
+				Recurrence:     *rule,
+				AutoPost:       true,
+				LastPostedDate: tt.listed,
+			}
+			groups := newFakeExpenseTemplateGroupRepo(group)
+			if tt.stored != nil {
+				groups.lastPosted[group.ID] = *tt.stored
+			}
+			service := NewExpenseTemplateGroupService(groups, nil, nil)
+
+			results, err := service.AutoPostDueGroups(tt.now)
+			if err != nil {
+				t.Fatalf("AutoPostDueGroups: %v", err)
+			}
+			var posted []time.Time
+			for _, result := range results {
+				posted = append(posted, result.PostDate)
+			}
+			if got := formatDates(posted); got != tt.wantPosted {
+				t.Errorf("posted %q, want %q", got, tt.wantPosted)
+			}
+			if got := lastPostedString(groups.lastPosted, group.ID); got != tt.wantLast {
+				t.Errorf("last posted date = %q, want %q", got, tt.wantLast)
+			}
+		})
+	}
+}
+
+func TestCreateExpensesFromGroupMarksPosted(t *testing.T) {
+	day := 10
+	tests := []struct {
+		name         string
+		recurringDay *int
+		rule         string
+		lastPosted   *time.Time
+		postedOn     time.Time
+		wantLast     string
+	}{
+		{
+			name:         "before the recurring day stands in for it",
+			recurringDay: &day,
+			postedOn:     time.Date(2026, time.April, 8, 0, 0, 0, 0, time.UTC),
+			wantLast:     "2026-04-10",
+		},
+		{
+			name:         "on the recurring day",
+			recurringDay: &day,
+			postedOn:     time.Date(2026, time.April, 10, 0, 0, 0, 0, time.UTC),
+			wantLast:     "2026-04-10",
+		},
+		{
+			name:         "after the recurring day covers the month",
+			recurringDay: &day,
+			lastPosted:   ptrDate(2026, time.March, 10),
+			postedOn:     time.Date(2026, time.April, 12, 0, 0, 0, 0, time.UTC),
+			wantLast:     "2026-04-12",
+		},
+		{
+			name:     "recurrence rule without auto-post",
+			rule:     "FREQ=MONTHLY;BYDAY=-1FR",
+			postedOn: time.Date(2026, time.April, 20, 0, 0, 0, 0, time.UTC),
+			wantLast: "2026-04-24",
+		},
+		{
+			name:         "a later post is kept",
+			recurringDay: &day,
+			lastPosted:   ptrDate(2026, time.May, 10),
+			postedOn:     time.Date(2026, time.April, 8, 0, 0, 0, 0, time.UTC),
+			wantLast:     "2026-05-10",
+		},
+		{
+			name:     "group without a schedule",
+			postedOn: time.Date(2026, time.April, 8, 0, 0, 0, 0, time.UTC),
+			wantLast: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			group := models.ExpenseTemplateGroup{
+				ID:             uuid.New(),
+				UserID:         uuid.New(),
+				Name:           "Tagihan",
+				RecurringDay:   tt.recurringDay,
+				CreatedAt:      time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC),
+				LastPostedDate: tt.lastPosted,
+			}
+			if tt.rule != "" {
+				rule, err := models.ParseRRule(tt.rule)
+				if err != nil {
+					t.Fatalf("ParseRRule: %v", err)
+				}
+				group.Recurrence = *rule
+			}
+			groups := newFakeExpenseTemplateGroupRepo(group)
+			service := NewExpenseTemplateGroupService(groups, nil, nil)
+
+			if _, err := service.CreateExpensesFromGroup(group.UserID, group.ID, &tt.postedOn); err != nil {
+				t.Fatalf("CreateExpensesFromGroup: %v", err)
+			}
+			if got := lastPostedString(groups.lastPosted, group.ID); got != tt.wantLast {
+				t.Errorf("last posted date = %q, want %q", got, tt.wantLast)
+			}
+		})
+	}
+}
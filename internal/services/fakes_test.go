@@ -0,0 +1,505 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+	"github.com/azzamdhx/moneybro/backend/internal/repository"
+)
+
+// memoryStore holds the ledger tables of a test. LedgerService writes to it
+// through the memledger SQL driver and reads it back through the fake
+// repositories, so account balances move as they would in the database.
+type memoryStore struct {
+	mu           sync.Mutex
+	accounts     map[uuid.UUID]*models.Account
+	transactions map[uuid.UUID]*models.Transaction
+	entries      []models.TransactionEntry
+}
+
+var memoryStores sync.Map
+
+func init() {
+	sql.Register("memledger", memoryDriver{})
+}
+
+// newTestLedger returns a LedgerService and account repository backed by a new
+// memory store
+func newTestLedger(t *testing.T) (*LedgerService, *fakeAccountRepo, *memoryStore) {
+	t.Helper()
+	store := &memoryStore{
+		accounts:     make(map[uuid.UUID]*models.Account),
+		transactions: make(map[uuid.UUID]*models.Transaction),
+	}
+	dsn := uuid.NewString()
+	memoryStores.Store(dsn, store)
+	t.Cleanup(func() { memoryStores.Delete(dsn) })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DriverName: "memledger", DSN: dsn}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open memory ledger: %v", err)
+	}
+
+	accounts := &fakeAccountRepo{store: store}
+	ledger := NewLedgerService(db, accounts, &fakeTransactionRepo{store: store}, &fakeTransactionEntryRepo{store: store})
+	return ledger, accounts, store
+}
+
+// balance returns an account's current balance
+func (s *memoryStore) balance(id uuid.UUID) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if account, ok := s.accounts[id]; ok {
+		return account.CurrentBalance
+	}
+	return 0
+}
+
+// countByReference returns the number of journal entries for a reference
+func (s *memoryStore) countByReference(referenceType string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var count int
+	for _, tx := range s.transactions {
+		if tx.ReferenceType != nil && *tx.ReferenceType == referenceType {
+			count++
+		}
+	}
+	return count
+}
+
+var (
+	insertPattern        = regexp.MustCompile(`^INSERT INTO "(\w+)" \((.*?)\) VALUES \(.*?\)(?: RETURNING (.*))?$`)
+	balancePattern       = regexp.MustCompile(`^UPDATE "accounts" SET "current_balance"=current_balance \+ \$1 WHERE id = \$2$`)
+	updateTxPattern      = regexp.MustCompile(`^UPDATE "transactions" SET (.*) WHERE id = \$\d+$`)
+	deleteEntriesPattern = regexp.MustCompile(`^DELETE FROM "transaction_entries" WHERE transaction_id = \$1$`)
+	deleteTxPattern      = regexp.MustCompile(`^DELETE FROM "transactions" WHERE id = \$1$`)
+	columnPattern        = regexp.MustCompile(`"(\w+)"`)
+)
+
+// run applies one statement of the ledger to the store and returns the
+// RETURNING row of an insert
+func (s *memoryStore) run(query string, args []driver.NamedValue) ([]string, []driver.Value, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+
+	if m := insertPattern.FindStringSubmatch(query); m != nil {
+		row := make(map[string]driver.Value)
+		for i, column := range columnPattern.FindAllStringSubmatch(m[2], -1) {
+			row[column[1]] = values[i]
+		}
+		id := uuid.New()
+		row["id"] = id.String()
+		if row["created_at"] == nil {
+			row["created_at"] = time.Now()
+		}
+
+		switch m[1] {
+		case "transactions":
+			tx := &models.Transaction{
+				ID:              id,
+				UserID:          asUUID(row["user_id"]),
+				TransactionDate: asTime(row["transaction_date"]),
+				Description:     asString(row["description"]),
+			}
+			if ref, ok := row["reference_id"].(string); ok {
+				refID := uuid.MustParse(ref)
+				tx.ReferenceID = &refID
+			}
+			if ref, ok := row["reference_type"].(string); ok {
+				tx.ReferenceType = &ref
+			}
+			s.transactions[id] = tx
+		case "transaction_entries":
+			s.entries = append(s.entries, models.TransactionEntry{
+				ID:            id,
+				TransactionID: asUUID(row["transaction_id"]),
+				AccountID:     asUUID(row["account_id"]),
+				Debit:         asInt64(row["debit"]),
+				Credit:        asInt64(row["credit"]),
+			})
+		default:
+			return nil, nil, fmt.Errorf("memledger: unexpected insert into %s", m[1])
+		}
+
+		var columns []string
+		var returned []driver.Value
+		for _, column := range columnPattern.FindAllStringSubmatch(m[3], -1) {
+			columns = append(columns, column[1])
+			value, ok := row[column[1]]
+			if !ok || value == nil {
+				value = int64(0)
+			}
+			returned = append(returned, value)
+		}
+		return columns, returned, nil
+	}
+
+	switch {
+	case balancePattern.MatchString(query):
+		if account, ok := s.accounts[asUUID(values[1])]; ok {
+			account.CurrentBalance += asInt64(values[0])
+		}
+	case updateTxPattern.MatchString(query):
+		tx, ok := s.transactions[asUUID(values[len(values)-1])]
+		if !ok {
+			return nil, nil, nil
+		}
+		set := updateTxPattern.FindStringSubmatch(query)[1]
+		for i, column := range columnPattern.FindAllStringSubmatch(set, -1) {
+			switch column[1] {
+			case "description":
+				tx.Description = asString(values[i])
+			case "transaction_date":
+				tx.TransactionDate = asTime(values[i])
+			}
+		}
+	case deleteEntriesPattern.MatchString(query):
+		transactionID := asUUID(values[0])
+		kept := s.entries[:0]
+		for _, entry := range s.entries {
+			if entry.TransactionID != transactionID {
+				kept = append(kept, entry)
+			}
+		}
+		s.entries = kept
+	case deleteTxPattern.MatchString(query):
+		delete(s.transactions, asUUID(values[0]))
+	default:
+		return nil, nil, fmt.Errorf("memledger: unexpected statement %q", query)
+	}
+	return nil, nil, nil
+}
+
+func asUUID(v driver.Value) uuid.UUID {
+	switch v := v.(type) {
+	case string:
+		return uuid.MustParse(v)
+	case []byte:
+		return uuid.MustParse(string(v))
+	}
+	return uuid.Nil
+}
+
+func asInt64(v driver.Value) int64 {
+	n, _ := v.(int64)
+	return n
+}
+
+func asString(v driver.Value) string {
+	s, _ := v.(string)
+	return s
+}
+
+func asTime(v driver.Value) time.Time {
+	t, _ := v.(time.Time)
+	return t
+}
+
+// memoryDriver is a database/sql driver over a memoryStore, enough for the
+// statements LedgerService runs
+type memoryDriver struct{}
+
+func (memoryDriver) Open(dsn string) (driver.Conn, error) {
+	store, ok := memoryStores.Load(dsn)
+	if !ok {
+		return nil, fmt.Errorf("memledger: unknown store %s", dsn)
+	}
+	return &memoryConn{store: store.(*memoryStore)}, nil
+}
+
+type memoryConn struct {
+	store *memoryStore
+}
+
+func (c *memoryConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("memledger: prepared statements are not supported")
+}
+
+func (c *memoryConn) Close() error { return nil }
+
+func (c *memoryConn) Begin() (driver.Tx, error) { return memoryTx{}, nil }
+
+func (c *memoryConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return memoryTx{}, nil
+}
+
+func (c *memoryConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if _, _, err := c.store.run(query, args); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *memoryConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	columns, row, err := c.store.run(query, args)
+	if err != nil {
+		return nil, err
+	}
+	return &memoryRows{columns: columns, row: row}, nil
+}
+
+// memoryTx doesn't roll back; a test that fails halfway fails anyway
+type memoryTx struct{}
+
+func (memoryTx) Commit() error   { return nil }
+func (memoryTx) Rollback() error { return nil }
+
+type memoryRows struct {
+	columns []string
+	row     []driver.Value
+	done    bool
+}
+
+func (r *memoryRows) Columns() []string { return r.columns }
+
+func (r *memoryRows) Close() error { return nil }
+
+func (r *memoryRows) Next(dest []driver.Value) error {
+	if r.done || r.row == nil {
+		return io.EOF
+	}
+	copy(dest, r.row)
+	r.done = true
+	return nil
+}
+
+type fakeAccountRepo struct {
+	repository.AccountRepository
+	store *memoryStore
+}
+
+func (r *fakeAccountRepo) Create(account *models.Account) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if account.ID == uuid.Nil {
+		account.ID = uuid.New()
+	}
+	saved := *account
+	r.store.accounts[account.ID] = &saved
+	return nil
+}
+
+func (r *fakeAccountRepo) GetByID(id uuid.UUID) (*models.Account, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	account, ok := r.store.accounts[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	found := *account
+	return &found, nil
+}
+
+func (r *fakeAccountRepo) GetDefaultByUserID(userID uuid.UUID) (*models.Account, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for _, account := range r.store.accounts {
+		if account.UserID == userID && account.IsDefault {
+			found := *account
+			return &found, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeAccountRepo) GetByReference(referenceID uuid.UUID, referenceType string) (*models.Account, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for _, account := range r.store.accounts {
+		if account.ReferenceID != nil && *account.ReferenceID == referenceID && *account.ReferenceType == referenceType {
+			found := *account
+			return &found, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeAccountRepo) DeleteByReference(referenceID uuid.UUID, referenceType string) error {
+	account, err := r.GetByReference(referenceID, referenceType)
+	if err != nil {
+		return nil
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	delete(r.store.accounts, account.ID)
+	return nil
+}
+
+type fakeTransactionRepo struct {
+	repository.TransactionRepository
+	store *memoryStore
+}
+
+func (r *fakeTransactionRepo) GetByID(id uuid.UUID) (*models.Transaction, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	tx, ok := r.store.transactions[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	found := *tx
+	return &found, nil
+}
+
+func (r *fakeTransactionRepo) GetByReference(referenceID uuid.UUID, referenceType string) (*models.Transaction, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for _, tx := range r.store.transactions {
+		if tx.ReferenceID != nil && *tx.ReferenceID == referenceID && *tx.ReferenceType == referenceType {
+			found := *tx
+			return &found, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeTransactionRepo) GetByUserIDAndDateRangeAndReferenceType(userID uuid.UUID, startDate, endDate, referenceType string) ([]models.Transaction, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	var transactions []models.Transaction
+	for _, tx := range r.store.transactions {
+		date := tx.TransactionDate.Format("2006-01-02")
+		if tx.UserID != userID || date < startDate || date > endDate || tx.ReferenceType == nil || *tx.ReferenceType != referenceType {
+			continue
+		}
+		found := *tx
+		for _, entry := range r.store.entries {
+			if entry.TransactionID == tx.ID {
+				account := *r.store.accounts[entry.AccountID]
+				entry.Account = &account
+				found.Entries = append(found.Entries, entry)
+			}
+		}
+		transactions = append(transactions, found)
+	}
+	return transactions, nil
+}
+
+type fakeTransactionEntryRepo struct {
+	repository.TransactionEntryRepository
+	store *memoryStore
+}
+
+func (r *fakeTransactionEntryRepo) GetByTransactionID(transactionID uuid.UUID) ([]models.TransactionEntry, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	var entries []models.TransactionEntry
+	for _, entry := range r.store.entries {
+		if entry.TransactionID == transactionID {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// newPocket adds a pocket account with a starting balance
+func newPocket(t *testing.T, accounts *fakeAccountRepo, userID uuid.UUID, balance int64, isDefault bool) *models.Account {
+	t.Helper()
+	pocket := &models.Account{
+		UserID:         userID,
+		Name:           "Pocket",
+		AccountType:    models.AccountTypeAsset,
+		CurrentBalance: balance,
+		IsDefault:      isDefault,
+		IsPocket:       true,
+	}
+	if err := accounts.Create(pocket); err != nil {
+		t.Fatalf("create pocket: %v", err)
+	}
+	return pocket
+}
+
+type fakeCategoryRepo struct {
+	repository.CategoryRepository
+	categories []models.Category
+}
+
+func (r *fakeCategoryRepo) GetByID(id uuid.UUID) (*models.Category, error) {
+	for _, category := range r.categories {
+		if category.ID == id {
+			found := category
+			return &found, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeCategoryRepo) GetByUserID(userID uuid.UUID) ([]models.Category, error) {
+	var categories []models.Category
+	for _, category := range r.categories {
+		if category.UserID == userID {
+			categories = append(categories, category)
+		}
+	}
+	return categories, nil
+}
+
+// fakeSpendingRepo reports category spending by month ("2006-01") without a ledger
+type fakeSpendingRepo struct {
+	repository.TransactionEntryRepository
+	spending map[string][]repository.CategorySpending
+	// movements are the net debits booked on an account after the report month
+	movements map[uuid.UUID]int64
+}
+
+func (r *fakeSpendingRepo) SumExpenseByCategory(userID uuid.UUID, startDate, endDate string) ([]repository.CategorySpending, error) {
+	return r.spending[startDate[:7]], nil
+}
+
+func (r *fakeSpendingRepo) SumByAccountIDAndDateRange(accountID uuid.UUID, startDate, endDate string) (int64, int64, error) {
+	return r.movements[accountID], 0, nil
+}
+
+// errContains reports whether err matches the expected error text, where an
+// empty want means no error
+func errContains(err error, want string) bool {
+	if want == "" {
+		return err == nil
+	}
+	return err != nil && strings.Contains(err.Error(), want)
+}
+
+// lastPostedString returns the stored last posted date, or "" when none is set
+func lastPostedString(lastPosted map[uuid.UUID]time.Time, id uuid.UUID) string {
+	if last, ok := lastPosted[id]; ok {
+		return last.Format("2006-01-02")
+	}
+	return ""
+}
+
+// formatDates joins dates as YYYY-MM-DD for comparing schedules
+func formatDates(dates []time.Time) string {
+	parts := make([]string, len(dates))
+	for i, date := range dates {
+		parts[i] = date.Format("2006-01-02")
+	}
+	return strings.Join(parts, " ")
+}
+
+// ptrDate returns a pointer to a UTC date
+func ptrDate(year int, month time.Month, day int) *time.Time {
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return &date
+}
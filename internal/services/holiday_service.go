@@ -0,0 +1,66 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+	"github.com/azzamdhx/moneybro/backend/internal/repository"
+)
+
+type HolidayService struct {
+	holidayRepo repository.HolidayRepository
+}
+
+func NewHolidayService(holidayRepo repository.HolidayRepository) *HolidayService {
+	return &HolidayService{
+		holidayRepo: holidayRepo,
+	}
+}
+
+func (s *HolidayService) Create(userID uuid.UUID, date time.Time, name string) (*models.Holiday, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("holiday name is required")
+	}
+
+	day := date.Format("2006-01-02")
+	existing, err := s.holidayRepo.GetByUserIDAndDateRange(userID, day, day)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) > 0 {
+		return nil, errors.New("a holiday already exists on this date")
+	}
+
+	holiday := &models.Holiday{
+		ID:     uuid.New(),
+		UserID: userID,
+		Date:   date,
+		Name:   name,
+	}
+	if err := s.holidayRepo.Create(holiday); err != nil {
+		return nil, err
+	}
+
+	return s.holidayRepo.GetByID(holiday.ID)
+}
+
+func (s *HolidayService) GetByYear(userID uuid.UUID, year int) ([]models.Holiday, error) {
+	return s.holidayRepo.GetByUserIDAndDateRange(userID, fmt.Sprintf("%d-01-01", year), fmt.Sprintf("%d-12-31", year))
+}
+
+func (s *HolidayService) Delete(userID uuid.UUID, id uuid.UUID) error {
+	holiday, err := s.holidayRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if holiday.UserID != userID {
+		return errors.New("holiday not found")
+	}
+	return s.holidayRepo.Delete(id)
+}
@@ -21,7 +21,7 @@ func NewIncomeCategoryService(incomeCategoryRepo repository.IncomeCategoryReposi
 	}
 }
 
-func (s *IncomeCategoryService) Create(userID uuid.UUID, name string) (*models.IncomeCategory, error) {
+func (s *IncomeCategoryService) Create(userID uuid.UUID, name string, parentID *uuid.UUID) (*models.IncomeCategory, error) {
 	if name == "" {
 		return nil, errors.New("income category name is required")
 	}
@@ -32,6 +32,13 @@ func (s *IncomeCategoryService) Create(userID uuid.UUID, name string) (*models.I
 		Name:   name,
 	}
 
+	if parentID != nil {
+		if err := s.validateParent(category, *parentID); err != nil {
+			return nil, err
+		}
+		category.ParentID = parentID
+	}
+
 	if err := s.incomeCategoryRepo.Create(category); err != nil {
 		return nil, err
 	}
@@ -52,7 +59,16 @@ func (s *IncomeCategoryService) GetByUserID(userID uuid.UUID) ([]models.IncomeCa
 	return s.incomeCategoryRepo.GetByUserID(userID)
 }
 
-func (s *IncomeCategoryService) Update(id uuid.UUID, name string) (*models.IncomeCategory, error) {
+// GetRootMap maps every income category of the user to its top-level ancestor
+func (s *IncomeCategoryService) GetRootMap(userID uuid.UUID) (map[uuid.UUID]models.IncomeCategory, error) {
+	categories, err := s.incomeCategoryRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return rootIncomeCategoryMap(categories), nil
+}
+
+func (s *IncomeCategoryService) Update(id uuid.UUID, name string, parentID *uuid.UUID, clearParent bool) (*models.IncomeCategory, error) {
 	category, err := s.incomeCategoryRepo.GetByID(id)
 	if err != nil {
 		return nil, err
@@ -64,6 +80,15 @@ func (s *IncomeCategoryService) Update(id uuid.UUID, name string) (*models.Incom
 
 	category.Name = name
 
+	if clearParent {
+		category.ParentID = nil
+	} else if parentID != nil {
+		if err := s.validateParent(category, *parentID); err != nil {
+			return nil, err
+		}
+		category.ParentID = parentID
+	}
+
 	if err := s.incomeCategoryRepo.Update(category); err != nil {
 		return nil, err
 	}
@@ -71,10 +96,129 @@ func (s *IncomeCategoryService) Update(id uuid.UUID, name string) (*models.Incom
 	return category, nil
 }
 
+// Delete removes the income category. Its children move up to the deleted
+// category's parent (or become top-level) and keep their own ledger accounts.
 func (s *IncomeCategoryService) Delete(id uuid.UUID) error {
+	category, err := s.incomeCategoryRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.incomeCategoryRepo.ReparentChildren(id, category.ParentID); err != nil {
+		return err
+	}
+
 	// Delete linked account first
 	if err := s.accountService.DeleteAccountByReference(id, "income_category"); err != nil {
 		return err
 	}
 	return s.incomeCategoryRepo.Delete(id)
 }
+
+// Merge consolidates the source income categories into the target. Incomes,
+// recurring items and ledger history move to the target; the sources are deleted.
+func (s *IncomeCategoryService) Merge(userID uuid.UUID, sourceIDs []uuid.UUID, targetID uuid.UUID) (*models.IncomeCategory, error) {
+	target, err := s.incomeCategoryRepo.GetByID(targetID)
+	if err != nil || target.UserID != userID {
+		return nil, errors.New("target income category not found")
+	}
+
+	sources := make(map[uuid.UUID]bool)
+	for _, id := range sourceIDs {
+		if id == targetID {
+			return nil, errors.New("target income category cannot be one of the sources")
+		}
+		source, err := s.incomeCategoryRepo.GetByID(id)
+		if err != nil || source.UserID != userID {
+			return nil, errors.New("source income category not found")
+		}
+		sources[id] = true
+	}
+	if len(sources) == 0 {
+		return nil, errors.New("at least one source income category is required")
+	}
+
+	// If the target sits below a source, attach it to the nearest surviving ancestor
+	for target.ParentID != nil && sources[*target.ParentID] {
+		parent, err := s.incomeCategoryRepo.GetByID(*target.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		target.ParentID = parent.ParentID
+	}
+
+	ids := make([]uuid.UUID, 0, len(sources))
+	for id := range sources {
+		ids = append(ids, id)
+	}
+	if err := s.incomeCategoryRepo.Merge(ids, target); err != nil {
+		return nil, err
+	}
+
+	return s.incomeCategoryRepo.GetByID(targetID)
+}
+
+func (s *IncomeCategoryService) validateParent(category *models.IncomeCategory, parentID uuid.UUID) error {
+	parent, err := s.incomeCategoryRepo.GetByID(parentID)
+	if err != nil || parent.UserID != category.UserID {
+		return errors.New("parent income category not found")
+	}
+
+	// Walk up the ancestors to prevent cycles
+	for current := parent; current != nil; {
+		if current.ID == category.ID {
+			return errors.New("income category cannot be its own ancestor")
+		}
+		if current.ParentID == nil {
+			break
+		}
+		current, err = s.incomeCategoryRepo.GetByID(*current.ParentID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadIncomeCategoryRoots returns the top-level ancestor of every income
+// category when rolling up, or nil when reporting leaf categories
+func loadIncomeCategoryRoots(repo repository.IncomeCategoryRepository, userID uuid.UUID, grouping CategoryGrouping) (map[uuid.UUID]models.IncomeCategory, error) {
+	if grouping != CategoryGroupingRollup {
+		return nil, nil
+	}
+	categories, err := repo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return rootIncomeCategoryMap(categories), nil
+}
+
+// reportIncomeCategory returns the income category an amount is reported under
+func reportIncomeCategory(roots map[uuid.UUID]models.IncomeCategory, category models.IncomeCategory) models.IncomeCategory {
+	if root, ok := roots[category.ID]; ok {
+		return root
+	}
+	return category
+}
+
+// rootIncomeCategoryMap maps every income category to its top-level ancestor
+func rootIncomeCategoryMap(categories []models.IncomeCategory) map[uuid.UUID]models.IncomeCategory {
+	byID := make(map[uuid.UUID]models.IncomeCategory, len(categories))
+	for _, c := range categories {
+		byID[c.ID] = c
+	}
+
+	roots := make(map[uuid.UUID]models.IncomeCategory, len(categories))
+	for _, c := range categories {
+		root := c
+		for i := 0; root.ParentID != nil && i < len(categories); i++ {
+			parent, ok := byID[*root.ParentID]
+			if !ok {
+				break
+			}
+			root = parent
+		}
+		roots[c.ID] = root
+	}
+	return roots
+}
@@ -14,6 +14,7 @@ type IncomeService struct {
 	incomeRepo         repository.IncomeRepository
 	incomeCategoryRepo repository.IncomeCategoryRepository
 	accountRepo        repository.AccountRepository
+	payeeRepo          repository.PayeeRepository
 	ledgerService      *LedgerService
 }
 
@@ -21,12 +22,14 @@ func NewIncomeService(
 	incomeRepo repository.IncomeRepository,
 	incomeCategoryRepo repository.IncomeCategoryRepository,
 	accountRepo repository.AccountRepository,
+	payeeRepo repository.PayeeRepository,
 	ledgerService *LedgerService,
 ) *IncomeService {
 	return &IncomeService{
 		incomeRepo:         incomeRepo,
 		incomeCategoryRepo: incomeCategoryRepo,
 		accountRepo:        accountRepo,
+		payeeRepo:          payeeRepo,
 		ledgerService:      ledgerService,
 	}
 }
@@ -39,6 +42,7 @@ type CreateIncomeInput struct {
 	IsRecurring bool
 	Notes       *string
 	PocketID    *uuid.UUID
+	PayeeID     *uuid.UUID
 }
 
 type UpdateIncomeInput struct {
@@ -49,6 +53,7 @@ type UpdateIncomeInput struct {
 	IsRecurring *bool
 	Notes       *string
 	PocketID    *uuid.UUID
+	PayeeID     *uuid.UUID
 }
 
 func (s *IncomeService) Create(userID uuid.UUID, input CreateIncomeInput) (*models.Income, error) {
@@ -69,6 +74,18 @@ func (s *IncomeService) Create(userID uuid.UUID, input CreateIncomeInput) (*mode
 		incomeDate = *input.IncomeDate
 	}
 
+	// Resolve payee: use provided or match by source name
+	payee, err := matchPayee(s.payeeRepo, userID, input.PayeeID, input.SourceName)
+	if err != nil {
+		return nil, err
+	}
+	if payee != nil {
+		input.PayeeID = &payee.ID
+		if input.PocketID == nil && payee.DefaultPocketID != nil {
+			input.PocketID = payee.DefaultPocketID
+		}
+	}
+
 	// Resolve pocket: use provided or fall back to default
 	pocketID := input.PocketID
 	if pocketID == nil {
@@ -89,6 +106,7 @@ func (s *IncomeService) Create(userID uuid.UUID, input CreateIncomeInput) (*mode
 		IsRecurring: input.IsRecurring,
 		Notes:       input.Notes,
 		PocketID:    pocketID,
+		PayeeID:     input.PayeeID,
 	}
 
 	if err := s.incomeRepo.Create(income); err != nil {
@@ -153,6 +171,13 @@ func (s *IncomeService) Update(id uuid.UUID, input UpdateIncomeInput) (*models.I
 	if input.PocketID != nil {
 		income.PocketID = input.PocketID
 	}
+	if input.PayeeID != nil {
+		payee, err := matchPayee(s.payeeRepo, income.UserID, input.PayeeID, "")
+		if err != nil {
+			return nil, err
+		}
+		income.PayeeID = &payee.ID
+	}
 
 	if err := s.incomeRepo.Update(income); err != nil {
 		return nil, err
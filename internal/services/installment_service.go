@@ -2,35 +2,49 @@ package services
 
 import (
 	"errors"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 
 	"github.com/azzamdhx/moneybro/backend/internal/models"
 	"github.com/azzamdhx/moneybro/backend/internal/repository"
 )
 
 type InstallmentService struct {
-	installmentRepo repository.InstallmentRepository
-	paymentRepo     repository.InstallmentPaymentRepository
-	accountRepo     repository.AccountRepository
-	accountService  *AccountService
-	ledgerService   *LedgerService
+	installmentRepo   repository.InstallmentRepository
+	paymentRepo       repository.InstallmentPaymentRepository
+	prepaymentRepo    repository.InstallmentPrepaymentRepository
+	termRepo          repository.InstallmentTermRepository
+	consolidationRepo repository.DebtConsolidationRepository
+	accountRepo       repository.AccountRepository
+	accountService    *AccountService
+	ledgerService     *LedgerService
+	expenseService    *ExpenseService
 }
 
 func NewInstallmentService(
 	installmentRepo repository.InstallmentRepository,
 	paymentRepo repository.InstallmentPaymentRepository,
+	prepaymentRepo repository.InstallmentPrepaymentRepository,
+	termRepo repository.InstallmentTermRepository,
+	consolidationRepo repository.DebtConsolidationRepository,
 	accountRepo repository.AccountRepository,
 	accountService *AccountService,
 	ledgerService *LedgerService,
+	expenseService *ExpenseService,
 ) *InstallmentService {
 	return &InstallmentService{
-		installmentRepo: installmentRepo,
-		paymentRepo:     paymentRepo,
-		accountRepo:     accountRepo,
-		accountService:  accountService,
-		ledgerService:   ledgerService,
+		installmentRepo:   installmentRepo,
+		paymentRepo:       paymentRepo,
+		prepaymentRepo:    prepaymentRepo,
+		termRepo:          termRepo,
+		consolidationRepo: consolidationRepo,
+		accountRepo:       accountRepo,
+		accountService:    accountService,
+		ledgerService:     ledgerService,
+		expenseService:    expenseService,
 	}
 }
 
@@ -42,9 +56,13 @@ type CreateInstallmentInput struct {
 	Tenor          int
 	StartDate      time.Time
 	DueDay         int
-	Icon           *string
-	CardBgColor    *string
-	Notes          *string
+	InterestMethod models.InstallmentInterestMethod
+	// InterestRate is an annual percentage; when set, MonthlyPayment and
+	// LoanAmount are computed from ActualAmount instead of taken from the input
+	InterestRate *float64
+	Icon         *string
+	CardBgColor  *string
+	Notes        *string
 }
 
 func (s *InstallmentService) Create(userID uuid.UUID, input CreateInstallmentInput) (*models.Installment, error) {
@@ -54,15 +72,18 @@ func (s *InstallmentService) Create(userID uuid.UUID, input CreateInstallmentInp
 	if input.ActualAmount <= 0 {
 		return nil, errors.New("actual amount must be positive")
 	}
+	if input.Tenor <= 0 {
+		return nil, errors.New("tenor must be positive")
+	}
+	if err := applyInterestRate(&input); err != nil {
+		return nil, err
+	}
 	if input.LoanAmount <= 0 {
 		return nil, errors.New("loan amount must be positive")
 	}
 	if input.MonthlyPayment <= 0 {
 		return nil, errors.New("monthly payment must be positive")
 	}
-	if input.Tenor <= 0 {
-		return nil, errors.New("tenor must be positive")
-	}
 	if input.DueDay < 1 || input.DueDay > 31 {
 		return nil, errors.New("due day must be between 1 and 31")
 	}
@@ -77,6 +98,8 @@ func (s *InstallmentService) Create(userID uuid.UUID, input CreateInstallmentInp
 		Tenor:          input.Tenor,
 		StartDate:      input.StartDate,
 		DueDay:         input.DueDay,
+		InterestMethod: input.InterestMethod,
+		InterestRate:   input.InterestRate,
 		Status:         models.InstallmentStatusActive,
 		Icon:           input.Icon,
 		CardBgColor:    input.CardBgColor,
@@ -109,6 +132,24 @@ func (s *InstallmentService) Update(id uuid.UUID, input CreateInstallmentInput,
 		return nil, err
 	}
 
+	if input.InterestMethod == "" {
+		input.InterestMethod = models.InstallmentInterestMethodFlat
+	}
+
+	// The payment figures are only derived again when the loan itself changes,
+	// so a rename keeps what prepayments and restructures have set
+	if termsChanged(installment, input) {
+		if len(installment.Terms) > 0 || len(installment.Prepayments) > 0 {
+			return nil, errors.New("installment has been restructured or prepaid, use restructureInstallment to change its terms")
+		}
+		if input.Tenor <= 0 {
+			return nil, errors.New("tenor must be positive")
+		}
+		if err := applyInterestRate(&input); err != nil {
+			return nil, err
+		}
+	}
+
 	installment.Name = input.Name
 	installment.ActualAmount = input.ActualAmount
 	installment.LoanAmount = input.LoanAmount
@@ -116,6 +157,8 @@ func (s *InstallmentService) Update(id uuid.UUID, input CreateInstallmentInput,
 	installment.Tenor = input.Tenor
 	installment.StartDate = input.StartDate
 	installment.DueDay = input.DueDay
+	installment.InterestMethod = input.InterestMethod
+	installment.InterestRate = input.InterestRate
 	installment.Icon = input.Icon
 	installment.CardBgColor = input.CardBgColor
 	installment.Notes = input.Notes
@@ -137,11 +180,34 @@ func (s *InstallmentService) Delete(id uuid.UUID) error {
 	if err != nil {
 		return err
 	}
+	if _, err := s.consolidationRepo.GetByLoanID(id); err == nil {
+		return errors.New("installment pays off a consolidation and cannot be deleted")
+	}
+	if installment.ConsolidationID != nil {
+		return errors.New("installment was paid off by a consolidation and cannot be deleted")
+	}
 
 	// Delete all payment transactions first (before CASCADE deletes payments)
 	for _, payment := range installment.Payments {
 		_ = s.ledgerService.DeleteByReference(payment.ID, "installment_payment")
 	}
+	for _, prepayment := range installment.Prepayments {
+		_ = s.ledgerService.DeleteByReference(prepayment.ID, "installment_prepayment")
+	}
+	for _, term := range installment.Terms {
+		_ = s.ledgerService.DeleteByReference(term.ID, "installment_restructure")
+	}
+	_ = s.ledgerService.DeleteByReference(id, "installment_fee")
+
+	// A converted purchase goes back to being paid from its pocket
+	if installment.ExpenseID != nil {
+		expense, err := s.expenseService.GetByID(*installment.ExpenseID)
+		if err == nil && expense.InstallmentID != nil && *expense.InstallmentID == id {
+			if err := s.expenseService.SetInstallment(expense, nil); err != nil {
+				return err
+			}
+		}
+	}
 
 	// Delete linked account
 	if err := s.accountService.DeleteAccountByReference(id, "installment"); err != nil {
@@ -190,6 +256,100 @@ func (s *InstallmentService) RecordPayment(installmentID uuid.UUID, amount int64
 	return payment, nil
 }
 
+// UpdatePayment corrects a recorded payment and its ledger entry
+func (s *InstallmentService) UpdatePayment(userID, id uuid.UUID, input UpdatePaymentInput) (*models.InstallmentPayment, error) {
+	payment, err := s.getOwnedPayment(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := input.apply(&payment.Amount, &payment.PaidAt, &payment.PocketID); err != nil {
+		return nil, err
+	}
+
+	if err := s.paymentRepo.Update(payment); err != nil {
+		return nil, err
+	}
+	if err := s.renumberPayments(payment.InstallmentID, payment.ID); err != nil {
+		return nil, err
+	}
+
+	return s.paymentRepo.GetByID(id)
+}
+
+// DeletePayment removes a recorded payment and its ledger entry. A completed
+// installment is reopened when it no longer has all its payments.
+func (s *InstallmentService) DeletePayment(userID, id uuid.UUID) error {
+	payment, err := s.getOwnedPayment(userID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.ledgerService.DeleteByReference(payment.ID, "installment_payment"); err != nil {
+		return err
+	}
+	if err := s.paymentRepo.Delete(id); err != nil {
+		return err
+	}
+	if err := s.renumberPayments(payment.InstallmentID, uuid.Nil); err != nil {
+		return err
+	}
+
+	installment, err := s.installmentRepo.GetByID(payment.InstallmentID)
+	if err != nil {
+		return err
+	}
+	if installment.Status == models.InstallmentStatusCompleted && installment.PaidCount() < installment.Tenor {
+		installment.Status = models.InstallmentStatusActive
+		return s.installmentRepo.Update(installment)
+	}
+	return nil
+}
+
+// renumberPayments numbers the installment's payments 1..n in date order. The
+// ledger entry of a renumbered payment moves to its new period, as does the
+// one of changedID.
+func (s *InstallmentService) renumberPayments(installmentID, changedID uuid.UUID) error {
+	// The periods depend on the installment's terms, which the payment doesn't preload
+	installment, err := s.installmentRepo.GetByID(installmentID)
+	if err != nil {
+		return err
+	}
+	payments, err := s.paymentRepo.GetByInstallmentID(installmentID)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(payments, func(i, j int) bool { return payments[i].PaidAt.Before(payments[j].PaidAt) })
+
+	for i := range payments {
+		payment := &payments[i]
+		if payment.PaymentNumber == i+1 && payment.ID != changedID {
+			continue
+		}
+		payment.PaymentNumber = i + 1
+		if err := s.paymentRepo.Update(payment); err != nil {
+			return err
+		}
+		if err := s.updatePaymentLedgerEntry(installment.UserID, installment, payment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *InstallmentService) getOwnedPayment(userID, id uuid.UUID) (*models.InstallmentPayment, error) {
+	payment, err := s.paymentRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if payment.Installment == nil || payment.Installment.UserID != userID {
+		return nil, errors.New("installment payment not found")
+	}
+	if payment.Installment.ConsolidationID != nil {
+		return nil, errors.New("installment was paid off by a consolidation")
+	}
+	return payment, nil
+}
+
 func (s *InstallmentService) MarkComplete(id uuid.UUID) (*models.Installment, error) {
 	installment, err := s.installmentRepo.GetByID(id)
 	if err != nil {
@@ -204,39 +364,428 @@ func (s *InstallmentService) MarkComplete(id uuid.UUID) (*models.Installment, er
 	return s.installmentRepo.GetByID(id)
 }
 
-func (s *InstallmentService) createPaymentLedgerEntry(userID uuid.UUID, installment *models.Installment, payment *models.InstallmentPayment) error {
-	// Get liability account (linked to installment)
+// SimulatePrepayment shows the effect of an extra payment without saving anything
+func (s *InstallmentService) SimulatePrepayment(userID, id uuid.UUID, input PrepaymentInput) (*PrepaymentResult, error) {
+	installment, err := s.getOwned(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	result, _, err := planInstallmentPrepayment(installment, input)
+	return result, err
+}
+
+// ApplyPrepayment records an extra payment on the principal, books it on the
+// ledger and updates the installment's tenor or monthly payment
+func (s *InstallmentService) ApplyPrepayment(userID, id uuid.UUID, input PrepaymentInput) (*PrepaymentResult, error) {
+	installment, err := s.getOwned(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	result, prepayment, err := planInstallmentPrepayment(installment, input)
+	if err != nil {
+		return nil, err
+	}
+	prepayment.PocketID = input.PocketID
+
+	if err := s.prepaymentRepo.Create(prepayment); err != nil {
+		return nil, err
+	}
+
+	// Create ledger entry: DEBIT Liability Account, CREDIT Cash Account
+	if err := s.createLiabilityLedgerEntry(userID, installment, prepayment.PocketID, prepayment.Amount, prepayment.PaidAt,
+		"Installment Prepayment: "+installment.Name, prepayment.ID, "installment_prepayment"); err != nil {
+		return nil, err
+	}
+
+	if err := s.installmentRepo.Update(result.Installment); err != nil {
+		return nil, err
+	}
+
+	result.Installment, err = s.installmentRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// planInstallmentPrepayment applies a prepayment to a copy of the installment
+func planInstallmentPrepayment(installment *models.Installment, input PrepaymentInput) (*PrepaymentResult, *models.InstallmentPrepayment, error) {
+	if installment.Status != models.InstallmentStatusActive {
+		return nil, nil, errors.New("installment is not active")
+	}
+
+	before := installment.AmortizationSchedule()
+	paid := installment.PaidCount()
+
+	planned := *installment
+	planned.Prepayments = append([]models.InstallmentPrepayment(nil), installment.Prepayments...)
+	prepayment, err := planned.ApplyPrepayment(input.ExtraAmount, input.Date, input.Strategy)
+	if err != nil {
+		return nil, nil, err
+	}
+	after := planned.AmortizationSchedule()
+
+	result := &PrepaymentResult{
+		Strategy:                input.Strategy,
+		ExtraAmount:             input.ExtraAmount,
+		MonthlyPaymentBefore:    installment.MonthlyPayment,
+		RemainingPaymentsBefore: len(before) - paid,
+		RemainingPaymentsAfter:  len(after) - paid,
+		EndDateBefore:           scheduleEndDate(before),
+		EndDateAfter:            scheduleEndDate(after),
+		InterestSaved:           installment.LoanAmount - planned.LoanAmount,
+		Schedule:                after[paid:],
+		Installment:             &planned,
+	}
+	for _, p := range before[paid:] {
+		result.RemainingBefore += p.Principal
+	}
+	result.RemainingAfter = result.RemainingBefore - input.ExtraAmount
+	if len(after) > paid {
+		result.MonthlyPaymentAfter = after[paid].Payment
+	}
+	return result, prepayment, nil
+}
+
+type RestructureInput struct {
+	EffectiveDate time.Time
+	// Tenor is the number of payments left under the new terms
+	Tenor int
+	// MonthlyPayment is required without an InterestRate and computed with one
+	MonthlyPayment int64
+	InterestMethod models.InstallmentInterestMethod
+	InterestRate   *float64
+	DueDay         *int
+	// Fee and InterestAdjustment are added to the principal left; a negative
+	// adjustment waives part of it. A non-zero total is booked on CategoryID.
+	Fee                int64
+	InterestAdjustment int64
+	CategoryID         *uuid.UUID
+	Notes              *string
+}
+
+// Restructure versions the installment's terms: the payments made so far keep
+// the old terms and the rest follow the new ones from the effective date. Fees
+// and interest adjustments are booked on the liability account.
+func (s *InstallmentService) Restructure(userID, id uuid.UUID, input RestructureInput) (*models.Installment, error) {
+	installment, err := s.getOwned(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if installment.Status != models.InstallmentStatusActive {
+		return nil, errors.New("installment is not active")
+	}
+
+	dueDay := installment.DueDay
+	if input.DueDay != nil {
+		dueDay = *input.DueDay
+	}
+	term, err := installment.Restructure(models.InstallmentTerm{
+		EffectiveDate:      input.EffectiveDate,
+		Tenor:              input.Tenor,
+		MonthlyPayment:     input.MonthlyPayment,
+		InterestMethod:     input.InterestMethod,
+		InterestRate:       input.InterestRate,
+		DueDay:             dueDay,
+		Fee:                input.Fee,
+		InterestAdjustment: input.InterestAdjustment,
+		CategoryID:         input.CategoryID,
+		Notes:              input.Notes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if term.Capitalized() != 0 {
+		if term.CategoryID == nil {
+			return nil, errors.New("category is required for a fee or interest adjustment")
+		}
+		account, err := s.accountRepo.GetByReference(*term.CategoryID, "category")
+		if err != nil || account.UserID != userID {
+			return nil, errors.New("category not found")
+		}
+	}
+
+	if err := s.termRepo.Create(term); err != nil {
+		return nil, err
+	}
+
+	// Undo the term when the restructure can't be booked, so the schedule
+	// doesn't follow terms the ledger never saw
+	if term.Capitalized() != 0 {
+		if err := s.createCostLedgerEntry(userID, installment, *term.CategoryID, term.Capitalized(), term.EffectiveDate,
+			"Installment Restructure: "+installment.Name, term.ID, "installment_restructure"); err != nil {
+			_ = s.termRepo.Delete(term.ID)
+			return nil, err
+		}
+	}
+
+	if err := s.installmentRepo.Update(installment); err != nil {
+		_ = s.ledgerService.DeleteByReference(term.ID, "installment_restructure")
+		_ = s.termRepo.Delete(term.ID)
+		return nil, err
+	}
+
+	return s.installmentRepo.GetByID(id)
+}
+
+type ConvertExpenseInput struct {
+	Tenor int
+	// MonthlyPayment defaults to the purchase plus fee spread over the tenor;
+	// payments above that are interest
+	MonthlyPayment *int64
+	// Fee is a one-time fee added to the loan
+	Fee    int64
+	DueDay int
+	// StartDate is in the month of the first payment, by default the month
+	// after the purchase
+	StartDate *time.Time
+	// FeeCategoryID is where the fee and interest are booked, by default the
+	// category of the purchase
+	FeeCategoryID *uuid.UUID
+}
+
+// ConvertExpense turns a purchase into an installment plan, as with paylater
+// or a 0% card plan. The purchase keeps its category and month, but its ledger
+// entry now credits the installment's liability account instead of the pocket.
+// The fee and any interest in the payments are booked on the liability account
+// as well, so it is cleared by the last payment.
+func (s *InstallmentService) ConvertExpense(userID, expenseID uuid.UUID, input ConvertExpenseInput) (*models.Installment, error) {
+	expense, err := s.expenseService.GetByID(expenseID)
+	if err != nil {
+		return nil, err
+	}
+	if expense.UserID != userID {
+		return nil, errors.New("expense not found")
+	}
+	if expense.InstallmentID != nil {
+		return nil, errors.New("expense is already paid by an installment")
+	}
+	if len(expense.Refunds) > 0 {
+		return nil, errors.New("expense with refunds cannot be converted")
+	}
+	if input.Tenor <= 0 {
+		return nil, errors.New("tenor must be positive")
+	}
+	if input.Fee < 0 {
+		return nil, errors.New("fee cannot be negative")
+	}
+
+	purchaseDate := expense.CreatedAt
+	if expense.ExpenseDate != nil {
+		purchaseDate = *expense.ExpenseDate
+	}
+	startDate := time.Date(purchaseDate.Year(), purchaseDate.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	if input.StartDate != nil {
+		startDate = *input.StartDate
+	}
+
+	amount := expense.Total()
+	loanAmount := amount + input.Fee
+	monthlyPayment := loanAmount / int64(input.Tenor)
+	if input.MonthlyPayment != nil {
+		monthlyPayment = *input.MonthlyPayment
+		loanAmount = monthlyPayment * int64(input.Tenor)
+		if loanAmount < amount+input.Fee {
+			return nil, errors.New("monthly payments do not cover the purchase and fee")
+		}
+	}
+	feeCategoryID := expense.CategoryID
+	if input.FeeCategoryID != nil {
+		feeCategoryID = *input.FeeCategoryID
+	}
+	cost := loanAmount - amount
+	if cost > 0 {
+		account, err := s.accountRepo.GetByReference(feeCategoryID, "category")
+		if err != nil || account.UserID != userID {
+			return nil, errors.New("category not found")
+		}
+	}
+
+	installment, err := s.Create(userID, CreateInstallmentInput{
+		Name:           expense.ItemName,
+		ActualAmount:   amount,
+		LoanAmount:     loanAmount,
+		MonthlyPayment: monthlyPayment,
+		Tenor:          input.Tenor,
+		StartDate:      startDate,
+		DueDay:         input.DueDay,
+		Notes:          expense.Notes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	installment.ExpenseID = &expense.ID
+	if err := s.installmentRepo.Update(installment); err != nil {
+		return nil, err
+	}
+
+	// Reroute the purchase: DEBIT Expense Account, CREDIT Liability Account
+	if err := s.expenseService.SetInstallment(expense, &installment.ID); err != nil {
+		return nil, err
+	}
+
+	if cost > 0 {
+		if err := s.createCostLedgerEntry(userID, installment, feeCategoryID, cost, purchaseDate,
+			"Installment Fee: "+installment.Name, installment.ID, "installment_fee"); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.installmentRepo.GetByID(installment.ID)
+}
+
+// createCostLedgerEntry books a fee or interest added to the loan: DEBIT
+// Expense Account, CREDIT Liability Account, or the reverse for a negative
+// amount that lowers the loan
+func (s *InstallmentService) createCostLedgerEntry(userID uuid.UUID, installment *models.Installment, categoryID uuid.UUID, amount int64, date time.Time, description string, referenceID uuid.UUID, referenceType string) error {
 	liabilityAccount, err := s.accountRepo.GetByReference(installment.ID, "installment")
 	if err != nil {
 		return err
 	}
+	expenseAccount, err := s.accountRepo.GetByReference(categoryID, "category")
+	if err != nil {
+		return err
+	}
+	if expenseAccount.UserID != userID {
+		return errors.New("category not found")
+	}
 
-	// Get pocket account
-	var pocketAccount *models.Account
-	if payment.PocketID != nil {
-		pocketAccount, err = s.accountRepo.GetByID(*payment.PocketID)
-	} else {
-		pocketAccount, err = s.accountRepo.GetDefaultByUserID(userID)
+	entries := []LedgerEntry{
+		{AccountID: expenseAccount.ID, Debit: amount, Credit: 0},
+		{AccountID: liabilityAccount.ID, Debit: 0, Credit: amount},
+	}
+	if amount < 0 {
+		entries = []LedgerEntry{
+			{AccountID: liabilityAccount.ID, Debit: -amount, Credit: 0},
+			{AccountID: expenseAccount.ID, Debit: 0, Credit: -amount},
+		}
+	}
+
+	_, err = s.ledgerService.CreateJournalEntry(
+		userID,
+		date,
+		description,
+		entries,
+		&referenceID,
+		referenceType,
+	)
+	return err
+}
+
+func (s *InstallmentService) getOwned(userID, id uuid.UUID) (*models.Installment, error) {
+	installment, err := s.installmentRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if installment.UserID != userID {
+		return nil, errors.New("installment not found")
+	}
+	return installment, nil
+}
+
+// termsChanged reports whether the input changes any of the installment's
+// financial fields
+func termsChanged(installment *models.Installment, input CreateInstallmentInput) bool {
+	sameRate := installment.InterestRate == nil && input.InterestRate == nil ||
+		installment.InterestRate != nil && input.InterestRate != nil && *installment.InterestRate == *input.InterestRate
+	return input.ActualAmount != installment.ActualAmount ||
+		input.LoanAmount != installment.LoanAmount ||
+		input.MonthlyPayment != installment.MonthlyPayment ||
+		input.Tenor != installment.Tenor ||
+		!input.StartDate.Equal(installment.StartDate) ||
+		input.DueDay != installment.DueDay ||
+		input.InterestMethod != installment.InterestMethod ||
+		!sameRate
+}
+
+// applyInterestRate defaults the interest method and, when a rate is given,
+// derives the monthly payment and loan amount from the principal
+func applyInterestRate(input *CreateInstallmentInput) error {
+	if input.InterestMethod == "" {
+		input.InterestMethod = models.InstallmentInterestMethodFlat
+	}
+	if !input.InterestMethod.IsValid() {
+		return errors.New("invalid interest method")
+	}
+	if input.InterestRate == nil {
+		return nil
+	}
+	if *input.InterestRate < 0 || *input.InterestRate > 100 {
+		return errors.New("interest rate must be between 0 and 100 percent")
+	}
+	input.MonthlyPayment, input.LoanAmount = models.CalculateInstallmentPayment(input.InterestMethod, input.ActualAmount, *input.InterestRate, input.Tenor)
+	return nil
+}
+
+func (s *InstallmentService) createPaymentLedgerEntry(userID uuid.UUID, installment *models.Installment, payment *models.InstallmentPayment) error {
+	return s.createLiabilityLedgerEntry(userID, installment, payment.PocketID, payment.Amount, paymentPeriodDate(installment, payment),
+		"Installment Payment: "+installment.Name, payment.ID, "installment_payment")
+}
+
+func (s *InstallmentService) updatePaymentLedgerEntry(userID uuid.UUID, installment *models.Installment, payment *models.InstallmentPayment) error {
+	tx, err := s.ledgerService.GetTransactionByReference(payment.ID, "installment_payment")
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return s.createPaymentLedgerEntry(userID, installment, payment)
 	}
 	if err != nil {
 		return err
 	}
 
-	entries := []LedgerEntry{
-		{AccountID: liabilityAccount.ID, Debit: payment.Amount, Credit: 0},
-		{AccountID: pocketAccount.ID, Debit: 0, Credit: payment.Amount},
+	entries, err := s.liabilityLedgerEntries(userID, installment, payment.PocketID, payment.Amount)
+	if err != nil {
+		return err
 	}
 
-	// Calculate period date based on installment start_date + (payment_number - 1) months
-	periodDate := installment.StartDate.AddDate(0, payment.PaymentNumber-1, 0)
+	_, err = s.ledgerService.UpdateJournalEntry(tx.ID, paymentPeriodDate(installment, payment), "Installment Payment: "+installment.Name, entries)
+	return err
+}
+
+// paymentPeriodDate is the installment start_date + (payment_number - 1) months,
+// counted from the effective date of a restructure for the payments after it
+func paymentPeriodDate(installment *models.Installment, payment *models.InstallmentPayment) time.Time {
+	return installment.PeriodDate(payment.PaymentNumber)
+}
+
+func (s *InstallmentService) createLiabilityLedgerEntry(userID uuid.UUID, installment *models.Installment, pocketID *uuid.UUID, amount int64, date time.Time, description string, referenceID uuid.UUID, referenceType string) error {
+	entries, err := s.liabilityLedgerEntries(userID, installment, pocketID, amount)
+	if err != nil {
+		return err
+	}
 
 	_, err = s.ledgerService.CreateJournalEntry(
 		userID,
-		periodDate,
-		"Installment Payment: "+installment.Name,
+		date,
+		description,
 		entries,
-		&payment.ID,
-		"installment_payment",
+		&referenceID,
+		referenceType,
 	)
 	return err
 }
+
+// liabilityLedgerEntries debits the installment's liability account and
+// credits the pocket
+func (s *InstallmentService) liabilityLedgerEntries(userID uuid.UUID, installment *models.Installment, pocketID *uuid.UUID, amount int64) ([]LedgerEntry, error) {
+	// Get liability account (linked to installment)
+	liabilityAccount, err := s.accountRepo.GetByReference(installment.ID, "installment")
+	if err != nil {
+		return nil, err
+	}
+
+	// Get pocket account
+	var pocketAccount *models.Account
+	if pocketID != nil {
+		pocketAccount, err = s.accountRepo.GetByID(*pocketID)
+	} else {
+		pocketAccount, err = s.accountRepo.GetDefaultByUserID(userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return []LedgerEntry{
+		{AccountID: liabilityAccount.ID, Debit: amount, Credit: 0},
+		{AccountID: pocketAccount.ID, Debit: 0, Credit: amount},
+	}, nil
+}
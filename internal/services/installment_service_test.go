@@ -0,0 +1,135 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+)
+
+func TestInstallmentUpdate(t *testing.T) {
+	rate := 12.0
+	tests := []struct {
+		name string
+		// prepaid applies a prepayment of 1.200.000 after 3 payments first
+		prepaid            bool
+		interestRate       *float64
+		change             func(input *CreateInstallmentInput)
+		wantErr            string
+		wantMonthlyPayment int64
+		wantLoanAmount     int64
+	}{
+		{
+			name:               "rename keeps the payment",
+			interestRate:       &rate,
+			change:             func(input *CreateInstallmentInput) { input.Name = "Laptop Kantor" },
+			wantMonthlyPayment: 1120000,
+			wantLoanAmount:     13440000,
+		},
+		{
+			name:               "rename after a prepayment keeps the re-amortized payment",
+			prepaid:            true,
+			change:             func(input *CreateInstallmentInput) { input.Name = "Laptop Kantor" },
+			wantMonthlyPayment: 866666,
+			wantLoanAmount:     12000000,
+		},
+		{
+			name:               "new tenor with an interest rate is derived again",
+			interestRate:       &rate,
+			change:             func(input *CreateInstallmentInput) { input.Tenor = 24 },
+			wantMonthlyPayment: 620000,
+			wantLoanAmount:     14880000,
+		},
+		{
+			name:    "new tenor after a prepayment",
+			prepaid: true,
+			change:  func(input *CreateInstallmentInput) { input.Tenor = 24 },
+			wantErr: "use restructureInstallment",
+		},
+		{
+			name:    "new due day after a prepayment",
+			prepaid: true,
+			change:  func(input *CreateInstallmentInput) { input.DueDay = 25 },
+			wantErr: "use restructureInstallment",
+		},
+		{
+			name:    "new interest method after a prepayment",
+			prepaid: true,
+			change: func(input *CreateInstallmentInput) {
+				input.InterestMethod = models.InstallmentInterestMethodAnnuity
+			},
+			wantErr: "use restructureInstallment",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			installments := &fakeInstallmentRepo{installments: make(map[uuid.UUID]models.Installment)}
+			service := NewInstallmentService(installments, nil, nil, nil, nil, nil, nil, nil, nil)
+
+			installment := models.Installment{
+				ID:             uuid.New(),
+				UserID:         uuid.New(),
+				Name:           "Laptop",
+				ActualAmount:   12000000,
+				LoanAmount:     12000000,
+				MonthlyPayment: 1000000,
+				Tenor:          12,
+				StartDate:      time.Date(2026, time.January, 10, 0, 0, 0, 0, time.UTC),
+				DueDay:         10,
+				InterestMethod: models.InstallmentInterestMethodFlat,
+				InterestRate:   tt.interestRate,
+				Status:         models.InstallmentStatusActive,
+			}
+			if tt.interestRate != nil {
+				installment.MonthlyPayment, installment.LoanAmount = models.CalculateInstallmentPayment(installment.InterestMethod, installment.ActualAmount, *tt.interestRate, installment.Tenor)
+			}
+			if tt.prepaid {
+				for n := 1; n <= 3; n++ {
+					installment.Payments = append(installment.Payments, models.InstallmentPayment{PaymentNumber: n, Amount: installment.MonthlyPayment})
+				}
+				if _, err := installment.ApplyPrepayment(1200000, time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC), models.PrepaymentStrategyReducePayment); err != nil {
+					t.Fatalf("ApplyPrepayment: %v", err)
+				}
+			}
+			installments.installments[installment.ID] = installment
+
+			input := CreateInstallmentInput{
+				Name:           installment.Name,
+				ActualAmount:   installment.ActualAmount,
+				LoanAmount:     installment.LoanAmount,
+				MonthlyPayment: installment.MonthlyPayment,
+				Tenor:          installment.Tenor,
+				StartDate:      installment.StartDate,
+				DueDay:         installment.DueDay,
+				InterestMethod: installment.InterestMethod,
+				InterestRate:   installment.InterestRate,
+			}
+			tt.change(&input)
+
+			updated, err := service.Update(installment.ID, input, nil)
+			if !errContains(err, tt.wantErr) {
+				t.Fatalf("Update error = %v, want %q", err, tt.wantErr)
+			}
+			if tt.wantErr != "" {
+				saved := installments.installments[installment.ID]
+				if saved.Tenor != installment.Tenor || saved.DueDay != installment.DueDay || saved.InterestMethod != installment.InterestMethod {
+					t.Errorf("rejected update changed the installment to %+v", saved)
+				}
+				return
+			}
+
+			if updated.Name != input.Name {
+				t.Errorf("name = %q, want %q", updated.Name, input.Name)
+			}
+			if updated.MonthlyPayment != tt.wantMonthlyPayment {
+				t.Errorf("monthly payment = %d, want %d", updated.MonthlyPayment, tt.wantMonthlyPayment)
+			}
+			if updated.LoanAmount != tt.wantLoanAmount {
+				t.Errorf("loan amount = %d, want %d", updated.LoanAmount, tt.wantLoanAmount)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UpdatePaymentInput corrects a recorded installment or debt payment. Nil fields
+// are left unchanged.
+type UpdatePaymentInput struct {
+	Amount   *int64
+	PaidAt   *time.Time
+	PocketID *uuid.UUID
+}
+
+func (in UpdatePaymentInput) apply(amount *int64, paidAt *time.Time, pocketID **uuid.UUID) error {
+	if in.Amount != nil {
+		if *in.Amount <= 0 {
+			return errors.New("amount must be positive")
+		}
+		*amount = *in.Amount
+	}
+	if in.PaidAt != nil {
+		*paidAt = *in.PaidAt
+	}
+	if in.PocketID != nil {
+		*pocketID = in.PocketID
+	}
+	return nil
+}
@@ -104,8 +104,7 @@ func (s *MonthlySummaryService) getAvailableFutureMonths(userID uuid.UUID) ([]st
 		for _, inst := range installments {
 			remaining := inst.Tenor - inst.PaidCount()
 			for i := 0; i < remaining; i++ {
-				paymentDate := time.Date(inst.StartDate.Year(), inst.StartDate.Month(), 1, 0, 0, 0, 0, time.UTC)
-				paymentDate = paymentDate.AddDate(0, inst.PaidCount()+i, 0)
+				paymentDate := inst.DueDateFor(inst.PaidCount() + i + 1)
 				mk := monthKey(paymentDate)
 				if mk > current {
 					monthSet[mk] = true
@@ -114,6 +113,24 @@ func (s *MonthlySummaryService) getAvailableFutureMonths(userID uuid.UUID) ([]st
 		}
 	}
 
+	// Scheduled expense templates and recurring incomes over the next year
+	now := time.Now()
+	horizon := now.AddDate(1, 0, 0)
+	if occurrences, err := s.upcomingPayments.recurringExpenseOccurrences(userID, now, horizon); err == nil {
+		for _, occ := range occurrences {
+			if mk := monthKey(occ.Date); mk > current {
+				monthSet[mk] = true
+			}
+		}
+	}
+	if occurrences, err := s.upcomingPayments.recurringIncomeOccurrences(userID, now, horizon); err == nil {
+		for _, occ := range occurrences {
+			if mk := monthKey(occ.Date); mk > current {
+				monthSet[mk] = true
+			}
+		}
+	}
+
 	months := make([]string, 0, len(monthSet))
 	for m := range monthSet {
 		months = append(months, m)
@@ -167,16 +184,19 @@ func (s *MonthlySummaryService) calculateExpenseSummary(userID uuid.UUID, startD
 	categoryMap := make(map[uuid.UUID]*CategorySummary)
 
 	for _, exp := range expenses {
-		summary.Total += exp.Total()
+		// A purchase paid by an installment is counted through its payments
+		if exp.InstallmentID == nil {
+			summary.Total += exp.NetTotal()
+		}
 
 		if exp.Category != nil {
 			if cs, exists := categoryMap[exp.CategoryID]; exists {
-				cs.TotalAmount += exp.Total()
+				cs.TotalAmount += exp.NetTotal()
 				cs.ExpenseCount++
 			} else {
 				categoryMap[exp.CategoryID] = &CategorySummary{
 					Category:     *exp.Category,
-					TotalAmount:  exp.Total(),
+					TotalAmount:  exp.NetTotal(),
 					ExpenseCount: 1,
 				}
 			}
@@ -190,6 +210,80 @@ func (s *MonthlySummaryService) calculateExpenseSummary(userID uuid.UUID, startD
 	return summary
 }
 
+// addRecurringExpenseForecast adds the month's not yet posted expense template
+// occurrences to a forecast expense summary
+func (s *MonthlySummaryService) addRecurringExpenseForecast(userID uuid.UUID, month, year int, summary *ExpenseBreakdown) {
+	monthStart := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	occurrences, err := s.upcomingPayments.recurringExpenseOccurrences(userID, monthStart, monthStart.AddDate(0, 1, -1))
+	if err != nil {
+		return
+	}
+
+	for _, occ := range occurrences {
+		for _, item := range occ.Group.Items {
+			summary.Total += item.Total()
+			summary.Count++
+			if item.Category == nil {
+				continue
+			}
+
+			found := false
+			for i := range summary.ByCategory {
+				if summary.ByCategory[i].Category.ID == item.CategoryID {
+					summary.ByCategory[i].TotalAmount += item.Total()
+					summary.ByCategory[i].ExpenseCount++
+					found = true
+					break
+				}
+			}
+			if !found {
+				summary.ByCategory = append(summary.ByCategory, CategorySummary{
+					Category:     *item.Category,
+					TotalAmount:  item.Total(),
+					ExpenseCount: 1,
+				})
+			}
+		}
+	}
+}
+
+// addRecurringIncomeForecast adds the month's not yet posted recurring income
+// occurrences to a forecast income summary
+func (s *MonthlySummaryService) addRecurringIncomeForecast(userID uuid.UUID, month, year int, summary *IncomeBreakdown) {
+	monthStart := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	occurrences, err := s.upcomingPayments.recurringIncomeOccurrences(userID, monthStart, monthStart.AddDate(0, 1, -1))
+	if err != nil {
+		return
+	}
+
+	for _, occ := range occurrences {
+		for _, item := range occ.Group.Items {
+			summary.Total += item.Amount
+			summary.Count++
+			if item.Category == nil {
+				continue
+			}
+
+			found := false
+			for i := range summary.ByCategory {
+				if summary.ByCategory[i].Category.ID == item.CategoryID {
+					summary.ByCategory[i].TotalAmount += item.Amount
+					summary.ByCategory[i].IncomeCount++
+					found = true
+					break
+				}
+			}
+			if !found {
+				summary.ByCategory = append(summary.ByCategory, IncomeCategorySummary{
+					Category:    *item.Category,
+					TotalAmount: item.Amount,
+					IncomeCount: 1,
+				})
+			}
+		}
+	}
+}
+
 func (s *MonthlySummaryService) GetHistorySummary(userID uuid.UUID, month, year *int) (*HistorySummaryResult, error) {
 	availableMonths, err := s.getAvailablePastMonths(userID)
 	if err != nil {
@@ -279,6 +373,7 @@ func (s *MonthlySummaryService) GetForecastSummary(userID uuid.UUID, month, year
 		result.Payments = &UpcomingPaymentsReport{
 			Installments: []UpcomingInstallmentPayment{},
 			Debts:        []UpcomingDebtPayment{},
+			Receivables:  []UpcomingDebtPayment{},
 		}
 		return result, nil
 	}
@@ -290,11 +385,13 @@ func (s *MonthlySummaryService) GetForecastSummary(userID uuid.UUID, month, year
 
 	g.Go(func() error {
 		result.IncomeSummary = s.calculateIncomeSummary(userID, startDate, endDate)
+		s.addRecurringIncomeForecast(userID, selectedMonth, selectedYear, &result.IncomeSummary)
 		return nil
 	})
 
 	g.Go(func() error {
 		result.ExpenseSummary = s.calculateExpenseSummary(userID, startDate, endDate)
+		s.addRecurringExpenseForecast(userID, selectedMonth, selectedYear, &result.ExpenseSummary)
 		return nil
 	})
 
@@ -304,6 +401,7 @@ func (s *MonthlySummaryService) GetForecastSummary(userID uuid.UUID, month, year
 			payments = &UpcomingPaymentsReport{
 				Installments: []UpcomingInstallmentPayment{},
 				Debts:        []UpcomingDebtPayment{},
+				Receivables:  []UpcomingDebtPayment{},
 			}
 		}
 		result.Payments = payments
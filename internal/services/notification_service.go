@@ -2,7 +2,9 @@ package services
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,14 +14,20 @@ import (
 )
 
 type NotificationService struct {
-	repos        *repository.Repositories
-	emailService *EmailService
+	repos          *repository.Repositories
+	emailService   *EmailService
+	budgetService  *BudgetService
+	overdueService *OverdueService
+	// budgetAlertMu keeps concurrent checks from sending the same alert twice
+	budgetAlertMu sync.Mutex
 }
 
-func NewNotificationService(repos *repository.Repositories, emailService *EmailService) *NotificationService {
+func NewNotificationService(repos *repository.Repositories, emailService *EmailService, budgetService *BudgetService, overdueService *OverdueService) *NotificationService {
 	return &NotificationService{
-		repos:        repos,
-		emailService: emailService,
+		repos:          repos,
+		emailService:   emailService,
+		budgetService:  budgetService,
+		overdueService: overdueService,
 	}
 }
 
@@ -43,15 +51,25 @@ func (s *NotificationService) SendDueReminders(ctx context.Context) error {
 			s.sendInstallmentRemindersForUser(ctx, user, now)
 		}
 
-		// Send debt reminders based on user preference
-		if user.NotifyDebt {
+		// Send debt and receivable reminders based on user preference
+		if user.NotifyDebt || user.NotifyReceivable {
 			s.sendDebtRemindersForUser(ctx, user, now)
 		}
 
+		// Send overdue notices for missed installment and debt payments
+		if user.NotifyInstallment || user.NotifyDebt {
+			s.sendOverdueRemindersForUser(ctx, user, now)
+		}
+
 		// Send savings goal reminders based on user preference
 		if user.NotifySavingsGoal {
 			s.sendSavingsGoalRemindersForUser(ctx, user, now)
 		}
+
+		// Send budget alerts based on user preference
+		if user.NotifyBudget {
+			s.sendBudgetAlertsForUser(ctx, user, now)
+		}
 	}
 
 	return nil
@@ -80,7 +98,7 @@ func (s *NotificationService) sendInstallmentRemindersForUser(ctx context.Contex
 			}
 
 			// Check if the installment is still within its payment period
-			if !isInstallmentDueInMonth(inst.StartDate, inst.Tenor, int(now.Month()), now.Year()) {
+			if !isInstallmentDueInMonth(inst.StartDate, inst.MonthSpan(), int(now.Month()), now.Year()) {
 				continue
 			}
 
@@ -134,7 +152,18 @@ func (s *NotificationService) sendDebtRemindersForUser(ctx context.Context, user
 				continue
 			}
 
-			exists, err := s.repos.NotificationLog.ExistsForToday(debt.UserID, debt.ID, models.NotificationTypeDebtReminder)
+			// Receivables remind the user to chase the borrower
+			notificationType := models.NotificationTypeDebtReminder
+			if debt.IsReceivable() {
+				if !user.NotifyReceivable {
+					continue
+				}
+				notificationType = models.NotificationTypeReceivableReminder
+			} else if !user.NotifyDebt {
+				continue
+			}
+
+			exists, err := s.repos.NotificationLog.ExistsForToday(debt.UserID, debt.ID, notificationType)
 			if err != nil {
 				log.Printf("Error checking notification log: %v", err)
 				continue
@@ -143,16 +172,21 @@ func (s *NotificationService) sendDebtRemindersForUser(ctx context.Context, user
 				continue
 			}
 
-			err = s.emailService.SendDebtReminder(ctx, user.Email, debt.PersonName, daysAhead, debt.RemainingAmount())
+			subject := "Reminder: Hutang ke " + debt.PersonName + " jatuh tempo"
+			if debt.IsReceivable() {
+				err = s.emailService.SendReceivableReminder(ctx, user.Email, debt.PersonName, daysAhead, debt.RemainingAmount())
+				subject = "Reminder: Piutang dari " + debt.PersonName + " jatuh tempo"
+			} else {
+				err = s.emailService.SendDebtReminder(ctx, user.Email, debt.PersonName, daysAhead, debt.RemainingAmount())
+			}
 			if err != nil {
 				log.Printf("Error sending debt reminder to %s: %v", user.Email, err)
 				continue
 			}
 
-			subject := "Reminder: Hutang ke " + debt.PersonName + " jatuh tempo"
 			logEntry := &models.NotificationLog{
 				UserID:       debt.UserID,
-				Type:         models.NotificationTypeDebtReminder,
+				Type:         notificationType,
 				ReferenceID:  debt.ID,
 				SentAt:       now,
 				EmailSubject: &subject,
@@ -165,6 +199,59 @@ func (s *NotificationService) sendDebtRemindersForUser(ctx context.Context, user
 	}
 }
 
+// sendOverdueRemindersForUser emails once for every newly missed period, unlike
+// the due reminders that repeat daily before the due date
+func (s *NotificationService) sendOverdueRemindersForUser(ctx context.Context, user models.User, now time.Time) {
+	loans, err := s.overdueService.GetOverdueLoans(user.ID, now)
+	if err != nil {
+		log.Printf("Error getting overdue loans for %s: %v", user.Email, err)
+		return
+	}
+
+	for _, loan := range loans {
+		label := "Cicilan"
+		if loan.Kind == models.LoanKindDebt {
+			if !user.NotifyDebt {
+				continue
+			}
+			label = "Hutang ke"
+		} else if !user.NotifyInstallment {
+			continue
+		}
+
+		periodKey := loan.Overdue.LastMissed().DueDate.Format("2006-01-02")
+		exists, err := s.repos.NotificationLog.ExistsForPeriod(user.ID, loan.LoanID, models.NotificationTypeOverdueReminder, periodKey)
+		if err != nil {
+			log.Printf("Error checking notification log: %v", err)
+			continue
+		}
+		if exists {
+			continue
+		}
+
+		missed := len(loan.Overdue.MissedPeriods)
+		err = s.emailService.SendOverdueReminder(ctx, user.Email, label, loan.Name, loan.Overdue.DaysLate, missed, loan.Overdue.OverdueAmount)
+		if err != nil {
+			log.Printf("Error sending overdue reminder to %s: %v", user.Email, err)
+			continue
+		}
+
+		subject := fmt.Sprintf("Terlambat: %s %s sudah lewat jatuh tempo", label, loan.Name)
+		logEntry := &models.NotificationLog{
+			UserID:       user.ID,
+			Type:         models.NotificationTypeOverdueReminder,
+			ReferenceID:  loan.LoanID,
+			PeriodKey:    &periodKey,
+			SentAt:       now,
+			EmailSubject: &subject,
+		}
+		if err := s.repos.NotificationLog.Create(logEntry); err != nil {
+			log.Printf("Error creating notification log: %v", err)
+		}
+		log.Printf("Sent overdue reminder to %s for %s (%d days late, %d missed)", user.Email, loan.Name, loan.Overdue.DaysLate, missed)
+	}
+}
+
 func (s *NotificationService) sendSavingsGoalRemindersForUser(ctx context.Context, user models.User, now time.Time) {
 	// Check for savings goals with target dates within user's preferred notification window
 	for daysAhead := 0; daysAhead <= user.NotifyDaysBefore; daysAhead++ {
@@ -193,7 +280,7 @@ func (s *NotificationService) sendSavingsGoalRemindersForUser(ctx context.Contex
 				continue
 			}
 
-			err = s.emailService.SendSavingsGoalReminder(ctx, user.Email, goal.Name, daysAhead, goal.RemainingAmount(), goal.CurrentAmount, goal.Progress())
+			err = s.emailService.SendSavingsGoalReminder(ctx, user.Email, goal.Name, daysAhead, goal.RemainingAmount(), goal.CurrentAmount, goal.Progress(), goal.Forecast(now))
 			if err != nil {
 				log.Printf("Error sending savings goal reminder to %s: %v", user.Email, err)
 				continue
@@ -215,6 +302,191 @@ func (s *NotificationService) sendSavingsGoalRemindersForUser(ctx context.Contex
 	}
 }
 
+// CheckBudgetAlerts sends budget alerts for one user, e.g. right after an
+// expense was recorded
+func (s *NotificationService) CheckBudgetAlerts(ctx context.Context, userID uuid.UUID) {
+	user, err := s.repos.User.GetByID(userID)
+	if err != nil {
+		log.Printf("Error getting user %s: %v", userID, err)
+		return
+	}
+	if !user.NotifyBudget {
+		return
+	}
+	s.sendBudgetAlertsForUser(ctx, *user, time.Now())
+}
+
+// sendBudgetAlertsForUser emails once per budget, threshold and month. When
+// several thresholds are crossed at once only the highest one is emailed, but
+// all of them are logged so the lower ones are not sent later.
+func (s *NotificationService) sendBudgetAlertsForUser(ctx context.Context, user models.User, now time.Time) {
+	s.budgetAlertMu.Lock()
+	defer s.budgetAlertMu.Unlock()
+
+	month, year := int(now.Month()), now.Year()
+	status, err := s.budgetService.GetStatus(user.ID, month, year)
+	if err != nil {
+		log.Printf("Error getting budget status for %s: %v", user.Email, err)
+		return
+	}
+
+	for _, item := range status.Items {
+		if item.Budgeted <= 0 {
+			continue
+		}
+
+		var crossed []int
+		for _, threshold := range item.Budget.Thresholds() {
+			if item.Percentage < float64(threshold) {
+				continue
+			}
+			exists, err := s.repos.NotificationLog.ExistsForPeriod(user.ID, item.Budget.ID, models.NotificationTypeBudgetAlert, budgetAlertPeriodKey(month, year, threshold))
+			if err != nil {
+				log.Printf("Error checking notification log: %v", err)
+				continue
+			}
+			if !exists {
+				crossed = append(crossed, threshold)
+			}
+		}
+		if len(crossed) == 0 {
+			continue
+		}
+
+		name := "Total"
+		if item.Budget.Category != nil {
+			name = item.Budget.Category.Name
+		}
+		threshold := crossed[len(crossed)-1]
+		period := now.Format("January 2006")
+
+		err = s.emailService.SendBudgetAlert(ctx, user.Email, name, threshold, item.Percentage, item.Budgeted, item.Spent, period)
+		if err != nil {
+			log.Printf("Error sending budget alert to %s: %v", user.Email, err)
+			continue
+		}
+
+		subject := fmt.Sprintf("Peringatan: Anggaran %s sudah terpakai %d%%", name, threshold)
+		for _, t := range crossed {
+			periodKey := budgetAlertPeriodKey(month, year, t)
+			logEntry := &models.NotificationLog{
+				UserID:       user.ID,
+				Type:         models.NotificationTypeBudgetAlert,
+				ReferenceID:  item.Budget.ID,
+				PeriodKey:    &periodKey,
+				SentAt:       now,
+				EmailSubject: &subject,
+			}
+			if err := s.repos.NotificationLog.Create(logEntry); err != nil {
+				log.Printf("Error creating notification log: %v", err)
+			}
+		}
+		log.Printf("Sent budget alert to %s for %s (%d%% threshold)", user.Email, name, threshold)
+	}
+}
+
+// SendTemplateAutoPostSummaries emails a summary of auto-posted expenses for
+// groups that opted in to notifications
+func (s *NotificationService) SendTemplateAutoPostSummaries(ctx context.Context, results []AutoPostResult) {
+	now := time.Now()
+
+	for _, result := range results {
+		if !result.Group.NotifyOnAutoPost || len(result.Expenses) == 0 {
+			continue
+		}
+
+		exists, err := s.repos.NotificationLog.ExistsForToday(result.Group.UserID, result.Group.ID, models.NotificationTypeTemplateAutoPost)
+		if err != nil {
+			log.Printf("Error checking notification log: %v", err)
+			continue
+		}
+		if exists {
+			continue
+		}
+
+		user, err := s.repos.User.GetByID(result.Group.UserID)
+		if err != nil {
+			log.Printf("Error getting user %s: %v", result.Group.UserID, err)
+			continue
+		}
+
+		err = s.emailService.SendTemplateAutoPostSummary(ctx, user.Email, result.Group.Name, result.PostDate, result.Expenses)
+		if err != nil {
+			log.Printf("Error sending template auto-post summary to %s: %v", user.Email, err)
+			continue
+		}
+
+		subject := "Pengeluaran rutin " + result.Group.Name + " telah dicatat"
+		logEntry := &models.NotificationLog{
+			UserID:       result.Group.UserID,
+			Type:         models.NotificationTypeTemplateAutoPost,
+			ReferenceID:  result.Group.ID,
+			SentAt:       now,
+			EmailSubject: &subject,
+		}
+		if err := s.repos.NotificationLog.Create(logEntry); err != nil {
+			log.Printf("Error creating notification log: %v", err)
+		}
+		log.Printf("Sent template auto-post summary to %s for %s", user.Email, result.Group.Name)
+	}
+}
+
+// SendSavingsAutoSkippedAlerts emails the owner of every auto rule run that was
+// skipped because the source pocket couldn't cover the contribution
+func (s *NotificationService) SendSavingsAutoSkippedAlerts(ctx context.Context, runs []models.SavingsAutoRun) {
+	now := time.Now()
+
+	for _, run := range runs {
+		if run.Status != models.SavingsAutoRunStatusInsufficientFunds || run.Rule == nil {
+			continue
+		}
+		rule := run.Rule
+		if rule.SavingsGoal == nil || rule.SourcePocket == nil {
+			continue
+		}
+
+		periodKey := run.ScheduledDate.Format("2006-01-02")
+		exists, err := s.repos.NotificationLog.ExistsForPeriod(run.UserID, rule.ID, models.NotificationTypeSavingsAutoSkipped, periodKey)
+		if err != nil {
+			log.Printf("Error checking notification log: %v", err)
+			continue
+		}
+		if exists {
+			continue
+		}
+
+		user, err := s.repos.User.GetByID(run.UserID)
+		if err != nil {
+			log.Printf("Error getting user %s: %v", run.UserID, err)
+			continue
+		}
+
+		err = s.emailService.SendSavingsAutoSkipped(ctx, user.Email, rule.SavingsGoal.Name, rule.SourcePocket.Name, run.ScheduledDate, run.Amount, rule.SourcePocket.CurrentBalance)
+		if err != nil {
+			log.Printf("Error sending savings auto skipped alert to %s: %v", user.Email, err)
+			continue
+		}
+
+		subject := fmt.Sprintf("Tabungan otomatis %s dilewati: saldo tidak cukup", rule.SavingsGoal.Name)
+		logEntry := &models.NotificationLog{
+			UserID:       run.UserID,
+			Type:         models.NotificationTypeSavingsAutoSkipped,
+			ReferenceID:  rule.ID,
+			PeriodKey:    &periodKey,
+			SentAt:       now,
+			EmailSubject: &subject,
+		}
+		if err := s.repos.NotificationLog.Create(logEntry); err != nil {
+			log.Printf("Error creating notification log: %v", err)
+		}
+		log.Printf("Sent savings auto skipped alert to %s for %s", user.Email, rule.SavingsGoal.Name)
+	}
+}
+
+func budgetAlertPeriodKey(month, year, threshold int) string {
+	return fmt.Sprintf("%04d-%02d:%d", year, month, threshold)
+}
+
 // isInstallmentDueInMonth checks if an installment has a payment due in the specified month
 func isInstallmentDueInMonth(startDate time.Time, tenor int, month int, year int) bool {
 	targetMonth := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
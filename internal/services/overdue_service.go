@@ -0,0 +1,414 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+	"github.com/azzamdhx/moneybro/backend/internal/repository"
+)
+
+type OverdueService struct {
+	installmentRepo repository.InstallmentRepository
+	debtRepo        repository.DebtRepository
+	categoryRepo    repository.CategoryRepository
+	accountRepo     repository.AccountRepository
+	ruleRepo        repository.LateFeeRuleRepository
+	chargeRepo      repository.LateFeeChargeRepository
+	expenseService  *ExpenseService
+	// chargeMu keeps the cron job and a manual run from charging a period twice
+	chargeMu sync.Mutex
+}
+
+func NewOverdueService(
+	installmentRepo repository.InstallmentRepository,
+	debtRepo repository.DebtRepository,
+	categoryRepo repository.CategoryRepository,
+	accountRepo repository.AccountRepository,
+	ruleRepo repository.LateFeeRuleRepository,
+	chargeRepo repository.LateFeeChargeRepository,
+	expenseService *ExpenseService,
+) *OverdueService {
+	return &OverdueService{
+		installmentRepo: installmentRepo,
+		debtRepo:        debtRepo,
+		categoryRepo:    categoryRepo,
+		accountRepo:     accountRepo,
+		ruleRepo:        ruleRepo,
+		chargeRepo:      chargeRepo,
+		expenseService:  expenseService,
+	}
+}
+
+// OverdueLoan is an active installment or debt with at least one missed period
+type OverdueLoan struct {
+	LoanID  uuid.UUID
+	Kind    models.LoanKind
+	Name    string
+	Overdue models.OverdueInfo
+}
+
+type CreateLateFeeRuleInput struct {
+	Name       string
+	LoanKind   *models.LoanKind
+	LoanID     *uuid.UUID
+	FeeType    models.LateFeeType
+	Amount     int64
+	Percentage float64
+	GraceDays  int
+	CategoryID uuid.UUID
+	PocketID   *uuid.UUID
+}
+
+// UpdateLateFeeRuleInput changes the non-nil fields of a rule. ClearLoanKind
+// and ClearLoanID widen the rule again.
+type UpdateLateFeeRuleInput struct {
+	Name          *string
+	LoanKind      *models.LoanKind
+	ClearLoanKind bool
+	LoanID        *uuid.UUID
+	ClearLoanID   bool
+	FeeType       *models.LateFeeType
+	Amount        *int64
+	Percentage    *float64
+	GraceDays     *int
+	CategoryID    *uuid.UUID
+	PocketID      *uuid.UUID
+	IsActive      *bool
+}
+
+// GetOverdueLoans lists the user's installments and debts that missed a
+// payment before asOf, the most late first. Money owed to the user isn't
+// included.
+func (s *OverdueService) GetOverdueLoans(userID uuid.UUID, asOf time.Time) ([]OverdueLoan, error) {
+	installmentStatus := models.InstallmentStatusActive
+	installments, err := s.installmentRepo.GetByUserID(userID, &installmentStatus)
+	if err != nil {
+		return nil, err
+	}
+	debtStatus := models.DebtStatusActive
+	debts, err := s.debtRepo.GetByUserID(userID, &debtStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	loans := []OverdueLoan{}
+	for _, inst := range installments {
+		if overdue := inst.Overdue(asOf); overdue.IsOverdue() {
+			loans = append(loans, OverdueLoan{LoanID: inst.ID, Kind: models.LoanKindInstallment, Name: inst.Name, Overdue: overdue})
+		}
+	}
+	for _, debt := range debts {
+		if debt.IsReceivable() {
+			continue
+		}
+		if overdue := debt.Overdue(asOf); overdue.IsOverdue() {
+			loans = append(loans, OverdueLoan{LoanID: debt.ID, Kind: models.LoanKindDebt, Name: debt.PersonName, Overdue: overdue})
+		}
+	}
+
+	sort.SliceStable(loans, func(i, j int) bool { return loans[i].Overdue.DaysLate > loans[j].Overdue.DaysLate })
+	return loans, nil
+}
+
+func (s *OverdueService) CreateRule(userID uuid.UUID, input CreateLateFeeRuleInput) (*models.LateFeeRule, error) {
+	rule := &models.LateFeeRule{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Name:       input.Name,
+		LoanKind:   input.LoanKind,
+		LoanID:     input.LoanID,
+		FeeType:    input.FeeType,
+		Amount:     input.Amount,
+		Percentage: input.Percentage,
+		GraceDays:  input.GraceDays,
+		CategoryID: input.CategoryID,
+		PocketID:   input.PocketID,
+		IsActive:   true,
+	}
+	if err := s.validateRule(rule); err != nil {
+		return nil, err
+	}
+
+	if err := s.ruleRepo.Create(rule); err != nil {
+		return nil, err
+	}
+
+	return s.ruleRepo.GetByID(rule.ID)
+}
+
+func (s *OverdueService) GetRules(userID uuid.UUID) ([]models.LateFeeRule, error) {
+	return s.ruleRepo.GetByUserID(userID)
+}
+
+func (s *OverdueService) UpdateRule(userID, id uuid.UUID, input UpdateLateFeeRuleInput) (*models.LateFeeRule, error) {
+	rule, err := s.getOwnedRule(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		rule.Name = *input.Name
+	}
+	if input.LoanKind != nil {
+		rule.LoanKind = input.LoanKind
+	}
+	if input.ClearLoanKind {
+		rule.LoanKind = nil
+	}
+	if input.LoanID != nil {
+		rule.LoanID = input.LoanID
+	}
+	if input.ClearLoanID {
+		rule.LoanID = nil
+	}
+	if input.FeeType != nil {
+		rule.FeeType = *input.FeeType
+	}
+	if input.Amount != nil {
+		rule.Amount = *input.Amount
+	}
+	if input.Percentage != nil {
+		rule.Percentage = *input.Percentage
+	}
+	if input.GraceDays != nil {
+		rule.GraceDays = *input.GraceDays
+	}
+	if input.CategoryID != nil {
+		rule.CategoryID = *input.CategoryID
+	}
+	if input.PocketID != nil {
+		rule.PocketID = input.PocketID
+	}
+	if input.IsActive != nil {
+		rule.IsActive = *input.IsActive
+	}
+	if err := s.validateRule(rule); err != nil {
+		return nil, err
+	}
+
+	if err := s.ruleRepo.Update(rule); err != nil {
+		return nil, err
+	}
+
+	return s.ruleRepo.GetByID(rule.ID)
+}
+
+// DeleteRule removes a rule and its charge history. Fee expenses already
+// posted are kept.
+func (s *OverdueService) DeleteRule(userID, id uuid.UUID) error {
+	if _, err := s.getOwnedRule(userID, id); err != nil {
+		return err
+	}
+	return s.ruleRepo.Delete(id)
+}
+
+func (s *OverdueService) GetCharges(userID uuid.UUID, loanID *uuid.UUID) ([]models.LateFeeCharge, error) {
+	return s.chargeRepo.GetByUserID(userID, loanID)
+}
+
+// ChargeLateFees runs every active rule for all users, logging the users that
+// fail so the others are still charged
+func (s *OverdueService) ChargeLateFees(asOf time.Time) ([]models.LateFeeCharge, error) {
+	rules, err := s.ruleRepo.GetActive()
+	if err != nil {
+		return nil, err
+	}
+
+	byUser := make(map[uuid.UUID][]models.LateFeeRule)
+	var userIDs []uuid.UUID
+	for _, rule := range rules {
+		if _, ok := byUser[rule.UserID]; !ok {
+			userIDs = append(userIDs, rule.UserID)
+		}
+		byUser[rule.UserID] = append(byUser[rule.UserID], rule)
+	}
+
+	var charges []models.LateFeeCharge
+	for _, userID := range userIDs {
+		userCharges, err := s.chargeForUser(userID, byUser[userID], asOf)
+		charges = append(charges, userCharges...)
+		if err != nil {
+			log.Printf("Error charging late fees for user %s: %v", userID, err)
+		}
+	}
+	return charges, nil
+}
+
+// ChargeLateFeesForUser runs the user's active rules now instead of waiting
+// for the daily job
+func (s *OverdueService) ChargeLateFeesForUser(userID uuid.UUID, asOf time.Time) ([]models.LateFeeCharge, error) {
+	rules, err := s.ruleRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	active := make([]models.LateFeeRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.IsActive {
+			active = append(active, rule)
+		}
+	}
+	return s.chargeForUser(userID, active, asOf)
+}
+
+// chargeForUser posts a fee expense for every missed period that is past the
+// rule's grace days and wasn't charged by the rule yet
+func (s *OverdueService) chargeForUser(userID uuid.UUID, rules []models.LateFeeRule, asOf time.Time) ([]models.LateFeeCharge, error) {
+	charges := []models.LateFeeCharge{}
+	if len(rules) == 0 {
+		return charges, nil
+	}
+
+	s.chargeMu.Lock()
+	defer s.chargeMu.Unlock()
+
+	loans, err := s.GetOverdueLoans(userID, asOf)
+	if err != nil {
+		return charges, err
+	}
+
+	today := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.UTC)
+	for _, loan := range loans {
+		for i := range rules {
+			rule := &rules[i]
+			if !rule.AppliesTo(loan.Kind, loan.LoanID) {
+				continue
+			}
+			for _, period := range loan.Overdue.MissedPeriods {
+				if !period.DueDate.AddDate(0, 0, rule.GraceDays).Before(today) {
+					continue
+				}
+				fee := rule.FeeFor(period.Amount)
+				if fee <= 0 {
+					continue
+				}
+				exists, err := s.chargeRepo.Exists(rule.ID, loan.LoanID, period.PaymentNumber)
+				if err != nil {
+					return charges, err
+				}
+				if exists {
+					continue
+				}
+
+				charge, err := s.charge(userID, rule, loan, period, fee, today)
+				if err != nil {
+					return charges, err
+				}
+				charges = append(charges, *charge)
+			}
+		}
+	}
+	return charges, nil
+}
+
+func (s *OverdueService) charge(userID uuid.UUID, rule *models.LateFeeRule, loan OverdueLoan, period models.MissedPeriod, fee int64, date time.Time) (*models.LateFeeCharge, error) {
+	notes := rule.Name
+	expense, err := s.expenseService.Create(userID, CreateExpenseInput{
+		CategoryID:  rule.CategoryID,
+		ItemName:    fmt.Sprintf("Late Fee: %s (payment #%d)", loan.Name, period.PaymentNumber),
+		UnitPrice:   fee,
+		Quantity:    1,
+		Notes:       &notes,
+		ExpenseDate: &date,
+		PocketID:    rule.PocketID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	charge := &models.LateFeeCharge{
+		ID:            uuid.New(),
+		UserID:        userID,
+		RuleID:        rule.ID,
+		LoanKind:      loan.Kind,
+		LoanID:        loan.LoanID,
+		PaymentNumber: period.PaymentNumber,
+		DueDate:       period.DueDate,
+		Amount:        fee,
+		ExpenseID:     &expense.ID,
+	}
+	if err := s.chargeRepo.Create(charge); err != nil {
+		return nil, err
+	}
+	return charge, nil
+}
+
+func (s *OverdueService) validateRule(rule *models.LateFeeRule) error {
+	if rule.Name == "" {
+		return errors.New("name is required")
+	}
+	if rule.LoanKind != nil && !rule.LoanKind.IsValid() {
+		return errors.New("invalid loan kind")
+	}
+	if rule.LoanID != nil {
+		if rule.LoanKind == nil {
+			return errors.New("loan kind is required when a loan is given")
+		}
+		if err := s.checkLoan(rule.UserID, *rule.LoanKind, *rule.LoanID); err != nil {
+			return err
+		}
+	}
+
+	switch rule.FeeType {
+	case models.LateFeeTypeFixed:
+		if rule.Amount <= 0 {
+			return errors.New("fee amount must be positive")
+		}
+	case models.LateFeeTypePercentage:
+		if rule.Percentage <= 0 || rule.Percentage > 100 {
+			return errors.New("fee percentage must be between 0 and 100")
+		}
+	default:
+		return errors.New("invalid fee type")
+	}
+	if rule.GraceDays < 0 {
+		return errors.New("grace days can't be negative")
+	}
+
+	category, err := s.categoryRepo.GetByID(rule.CategoryID)
+	if err != nil || category.UserID != rule.UserID {
+		return errors.New("category not found")
+	}
+	if rule.PocketID != nil {
+		pocket, err := s.accountRepo.GetByID(*rule.PocketID)
+		if err != nil || pocket.UserID != rule.UserID || !pocket.IsPocket {
+			return errors.New("pocket not found")
+		}
+	}
+	return nil
+}
+
+func (s *OverdueService) checkLoan(userID uuid.UUID, kind models.LoanKind, loanID uuid.UUID) error {
+	if kind == models.LoanKindInstallment {
+		installment, err := s.installmentRepo.GetByID(loanID)
+		if err != nil || installment.UserID != userID {
+			return errors.New("installment not found")
+		}
+		return nil
+	}
+	debt, err := s.debtRepo.GetByID(loanID)
+	if err != nil || debt.UserID != userID {
+		return errors.New("debt not found")
+	}
+	if debt.IsReceivable() {
+		return errors.New("late fees only apply to money the user owes")
+	}
+	return nil
+}
+
+func (s *OverdueService) getOwnedRule(userID, id uuid.UUID) (*models.LateFeeRule, error) {
+	rule, err := s.ruleRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if rule.UserID != userID {
+		return nil, errors.New("late fee rule not found")
+	}
+	return rule, nil
+}
@@ -0,0 +1,261 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+	"github.com/azzamdhx/moneybro/backend/internal/repository"
+)
+
+const (
+	payeeTrendMonths      = 12
+	payeeLastTransactions = 10
+)
+
+type PayeeService struct {
+	payeeRepo    repository.PayeeRepository
+	expenseRepo  repository.ExpenseRepository
+	categoryRepo repository.CategoryRepository
+	accountRepo  repository.AccountRepository
+}
+
+func NewPayeeService(
+	payeeRepo repository.PayeeRepository,
+	expenseRepo repository.ExpenseRepository,
+	categoryRepo repository.CategoryRepository,
+	accountRepo repository.AccountRepository,
+) *PayeeService {
+	return &PayeeService{
+		payeeRepo:    payeeRepo,
+		expenseRepo:  expenseRepo,
+		categoryRepo: categoryRepo,
+		accountRepo:  accountRepo,
+	}
+}
+
+type CreatePayeeInput struct {
+	Name              string
+	Aliases           []string
+	DefaultCategoryID *uuid.UUID
+	DefaultPocketID   *uuid.UUID
+	Notes             *string
+}
+
+type UpdatePayeeInput struct {
+	Name              *string
+	Aliases           []string
+	DefaultCategoryID *uuid.UUID
+	DefaultPocketID   *uuid.UUID
+	Notes             *string
+}
+
+type PayeeDetail struct {
+	Payee            *models.Payee
+	MonthlyTrend     []repository.PayeeMonthlySpending
+	LastTransactions []models.Expense
+}
+
+func (s *PayeeService) Create(userID uuid.UUID, input CreatePayeeInput) (*models.Payee, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, errors.New("payee name is required")
+	}
+	if err := s.validateDefaults(userID, input.DefaultCategoryID, input.DefaultPocketID); err != nil {
+		return nil, err
+	}
+
+	payee := &models.Payee{
+		ID:                uuid.New(),
+		UserID:            userID,
+		Name:              name,
+		DefaultCategoryID: input.DefaultCategoryID,
+		DefaultPocketID:   input.DefaultPocketID,
+		Notes:             input.Notes,
+	}
+
+	if err := s.payeeRepo.Create(payee); err != nil {
+		return nil, err
+	}
+
+	if aliases := normalizeAliases(name, input.Aliases); len(aliases) > 0 {
+		if err := s.payeeRepo.ReplaceAliases(payee.ID, aliases); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.payeeRepo.GetByID(payee.ID)
+}
+
+func (s *PayeeService) GetByID(id uuid.UUID) (*models.Payee, error) {
+	return s.payeeRepo.GetByID(id)
+}
+
+func (s *PayeeService) GetByUserID(userID uuid.UUID) ([]models.Payee, error) {
+	return s.payeeRepo.GetByUserIDWithStats(userID)
+}
+
+// GetDetail returns the payee with lifetime spend, the spending trend of the
+// last 12 months and its most recent expenses
+func (s *PayeeService) GetDetail(userID, id uuid.UUID) (*PayeeDetail, error) {
+	payee, err := s.payeeRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if payee.UserID != userID {
+		return nil, errors.New("payee not found")
+	}
+
+	count, total, err := s.payeeRepo.GetSpendingStats(id)
+	if err != nil {
+		return nil, err
+	}
+	payee.ExpenseCount = count
+	payee.LifetimeSpend = total
+
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -(payeeTrendMonths - 1), 0)
+	spending, err := s.payeeRepo.GetMonthlySpending(id, start.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Fill months without spending so the trend is continuous
+	byMonth := make(map[string]repository.PayeeMonthlySpending)
+	for _, m := range spending {
+		byMonth[m.Month] = m
+	}
+	trend := make([]repository.PayeeMonthlySpending, 0, payeeTrendMonths)
+	for i := 0; i < payeeTrendMonths; i++ {
+		key := monthKey(start.AddDate(0, i, 0))
+		if m, ok := byMonth[key]; ok {
+			trend = append(trend, m)
+		} else {
+			trend = append(trend, repository.PayeeMonthlySpending{Month: key})
+		}
+	}
+
+	expenses, err := s.expenseRepo.GetRecentByPayeeID(id, payeeLastTransactions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PayeeDetail{
+		Payee:            payee,
+		MonthlyTrend:     trend,
+		LastTransactions: expenses,
+	}, nil
+}
+
+func (s *PayeeService) Update(userID, id uuid.UUID, input UpdatePayeeInput) (*models.Payee, error) {
+	payee, err := s.payeeRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if payee.UserID != userID {
+		return nil, errors.New("payee not found")
+	}
+
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		if name == "" {
+			return nil, errors.New("payee name is required")
+		}
+		payee.Name = name
+	}
+	if err := s.validateDefaults(userID, input.DefaultCategoryID, input.DefaultPocketID); err != nil {
+		return nil, err
+	}
+	if input.DefaultCategoryID != nil {
+		payee.DefaultCategoryID = input.DefaultCategoryID
+	}
+	if input.DefaultPocketID != nil {
+		payee.DefaultPocketID = input.DefaultPocketID
+	}
+	if input.Notes != nil {
+		payee.Notes = input.Notes
+	}
+
+	if err := s.payeeRepo.Update(payee); err != nil {
+		return nil, err
+	}
+
+	if input.Aliases != nil {
+		if err := s.payeeRepo.ReplaceAliases(payee.ID, normalizeAliases(payee.Name, input.Aliases)); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.payeeRepo.GetByID(payee.ID)
+}
+
+func (s *PayeeService) Delete(userID, id uuid.UUID) error {
+	payee, err := s.payeeRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if payee.UserID != userID {
+		return errors.New("payee not found")
+	}
+	return s.payeeRepo.Delete(id)
+}
+
+func (s *PayeeService) validateDefaults(userID uuid.UUID, categoryID, pocketID *uuid.UUID) error {
+	if categoryID != nil {
+		category, err := s.categoryRepo.GetByID(*categoryID)
+		if err != nil || category.UserID != userID {
+			return errors.New("invalid default category")
+		}
+	}
+	if pocketID != nil {
+		pocket, err := s.accountRepo.GetByID(*pocketID)
+		if err != nil || pocket.UserID != userID || !pocket.IsPocket {
+			return errors.New("invalid default pocket")
+		}
+	}
+	return nil
+}
+
+// normalizeAliases trims aliases and drops blanks, duplicates and the payee name itself
+func normalizeAliases(name string, aliases []string) []string {
+	seen := map[string]bool{strings.ToLower(name): true}
+	result := make([]string, 0, len(aliases))
+	for _, alias := range aliases {
+		alias = strings.TrimSpace(alias)
+		key := strings.ToLower(alias)
+		if alias == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, alias)
+	}
+	return result
+}
+
+// matchPayee returns the explicitly selected payee, or the payee whose name or
+// alias matches the free-text name. No match is not an error.
+func matchPayee(payeeRepo repository.PayeeRepository, userID uuid.UUID, payeeID *uuid.UUID, name string) (*models.Payee, error) {
+	if payeeID != nil {
+		payee, err := payeeRepo.GetByID(*payeeID)
+		if err != nil || payee.UserID != userID {
+			return nil, errors.New("payee not found")
+		}
+		return payee, nil
+	}
+
+	if strings.TrimSpace(name) == "" {
+		return nil, nil
+	}
+	payee, err := payeeRepo.FindByName(userID, name)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return payee, nil
+}
@@ -0,0 +1,577 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+	"github.com/azzamdhx/moneybro/backend/internal/repository"
+)
+
+type PayoffLoanKind string
+
+const (
+	PayoffLoanKindInstallment PayoffLoanKind = "INSTALLMENT"
+	PayoffLoanKindDebt        PayoffLoanKind = "DEBT"
+)
+
+// payoffMaxMonths stops plans whose payments never catch up with the interest
+const payoffMaxMonths = 600
+
+type PayoffPlanService struct {
+	installmentRepo repository.InstallmentRepository
+	debtRepo        repository.DebtRepository
+	planRepo        repository.PayoffPlanRepository
+}
+
+func NewPayoffPlanService(
+	installmentRepo repository.InstallmentRepository,
+	debtRepo repository.DebtRepository,
+	planRepo repository.PayoffPlanRepository,
+) *PayoffPlanService {
+	return &PayoffPlanService{
+		installmentRepo: installmentRepo,
+		debtRepo:        debtRepo,
+		planRepo:        planRepo,
+	}
+}
+
+type PayoffPlanInput struct {
+	ExtraMonthlyBudget int64
+	Strategy           models.PayoffStrategy
+	// CustomOrder lists installment and debt IDs for CUSTOM_ORDER; loans left
+	// out follow in snowball order
+	CustomOrder []uuid.UUID
+}
+
+// PayoffLoan is an active installment or debt as it enters the plan. Debts have
+// a fixed total to pay and carry no interest rate unless interest accrues on
+// them. One-time debts are paid in full in their due month.
+type PayoffLoan struct {
+	ID             uuid.UUID
+	Kind           PayoffLoanKind
+	Name           string
+	Balance        int64
+	AnnualRate     float64
+	MinimumPayment int64
+	DueDate        *time.Time
+	PayoffDate     *time.Time
+	TotalInterest  int64
+	TotalPaid      int64
+}
+
+type PayoffAllocation struct {
+	LoanID           uuid.UUID
+	Kind             PayoffLoanKind
+	Name             string
+	Payment          int64
+	Interest         int64
+	Extra            int64
+	RemainingBalance int64
+}
+
+type PayoffMonth struct {
+	Year             int
+	Month            int
+	Payment          int64
+	Interest         int64
+	RemainingBalance int64
+	Allocations      []PayoffAllocation
+}
+
+// PayoffPlanResult is a month by month simulation starting next month. Loans
+// are listed in payoff order. The minimum-only figures pay just the minimum
+// payments, without the extra budget or rolling over paid-off payments.
+type PayoffPlanResult struct {
+	Strategy                models.PayoffStrategy
+	ExtraMonthlyBudget      int64
+	StartDate               time.Time
+	DebtFreeDate            *time.Time
+	MonthsToDebtFree        int
+	TotalInterest           int64
+	TotalPaid               int64
+	MinimumOnlyDebtFreeDate *time.Time
+	MinimumOnlyInterest     int64
+	InterestSaved           int64
+	Loans                   []PayoffLoan
+	Months                  []PayoffMonth
+}
+
+type PayoffComparisonMonth struct {
+	Year           int
+	Month          int
+	PlannedPayment int64
+	ActualPayment  int64
+	PlannedBalance int64
+}
+
+// PayoffPlanComparison sets a saved plan against the payments recorded on its
+// loans, for the months that have started so far
+type PayoffPlanComparison struct {
+	Plan                  *models.PayoffPlan
+	Months                []PayoffComparisonMonth
+	CumulativePlanned     int64
+	CumulativeActual      int64
+	ExpectedBalance       int64
+	CurrentBalance        int64
+	ProjectedDebtFreeDate *time.Time
+	OnTrack               bool
+}
+
+// Simulate pays every month's minimum payments, then puts the extra budget
+// plus the minimum payments freed by paid-off loans on the first unpaid loan in
+// strategy order. Interest is charged monthly on the remaining balance.
+func (s *PayoffPlanService) Simulate(userID uuid.UUID, input PayoffPlanInput) (*PayoffPlanResult, error) {
+	loans, err := s.activeLoans(userID)
+	if err != nil {
+		return nil, err
+	}
+	return simulatePayoffPlan(loans, input)
+}
+
+func (s *PayoffPlanService) Save(userID uuid.UUID, name string, input PayoffPlanInput) (*models.PayoffPlan, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+
+	result, err := s.Simulate(userID, input)
+	if err != nil {
+		return nil, err
+	}
+	if result.DebtFreeDate == nil {
+		return nil, errors.New("no active debts or installments to plan")
+	}
+
+	plan := &models.PayoffPlan{
+		ID:                 uuid.New(),
+		UserID:             userID,
+		Name:               name,
+		Strategy:           input.Strategy,
+		ExtraMonthlyBudget: input.ExtraMonthlyBudget,
+		CustomOrder:        models.JoinLoanIDs(input.CustomOrder),
+		StartDate:          result.StartDate,
+		DebtFreeDate:       *result.DebtFreeDate,
+		TotalInterest:      result.TotalInterest,
+		TotalPaid:          result.TotalPaid,
+	}
+	order := make([]uuid.UUID, len(result.Loans))
+	for i, loan := range result.Loans {
+		order[i] = loan.ID
+	}
+	plan.PayoffOrder = models.JoinLoanIDs(order)
+	for _, m := range result.Months {
+		plan.Months = append(plan.Months, models.PayoffPlanMonth{
+			ID:             uuid.New(),
+			PlanID:         plan.ID,
+			Year:           m.Year,
+			Month:          m.Month,
+			PlannedPayment: m.Payment,
+			PlannedBalance: m.RemainingBalance,
+		})
+	}
+
+	if err := s.planRepo.Create(plan); err != nil {
+		return nil, err
+	}
+	return s.planRepo.GetByID(plan.ID)
+}
+
+func (s *PayoffPlanService) GetSaved(userID uuid.UUID) ([]models.PayoffPlan, error) {
+	return s.planRepo.GetByUserID(userID)
+}
+
+func (s *PayoffPlanService) Delete(userID, id uuid.UUID) error {
+	if _, err := s.getOwned(userID, id); err != nil {
+		return err
+	}
+	return s.planRepo.Delete(id)
+}
+
+// Compare adds up the payments made on the plan's loans per month, including
+// installment prepayments, and re-runs the plan from today's balances to
+// project the debt-free date
+func (s *PayoffPlanService) Compare(userID, id uuid.UUID) (*PayoffPlanComparison, error) {
+	plan, err := s.getOwned(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	inPlan := make(map[uuid.UUID]bool)
+	for _, loanID := range models.LoanIDs(plan.PayoffOrder) {
+		inPlan[loanID] = true
+	}
+
+	installments, err := s.installmentRepo.GetByUserID(userID, nil)
+	if err != nil {
+		return nil, err
+	}
+	debts, err := s.debtRepo.GetByUserID(userID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := &PayoffPlanComparison{
+		Plan:    plan,
+		Months:  []PayoffComparisonMonth{},
+		OnTrack: true,
+	}
+
+	actual := make(map[string]int64)
+	for _, inst := range installments {
+		if !inPlan[inst.ID] {
+			continue
+		}
+		for _, p := range inst.Payments {
+			actual[monthKey(p.PaidAt)] += p.Amount
+		}
+		for _, p := range inst.Prepayments {
+			actual[monthKey(p.PaidAt)] += p.Amount
+		}
+		if inst.Status == models.InstallmentStatusActive {
+			comparison.CurrentBalance += inst.RemainingPrincipal()
+		}
+	}
+	for _, debt := range debts {
+		if !inPlan[debt.ID] {
+			continue
+		}
+		for _, p := range debt.Payments {
+			actual[monthKey(p.PaidAt)] += p.Amount
+		}
+		if debt.Status == models.DebtStatusActive && debt.RemainingAmount() > 0 {
+			comparison.CurrentBalance += debt.RemainingAmount()
+		}
+	}
+
+	now := time.Now()
+	current := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for _, m := range plan.Months {
+		period := time.Date(m.Year, time.Month(m.Month), 1, 0, 0, 0, 0, time.UTC)
+		if period.After(current) {
+			break
+		}
+		month := PayoffComparisonMonth{
+			Year:           m.Year,
+			Month:          m.Month,
+			PlannedPayment: m.PlannedPayment,
+			ActualPayment:  actual[monthKey(period)],
+			PlannedBalance: m.PlannedBalance,
+		}
+		comparison.CumulativePlanned += month.PlannedPayment
+		comparison.CumulativeActual += month.ActualPayment
+		comparison.ExpectedBalance = month.PlannedBalance
+		comparison.Months = append(comparison.Months, month)
+	}
+	if len(comparison.Months) > 0 {
+		comparison.OnTrack = comparison.CumulativeActual >= comparison.CumulativePlanned
+	}
+
+	loans, err := s.activeLoans(userID)
+	if err != nil {
+		return nil, err
+	}
+	var remaining []PayoffLoan
+	isRemaining := make(map[uuid.UUID]bool)
+	for _, loan := range loans {
+		if inPlan[loan.ID] {
+			remaining = append(remaining, loan)
+			isRemaining[loan.ID] = true
+		}
+	}
+	// Loans paid off since the plan was saved drop out of the custom order
+	var customOrder []uuid.UUID
+	for _, loanID := range models.LoanIDs(plan.CustomOrder) {
+		if isRemaining[loanID] {
+			customOrder = append(customOrder, loanID)
+		}
+	}
+	projection, err := simulatePayoffPlan(remaining, PayoffPlanInput{
+		ExtraMonthlyBudget: plan.ExtraMonthlyBudget,
+		Strategy:           plan.Strategy,
+		CustomOrder:        customOrder,
+	})
+	if err != nil {
+		log.Printf("Error projecting payoff plan %s: %v", plan.ID, err)
+	} else {
+		comparison.ProjectedDebtFreeDate = projection.DebtFreeDate
+	}
+
+	return comparison, nil
+}
+
+func (s *PayoffPlanService) getOwned(userID, id uuid.UUID) (*models.PayoffPlan, error) {
+	plan, err := s.planRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if plan.UserID != userID {
+		return nil, errors.New("payoff plan not found")
+	}
+	return plan, nil
+}
+
+// activeLoans collects the active installments and debts with something left to pay
+func (s *PayoffPlanService) activeLoans(userID uuid.UUID) ([]PayoffLoan, error) {
+	installmentStatus := models.InstallmentStatusActive
+	installments, err := s.installmentRepo.GetByUserID(userID, &installmentStatus)
+	if err != nil {
+		return nil, err
+	}
+	debtStatus := models.DebtStatusActive
+	debts, err := s.debtRepo.GetByUserID(userID, &debtStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	var loans []PayoffLoan
+	for i := range installments {
+		if loan := installmentPayoffLoan(&installments[i]); loan.Balance > 0 {
+			loans = append(loans, loan)
+		}
+	}
+	for i := range debts {
+		// Money owed to the user isn't paid off by the user
+		if debts[i].IsReceivable() {
+			continue
+		}
+		if loan := debtPayoffLoan(&debts[i]); loan.Balance > 0 {
+			loans = append(loans, loan)
+		}
+	}
+	return loans, nil
+}
+
+// installmentPayoffLoan enters an installment with its remaining principal and
+// next scheduled payment
+func installmentPayoffLoan(inst *models.Installment) PayoffLoan {
+	minimum := inst.MonthlyPayment
+	if schedule := inst.AmortizationSchedule(); inst.PaidCount() < len(schedule) {
+		minimum = schedule[inst.PaidCount()].Payment
+	}
+	return PayoffLoan{
+		ID:             inst.ID,
+		Kind:           PayoffLoanKindInstallment,
+		Name:           inst.Name,
+		Balance:        inst.RemainingPrincipal(),
+		AnnualRate:     inst.EffectiveAnnualRate(),
+		MinimumPayment: minimum,
+	}
+}
+
+// debtPayoffLoan enters a debt with the amount left to pay
+func debtPayoffLoan(debt *models.Debt) PayoffLoan {
+	loan := PayoffLoan{
+		ID:      debt.ID,
+		Kind:    PayoffLoanKindDebt,
+		Name:    debt.PersonName,
+		Balance: debt.RemainingAmount(),
+	}
+	if debt.HasInterest() {
+		loan.AnnualRate = *debt.InterestRate
+	}
+	if debt.IsInstallment() && debt.MonthlyPayment != nil && *debt.MonthlyPayment > 0 {
+		loan.MinimumPayment = *debt.MonthlyPayment
+	} else {
+		loan.DueDate = debt.DueDate
+	}
+	return loan
+}
+
+func simulatePayoffPlan(loans []PayoffLoan, input PayoffPlanInput) (*PayoffPlanResult, error) {
+	if !input.Strategy.IsValid() {
+		return nil, errors.New("invalid payoff strategy")
+	}
+	if input.ExtraMonthlyBudget < 0 {
+		return nil, errors.New("extra monthly budget cannot be negative")
+	}
+
+	ordered, err := orderPayoffLoans(loans, input.Strategy, input.CustomOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 1, 0)
+	result := &PayoffPlanResult{
+		Strategy:           input.Strategy,
+		ExtraMonthlyBudget: input.ExtraMonthlyBudget,
+		StartDate:          start,
+		Loans:              []PayoffLoan{},
+		Months:             []PayoffMonth{},
+	}
+	if len(ordered) == 0 {
+		return result, nil
+	}
+
+	months, paidOff, err := runPayoff(ordered, input.ExtraMonthlyBudget, start, true)
+	if err != nil {
+		return nil, err
+	}
+	result.Months = months
+	result.Loans = paidOff
+	result.MonthsToDebtFree = len(months)
+	last := start.AddDate(0, len(months)-1, 0)
+	result.DebtFreeDate = &last
+	for _, m := range months {
+		result.TotalInterest += m.Interest
+		result.TotalPaid += m.Payment
+	}
+
+	if minimumOnly, _, err := runPayoff(ordered, 0, start, false); err == nil {
+		last := start.AddDate(0, len(minimumOnly)-1, 0)
+		result.MinimumOnlyDebtFreeDate = &last
+		for _, m := range minimumOnly {
+			result.MinimumOnlyInterest += m.Interest
+		}
+		result.InterestSaved = result.MinimumOnlyInterest - result.TotalInterest
+	}
+
+	return result, nil
+}
+
+// runPayoff simulates the loans month by month and returns the months and the
+// loans in the order they were paid off. With rollover, the extra budget and
+// the minimum payments of paid-off loans go to the first unpaid loan.
+func runPayoff(loans []PayoffLoan, extra int64, start time.Time, rollover bool) ([]PayoffMonth, []PayoffLoan, error) {
+	state := make([]PayoffLoan, len(loans))
+	copy(state, loans)
+
+	budget := extra
+	for _, loan := range state {
+		budget += loan.MinimumPayment
+	}
+
+	var months []PayoffMonth
+	var paidOff []PayoffLoan
+	for n := 0; len(paidOff) < len(state); n++ {
+		if n >= payoffMaxMonths {
+			return nil, nil, errors.New("debts are not paid off within 50 years with this budget")
+		}
+		date := start.AddDate(0, n, 0)
+		monthEnd := date.AddDate(0, 1, -1)
+
+		allocations := make([]PayoffAllocation, len(state))
+		var spent int64
+		for i := range state {
+			loan := &state[i]
+			if loan.Balance <= 0 {
+				continue
+			}
+			interest := int64(math.Round(float64(loan.Balance) * loan.AnnualRate / 12 / 100))
+			loan.Balance += interest
+
+			due := min(loan.MinimumPayment, loan.Balance)
+			if loan.MinimumPayment == 0 && (loan.DueDate == nil || !loan.DueDate.After(monthEnd)) {
+				// One-time debt: paid in full in its due month, outside the budget
+				due = loan.Balance
+			}
+			if loan.MinimumPayment > 0 {
+				spent += due
+			}
+			loan.Balance -= due
+
+			allocations[i] = PayoffAllocation{Payment: due, Interest: interest}
+		}
+
+		if rollover {
+			leftover := budget - spent
+			for i := range state {
+				if leftover <= 0 {
+					break
+				}
+				if state[i].Balance <= 0 {
+					continue
+				}
+				amount := min(leftover, state[i].Balance)
+				state[i].Balance -= amount
+				leftover -= amount
+				allocations[i].Payment += amount
+				allocations[i].Extra += amount
+			}
+		}
+
+		month := PayoffMonth{Year: date.Year(), Month: int(date.Month()), Allocations: []PayoffAllocation{}}
+		for i := range state {
+			loan := &state[i]
+			a := allocations[i]
+			if a.Payment == 0 && a.Interest == 0 {
+				continue
+			}
+			a.LoanID = loan.ID
+			a.Kind = loan.Kind
+			a.Name = loan.Name
+			a.RemainingBalance = loan.Balance
+			month.Allocations = append(month.Allocations, a)
+			month.Payment += a.Payment
+			month.Interest += a.Interest
+			loan.TotalInterest += a.Interest
+			loan.TotalPaid += a.Payment
+
+			if loan.Balance <= 0 && loan.PayoffDate == nil {
+				payoffDate := date
+				loan.PayoffDate = &payoffDate
+				paidOff = append(paidOff, *loan)
+			}
+		}
+		for _, loan := range state {
+			month.RemainingBalance += loan.Balance
+		}
+		months = append(months, month)
+	}
+
+	return months, paidOff, nil
+}
+
+// orderPayoffLoans sorts loans by the priority the extra budget follows
+func orderPayoffLoans(loans []PayoffLoan, strategy models.PayoffStrategy, customOrder []uuid.UUID) ([]PayoffLoan, error) {
+	ordered := append([]PayoffLoan(nil), loans...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if strategy == models.PayoffStrategyAvalanche && ordered[i].AnnualRate != ordered[j].AnnualRate {
+			return ordered[i].AnnualRate > ordered[j].AnnualRate
+		}
+		if ordered[i].Balance != ordered[j].Balance {
+			return ordered[i].Balance < ordered[j].Balance
+		}
+		return ordered[i].AnnualRate > ordered[j].AnnualRate
+	})
+
+	if strategy != models.PayoffStrategyCustomOrder {
+		return ordered, nil
+	}
+
+	rank := make(map[uuid.UUID]int, len(customOrder))
+	for i, id := range customOrder {
+		rank[id] = i
+	}
+	for id := range rank {
+		found := false
+		for _, loan := range ordered {
+			if loan.ID == id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.New("custom order contains an unknown or paid off loan")
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		ri, iok := rank[ordered[i].ID]
+		rj, jok := rank[ordered[j].ID]
+		switch {
+		case iok && jok:
+			return ri < rj
+		case iok != jok:
+			return iok
+		}
+		return false
+	})
+	return ordered, nil
+}
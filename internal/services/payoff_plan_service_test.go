@@ -0,0 +1,233 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+	"github.com/azzamdhx/moneybro/backend/internal/repository"
+)
+
+type fakePayoffPlanRepo struct {
+	repository.PayoffPlanRepository
+	plans map[uuid.UUID]models.PayoffPlan
+}
+
+func (r *fakePayoffPlanRepo) GetByID(id uuid.UUID) (*models.PayoffPlan, error) {
+	plan, ok := r.plans[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &plan, nil
+}
+
+func TestPayoffPlanCompareCustomOrder(t *testing.T) {
+	tests := []struct {
+		name string
+		// paidOff lists the debts, by position in the custom order, paid off
+		// since the plan was saved
+		paidOff       []int
+		wantProjected bool
+	}{
+		{name: "no loan paid off", wantProjected: true},
+		{name: "first loan paid off", paidOff: []int{0}, wantProjected: true},
+		{name: "last loan paid off", paidOff: []int{2}, wantProjected: true},
+		{name: "every loan paid off", paidOff: []int{0, 1, 2}, wantProjected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID := uuid.New()
+			debts := &fakeDebtRepo{debts: make(map[uuid.UUID]models.Debt)}
+			dueDate := time.Now().AddDate(1, 0, 0)
+			var order []uuid.UUID
+			for _, amount := range []int64{300000, 500000, 200000} {
+				debt := models.Debt{
+					ID:           uuid.New(),
+					UserID:       userID,
+					PersonName:   "Budi",
+					ActualAmount: amount,
+					PaymentType:  models.DebtPaymentTypeOneTime,
+					Direction:    models.DebtDirectionPayable,
+					DueDate:      &dueDate,
+					Status:       models.DebtStatusActive,
+				}
+				debts.debts[debt.ID] = debt
+				order = append(order, debt.ID)
+			}
+			for _, i := range tt.paidOff {
+				debt := debts.debts[order[i]]
+				debt.Payments = []models.DebtPayment{{ID: uuid.New(), DebtID: debt.ID, PaymentNumber: 1, Amount: debt.ActualAmount, PaidAt: time.Now()}}
+				debt.Status = models.DebtStatusCompleted
+				debts.debts[debt.ID] = debt
+			}
+
+			plan := models.PayoffPlan{
+				ID:          uuid.New(),
+				UserID:      userID,
+				Name:        "Lunas",
+				Strategy:    models.PayoffStrategyCustomOrder,
+				CustomOrder: models.JoinLoanIDs(order),
+				PayoffOrder: models.JoinLoanIDs(order),
+			}
+			service := NewPayoffPlanService(
+				&fakeInstallmentRepo{installments: make(map[uuid.UUID]models.Installment)},
+				debts,
+				&fakePayoffPlanRepo{plans: map[uuid.UUID]models.PayoffPlan{plan.ID: plan}},
+			)
+
+			comparison, err := service.Compare(userID, plan.ID)
+			if err != nil {
+				t.Fatalf("Compare: %v", err)
+			}
+			if projected := comparison.ProjectedDebtFreeDate != nil; projected != tt.wantProjected {
+				t.Errorf("projected debt-free date = %v, want one: %v", comparison.ProjectedDebtFreeDate, tt.wantProjected)
+			}
+		})
+	}
+}
+
+func payoffLoanNames(loans []PayoffLoan) string {
+	names := make([]string, len(loans))
+	for i, loan := range loans {
+		names[i] = loan.Name
+	}
+	return strings.Join(names, " ")
+}
+
+func TestOrderPayoffLoans(t *testing.T) {
+	a := PayoffLoan{ID: uuid.New(), Name: "A", Balance: 300000, MinimumPayment: 50000}
+	b := PayoffLoan{ID: uuid.New(), Name: "B", Balance: 1000000, AnnualRate: 24, MinimumPayment: 100000}
+	c := PayoffLoan{ID: uuid.New(), Name: "C", Balance: 500000, AnnualRate: 12, MinimumPayment: 50000}
+	d := PayoffLoan{ID: uuid.New(), Name: "D", Balance: 500000, AnnualRate: 18, MinimumPayment: 50000}
+
+	tests := []struct {
+		name        string
+		loans       []PayoffLoan
+		strategy    models.PayoffStrategy
+		customOrder []uuid.UUID
+		want        string
+		wantErr     string
+	}{
+		{name: "snowball pays the smallest balance first", loans: []PayoffLoan{b, c, a}, strategy: models.PayoffStrategySnowball, want: "A C B"},
+		{name: "snowball breaks ties by rate", loans: []PayoffLoan{c, d}, strategy: models.PayoffStrategySnowball, want: "D C"},
+		{name: "avalanche pays the highest rate first", loans: []PayoffLoan{a, c, b}, strategy: models.PayoffStrategyAvalanche, want: "B C A"},
+		{name: "custom order", loans: []PayoffLoan{a, b, c}, strategy: models.PayoffStrategyCustomOrder, customOrder: []uuid.UUID{b.ID, a.ID}, want: "B A C"},
+		{name: "loans left out follow in snowball order", loans: []PayoffLoan{b, c, a}, strategy: models.PayoffStrategyCustomOrder, customOrder: []uuid.UUID{b.ID}, want: "B A C"},
+		{name: "custom order with a paid off loan", loans: []PayoffLoan{a, c}, strategy: models.PayoffStrategyCustomOrder, customOrder: []uuid.UUID{b.ID, a.ID}, wantErr: "unknown or paid off loan"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ordered, err := orderPayoffLoans(tt.loans, tt.strategy, tt.customOrder)
+			if tt.wantErr != "" {
+				if !errContains(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("orderPayoffLoans: %v", err)
+			}
+			if got := payoffLoanNames(ordered); got != tt.want {
+				t.Errorf("order = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSimulatePayoffPlan(t *testing.T) {
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 1, 0)
+	dueNextMonth := start.AddDate(0, 1, 14)
+
+	a := PayoffLoan{ID: uuid.New(), Name: "A", Balance: 300000, MinimumPayment: 100000}
+	b := PayoffLoan{ID: uuid.New(), Name: "B", Balance: 600000, MinimumPayment: 100000}
+
+	tests := []struct {
+		name  string
+		loans []PayoffLoan
+		input PayoffPlanInput
+		// wantPaidOff lists the loans in the order they are paid off
+		wantPaidOff       string
+		wantMonths        int
+		wantMinimumMonths int
+		wantFirstInterest int64
+		wantErr           string
+	}{
+		{
+			name:              "extra budget and freed payments roll over",
+			loans:             []PayoffLoan{a, b},
+			input:             PayoffPlanInput{Strategy: models.PayoffStrategySnowball, ExtraMonthlyBudget: 100000},
+			wantPaidOff:       "A B",
+			wantMonths:        3,
+			wantMinimumMonths: 6,
+		},
+		{
+			name:              "custom order puts the extra on the larger loan",
+			loans:             []PayoffLoan{a, b},
+			input:             PayoffPlanInput{Strategy: models.PayoffStrategyCustomOrder, ExtraMonthlyBudget: 100000, CustomOrder: []uuid.UUID{b.ID}},
+			wantPaidOff:       "B A",
+			wantMonths:        3,
+			wantMinimumMonths: 6,
+		},
+		{
+			name:              "one-time debt is paid in its due month",
+			loans:             []PayoffLoan{a, {ID: uuid.New(), Name: "D", Balance: 400000, DueDate: &dueNextMonth}},
+			input:             PayoffPlanInput{Strategy: models.PayoffStrategySnowball},
+			wantPaidOff:       "D A",
+			wantMonths:        3,
+			wantMinimumMonths: 3,
+		},
+		{
+			name:              "interest is charged on the balance left",
+			loans:             []PayoffLoan{{ID: uuid.New(), Name: "K", Balance: 1200000, AnnualRate: 12, MinimumPayment: 100000}},
+			input:             PayoffPlanInput{Strategy: models.PayoffStrategyAvalanche},
+			wantPaidOff:       "K",
+			wantMonths:        13,
+			wantMinimumMonths: 13,
+			wantFirstInterest: 12000,
+		},
+		{name: "unknown strategy", loans: []PayoffLoan{a}, input: PayoffPlanInput{Strategy: "RANDOM"}, wantErr: "invalid payoff strategy"},
+		{name: "negative extra budget", loans: []PayoffLoan{a}, input: PayoffPlanInput{Strategy: models.PayoffStrategySnowball, ExtraMonthlyBudget: -1}, wantErr: "cannot be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := simulatePayoffPlan(tt.loans, tt.input)
+			if tt.wantErr != "" {
+				if !errContains(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("simulatePayoffPlan: %v", err)
+			}
+			if got := payoffLoanNames(result.Loans); got != tt.wantPaidOff {
+				t.Errorf("paid off %q, want %q", got, tt.wantPaidOff)
+			}
+			if result.MonthsToDebtFree != tt.wantMonths {
+				t.Errorf("months to debt free = %d, want %d", result.MonthsToDebtFree, tt.wantMonths)
+			}
+			if want := start.AddDate(0, tt.wantMinimumMonths-1, 0); result.MinimumOnlyDebtFreeDate == nil || !result.MinimumOnlyDebtFreeDate.Equal(want) {
+				t.Errorf("minimum-only debt free date = %v, want %v", result.MinimumOnlyDebtFreeDate, want)
+			}
+			if got := result.Months[0].Interest; got != tt.wantFirstInterest {
+				t.Errorf("first month interest = %d, want %d", got, tt.wantFirstInterest)
+			}
+
+			var balance int64
+			for _, loan := range tt.loans {
+				balance += loan.Balance
+			}
+			if result.TotalPaid != balance+result.TotalInterest {
+				t.Errorf("total paid = %d, want balance %d plus interest %d", result.TotalPaid, balance, result.TotalInterest)
+			}
+		})
+	}
+}
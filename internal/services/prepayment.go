@@ -0,0 +1,44 @@
+package services
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+)
+
+type PrepaymentInput struct {
+	ExtraAmount int64
+	Date        time.Time
+	Strategy    models.PrepaymentStrategy
+	PocketID    *uuid.UUID
+}
+
+// PrepaymentResult compares a loan before and after an extra payment. Remaining
+// amounts are the principal left for installments and the amount left to pay
+// for debts. Schedule lists the periods after the prepayment.
+type PrepaymentResult struct {
+	Strategy                models.PrepaymentStrategy
+	ExtraAmount             int64
+	RemainingBefore         int64
+	RemainingAfter          int64
+	MonthlyPaymentBefore    int64
+	MonthlyPaymentAfter     int64
+	RemainingPaymentsBefore int
+	RemainingPaymentsAfter  int
+	EndDateBefore           *time.Time
+	EndDateAfter            *time.Time
+	InterestSaved           int64
+	Schedule                []models.AmortizationPeriod
+	Installment             *models.Installment
+	Debt                    *models.Debt
+}
+
+func scheduleEndDate(schedule []models.AmortizationPeriod) *time.Time {
+	if len(schedule) == 0 {
+		return nil
+	}
+	end := schedule[len(schedule)-1].DueDate
+	return &end
+}
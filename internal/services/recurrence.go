@@ -0,0 +1,84 @@
+package services
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+)
+
+// RecurrenceInput describes a recurrence rule, either field by field or as an
+// RFC 5545 RRULE. When RRule is given the other rule fields are ignored, except
+// StartDate and EndDate which override the imported DTSTART/UNTIL.
+type RecurrenceInput struct {
+	Frequency  *string
+	Interval   *int
+	ByWeekday  []string
+	ByMonthDay []int
+	ByMonth    []int
+	StartDate  *time.Time
+	EndDate    *time.Time
+	Count      *int
+	RRule      *string
+}
+
+func buildRecurrence(input RecurrenceInput) (models.Recurrence, error) {
+	var rule models.Recurrence
+
+	if input.RRule != nil && strings.TrimSpace(*input.RRule) != "" {
+		parsed, err := models.ParseRRule(*input.RRule)
+		if err != nil {
+			return rule, err
+		}
+		rule = *parsed
+	} else {
+		if input.Frequency == nil {
+			return rule, errors.New("recurrence frequency is required")
+		}
+		frequency := models.RecurrenceFrequency(strings.ToUpper(*input.Frequency))
+		rule = models.Recurrence{
+			Frequency:  &frequency,
+			Interval:   1,
+			ByWeekday:  strings.ToUpper(strings.Join(input.ByWeekday, ",")),
+			ByMonthDay: joinInts(input.ByMonthDay),
+			ByMonth:    joinInts(input.ByMonth),
+			Count:      input.Count,
+		}
+		if input.Interval != nil {
+			rule.Interval = *input.Interval
+		}
+	}
+
+	if input.StartDate != nil {
+		rule.StartDate = input.StartDate
+	}
+	if input.EndDate != nil {
+		rule.EndDate = input.EndDate
+	}
+
+	if err := rule.Validate(); err != nil {
+		return rule, err
+	}
+	return rule, nil
+}
+
+// manualPostOccurrence picks the occurrence a manual post on date stands in for:
+// the first occurrence on or after it in the same month, or the date itself
+func manualPostOccurrence(occurrences func(from, to time.Time) []time.Time, date time.Time) time.Time {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	monthEnd := time.Date(date.Year(), date.Month()+1, 0, 0, 0, 0, 0, time.UTC)
+	if next := occurrences(day, monthEnd); len(next) > 0 {
+		return next[0]
+	}
+	return day
+}
+
+func joinInts(values []int) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = strconv.Itoa(v)
+	}
+	return strings.Join(parts, ",")
+}
@@ -2,6 +2,9 @@ package services
 
 import (
 	"errors"
+	"fmt"
+	"log"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,26 +17,39 @@ type RecurringIncomeGroupService struct {
 	groupRepo          repository.RecurringIncomeGroupRepository
 	incomeService      *IncomeService
 	incomeCategoryRepo repository.IncomeCategoryRepository
+	accountRepo        repository.AccountRepository
+	holidayRepo        repository.HolidayRepository
+	runRepo            repository.RecurringIncomeRunRepository
 }
 
 func NewRecurringIncomeGroupService(
 	groupRepo repository.RecurringIncomeGroupRepository,
 	incomeService *IncomeService,
 	incomeCategoryRepo repository.IncomeCategoryRepository,
+	accountRepo repository.AccountRepository,
+	holidayRepo repository.HolidayRepository,
+	runRepo repository.RecurringIncomeRunRepository,
 ) *RecurringIncomeGroupService {
 	return &RecurringIncomeGroupService{
 		groupRepo:          groupRepo,
 		incomeService:      incomeService,
 		incomeCategoryRepo: incomeCategoryRepo,
+		accountRepo:        accountRepo,
+		holidayRepo:        holidayRepo,
+		runRepo:            runRepo,
 	}
 }
 
 type CreateRecurringIncomeGroupInput struct {
-	Name         string
-	RecurringDay *int
-	IsActive     *bool
-	Notes        *string
-	Items        []CreateRecurringIncomeItemInput
+	Name               string
+	RecurringDay       *int
+	Recurrence         *RecurrenceInput
+	IsActive           *bool
+	Notes              *string
+	AutoPost           bool
+	TargetPocketID     *uuid.UUID
+	ShiftToBusinessDay bool
+	Items              []CreateRecurringIncomeItemInput
 }
 
 type CreateRecurringIncomeItemInput struct {
@@ -49,6 +65,9 @@ func (s *RecurringIncomeGroupService) Create(userID uuid.UUID, input CreateRecur
 	if input.RecurringDay != nil && (*input.RecurringDay < 1 || *input.RecurringDay > 31) {
 		return nil, errors.New("recurring day must be between 1 and 31")
 	}
+	if err := s.validateTargetPocket(userID, input.TargetPocketID); err != nil {
+		return nil, err
+	}
 	if len(input.Items) == 0 {
 		return nil, errors.New("at least one item is required")
 	}
@@ -59,12 +78,30 @@ func (s *RecurringIncomeGroupService) Create(userID uuid.UUID, input CreateRecur
 	}
 
 	group := &models.RecurringIncomeGroup{
-		ID:           uuid.New(),
-		UserID:       userID,
-		Name:         input.Name,
-		RecurringDay: input.RecurringDay,
-		IsActive:     isActive,
-		Notes:        input.Notes,
+		ID:                 uuid.New(),
+		UserID:             userID,
+		Name:               input.Name,
+		RecurringDay:       input.RecurringDay,
+		IsActive:           isActive,
+		Notes:              input.Notes,
+		AutoPost:           input.AutoPost,
+		TargetPocketID:     input.TargetPocketID,
+		ShiftToBusinessDay: input.ShiftToBusinessDay,
+	}
+	if input.Recurrence != nil {
+		rule, err := buildRecurrence(*input.Recurrence)
+		if err != nil {
+			return nil, err
+		}
+		group.Recurrence = rule
+	}
+	if group.AutoPost {
+		if group.Schedule() == nil {
+			return nil, errors.New("recurring day or recurrence is required for auto-post")
+		}
+		if err := s.skipPassedPaydays(group, time.Now()); err != nil {
+			return nil, err
+		}
 	}
 
 	if err := s.groupRepo.Create(group); err != nil {
@@ -105,10 +142,16 @@ func (s *RecurringIncomeGroupService) GetByUserID(userID uuid.UUID, isActive *bo
 }
 
 type UpdateRecurringIncomeGroupInput struct {
-	Name         *string
-	RecurringDay *int
-	IsActive     *bool
-	Notes        *string
+	Name               *string
+	RecurringDay       *int
+	Recurrence         *RecurrenceInput
+	ClearRecurrence    bool
+	IsActive           *bool
+	Notes              *string
+	AutoPost           *bool
+	TargetPocketID     *uuid.UUID
+	ClearTargetPocket  bool
+	ShiftToBusinessDay *bool
 }
 
 func (s *RecurringIncomeGroupService) Update(id uuid.UUID, input UpdateRecurringIncomeGroupInput) (*models.RecurringIncomeGroup, error) {
@@ -121,14 +164,54 @@ func (s *RecurringIncomeGroupService) Update(id uuid.UUID, input UpdateRecurring
 		group.Name = *input.Name
 	}
 	if input.RecurringDay != nil {
+		if *input.RecurringDay < 1 || *input.RecurringDay > 31 {
+			return nil, errors.New("recurring day must be between 1 and 31")
+		}
 		group.RecurringDay = input.RecurringDay
 	}
+	// Reactivating or enabling auto-post resumes from the next payday instead of
+	// backfilling the ones in between
+	resuming := (input.IsActive != nil && *input.IsActive && !group.IsActive) ||
+		(input.AutoPost != nil && *input.AutoPost && !group.AutoPost)
 	if input.IsActive != nil {
 		group.IsActive = *input.IsActive
 	}
 	if input.Notes != nil {
 		group.Notes = input.Notes
 	}
+	if input.ClearRecurrence {
+		group.Recurrence = models.Recurrence{Interval: 1}
+	} else if input.Recurrence != nil {
+		rule, err := buildRecurrence(*input.Recurrence)
+		if err != nil {
+			return nil, err
+		}
+		group.Recurrence = rule
+	}
+	if input.ClearTargetPocket {
+		group.TargetPocketID = nil
+	} else if input.TargetPocketID != nil {
+		if err := s.validateTargetPocket(group.UserID, input.TargetPocketID); err != nil {
+			return nil, err
+		}
+		group.TargetPocketID = input.TargetPocketID
+	}
+	if input.ShiftToBusinessDay != nil {
+		group.ShiftToBusinessDay = *input.ShiftToBusinessDay
+	}
+	if input.AutoPost != nil {
+		group.AutoPost = *input.AutoPost
+	}
+	if group.AutoPost {
+		if group.Schedule() == nil {
+			return nil, errors.New("recurring day or recurrence is required for auto-post")
+		}
+		if resuming {
+			if err := s.skipPassedPaydays(group, time.Now()); err != nil {
+				return nil, err
+			}
+		}
+	}
 
 	if err := s.groupRepo.Update(group); err != nil {
 		return nil, err
@@ -227,5 +310,186 @@ func (s *RecurringIncomeGroupService) CreateIncomesFromGroup(userID uuid.UUID, g
 		incomes = append(incomes, *income)
 	}
 
+	// A manual post stands in for the nearest occurrence so the auto-post job won't repeat it
+	if group.Schedule() != nil {
+		postedAt := time.Now()
+		if incomeDate != nil {
+			postedAt = *incomeDate
+		}
+		if _, err := s.groupRepo.MarkPosted(group.ID, manualPostOccurrence(group.Occurrences, postedAt)); err != nil {
+			return incomes, err
+		}
+	}
+
 	return incomes, nil
 }
+
+func (s *RecurringIncomeGroupService) GetRuns(userID uuid.UUID, groupID *uuid.UUID, limit int) ([]models.RecurringIncomeRun, error) {
+	return s.runRepo.GetByUserID(userID, groupID, limit)
+}
+
+// businessDayLookahead is how far past today scheduled paydays are checked, since
+// a payday on a weekend or holiday run can be posted this many days early at most
+const businessDayLookahead = 14
+
+// AutoPostDueGroups posts incomes for every occurrence of an active auto-post group
+// whose payday has been reached since its last posted occurrence, and logs a run
+// per occurrence. With ShiftToBusinessDay a payday on a weekend or holiday moves
+// to the previous business day, so upcoming occurrences are checked as well.
+func (s *RecurringIncomeGroupService) AutoPostDueGroups(now time.Time) ([]models.RecurringIncomeRun, error) {
+	groups, err := s.groupRepo.GetAutoPostGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	var runs []models.RecurringIncomeRun
+	for _, group := range groups {
+		from := today
+		if group.LastPostedDate != nil {
+			from = group.LastPostedDate.AddDate(0, 0, 1)
+		}
+		to := today
+		if group.ShiftToBusinessDay {
+			to = today.AddDate(0, 0, businessDayLookahead)
+		}
+
+		for _, scheduledDate := range group.Occurrences(from, to) {
+			postDate, err := s.postingDate(&group, scheduledDate)
+			if err != nil {
+				log.Printf("Error resolving payday for recurring income group %s: %v", group.ID, err)
+				break
+			}
+			if today.Before(postDate) {
+				break
+			}
+
+			// Claim the occurrence before posting so concurrent runs can't double-post
+			claimed, err := s.groupRepo.MarkPosted(group.ID, scheduledDate)
+			if err != nil {
+				log.Printf("Error marking recurring income group %s as posted: %v", group.ID, err)
+				break
+			}
+			if !claimed {
+				continue
+			}
+
+			run := s.postGroup(group, scheduledDate, postDate)
+			if err := s.runRepo.Create(&run); err != nil {
+				log.Printf("Error logging recurring income run for group %s: %v", group.ID, err)
+			}
+			runs = append(runs, run)
+		}
+	}
+
+	return runs, nil
+}
+
+// postGroup creates the incomes of a group for one occurrence and returns the run log
+func (s *RecurringIncomeGroupService) postGroup(group models.RecurringIncomeGroup, scheduledDate, postDate time.Time) models.RecurringIncomeRun {
+	run := models.RecurringIncomeRun{
+		ID:            uuid.New(),
+		UserID:        group.UserID,
+		GroupID:       group.ID,
+		ScheduledDate: scheduledDate,
+		PostedDate:    postDate,
+	}
+
+	var failures []string
+	for _, item := range group.Items {
+		_, err := s.incomeService.Create(group.UserID, CreateIncomeInput{
+			CategoryID:  item.CategoryID,
+			SourceName:  item.SourceName,
+			Amount:      item.Amount,
+			IncomeDate:  &postDate,
+			IsRecurring: true,
+			Notes:       group.Notes,
+			PocketID:    group.TargetPocketID,
+		})
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", item.SourceName, err))
+			continue
+		}
+		run.IncomeCount++
+		run.TotalAmount += item.Amount
+	}
+
+	switch {
+	case len(failures) == 0:
+		run.Status = models.RecurringIncomeRunStatusSuccess
+	case run.IncomeCount > 0:
+		run.Status = models.RecurringIncomeRunStatusPartial
+	default:
+		run.Status = models.RecurringIncomeRunStatusFailed
+	}
+	if len(failures) > 0 {
+		msg := strings.Join(failures, "; ")
+		run.Error = &msg
+	}
+
+	return run
+}
+
+// postingDate returns the date a scheduled payday is actually posted on, moved
+// back to the previous business day when the group is configured to
+func (s *RecurringIncomeGroupService) postingDate(group *models.RecurringIncomeGroup, scheduled time.Time) (time.Time, error) {
+	if !group.ShiftToBusinessDay {
+		return scheduled, nil
+	}
+
+	holidays, err := s.holidayRepo.GetByUserIDAndDateRange(
+		group.UserID,
+		scheduled.AddDate(0, 0, -businessDayLookahead).Format("2006-01-02"),
+		scheduled.Format("2006-01-02"),
+	)
+	if err != nil {
+		return scheduled, err
+	}
+	isHoliday := make(map[string]bool, len(holidays))
+	for _, h := range holidays {
+		isHoliday[h.Date.Format("2006-01-02")] = true
+	}
+
+	posted := scheduled
+	for posted.Weekday() == time.Saturday || posted.Weekday() == time.Sunday || isHoliday[posted.Format("2006-01-02")] {
+		posted = posted.AddDate(0, 0, -1)
+	}
+	return posted, nil
+}
+
+// skipPassedPaydays marks every occurrence whose payday is today or earlier as
+// posted, so enabling auto-post doesn't backfill paydays the user handled by hand
+func (s *RecurringIncomeGroupService) skipPassedPaydays(group *models.RecurringIncomeGroup, now time.Time) error {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	lastPosted := today
+
+	if group.ShiftToBusinessDay {
+		for _, scheduled := range group.Occurrences(today.AddDate(0, 0, 1), today.AddDate(0, 0, businessDayLookahead)) {
+			postDate, err := s.postingDate(group, scheduled)
+			if err != nil {
+				return err
+			}
+			if today.Before(postDate) {
+				break
+			}
+			lastPosted = scheduled
+		}
+	}
+
+	if group.LastPostedDate == nil || group.LastPostedDate.Before(lastPosted) {
+		group.LastPostedDate = &lastPosted
+	}
+	return nil
+}
+
+func (s *RecurringIncomeGroupService) validateTargetPocket(userID uuid.UUID, pocketID *uuid.UUID) error {
+	if pocketID == nil {
+		return nil
+	}
+	pocket, err := s.accountRepo.GetByID(*pocketID)
+	if err != nil || pocket.UserID != userID || !pocket.IsPocket {
+		return errors.New("invalid target pocket")
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+	"github.com/azzamdhx/moneybro/backend/internal/repository"
+)
+
+type fakeRecurringIncomeGroupRepo struct {
+	repository.RecurringIncomeGroupRepository
+	groups     map[uuid.UUID]models.RecurringIncomeGroup
+	lastPosted map[uuid.UUID]time.Time
+}
+
+func newFakeRecurringIncomeGroupRepo(group models.RecurringIncomeGroup) *fakeRecurringIncomeGroupRepo {
+	r := &fakeRecurringIncomeGroupRepo{
+		groups:     map[uuid.UUID]models.RecurringIncomeGroup{group.ID: group},
+		lastPosted: make(map[uuid.UUID]time.Time),
+	}
+	if group.LastPostedDate != nil {
+		r.lastPosted[group.ID] = *group.LastPostedDate
+	}
+	return r
+}
+
+func (r *fakeRecurringIncomeGroupRepo) GetByID(id uuid.UUID) (*models.RecurringIncomeGroup, error) {
+	group, ok := r.groups[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &group, nil
+}
+
+func (r *fakeRecurringIncomeGroupRepo) GetAutoPostGroups() ([]models.RecurringIncomeGroup, error) {
+	var groups []models.RecurringIncomeGroup
+	for _, group := range r.groups {
+		if group.AutoPost && group.IsActive {
+			groups = append(groups, group)
+		}
+	}
+	return groups, nil
+}
+
+func (r *fakeRecurringIncomeGroupRepo) MarkPosted(id uuid.UUID, date time.Time) (bool, error) {
+	if last, ok := r.lastPosted[id]; ok && !last.Before(date) {
+		return false, nil
+	}
+	r.lastPosted[id] = date
+	return true, nil
+}
+
+type fakeHolidayRepo struct {
+	repository.HolidayRepository
+	holidays []models.Holiday
+}
+
+func (r *fakeHolidayRepo) GetByUserIDAndDateRange(userID uuid.UUID, startDate, endDate string) ([]models.Holiday, error) {
+	var holidays []models.Holiday
+	for _, h := range r.holidays {
+		if date := h.Date.Format("2006-01-02"); h.UserID == userID && date >= startDate && date <= endDate {
+			holidays = append(holidays, h)
+		}
+	}
+	return holidays, nil
+}
+
+type fakeRecurringIncomeRunRepo struct {
+	repository.RecurringIncomeRunRepository
+	runs []models.RecurringIncomeRun
+}
+
+func (r *fakeRecurringIncomeRunRepo) Create(run *models.RecurringIncomeRun) error {
+	r.runs = append(r.runs, *run)
+	return nil
+}
+
+func TestRecurringIncomeAutoPostDueGroups(t *testing.T) {
+	tests := []struct {
+		name       string
+		shift      bool
+		holidays   []time.Time
+		lastPosted *time.Time
+		now        time.Time
+		// wantScheduled and wantPosted are the runs' scheduled and posting dates
+		wantScheduled string
+		wantPosted    string
+		wantLast      string
+	}{
+		{
+			name:          "missed paydays are caught up in order",
+			lastPosted:    ptrDate(2026, time.January, 25),
+			now:           time.Date(2026, time.April, 26, 0, 0, 0, 0, time.UTC),
+			wantScheduled: "2026-02-25 2026-03-25 2026-04-25",
+			wantPosted:    "2026-02-25 2026-03-25 2026-04-25",
+			wantLast:      "2026-04-25",
+		},
+		{
+			name:       "weekend payday waits without shifting",
+			lastPosted: ptrDate(2026, time.March, 25),
+			now:        time.Date(2026, time.April, 24, 0, 0, 0, 0, time.UTC),
+			wantLast:   "2026-03-25",
+		},
+		{
+			name:          "weekend payday is posted on Friday",
+			shift:         true,
+			lastPosted:    ptrDate(2026, time.March, 25),
+			now:           time.Date(2026, time.April, 24, 0, 0, 0, 0, time.UTC),
+			wantScheduled: "2026-04-25",
+			wantPosted:    "2026-04-24",
+			wantLast:      "2026-04-25",
+		},
+		{
+			name:       "shifted payday not reached yet",
+			shift:      true,
+			lastPosted: ptrDate(2026, time.March, 25),
+			now:        time.Date(2026, time.April, 23, 0, 0, 0, 0, time.UTC),
+			wantLast:   "2026-03-25",
+		},
+		{
+			name:          "holiday before a weekend payday",
+			shift:         true,
+			holidays:      []time.Time{time.Date(2026, time.April, 24, 0, 0, 0, 0, time.UTC)},
+			lastPosted:    ptrDate(2026, time.March, 25),
+			now:           time.Date(2026, time.April, 23, 0, 0, 0, 0, time.UTC),
+			wantScheduled: "2026-04-25",
+			wantPosted:    "2026-04-23",
+			wantLast:      "2026-04-25",
+		},
+		{
+			name:       "early post is not repeated on the payday",
+			shift:      true,
+			lastPosted: ptrDate(2026, time.April, 25),
+			now:        time.Date(2026, time.April, 25, 0, 0, 0, 0, time.UTC),
+			wantLast:   "2026-04-25",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			day := 25
+			group := models.RecurringIncomeGroup{
+				ID:                 uuid.New(),
+				UserID:             uuid.New(),
+				Name:               "Gaji",
+				RecurringDay:       &day,
+				IsActive:           true,
+				CreatedAt:          time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC),
+				AutoPost:           true,
+				ShiftToBusinessDay: tt.shift,
+				LastPostedDate:     tt.lastPosted,
+			}
+			holidays := &fakeHolidayRepo{}
+			for _, date := range tt.holidays {
+				holidays.holidays = append(holidays.holidays, models.Holiday{ID: uuid.New(), UserID: group.UserID, Date: date, Name: "Libur"})
+			}
+			groups := newFakeRecurringIncomeGroupRepo(group)
+			runRepo := &fakeRecurringIncomeRunRepo{}
+			service := NewRecurringIncomeGroupService(groups, nil, nil, nil, holidays, runRepo)
+
+			runs, err := service.AutoPostDueGroups(tt.now)
+			if err != nil {
+				t.Fatalf("AutoPostDueGroups: %v", err)
+			}
+			var scheduled, posted []time.Time
+			for _, run := range runs {
+				scheduled = append(scheduled, run.ScheduledDate)
+				posted = append(posted, run.PostedDate)
+			}
+			if got := formatDates(scheduled); got != tt.wantScheduled {
+				t.Errorf("scheduled %q, want %q", got, tt.wantScheduled)
+			}
+			if got := formatDates(posted); got != tt.wantPosted {
+				t.Errorf("posted %q, want %q", got, tt.wantPosted)
+			}
+			if len(runRepo.runs) != len(runs) {
+				t.Errorf("logged %d runs, want %d", len(runRepo.runs), len(runs))
+			}
+			if got := lastPostedString(groups.lastPosted, group.ID); got != tt.wantLast {
+				t.Errorf("last posted date = %q, want %q", got, tt.wantLast)
+			}
+		})
+	}
+}
+
+func TestCreateIncomesFromGroupMarksPosted(t *testing.T) {
+	day := 25
+	tests := []struct {
+		name         string
+		recurringDay *int
+		autoPost     bool
+		postedOn     time.Time
+		wantLast     string
+	}{
+		{name: "auto-post group", recurringDay: &day, autoPost: true, postedOn: time.Date(2026, time.April, 20, 0, 0, 0, 0, time.UTC), wantLast: "2026-04-25"},
+		{name: "scheduled group without auto-post", recurringDay: &day, postedOn: time.Date(2026, time.April, 20, 0, 0, 0, 0, time.UTC), wantLast: "2026-04-25"},
+		{name: "after the payday", recurringDay: &day, postedOn: time.Date(2026, time.April, 27, 0, 0, 0, 0, time.UTC), wantLast: "2026-04-27"},
+		{name: "group without a schedule", postedOn: time.Date(2026, time.April, 20, 0, 0, 0, 0, time.UTC), wantLast: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			group := models.RecurringIncomeGroup{
+				ID:           uuid.New(),
+				UserID:       uuid.New(),
+				Name:         "Gaji",
+				RecurringDay: tt.recurringDay,
+				IsActive:     true,
+				CreatedAt:    time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC),
+				AutoPost:     tt.autoPost,
+			}
+			groups := newFakeRecurringIncomeGroupRepo(group)
+			service := NewRecurringIncomeGroupService(groups, nil, nil, nil, nil, nil)
+
+			if _, err := service.CreateIncomesFromGroup(group.UserID, group.ID, &tt.postedOn); err != nil {
+				t.Fatalf("CreateIncomesFromGroup: %v", err)
+			}
+			if got := lastPostedString(groups.lastPosted, group.ID); got != tt.wantLast {
+				t.Errorf("last posted date = %q, want %q", got, tt.wantLast)
+			}
+		})
+	}
+}
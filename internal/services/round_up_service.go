@@ -0,0 +1,352 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+	"github.com/azzamdhx/moneybro/backend/internal/repository"
+)
+
+type RoundUpService struct {
+	settingRepo        repository.RoundUpSettingRepository
+	roundUpRepo        repository.RoundUpRepository
+	goalRepo           repository.SavingsGoalRepository
+	contributionRepo   repository.SavingsContributionRepository
+	savingsGoalService *SavingsGoalService
+}
+
+func NewRoundUpService(
+	settingRepo repository.RoundUpSettingRepository,
+	roundUpRepo repository.RoundUpRepository,
+	goalRepo repository.SavingsGoalRepository,
+	contributionRepo repository.SavingsContributionRepository,
+	savingsGoalService *SavingsGoalService,
+) *RoundUpService {
+	return &RoundUpService{
+		settingRepo:        settingRepo,
+		roundUpRepo:        roundUpRepo,
+		goalRepo:           goalRepo,
+		contributionRepo:   contributionRepo,
+		savingsGoalService: savingsGoalService,
+	}
+}
+
+// GetSettings returns the user's round-up settings, disabled by default
+func (s *RoundUpService) GetSettings(userID uuid.UUID) (*models.RoundUpSetting, error) {
+	setting, err := s.settingRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if setting == nil {
+		setting = &models.RoundUpSetting{
+			UserID:    userID,
+			Increment: models.RoundUpIncrements[0],
+			Mode:      models.RoundUpModePerExpense,
+		}
+	}
+	return setting, nil
+}
+
+type UpdateRoundUpSettingsInput struct {
+	IsEnabled     *bool
+	Increment     *int64
+	SavingsGoalID *uuid.UUID
+	Mode          *models.RoundUpMode
+}
+
+func (s *RoundUpService) UpdateSettings(userID uuid.UUID, input UpdateRoundUpSettingsInput) (*models.RoundUpSetting, error) {
+	setting, err := s.GetSettings(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Increment != nil {
+		if !slices.Contains(models.RoundUpIncrements, *input.Increment) {
+			return nil, errors.New("invalid round-up increment")
+		}
+		setting.Increment = *input.Increment
+	}
+	if input.Mode != nil {
+		if *input.Mode != models.RoundUpModePerExpense && *input.Mode != models.RoundUpModeDaily {
+			return nil, errors.New("invalid round-up mode")
+		}
+		setting.Mode = *input.Mode
+	}
+	if input.SavingsGoalID != nil {
+		goal, err := s.goalRepo.GetByID(*input.SavingsGoalID)
+		if err != nil || goal.UserID != userID {
+			return nil, errors.New("savings goal not found")
+		}
+		if goal.Status != models.SavingsGoalStatusActive {
+			return nil, errors.New("round-ups need an active savings goal")
+		}
+		setting.SavingsGoalID = input.SavingsGoalID
+	}
+	if input.IsEnabled != nil {
+		setting.IsEnabled = *input.IsEnabled
+	}
+	if setting.IsEnabled && setting.SavingsGoalID == nil {
+		return nil, errors.New("savings goal is required to enable round-ups")
+	}
+
+	now := time.Now()
+	setting.UpdatedAt = &now
+	if setting.ID == uuid.Nil {
+		setting.ID = uuid.New()
+	}
+	if err := s.settingRepo.Save(setting); err != nil {
+		return nil, err
+	}
+
+	return s.settingRepo.GetByUserID(userID)
+}
+
+// Apply rounds up a newly created expense. It does nothing when round-ups are
+// off, the expense isn't paid from a pocket, or the goal is no longer active.
+func (s *RoundUpService) Apply(expense *models.Expense) error {
+	if expense.InstallmentID != nil || expense.PocketID == nil {
+		return nil
+	}
+	setting, err := s.settingRepo.GetByUserID(expense.UserID)
+	if err != nil || setting == nil || !setting.IsEnabled || setting.SavingsGoalID == nil {
+		return err
+	}
+	goal, err := s.goalRepo.GetByID(*setting.SavingsGoalID)
+	if err != nil || goal.Status != models.SavingsGoalStatusActive {
+		return nil
+	}
+
+	amount := models.RoundUpAmount(expense.Total(), setting.Increment)
+	if amount == 0 {
+		return nil
+	}
+
+	roundUp := &models.RoundUp{
+		ID:            uuid.New(),
+		UserID:        expense.UserID,
+		ExpenseID:     expense.ID,
+		SavingsGoalID: goal.ID,
+		PocketID:      *expense.PocketID,
+		Increment:     setting.Increment,
+		Amount:        amount,
+		Date:          roundUpDate(expense),
+	}
+	if err := s.roundUpRepo.Create(roundUp); err != nil {
+		return err
+	}
+
+	if setting.Mode == models.RoundUpModeDaily {
+		return nil
+	}
+	if err := s.contribute([]models.RoundUp{*roundUp}, roundUp.Date); err != nil {
+		_ = s.roundUpRepo.Delete(roundUp.ID)
+		return err
+	}
+	return nil
+}
+
+// Sync adjusts an expense's round-up after the expense was edited. The original
+// increment and goal are kept; a change of pocket moves the round-up to the new
+// pocket as a contribution of its own.
+func (s *RoundUpService) Sync(expense *models.Expense) error {
+	roundUp, err := s.roundUpRepo.GetByExpenseID(expense.ID)
+	if err != nil || roundUp == nil {
+		return err
+	}
+
+	amount := models.RoundUpAmount(expense.Total(), roundUp.Increment)
+	if expense.InstallmentID != nil || expense.PocketID == nil {
+		amount = 0
+	}
+	if amount == 0 {
+		return s.remove(roundUp)
+	}
+
+	pocketChanged := *expense.PocketID != roundUp.PocketID
+	if amount == roundUp.Amount && !pocketChanged {
+		return nil
+	}
+
+	saved := roundUp.ContributionID != nil
+	if saved {
+		delta := amount - roundUp.Amount
+		if pocketChanged {
+			delta = -roundUp.Amount
+		}
+		if err := s.adjustContribution(*roundUp.ContributionID, delta); err != nil {
+			return err
+		}
+		if pocketChanged {
+			roundUp.ContributionID = nil
+		}
+	}
+
+	roundUp.Amount = amount
+	roundUp.PocketID = *expense.PocketID
+	roundUp.Date = roundUpDate(expense)
+	if err := s.roundUpRepo.Update(roundUp); err != nil {
+		return err
+	}
+
+	// A saved round-up moved to another pocket is saved again right away
+	if saved && pocketChanged {
+		if err := s.contribute([]models.RoundUp{*roundUp}, roundUp.Date); err != nil {
+			log.Printf("Error saving round-up for expense %s: %v", expense.ID, err)
+			return s.roundUpRepo.Delete(roundUp.ID)
+		}
+	}
+	return nil
+}
+
+// Remove reverses the round-up of an expense that is being deleted
+func (s *RoundUpService) Remove(expense *models.Expense) error {
+	roundUp, err := s.roundUpRepo.GetByExpenseID(expense.ID)
+	if err != nil || roundUp == nil {
+		return err
+	}
+	return s.remove(roundUp)
+}
+
+func (s *RoundUpService) remove(roundUp *models.RoundUp) error {
+	if roundUp.ContributionID != nil {
+		if err := s.adjustContribution(*roundUp.ContributionID, -roundUp.Amount); err != nil {
+			return err
+		}
+	}
+	return s.roundUpRepo.Delete(roundUp.ID)
+}
+
+// adjustContribution skips contributions the user already withdrew by hand
+func (s *RoundUpService) adjustContribution(contributionID uuid.UUID, delta int64) error {
+	if _, err := s.contributionRepo.GetByID(contributionID); err != nil {
+		return nil
+	}
+	return s.savingsGoalService.AdjustContribution(contributionID, delta)
+}
+
+// RunDailyBatch saves every pending round-up created before today, one
+// contribution per goal and pocket. It returns the number of contributions made.
+func (s *RoundUpService) RunDailyBatch(now time.Time) (int, error) {
+	pending, err := s.roundUpRepo.GetPending()
+	if err != nil {
+		return 0, err
+	}
+
+	today := startOfDay(now)
+	type batchKey struct {
+		goalID   uuid.UUID
+		pocketID uuid.UUID
+	}
+	var keys []batchKey
+	batches := make(map[batchKey][]models.RoundUp)
+	for _, roundUp := range pending {
+		if !roundUp.CreatedAt.Before(today) {
+			continue
+		}
+		key := batchKey{goalID: roundUp.SavingsGoalID, pocketID: roundUp.PocketID}
+		if _, ok := batches[key]; !ok {
+			keys = append(keys, key)
+		}
+		batches[key] = append(batches[key], roundUp)
+	}
+
+	var count int
+	for _, key := range keys {
+		if err := s.contribute(batches[key], today.AddDate(0, 0, -1)); err != nil {
+			// The goal can't take contributions anymore, so nothing will be saved
+			log.Printf("Error saving round-ups for savings goal %s: %v", key.goalID, err)
+			for _, roundUp := range batches[key] {
+				_ = s.roundUpRepo.Delete(roundUp.ID)
+			}
+			continue
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// contribute saves round-ups from the same goal and pocket as one contribution
+func (s *RoundUpService) contribute(roundUps []models.RoundUp, date time.Time) error {
+	var total int64
+	ids := make([]uuid.UUID, len(roundUps))
+	for i, roundUp := range roundUps {
+		total += roundUp.Amount
+		ids[i] = roundUp.ID
+	}
+
+	notes := "Round-up"
+	if len(roundUps) > 1 {
+		notes = fmt.Sprintf("Round-up %d pengeluaran", len(roundUps))
+	}
+	pocketID := roundUps[0].PocketID
+	contribution, err := s.savingsGoalService.AddContribution(roundUps[0].SavingsGoalID, total, date, &notes, &pocketID)
+	if err != nil {
+		return err
+	}
+
+	return s.roundUpRepo.SetContribution(ids, contribution.ID)
+}
+
+type RoundUpGoalTotal struct {
+	SavingsGoalID   uuid.UUID
+	SavingsGoalName string
+	TotalSaved      int64
+}
+
+type RoundUpSummary struct {
+	TotalSaved    int64
+	PendingAmount int64
+	RoundUpCount  int
+	ByGoal        []RoundUpGoalTotal
+}
+
+// GetSummary totals the round-ups of expenses dated in the range
+func (s *RoundUpService) GetSummary(userID uuid.UUID, startDate, endDate time.Time) (*RoundUpSummary, error) {
+	roundUps, err := s.roundUpRepo.GetByUserIDAndDateRange(userID, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &RoundUpSummary{RoundUpCount: len(roundUps)}
+	goalIndex := make(map[uuid.UUID]int)
+	for _, roundUp := range roundUps {
+		if roundUp.ContributionID == nil {
+			summary.PendingAmount += roundUp.Amount
+			continue
+		}
+		summary.TotalSaved += roundUp.Amount
+
+		i, ok := goalIndex[roundUp.SavingsGoalID]
+		if !ok {
+			total := RoundUpGoalTotal{SavingsGoalID: roundUp.SavingsGoalID}
+			if roundUp.SavingsGoal != nil {
+				total.SavingsGoalName = roundUp.SavingsGoal.Name
+			}
+			i = len(summary.ByGoal)
+			goalIndex[roundUp.SavingsGoalID] = i
+			summary.ByGoal = append(summary.ByGoal, total)
+		}
+		summary.ByGoal[i].TotalSaved += roundUp.Amount
+	}
+
+	return summary, nil
+}
+
+// roundUpDate is the day an expense was spent, defaulting to today
+func roundUpDate(expense *models.Expense) time.Time {
+	if expense.ExpenseDate != nil {
+		return *expense.ExpenseDate
+	}
+	return startOfDay(time.Now())
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,299 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+)
+
+type SavingsAutoRuleInput struct {
+	Type           models.SavingsAutoRuleType
+	Amount         *int64
+	Percentage     *float64
+	SourcePocketID uuid.UUID
+	Recurrence     RecurrenceInput
+	IsActive       *bool
+}
+
+func (s *SavingsGoalService) GetAutoRules(userID, goalID uuid.UUID) ([]models.SavingsAutoRule, error) {
+	goal, err := s.goalRepo.GetByID(goalID)
+	if err != nil || goal.UserID != userID {
+		return nil, errors.New("savings goal not found")
+	}
+	return s.autoRuleRepo.GetBySavingsGoalID(goalID)
+}
+
+func (s *SavingsGoalService) GetAutoRuns(userID uuid.UUID, ruleID *uuid.UUID, limit int) ([]models.SavingsAutoRun, error) {
+	return s.autoRunRepo.GetByUserID(userID, ruleID, limit)
+}
+
+func (s *SavingsGoalService) CreateAutoRule(userID, goalID uuid.UUID, input SavingsAutoRuleInput) (*models.SavingsAutoRule, error) {
+	goal, err := s.goalRepo.GetByID(goalID)
+	if err != nil || goal.UserID != userID {
+		return nil, errors.New("savings goal not found")
+	}
+	if goal.Status != models.SavingsGoalStatusActive {
+		return nil, errors.New("cannot add auto rule to non-active savings goal")
+	}
+
+	rule := &models.SavingsAutoRule{
+		ID:            uuid.New(),
+		UserID:        userID,
+		SavingsGoalID: goalID,
+		IsActive:      true,
+	}
+	if err := s.applyAutoRuleInput(rule, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.autoRuleRepo.Create(rule); err != nil {
+		return nil, err
+	}
+
+	return s.autoRuleRepo.GetByID(rule.ID)
+}
+
+func (s *SavingsGoalService) UpdateAutoRule(userID, id uuid.UUID, input SavingsAutoRuleInput) (*models.SavingsAutoRule, error) {
+	rule, err := s.autoRuleRepo.GetByID(id)
+	if err != nil || rule.UserID != userID {
+		return nil, errors.New("savings auto rule not found")
+	}
+
+	if err := s.applyAutoRuleInput(rule, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.autoRuleRepo.Update(rule); err != nil {
+		return nil, err
+	}
+
+	return s.autoRuleRepo.GetByID(rule.ID)
+}
+
+func (s *SavingsGoalService) DeleteAutoRule(userID, id uuid.UUID) error {
+	rule, err := s.autoRuleRepo.GetByID(id)
+	if err != nil || rule.UserID != userID {
+		return errors.New("savings auto rule not found")
+	}
+
+	if err := s.autoRunRepo.DeleteByRuleID(id); err != nil {
+		return err
+	}
+
+	return s.autoRuleRepo.Delete(id)
+}
+
+func (s *SavingsGoalService) applyAutoRuleInput(rule *models.SavingsAutoRule, input SavingsAutoRuleInput) error {
+	switch input.Type {
+	case models.SavingsAutoRuleTypeFixed:
+		if input.Amount == nil || *input.Amount <= 0 {
+			return errors.New("amount must be positive")
+		}
+		rule.Amount = input.Amount
+		rule.Percentage = nil
+	case models.SavingsAutoRuleTypeIncomePercentage:
+		if input.Percentage == nil || *input.Percentage <= 0 || *input.Percentage > 100 {
+			return errors.New("percentage must be between 0 and 100")
+		}
+		rule.Percentage = input.Percentage
+		rule.Amount = nil
+	default:
+		return errors.New("invalid auto rule type")
+	}
+	rule.Type = input.Type
+
+	pocket, err := s.accountRepo.GetByID(input.SourcePocketID)
+	if err != nil || pocket.UserID != rule.UserID || !pocket.IsPocket {
+		return errors.New("invalid source pocket")
+	}
+	rule.SourcePocketID = input.SourcePocketID
+
+	recurrence, err := buildRecurrence(input.Recurrence)
+	if err != nil {
+		return err
+	}
+	if recurrence.StartDate == nil {
+		return errors.New("recurrence start date is required")
+	}
+	rule.Recurrence = recurrence
+
+	if input.IsActive != nil {
+		reactivated := !rule.IsActive && *input.IsActive
+		rule.IsActive = *input.IsActive
+		if rule.IsActive {
+			rule.PausedReason = nil
+		}
+		// Occurrences missed while the rule was paused are not caught up
+		if reactivated {
+			now := time.Now()
+			yesterday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+			if rule.LastRunDate == nil || rule.LastRunDate.Before(yesterday) {
+				rule.LastRunDate = &yesterday
+			}
+		}
+	}
+
+	return nil
+}
+
+// RunDueAutoRules executes every active rule's occurrences up to now. Missed
+// occurrences since the last run are caught up; a new or reactivated rule
+// starts from today.
+func (s *SavingsGoalService) RunDueAutoRules(now time.Time) ([]models.SavingsAutoRun, error) {
+	rules, err := s.autoRuleRepo.GetActive()
+	if err != nil {
+		return nil, err
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	var runs []models.SavingsAutoRun
+	for _, rule := range rules {
+		from := today
+		windowStart := autoRuleStart(&rule)
+		if rule.LastRunDate != nil {
+			from = rule.LastRunDate.AddDate(0, 0, 1)
+			windowStart = from
+		}
+
+		for _, scheduledDate := range rule.Occurrences(from, today) {
+			// Claim the occurrence before contributing so concurrent runs can't double-contribute
+			claimed, err := s.autoRuleRepo.MarkRun(rule.ID, scheduledDate)
+			if err != nil {
+				log.Printf("Error marking savings auto rule %s as run: %v", rule.ID, err)
+				break
+			}
+			if !claimed {
+				continue
+			}
+			rule.LastRunDate = &scheduledDate
+
+			run := s.runAutoRule(&rule, windowStart, scheduledDate)
+			if err := s.autoRunRepo.Create(&run); err != nil {
+				log.Printf("Error logging savings auto run for rule %s: %v", rule.ID, err)
+			}
+			runs = append(runs, run)
+
+			if !rule.IsActive {
+				break
+			}
+			windowStart = scheduledDate.AddDate(0, 0, 1)
+		}
+	}
+
+	return runs, nil
+}
+
+// runAutoRule contributes one occurrence of a rule and returns the run log.
+// Incomes dated from windowStart through scheduledDate feed percentage rules.
+func (s *SavingsGoalService) runAutoRule(rule *models.SavingsAutoRule, windowStart, scheduledDate time.Time) models.SavingsAutoRun {
+	run := models.SavingsAutoRun{
+		ID:            uuid.New(),
+		UserID:        rule.UserID,
+		RuleID:        rule.ID,
+		SavingsGoalID: rule.SavingsGoalID,
+		ScheduledDate: scheduledDate,
+		Rule:          rule,
+	}
+
+	goal, err := s.goalRepo.GetByID(rule.SavingsGoalID)
+	if err != nil {
+		return failAutoRun(run, err)
+	}
+	rule.SavingsGoal = goal
+
+	if goal.Status != models.SavingsGoalStatusActive || goal.RemainingAmount() == 0 {
+		reason := models.SavingsAutoRulePausedGoalInactive
+		if goal.Status == models.SavingsGoalStatusCompleted || goal.RemainingAmount() == 0 {
+			reason = models.SavingsAutoRulePausedGoalReached
+		}
+		if err := s.pauseAutoRule(rule, reason); err != nil {
+			return failAutoRun(run, err)
+		}
+		return skipAutoRun(run, models.SavingsAutoRunStatusSkipped, "savings goal is no longer active")
+	}
+
+	amount, err := s.autoRuleAmount(rule, windowStart, scheduledDate)
+	if err != nil {
+		return failAutoRun(run, err)
+	}
+	run.Amount = min(amount, goal.RemainingAmount())
+	if run.Amount <= 0 {
+		return skipAutoRun(run, models.SavingsAutoRunStatusSkipped, "no income received in this period")
+	}
+
+	pocket, err := s.accountRepo.GetByID(rule.SourcePocketID)
+	if err != nil {
+		return failAutoRun(run, err)
+	}
+	rule.SourcePocket = pocket
+	if pocket.CurrentBalance < run.Amount {
+		return skipAutoRun(run, models.SavingsAutoRunStatusInsufficientFunds, "insufficient balance in source pocket")
+	}
+
+	contribution, err := s.AddContribution(goal.ID, run.Amount, scheduledDate, nil, &rule.SourcePocketID)
+	if err != nil {
+		return failAutoRun(run, err)
+	}
+	run.Status = models.SavingsAutoRunStatusSuccess
+	run.ContributionID = &contribution.ID
+
+	if run.Amount >= goal.RemainingAmount() {
+		if err := s.pauseAutoRule(rule, models.SavingsAutoRulePausedGoalReached); err != nil {
+			log.Printf("Error pausing savings auto rule %s: %v", rule.ID, err)
+		}
+	}
+
+	return run
+}
+
+func (s *SavingsGoalService) autoRuleAmount(rule *models.SavingsAutoRule, windowStart, scheduledDate time.Time) (int64, error) {
+	if rule.Type == models.SavingsAutoRuleTypeFixed {
+		return *rule.Amount, nil
+	}
+
+	incomes, err := s.incomeRepo.GetByUserIDAndDateRange(rule.UserID, windowStart.Format("2006-01-02"), scheduledDate.Format("2006-01-02"))
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, income := range incomes {
+		total += income.Amount
+	}
+	return int64(math.Round(float64(total) * *rule.Percentage / 100)), nil
+}
+
+func (s *SavingsGoalService) pauseAutoRule(rule *models.SavingsAutoRule, reason string) error {
+	rule.IsActive = false
+	rule.PausedReason = &reason
+	return s.autoRuleRepo.Update(rule)
+}
+
+// autoRuleStart is the first day whose incomes count toward a rule
+func autoRuleStart(rule *models.SavingsAutoRule) time.Time {
+	start := rule.CreatedAt
+	if rule.Recurrence.StartDate != nil && rule.Recurrence.StartDate.After(start) {
+		start = *rule.Recurrence.StartDate
+	}
+	return time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func skipAutoRun(run models.SavingsAutoRun, status models.SavingsAutoRunStatus, reason string) models.SavingsAutoRun {
+	run.Status = status
+	run.Reason = &reason
+	return run
+}
+
+func failAutoRun(run models.SavingsAutoRun, err error) models.SavingsAutoRun {
+	reason := fmt.Sprintf("%v", err)
+	run.Status = models.SavingsAutoRunStatusFailed
+	run.Reason = &reason
+	return run
+}
@@ -13,6 +13,11 @@ import (
 type SavingsGoalService struct {
 	goalRepo         repository.SavingsGoalRepository
 	contributionRepo repository.SavingsContributionRepository
+	withdrawalRepo   repository.SavingsWithdrawalRepository
+	autoRuleRepo     repository.SavingsAutoRuleRepository
+	autoRunRepo      repository.SavingsAutoRunRepository
+	roundUpRepo      repository.RoundUpRepository
+	incomeRepo       repository.IncomeRepository
 	accountRepo      repository.AccountRepository
 	accountService   *AccountService
 	ledgerService    *LedgerService
@@ -21,6 +26,11 @@ type SavingsGoalService struct {
 func NewSavingsGoalService(
 	goalRepo repository.SavingsGoalRepository,
 	contributionRepo repository.SavingsContributionRepository,
+	withdrawalRepo repository.SavingsWithdrawalRepository,
+	autoRuleRepo repository.SavingsAutoRuleRepository,
+	autoRunRepo repository.SavingsAutoRunRepository,
+	roundUpRepo repository.RoundUpRepository,
+	incomeRepo repository.IncomeRepository,
 	accountRepo repository.AccountRepository,
 	accountService *AccountService,
 	ledgerService *LedgerService,
@@ -28,6 +38,11 @@ func NewSavingsGoalService(
 	return &SavingsGoalService{
 		goalRepo:         goalRepo,
 		contributionRepo: contributionRepo,
+		withdrawalRepo:   withdrawalRepo,
+		autoRuleRepo:     autoRuleRepo,
+		autoRunRepo:      autoRunRepo,
+		roundUpRepo:      roundUpRepo,
+		incomeRepo:       incomeRepo,
 		accountRepo:      accountRepo,
 		accountService:   accountService,
 		ledgerService:    ledgerService,
@@ -149,6 +164,23 @@ func (s *SavingsGoalService) Delete(id uuid.UUID) error {
 	for _, contribution := range goal.Contributions {
 		_ = s.ledgerService.DeleteByReference(contribution.ID, "savings_contribution")
 	}
+	for _, withdrawal := range goal.Withdrawals {
+		_ = s.ledgerService.DeleteByReference(withdrawal.ID, "savings_withdrawal")
+	}
+	if err := s.withdrawalRepo.DeleteBySavingsGoalID(id); err != nil {
+		return err
+	}
+
+	// Delete auto-contribution rules and their run log
+	if err := s.autoRunRepo.DeleteBySavingsGoalID(id); err != nil {
+		return err
+	}
+	if err := s.autoRuleRepo.DeleteBySavingsGoalID(id); err != nil {
+		return err
+	}
+	if err := s.roundUpRepo.DeleteBySavingsGoalID(id); err != nil {
+		return err
+	}
 
 	// Delete linked account
 	if err := s.accountService.DeleteAccountByReference(id, "savings_goal"); err != nil {
@@ -217,6 +249,10 @@ func (s *SavingsGoalService) WithdrawContribution(contributionID uuid.UUID) erro
 	if err != nil {
 		return err
 	}
+	// Part of it may already have been withdrawn from the goal
+	if contribution.Amount > goal.CurrentAmount {
+		return errors.New("contribution is larger than the savings left in the goal")
+	}
 
 	// Delete ledger entry
 	if err := s.ledgerService.DeleteByReference(contributionID, "savings_contribution"); err != nil {
@@ -225,9 +261,6 @@ func (s *SavingsGoalService) WithdrawContribution(contributionID uuid.UUID) erro
 
 	// Update current_amount on the goal
 	goal.CurrentAmount -= contribution.Amount
-	if goal.CurrentAmount < 0 {
-		goal.CurrentAmount = 0
-	}
 
 	// If it was completed, reactivate
 	if goal.Status == models.SavingsGoalStatusCompleted {
@@ -241,6 +274,105 @@ func (s *SavingsGoalService) WithdrawContribution(contributionID uuid.UUID) erro
 	return s.contributionRepo.Delete(contributionID)
 }
 
+// AdjustContribution changes a contribution's amount by delta, e.g. when the
+// expense behind a round-up is edited. The contribution is withdrawn when
+// nothing is left of it.
+func (s *SavingsGoalService) AdjustContribution(contributionID uuid.UUID, delta int64) error {
+	contribution, err := s.contributionRepo.GetByID(contributionID)
+	if err != nil {
+		return err
+	}
+	if contribution.Amount+delta <= 0 {
+		return s.WithdrawContribution(contributionID)
+	}
+
+	goal, err := s.goalRepo.GetByID(contribution.SavingsGoalID)
+	if err != nil {
+		return err
+	}
+	if -delta > goal.CurrentAmount {
+		return errors.New("contribution is larger than the savings left in the goal")
+	}
+
+	contribution.Amount += delta
+	if err := s.contributionRepo.Update(contribution); err != nil {
+		return err
+	}
+
+	goal.CurrentAmount += delta
+	if goal.Status == models.SavingsGoalStatusCompleted && goal.CurrentAmount < goal.TargetAmount {
+		goal.Status = models.SavingsGoalStatusActive
+	} else if goal.Status == models.SavingsGoalStatusActive && goal.CurrentAmount >= goal.TargetAmount {
+		goal.Status = models.SavingsGoalStatusCompleted
+	}
+	if err := s.goalRepo.Update(goal); err != nil {
+		return err
+	}
+
+	transaction, err := s.ledgerService.GetTransactionByReference(contributionID, "savings_contribution")
+	if err != nil {
+		return err
+	}
+	entries, err := s.contributionLedgerEntries(goal.UserID, goal, contribution)
+	if err != nil {
+		return err
+	}
+	_, err = s.ledgerService.UpdateJournalEntry(transaction.ID, contribution.ContributionDate, "Savings Contribution: "+goal.Name, entries)
+	return err
+}
+
+// WithdrawFromGoal moves part of a goal's savings back to a pocket (the default
+// pocket when pocketID is nil) and records it in the goal's history
+func (s *SavingsGoalService) WithdrawFromGoal(userID, goalID uuid.UUID, amount int64, withdrawalDate time.Time, pocketID *uuid.UUID, reason *string) (*models.SavingsWithdrawal, error) {
+	goal, err := s.goalRepo.GetByID(goalID)
+	if err != nil || goal.UserID != userID {
+		return nil, errors.New("savings goal not found")
+	}
+
+	if amount <= 0 {
+		return nil, errors.New("withdrawal amount must be positive")
+	}
+	if amount > goal.CurrentAmount {
+		return nil, errors.New("withdrawal amount exceeds current savings")
+	}
+
+	if pocketID != nil {
+		pocket, err := s.accountRepo.GetByID(*pocketID)
+		if err != nil || pocket.UserID != userID || !pocket.IsPocket {
+			return nil, errors.New("invalid target pocket")
+		}
+	}
+
+	withdrawal := &models.SavingsWithdrawal{
+		ID:             uuid.New(),
+		SavingsGoalID:  goalID,
+		Amount:         amount,
+		WithdrawalDate: withdrawalDate,
+		PocketID:       pocketID,
+		Reason:         reason,
+	}
+
+	if err := s.withdrawalRepo.Create(withdrawal); err != nil {
+		return nil, err
+	}
+
+	// Update current_amount on the goal, reopening it if it drops below target
+	goal.CurrentAmount -= amount
+	if goal.Status == models.SavingsGoalStatusCompleted && goal.CurrentAmount < goal.TargetAmount {
+		goal.Status = models.SavingsGoalStatusActive
+	}
+	if err := s.goalRepo.Update(goal); err != nil {
+		return nil, err
+	}
+
+	// Create ledger entry: DEBIT Cash Account (Asset), CREDIT Savings Account (Asset)
+	if err := s.createWithdrawalLedgerEntry(userID, goal, withdrawal); err != nil {
+		return nil, err
+	}
+
+	return s.withdrawalRepo.GetByID(withdrawal.ID)
+}
+
 func (s *SavingsGoalService) MarkComplete(id uuid.UUID) (*models.SavingsGoal, error) {
 	goal, err := s.goalRepo.GetByID(id)
 	if err != nil {
@@ -256,10 +388,29 @@ func (s *SavingsGoalService) MarkComplete(id uuid.UUID) (*models.SavingsGoal, er
 }
 
 func (s *SavingsGoalService) createContributionLedgerEntry(userID uuid.UUID, goal *models.SavingsGoal, contribution *models.SavingsContribution) error {
+	entries, err := s.contributionLedgerEntries(userID, goal, contribution)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.ledgerService.CreateJournalEntry(
+		userID,
+		contribution.ContributionDate,
+		"Savings Contribution: "+goal.Name,
+		entries,
+		&contribution.ID,
+		"savings_contribution",
+	)
+	return err
+}
+
+// contributionLedgerEntries debits the goal's savings account and credits the
+// contribution's pocket (or the default pocket)
+func (s *SavingsGoalService) contributionLedgerEntries(userID uuid.UUID, goal *models.SavingsGoal, contribution *models.SavingsContribution) ([]LedgerEntry, error) {
 	// Get savings asset account (linked to goal)
 	savingsAccount, err := s.accountRepo.GetByReference(goal.ID, "savings_goal")
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Get pocket account
@@ -270,21 +421,43 @@ func (s *SavingsGoalService) createContributionLedgerEntry(userID uuid.UUID, goa
 		pocketAccount, err = s.accountRepo.GetDefaultByUserID(userID)
 	}
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	entries := []LedgerEntry{
+	return []LedgerEntry{
 		{AccountID: savingsAccount.ID, Debit: contribution.Amount, Credit: 0},
 		{AccountID: pocketAccount.ID, Debit: 0, Credit: contribution.Amount},
+	}, nil
+}
+
+func (s *SavingsGoalService) createWithdrawalLedgerEntry(userID uuid.UUID, goal *models.SavingsGoal, withdrawal *models.SavingsWithdrawal) error {
+	savingsAccount, err := s.accountRepo.GetByReference(goal.ID, "savings_goal")
+	if err != nil {
+		return err
+	}
+
+	var pocketAccount *models.Account
+	if withdrawal.PocketID != nil {
+		pocketAccount, err = s.accountRepo.GetByID(*withdrawal.PocketID)
+	} else {
+		pocketAccount, err = s.accountRepo.GetDefaultByUserID(userID)
+	}
+	if err != nil {
+		return err
+	}
+
+	entries := []LedgerEntry{
+		{AccountID: pocketAccount.ID, Debit: withdrawal.Amount, Credit: 0},
+		{AccountID: savingsAccount.ID, Debit: 0, Credit: withdrawal.Amount},
 	}
 
 	_, err = s.ledgerService.CreateJournalEntry(
 		userID,
-		contribution.ContributionDate,
-		"Savings Contribution: "+goal.Name,
+		withdrawal.WithdrawalDate,
+		"Savings Withdrawal: "+goal.Name,
 		entries,
-		&contribution.ID,
-		"savings_contribution",
+		&withdrawal.ID,
+		"savings_withdrawal",
 	)
 	return err
 }
@@ -0,0 +1,630 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+	"github.com/azzamdhx/moneybro/backend/internal/repository"
+)
+
+type fakeSavingsGoalRepo struct {
+	repository.SavingsGoalRepository
+	goals map[uuid.UUID]models.SavingsGoal
+}
+
+func (r *fakeSavingsGoalRepo) GetByID(id uuid.UUID) (*models.SavingsGoal, error) {
+	goal, ok := r.goals[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &goal, nil
+}
+
+func (r *fakeSavingsGoalRepo) Update(goal *models.SavingsGoal) error {
+	r.goals[goal.ID] = *goal
+	return nil
+}
+
+type fakeSavingsContributionRepo struct {
+	repository.SavingsContributionRepository
+	contributions map[uuid.UUID]models.SavingsContribution
+}
+
+func (r *fakeSavingsContributionRepo) Create(contribution *models.SavingsContribution) error {
+	r.contributions[contribution.ID] = *contribution
+	return nil
+}
+
+func (r *fakeSavingsContributionRepo) GetByID(id uuid.UUID) (*models.SavingsContribution, error) {
+	contribution, ok := r.contributions[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &contribution, nil
+}
+
+func (r *fakeSavingsContributionRepo) Update(contribution *models.SavingsContribution) error {
+	r.contributions[contribution.ID] = *contribution
+	return nil
+}
+
+func (r *fakeSavingsContributionRepo) Delete(id uuid.UUID) error {
+	delete(r.contributions, id)
+	return nil
+}
+
+type fakeSavingsWithdrawalRepo struct {
+	repository.SavingsWithdrawalRepository
+	withdrawals map[uuid.UUID]models.SavingsWithdrawal
+}
+
+func (r *fakeSavingsWithdrawalRepo) Create(withdrawal *models.SavingsWithdrawal) error {
+	r.withdrawals[withdrawal.ID] = *withdrawal
+	return nil
+}
+
+func (r *fakeSavingsWithdrawalRepo) GetByID(id uuid.UUID) (*models.SavingsWithdrawal, error) {
+	withdrawal, ok := r.withdrawals[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &withdrawal, nil
+}
+
+type fakeSavingsAutoRuleRepo struct {
+	repository.SavingsAutoRuleRepository
+	rules map[uuid.UUID]models.SavingsAutoRule
+}
+
+func (r *fakeSavingsAutoRuleRepo) Create(rule *models.SavingsAutoRule) error {
+	r.rules[rule.ID] = *rule
+	return nil
+}
+
+func (r *fakeSavingsAutoRuleRepo) GetByID(id uuid.UUID) (*models.SavingsAutoRule, error) {
+	rule, ok := r.rules[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &rule, nil
+}
+
+func (r *fakeSavingsAutoRuleRepo) GetActive() ([]models.SavingsAutoRule, error) {
+	var rules []models.SavingsAutoRule
+	for _, rule := range r.rules {
+		if rule.IsActive {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+func (r *fakeSavingsAutoRuleRepo) Update(rule *models.SavingsAutoRule) error {
+	r.rules[rule.ID] = *rule
+	return nil
+}
+
+func (r *fakeSavingsAutoRuleRepo) MarkRun(id uuid.UUID, date time.Time) (bool, error) {
+	rule := r.rules[id]
+	if rule.LastRunDate != nil && !rule.LastRunDate.Before(date) {
+		return false, nil
+	}
+	rule.LastRunDate = &date
+	r.rules[id] = rule
+	return true, nil
+}
+
+type fakeSavingsAutoRunRepo struct {
+	repository.SavingsAutoRunRepository
+	runs []models.SavingsAutoRun
+}
+
+func (r *fakeSavingsAutoRunRepo) Create(run *models.SavingsAutoRun) error {
+	r.runs = append(r.runs, *run)
+	return nil
+}
+
+// savingsTest is a goal of 1.000.000 with contributions of 300.000 and
+// 500.000 from a pocket that started at 2.000.000
+type savingsTest struct {
+	service        *SavingsGoalService
+	store          *memoryStore
+	goals          *fakeSavingsGoalRepo
+	withdrawals    *fakeSavingsWithdrawalRepo
+	autoRules      *fakeSavingsAutoRuleRepo
+	autoRuns       *fakeSavingsAutoRunRepo
+	accounts       *fakeAccountRepo
+	userID         uuid.UUID
+	goalID         uuid.UUID
+	pocket         *models.Account
+	savingsAccount *models.Account
+	contributions  []*models.SavingsContribution
+}
+
+func newSavingsTest(t *testing.T) *savingsTest {
+	t.Helper()
+	ledger, accounts, store := newTestLedger(t)
+	userID := uuid.New()
+	goal := models.SavingsGoal{
+		ID:           uuid.New(),
+		UserID:       userID,
+		Name:         "Dana Darurat",
+		TargetAmount: 1000000,
+		TargetDate:   time.Now().AddDate(1, 0, 0),
+		Status:       models.SavingsGoalStatusActive,
+	}
+	goals := &fakeSavingsGoalRepo{goals: map[uuid.UUID]models.SavingsGoal{goal.ID: goal}}
+	withdrawals := &fakeSavingsWithdrawalRepo{withdrawals: make(map[uuid.UUID]models.SavingsWithdrawal)}
+	autoRules := &fakeSavingsAutoRuleRepo{rules: make(map[uuid.UUID]models.SavingsAutoRule)}
+	autoRuns := &fakeSavingsAutoRunRepo{}
+	accountService := NewAccountService(accounts)
+	savingsAccount, err := accountService.CreateLinkedAccount(userID, goal.Name, models.AccountTypeAsset, goal.ID, "savings_goal")
+	if err != nil {
+		t.Fatalf("create savings account: %v", err)
+	}
+
+	st := &savingsTest{
+		service: NewSavingsGoalService(
+			goals,
+			&fakeSavingsContributionRepo{contributions: make(map[uuid.UUID]models.SavingsContribution)},
+			withdrawals,
+			autoRules,
+			autoRuns,
+			nil,
+			&fakeIncomeRepo{},
+			accounts,
+			accountService,
+			ledger,
+		),
+		store:          store,
+		goals:          goals,
+		withdrawals:    withdrawals,
+		autoRules:      autoRules,
+		autoRuns:       autoRuns,
+		accounts:       accounts,
+		userID:         userID,
+		goalID:         goal.ID,
+		pocket:         newPocket(t, accounts, userID, 2000000, true),
+		savingsAccount: savingsAccount,
+	}
+	for _, amount := range []int64{300000, 500000} {
+		contribution, err := st.service.AddContribution(goal.ID, amount, time.Now(), nil, nil)
+		if err != nil {
+			t.Fatalf("AddContribution: %v", err)
+		}
+		st.contributions = append(st.contributions, contribution)
+	}
+	return st
+}
+
+func (st *savingsTest) goal(t *testing.T) *models.SavingsGoal {
+	t.Helper()
+	goal, err := st.goals.GetByID(st.goalID)
+	if err != nil {
+		t.Fatalf("get goal: %v", err)
+	}
+	return goal
+}
+
+func TestWithdrawFromGoal(t *testing.T) {
+	tests := []struct {
+		name string
+		// topUp is contributed before the withdrawal
+		topUp         int64
+		amount        int64
+		otherPocket   bool
+		wantErr       string
+		wantCurrent   int64
+		wantStatus    models.SavingsGoalStatus
+		wantPocket    int64
+		wantSavings   int64
+		wantWithdrawn int
+	}{
+		{
+			name:          "partial withdrawal to the default pocket",
+			amount:        200000,
+			wantCurrent:   600000,
+			wantStatus:    models.SavingsGoalStatusActive,
+			wantPocket:    1400000,
+			wantSavings:   600000,
+			wantWithdrawn: 1,
+		},
+		{
+			name:          "withdraw everything",
+			amount:        800000,
+			wantCurrent:   0,
+			wantStatus:    models.SavingsGoalStatusActive,
+			wantPocket:    2000000,
+			wantSavings:   0,
+			wantWithdrawn: 1,
+		},
+		{
+			name:          "completed goal reopens below its target",
+			topUp:         200000,
+			amount:        100000,
+			wantCurrent:   900000,
+			wantStatus:    models.SavingsGoalStatusActive,
+			wantPocket:    1100000,
+			wantSavings:   900000,
+			wantWithdrawn: 1,
+		},
+		{
+			name:        "more than the savings",
+			amount:      800001,
+			wantErr:     "exceeds current savings",
+			wantCurrent: 800000,
+			wantStatus:  models.SavingsGoalStatusActive,
+			wantPocket:  1200000,
+			wantSavings: 800000,
+		},
+		{
+			name:        "nothing",
+			amount:      0,
+			wantErr:     "must be positive",
+			wantCurrent: 800000,
+			wantStatus:  models.SavingsGoalStatusActive,
+			wantPocket:  1200000,
+			wantSavings: 800000,
+		},
+		{
+			name:        "pocket of another user",
+			amount:      100000,
+			otherPocket: true,
+			wantErr:     "invalid target pocket",
+			wantCurrent: 800000,
+			wantStatus:  models.SavingsGoalStatusActive,
+			wantPocket:  1200000,
+			wantSavings: 800000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newSavingsTest(t)
+			if tt.topUp > 0 {
+				if _, err := st.service.AddContribution(st.goalID, tt.topUp, time.Now(), nil, nil); err != nil {
+					t.Fatalf("AddContribution: %v", err)
+				}
+				if status := st.goal(t).Status; status != models.SavingsGoalStatusCompleted {
+					t.Fatalf("goal status after reaching the target = %s", status)
+				}
+			}
+			var pocketID *uuid.UUID
+			if tt.otherPocket {
+				_, accounts, _ := newTestLedger(t)
+				pocketID = &newPocket(t, accounts, uuid.New(), 0, false).ID
+			}
+
+			_, err := st.service.WithdrawFromGoal(st.userID, st.goalID, tt.amount, time.Now(), pocketID, nil)
+			if !errContains(err, tt.wantErr) {
+				t.Fatalf("WithdrawFromGoal error = %v, want %q", err, tt.wantErr)
+			}
+
+			goal := st.goal(t)
+			if goal.CurrentAmount != tt.wantCurrent {
+				t.Errorf("current amount = %d, want %d", goal.CurrentAmount, tt.wantCurrent)
+			}
+			if goal.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", goal.Status, tt.wantStatus)
+			}
+			if got := st.store.balance(st.pocket.ID); got != tt.wantPocket {
+				t.Errorf("pocket balance = %d, want %d", got, tt.wantPocket)
+			}
+			if got := st.store.balance(st.savingsAccount.ID); got != tt.wantSavings {
+				t.Errorf("savings account balance = %d, want %d", got, tt.wantSavings)
+			}
+			if got := len(st.withdrawals.withdrawals); got != tt.wantWithdrawn {
+				t.Errorf("withdrawals = %d, want %d", got, tt.wantWithdrawn)
+			}
+			if got := st.store.countByReference("savings_withdrawal"); got != tt.wantWithdrawn {
+				t.Errorf("withdrawal journal entries = %d, want %d", got, tt.wantWithdrawn)
+			}
+		})
+	}
+}
+
+func TestWithdrawContribution(t *testing.T) {
+	tests := []struct {
+		name string
+		// withdrawn is taken out of the goal before the contribution is undone
+		withdrawn    int64
+		contribution int
+		wantErr      string
+		wantCurrent  int64
+		wantPocket   int64
+		wantSavings  int64
+	}{
+		{
+			name:         "undo a contribution",
+			contribution: 1,
+			wantCurrent:  300000,
+			wantPocket:   1700000,
+			wantSavings:  300000,
+		},
+		{
+			name:         "undo after a partial withdrawal",
+			withdrawn:    400000,
+			contribution: 0,
+			wantCurrent:  100000,
+			wantPocket:   1900000,
+			wantSavings:  100000,
+		},
+		{
+			name:         "contribution already withdrawn",
+			withdrawn:    400000,
+			contribution: 1,
+			wantErr:      "larger than the savings left",
+			wantCurrent:  400000,
+			wantPocket:   1600000,
+			wantSavings:  400000,
+		},
+		{
+			name:         "goal emptied",
+			withdrawn:    800000,
+			contribution: 0,
+			wantErr:      "larger than the savings left",
+			wantCurrent:  0,
+			wantPocket:   2000000,
+			wantSavings:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newSavingsTest(t)
+			if tt.withdrawn > 0 {
+				if _, err := st.service.WithdrawFromGoal(st.userID, st.goalID, tt.withdrawn, time.Now(), nil, nil); err != nil {
+					t.Fatalf("WithdrawFromGoal: %v", err)
+				}
+			}
+			contributionID := st.contributions[tt.contribution].ID
+
+			err := st.service.WithdrawContribution(contributionID)
+			if !errContains(err, tt.wantErr) {
+				t.Fatalf("WithdrawContribution error = %v, want %q", err, tt.wantErr)
+			}
+
+			if got := st.goal(t).CurrentAmount; got != tt.wantCurrent {
+				t.Errorf("current amount = %d, want %d", got, tt.wantCurrent)
+			}
+			if got := st.store.balance(st.pocket.ID); got != tt.wantPocket {
+				t.Errorf("pocket balance = %d, want %d", got, tt.wantPocket)
+			}
+			if got := st.store.balance(st.savingsAccount.ID); got != tt.wantSavings {
+				t.Errorf("savings account balance = %d, want %d", got, tt.wantSavings)
+			}
+			// The goal's current amount and its ledger account must agree
+			if got := st.store.balance(st.savingsAccount.ID); got != st.goal(t).CurrentAmount {
+				t.Errorf("savings account balance %d differs from the goal's %d", got, st.goal(t).CurrentAmount)
+			}
+			_, lookupErr := st.service.contributionRepo.GetByID(contributionID)
+			if undone := lookupErr != nil; undone != (tt.wantErr == "") {
+				t.Errorf("contribution removed = %v, want %v", undone, tt.wantErr == "")
+			}
+		})
+	}
+}
+
+func TestRunDueAutoRulesAfterPause(t *testing.T) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		// lastRun is the last occurrence run, in days before today
+		lastRun     int
+		reactivate  bool
+		wantRuns    int
+		wantCurrent int64
+	}{
+		{name: "active rule catches up missed days", lastRun: 3, wantRuns: 3, wantCurrent: 830000},
+		{name: "reactivated rule starts from today", lastRun: 3, reactivate: true, wantRuns: 1, wantCurrent: 810000},
+		{name: "reactivated the day after its last run", lastRun: 1, reactivate: true, wantRuns: 1, wantCurrent: 810000},
+		{name: "reactivated the day it last ran", lastRun: 0, reactivate: true, wantRuns: 0, wantCurrent: 800000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newSavingsTest(t)
+			daily := "DAILY"
+			amount := int64(10000)
+			startDate := today.AddDate(0, 0, -30)
+			input := SavingsAutoRuleInput{
+				Type:           models.SavingsAutoRuleTypeFixed,
+				Amount:         &amount,
+				SourcePocketID: st.pocket.ID,
+				Recurrence:     RecurrenceInput{Frequency: &daily, StartDate: &startDate},
+			}
+			rule, err := st.service.CreateAutoRule(st.userID, st.goalID, input)
+			if err != nil {
+				t.Fatalf("CreateAutoRule: %v", err)
+			}
+			lastRun := today.AddDate(0, 0, -tt.lastRun)
+			rule.LastRunDate = &lastRun
+			if tt.reactivate {
+				reason := models.SavingsAutoRulePausedGoalReached
+				rule.IsActive = false
+				rule.PausedReason = &reason
+			}
+			st.autoRules.rules[rule.ID] = *rule
+
+			if tt.reactivate {
+				active := true
+				input.IsActive = &active
+				if _, err := st.service.UpdateAutoRule(st.userID, rule.ID, input); err != nil {
+					t.Fatalf("UpdateAutoRule: %v", err)
+				}
+			}
+
+			runs, err := st.service.RunDueAutoRules(now)
+			if err != nil {
+				t.Fatalf("RunDueAutoRules: %v", err)
+			}
+			if len(runs) != tt.wantRuns {
+				t.Errorf("runs = %d, want %d", len(runs), tt.wantRuns)
+			}
+			if got := st.goal(t).CurrentAmount; got != tt.wantCurrent {
+				t.Errorf("current amount = %d, want %d", got, tt.wantCurrent)
+			}
+		})
+	}
+}
+
+func TestRunDueAutoRules(t *testing.T) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		ruleType   models.SavingsAutoRuleType
+		amount     int64
+		percentage float64
+		// pocketBalance, when set, funds the rule from a new pocket
+		pocketBalance int64
+		incomes       map[int]int64
+		goalStatus    models.SavingsGoalStatus
+		// lastRun is the last occurrence run, in days before today
+		lastRun      int
+		wantStatuses []models.SavingsAutoRunStatus
+		wantAmount   int64
+		wantCurrent  int64
+		wantPaused   string
+	}{
+		{
+			name:         "fixed amount",
+			ruleType:     models.SavingsAutoRuleTypeFixed,
+			amount:       100000,
+			lastRun:      1,
+			wantStatuses: []models.SavingsAutoRunStatus{models.SavingsAutoRunStatusSuccess},
+			wantAmount:   100000,
+			wantCurrent:  900000,
+		},
+		{
+			name:         "capped at what the goal still needs",
+			ruleType:     models.SavingsAutoRuleTypeFixed,
+			amount:       300000,
+			lastRun:      3,
+			wantStatuses: []models.SavingsAutoRunStatus{models.SavingsAutoRunStatusSuccess},
+			wantAmount:   200000,
+			wantCurrent:  1000000,
+			wantPaused:   models.SavingsAutoRulePausedGoalReached,
+		},
+		{
+			name:          "source pocket without enough money",
+			ruleType:      models.SavingsAutoRuleTypeFixed,
+			amount:        100000,
+			pocketBalance: 50000,
+			lastRun:       1,
+			wantStatuses:  []models.SavingsAutoRunStatus{models.SavingsAutoRunStatusInsufficientFunds},
+			wantAmount:    100000,
+			wantCurrent:   800000,
+		},
+		{
+			name:         "percentage of the income since the last run",
+			ruleType:     models.SavingsAutoRuleTypeIncomePercentage,
+			percentage:   10,
+			incomes:      map[int]int64{0: 1000000, 1: 500000},
+			lastRun:      1,
+			wantStatuses: []models.SavingsAutoRunStatus{models.SavingsAutoRunStatusSuccess},
+			wantAmount:   100000,
+			wantCurrent:  900000,
+		},
+		{
+			name:         "percentage without income",
+			ruleType:     models.SavingsAutoRuleTypeIncomePercentage,
+			percentage:   10,
+			incomes:      map[int]int64{1: 500000},
+			lastRun:      1,
+			wantStatuses: []models.SavingsAutoRunStatus{models.SavingsAutoRunStatusSkipped},
+			wantCurrent:  800000,
+		},
+		{
+			name:         "cancelled goal pauses the rule",
+			ruleType:     models.SavingsAutoRuleTypeFixed,
+			amount:       100000,
+			goalStatus:   models.SavingsGoalStatusCancelled,
+			lastRun:      3,
+			wantStatuses: []models.SavingsAutoRunStatus{models.SavingsAutoRunStatusSkipped},
+			wantCurrent:  800000,
+			wantPaused:   models.SavingsAutoRulePausedGoalInactive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newSavingsTest(t)
+			incomes := &fakeIncomeRepo{}
+			for daysAgo, amount := range tt.incomes {
+				incomes.incomes = append(incomes.incomes, models.Income{ID: uuid.New(), UserID: st.userID, Amount: amount, IncomeDate: today.AddDate(0, 0, -daysAgo)})
+			}
+			st.service.incomeRepo = incomes
+			pocketID := st.pocket.ID
+			if tt.pocketBalance > 0 {
+				pocketID = newPocket(t, st.accounts, st.userID, tt.pocketBalance, false).ID
+			}
+
+			daily := "DAILY"
+			startDate := today.AddDate(0, 0, -30)
+			input := SavingsAutoRuleInput{
+				Type:           tt.ruleType,
+				SourcePocketID: pocketID,
+				Recurrence:     RecurrenceInput{Frequency: &daily, StartDate: &startDate},
+			}
+			if tt.ruleType == models.SavingsAutoRuleTypeFixed {
+				input.Amount = &tt.amount
+			} else {
+				input.Percentage = &tt.percentage
+			}
+			rule, err := st.service.CreateAutoRule(st.userID, st.goalID, input)
+			if err != nil {
+				t.Fatalf("CreateAutoRule: %v", err)
+			}
+			lastRun := today.AddDate(0, 0, -tt.lastRun)
+			rule.LastRunDate = &lastRun
+			st.autoRules.rules[rule.ID] = *rule
+
+			// The goal changes after the rule was set up
+			if tt.goalStatus != "" {
+				goal := st.goal(t)
+				goal.Status = tt.goalStatus
+				st.goals.goals[goal.ID] = *goal
+			}
+
+			runs, err := st.service.RunDueAutoRules(now)
+			if err != nil {
+				t.Fatalf("RunDueAutoRules: %v", err)
+			}
+			var statuses []models.SavingsAutoRunStatus
+			for _, run := range runs {
+				statuses = append(statuses, run.Status)
+			}
+			if len(statuses) != len(tt.wantStatuses) {
+				t.Fatalf("run statuses = %v, want %v", statuses, tt.wantStatuses)
+			}
+			for i := range statuses {
+				if statuses[i] != tt.wantStatuses[i] {
+					t.Fatalf("run statuses = %v, want %v", statuses, tt.wantStatuses)
+				}
+			}
+			if got := runs[0].Amount; got != tt.wantAmount {
+				t.Errorf("run amount = %d, want %d", got, tt.wantAmount)
+			}
+			if len(st.autoRuns.runs) != len(runs) {
+				t.Errorf("logged %d runs, want %d", len(st.autoRuns.runs), len(runs))
+			}
+			if got := st.goal(t).CurrentAmount; got != tt.wantCurrent {
+				t.Errorf("current amount = %d, want %d", got, tt.wantCurrent)
+			}
+
+			saved := st.autoRules.rules[rule.ID]
+			if saved.IsActive != (tt.wantPaused == "") {
+				t.Errorf("rule active = %v, want %v", saved.IsActive, tt.wantPaused == "")
+			}
+			if tt.wantPaused != "" && (saved.PausedReason == nil || *saved.PausedReason != tt.wantPaused) {
+				t.Errorf("paused reason = %v, want %s", saved.PausedReason, tt.wantPaused)
+			}
+		})
+	}
+}
@@ -38,6 +38,15 @@ type Services struct {
 	ActualPayments       *ActualPaymentsService
 	SavingsGoal          *SavingsGoalService
 	MonthlySummary       *MonthlySummaryService
+	Payee                *PayeeService
+	Holiday              *HolidayService
+	Subscription         *SubscriptionService
+	Budget               *BudgetService
+	Envelope             *EnvelopeService
+	PayoffPlan           *PayoffPlanService
+	Overdue              *OverdueService
+	Consolidation        *ConsolidationService
+	RoundUp              *RoundUpService
 }
 
 func NewServices(cfg Config) *Services {
@@ -46,29 +55,48 @@ func NewServices(cfg Config) *Services {
 	ledgerService := NewLedgerService(cfg.DB, cfg.Repos.Account, cfg.Repos.Transaction, cfg.Repos.TransactionEntry)
 
 	// Create services that will be dependencies for others
-	incomeService := NewIncomeService(cfg.Repos.Income, cfg.Repos.IncomeCategory, cfg.Repos.Account, ledgerService)
-	expenseService := NewExpenseService(cfg.Repos.Expense, cfg.Repos.Category, cfg.Repos.Account, ledgerService)
+	incomeService := NewIncomeService(cfg.Repos.Income, cfg.Repos.IncomeCategory, cfg.Repos.Account, cfg.Repos.Payee, ledgerService)
+	expenseService := NewExpenseService(cfg.Repos.Expense, cfg.Repos.ExpenseRefund, cfg.Repos.Category, cfg.Repos.Account, cfg.Repos.Payee, ledgerService)
+	budgetService := NewBudgetService(cfg.Repos.Budget, cfg.Repos.Category, cfg.Repos.TransactionEntry)
+	expenseTemplateGroupService := NewExpenseTemplateGroupService(cfg.Repos.ExpenseTemplateGroup, expenseService, cfg.Repos.Category)
+	overdueService := NewOverdueService(cfg.Repos.Installment, cfg.Repos.Debt, cfg.Repos.Category, cfg.Repos.Account, cfg.Repos.LateFeeRule, cfg.Repos.LateFeeCharge, expenseService)
+	notificationService := NewNotificationService(cfg.Repos, emailService, budgetService, overdueService)
+	expenseService.budgetAlerts = notificationService
+	installmentService := NewInstallmentService(cfg.Repos.Installment, cfg.Repos.InstallmentPayment, cfg.Repos.InstallmentPrepayment, cfg.Repos.InstallmentTerm, cfg.Repos.DebtConsolidation, cfg.Repos.Account, accountService, ledgerService, expenseService)
+	savingsGoalService := NewSavingsGoalService(cfg.Repos.SavingsGoal, cfg.Repos.SavingsContribution, cfg.Repos.SavingsWithdrawal, cfg.Repos.SavingsAutoRule, cfg.Repos.SavingsAutoRun, cfg.Repos.RoundUp, cfg.Repos.Income, cfg.Repos.Account, accountService, ledgerService)
+	roundUpService := NewRoundUpService(cfg.Repos.RoundUpSetting, cfg.Repos.RoundUp, cfg.Repos.SavingsGoal, cfg.Repos.SavingsContribution, savingsGoalService)
+	expenseService.roundUps = roundUpService
+	debtService := NewDebtService(cfg.Repos.Debt, cfg.Repos.DebtPayment, cfg.Repos.DebtInterestAccrual, cfg.Repos.DebtConsolidation, cfg.Repos.Account, cfg.Repos.Payee, accountService, ledgerService)
 
 	return &Services{
 		Auth:                 NewAuthService(cfg.Repos.User, cfg.Repos.PasswordResetToken, cfg.Repos.TwoFACode, cfg.Repos.RefreshToken, emailService, cfg.JWTSecret, cfg.FrontendURL, accountService),
 		User:                 NewUserService(cfg.Repos.User),
 		Category:             NewCategoryService(cfg.Repos.Category, accountService),
 		Expense:              expenseService,
-		ExpenseTemplateGroup: NewExpenseTemplateGroupService(cfg.Repos.ExpenseTemplateGroup, expenseService, cfg.Repos.Category),
-		Installment:          NewInstallmentService(cfg.Repos.Installment, cfg.Repos.InstallmentPayment, cfg.Repos.Account, accountService, ledgerService),
-		Debt:                 NewDebtService(cfg.Repos.Debt, cfg.Repos.DebtPayment, cfg.Repos.Account, accountService, ledgerService),
-		Dashboard:            NewDashboardService(cfg.Repos, cfg.Redis, ledgerService),
+		ExpenseTemplateGroup: expenseTemplateGroupService,
+		Installment:          installmentService,
+		Debt:                 debtService,
+		Dashboard:            NewDashboardService(cfg.Repos, cfg.Redis, ledgerService, budgetService),
 		Email:                emailService,
-		Notification:         NewNotificationService(cfg.Repos, emailService),
+		Notification:         notificationService,
 		IncomeCategory:       NewIncomeCategoryService(cfg.Repos.IncomeCategory, accountService),
 		Income:               incomeService,
-		RecurringIncome:      NewRecurringIncomeGroupService(cfg.Repos.RecurringIncomeGroup, incomeService, cfg.Repos.IncomeCategory),
+		RecurringIncome:      NewRecurringIncomeGroupService(cfg.Repos.RecurringIncomeGroup, incomeService, cfg.Repos.IncomeCategory, cfg.Repos.Account, cfg.Repos.Holiday, cfg.Repos.RecurringIncomeRun),
 		Balance:              NewBalanceService(cfg.Repos, ledgerService),
 		Account:              accountService,
 		Ledger:               ledgerService,
 		UpcomingPayments:     NewUpcomingPaymentsService(cfg.Repos),
 		ActualPayments:       NewActualPaymentsService(cfg.Repos),
-		SavingsGoal:          NewSavingsGoalService(cfg.Repos.SavingsGoal, cfg.Repos.SavingsContribution, cfg.Repos.Account, accountService, ledgerService),
+		SavingsGoal:          savingsGoalService,
 		MonthlySummary:       NewMonthlySummaryService(cfg.Repos, NewUpcomingPaymentsService(cfg.Repos), NewActualPaymentsService(cfg.Repos)),
+		Payee:                NewPayeeService(cfg.Repos.Payee, cfg.Repos.Expense, cfg.Repos.Category, cfg.Repos.Account),
+		Holiday:              NewHolidayService(cfg.Repos.Holiday),
+		Subscription:         NewSubscriptionService(cfg.Repos.Expense, cfg.Repos.Payee, cfg.Repos.ExpenseTemplateGroup, expenseTemplateGroupService),
+		Budget:               budgetService,
+		Envelope:             NewEnvelopeService(cfg.Repos.EnvelopeAssignment, cfg.Repos.Category, cfg.Repos.Account, cfg.Repos.TransactionEntry, cfg.Repos.Income),
+		PayoffPlan:           NewPayoffPlanService(cfg.Repos.Installment, cfg.Repos.Debt, cfg.Repos.PayoffPlan),
+		Overdue:              overdueService,
+		Consolidation:        NewConsolidationService(cfg.Repos.DebtConsolidation, cfg.Repos.Installment, cfg.Repos.InstallmentPrepayment, cfg.Repos.Debt, cfg.Repos.DebtPayment, cfg.Repos.Account, installmentService, debtService, ledgerService),
+		RoundUp:              roundUpService,
 	}
 }
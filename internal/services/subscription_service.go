@@ -0,0 +1,370 @@
+package services
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/azzamdhx/moneybro/backend/internal/models"
+	"github.com/azzamdhx/moneybro/backend/internal/repository"
+)
+
+type SubscriptionCadence string
+
+const (
+	SubscriptionCadenceWeekly  SubscriptionCadence = "WEEKLY"
+	SubscriptionCadenceMonthly SubscriptionCadence = "MONTHLY"
+	SubscriptionCadenceYearly  SubscriptionCadence = "YEARLY"
+)
+
+const (
+	// subscriptionLookbackMonths is how much expense history is scanned
+	subscriptionLookbackMonths = 25
+	// subscriptionAmountTolerance is how far a charge may drift from the typical
+	// amount (price changes, exchange rates) and still count as the same subscription
+	subscriptionAmountTolerance = 0.2
+	// subscriptionIntervalMatchRatio is the share of gaps that must fit the cadence
+	subscriptionIntervalMatchRatio = 0.75
+)
+
+type subscriptionCadenceRule struct {
+	cadence    SubscriptionCadence
+	minDays    int
+	maxDays    int
+	minCharges int
+	perYear    int64
+	frequency  models.RecurrenceFrequency
+	// advance returns the n-th charge date after t, keeping t's day of month
+	advance func(t time.Time, n int) time.Time
+}
+
+var subscriptionCadences = []subscriptionCadenceRule{
+	{
+		cadence:    SubscriptionCadenceWeekly,
+		minDays:    6,
+		maxDays:    8,
+		minCharges: 4,
+		perYear:    52,
+		frequency:  models.RecurrenceFrequencyWeekly,
+		advance:    func(t time.Time, n int) time.Time { return t.AddDate(0, 0, 7*n) },
+	},
+	{
+		cadence:    SubscriptionCadenceMonthly,
+		minDays:    27,
+		maxDays:    33,
+		minCharges: 3,
+		perYear:    12,
+		frequency:  models.RecurrenceFrequencyMonthly,
+		advance:    func(t time.Time, n int) time.Time { return calculateDueDate(t.Day(), int(t.Month())+n, t.Year()) },
+	},
+	{
+		cadence:    SubscriptionCadenceYearly,
+		minDays:    355,
+		maxDays:    375,
+		minCharges: 2,
+		perYear:    1,
+		frequency:  models.RecurrenceFrequencyYearly,
+		advance:    func(t time.Time, n int) time.Time { return calculateDueDate(t.Day(), int(t.Month()), t.Year()+n) },
+	},
+}
+
+type SubscriptionService struct {
+	expenseRepo     repository.ExpenseRepository
+	payeeRepo       repository.PayeeRepository
+	templateRepo    repository.ExpenseTemplateGroupRepository
+	templateService *ExpenseTemplateGroupService
+}
+
+func NewSubscriptionService(
+	expenseRepo repository.ExpenseRepository,
+	payeeRepo repository.PayeeRepository,
+	templateRepo repository.ExpenseTemplateGroupRepository,
+	templateService *ExpenseTemplateGroupService,
+) *SubscriptionService {
+	return &SubscriptionService{
+		expenseRepo:     expenseRepo,
+		payeeRepo:       payeeRepo,
+		templateRepo:    templateRepo,
+		templateService: templateService,
+	}
+}
+
+// SubscriptionCandidate is a repeating charge found in the expense history.
+// Key identifies it across calls: "payee:<id>" or "item:<lowercased item name>".
+type SubscriptionCandidate struct {
+	Key              string
+	Name             string
+	Payee            *models.Payee
+	Category         *models.Category
+	Cadence          SubscriptionCadence
+	ChargeCount      int
+	AverageAmount    int64
+	LastAmount       int64
+	LastChargeDate   time.Time
+	NextExpectedDate time.Time
+	AnnualizedCost   int64
+}
+
+type ConvertSubscriptionInput struct {
+	Key              string
+	Name             *string
+	AutoPost         bool
+	NotifyOnAutoPost bool
+}
+
+type subscriptionCharge struct {
+	date     time.Time
+	amount   int64
+	itemName string
+	category *models.Category
+}
+
+// DetectSubscriptions scans the expense history for charges that repeat weekly,
+// monthly or yearly with a similar amount. Expenses are grouped by payee, or by
+// item name when no payee is set. Charges already covered by an expense template
+// and subscriptions that seem cancelled (two periods without a charge) are left out.
+func (s *SubscriptionService) DetectSubscriptions(userID uuid.UUID) ([]SubscriptionCandidate, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	start := today.AddDate(0, -subscriptionLookbackMonths, 0)
+
+	expenses, err := s.expenseRepo.GetByUserIDAndDateRange(userID, start.Format("2006-01-02"), today.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+
+	payees, err := s.payeeRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	payeeByID := make(map[uuid.UUID]*models.Payee, len(payees))
+	for i := range payees {
+		payeeByID[payees[i].ID] = &payees[i]
+	}
+
+	templated, err := s.templatedItemNames(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Group charges by payee or item name, merging same-day charges
+	charges := make(map[string]map[time.Time]*subscriptionCharge)
+	for _, exp := range expenses {
+		if exp.ExpenseDate == nil || exp.NetTotal() <= 0 {
+			continue
+		}
+		key := subscriptionKey(exp.PayeeID, exp.ItemName)
+		if charges[key] == nil {
+			charges[key] = make(map[time.Time]*subscriptionCharge)
+		}
+		date := time.Date(exp.ExpenseDate.Year(), exp.ExpenseDate.Month(), exp.ExpenseDate.Day(), 0, 0, 0, 0, time.UTC)
+		if c, ok := charges[key][date]; ok {
+			c.amount += exp.NetTotal()
+			continue
+		}
+		charges[key][date] = &subscriptionCharge{
+			date:     date,
+			amount:   exp.NetTotal(),
+			itemName: exp.ItemName,
+			category: exp.Category,
+		}
+	}
+
+	var candidates []SubscriptionCandidate
+	for key, byDate := range charges {
+		list := make([]subscriptionCharge, 0, len(byDate))
+		for _, c := range byDate {
+			list = append(list, *c)
+		}
+		sort.Slice(list, func(i, j int) bool { return list[i].date.Before(list[j].date) })
+
+		last := list[len(list)-1]
+		if templated[strings.ToLower(strings.TrimSpace(last.itemName))] {
+			continue
+		}
+
+		candidate, ok := detectCadence(list, today)
+		if !ok {
+			continue
+		}
+		candidate.Key = key
+		candidate.Name = last.itemName
+		if strings.HasPrefix(key, "payee:") {
+			if id, err := uuid.Parse(strings.TrimPrefix(key, "payee:")); err == nil {
+				if payee, ok := payeeByID[id]; ok {
+					candidate.Payee = payee
+					candidate.Name = payee.Name
+				}
+			}
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].AnnualizedCost != candidates[j].AnnualizedCost {
+			return candidates[i].AnnualizedCost > candidates[j].AnnualizedCost
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+	return candidates, nil
+}
+
+// ConvertToTemplate turns a detected subscription into an expense template group
+// that recurs on the subscription's cadence, anchored at its last charge
+func (s *SubscriptionService) ConvertToTemplate(userID uuid.UUID, input ConvertSubscriptionInput) (*models.ExpenseTemplateGroup, error) {
+	candidates, err := s.DetectSubscriptions(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidate *SubscriptionCandidate
+	for i := range candidates {
+		if candidates[i].Key == input.Key {
+			candidate = &candidates[i]
+			break
+		}
+	}
+	if candidate == nil {
+		return nil, errors.New("subscription not found")
+	}
+	if candidate.Category == nil {
+		return nil, errors.New("subscription has no category")
+	}
+
+	name := candidate.Name
+	if input.Name != nil && strings.TrimSpace(*input.Name) != "" {
+		name = strings.TrimSpace(*input.Name)
+	}
+
+	return s.templateService.Create(userID, CreateExpenseTemplateGroupInput{
+		Name:             name,
+		Recurrence:       subscriptionRecurrence(candidate.Cadence, candidate.LastChargeDate),
+		AutoPost:         input.AutoPost,
+		NotifyOnAutoPost: input.NotifyOnAutoPost,
+		Items: []CreateExpenseTemplateItemInput{
+			{
+				CategoryID: candidate.Category.ID,
+				ItemName:   candidate.Name,
+				UnitPrice:  candidate.LastAmount,
+				Quantity:   1,
+			},
+		},
+	})
+}
+
+func (s *SubscriptionService) templatedItemNames(userID uuid.UUID) (map[string]bool, error) {
+	groups, err := s.templateRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool)
+	for _, group := range groups {
+		if group.Schedule() == nil {
+			continue
+		}
+		for _, item := range group.Items {
+			names[strings.ToLower(strings.TrimSpace(item.ItemName))] = true
+		}
+	}
+	return names, nil
+}
+
+func subscriptionKey(payeeID *uuid.UUID, itemName string) string {
+	if payeeID != nil {
+		return "payee:" + payeeID.String()
+	}
+	return "item:" + strings.ToLower(strings.Join(strings.Fields(itemName), " "))
+}
+
+// detectCadence checks whether date-sorted charges repeat on a known cadence
+// with a similar amount, and summarizes them if so
+func detectCadence(charges []subscriptionCharge, today time.Time) (SubscriptionCandidate, bool) {
+	if len(charges) < 2 {
+		return SubscriptionCandidate{}, false
+	}
+
+	gaps := make([]int, len(charges)-1)
+	for i := 1; i < len(charges); i++ {
+		gaps[i-1] = int(charges[i].date.Sub(charges[i-1].date).Hours() / 24)
+	}
+	sortedGaps := append([]int(nil), gaps...)
+	sort.Ints(sortedGaps)
+	medianGap := sortedGaps[len(sortedGaps)/2]
+
+	for _, rule := range subscriptionCadences {
+		if medianGap < rule.minDays || medianGap > rule.maxDays || len(charges) < rule.minCharges {
+			continue
+		}
+
+		matched := 0
+		for _, gap := range gaps {
+			if gap >= rule.minDays && gap <= rule.maxDays {
+				matched++
+			}
+		}
+		if float64(matched) < float64(len(gaps))*subscriptionIntervalMatchRatio {
+			return SubscriptionCandidate{}, false
+		}
+
+		amounts := make([]int64, len(charges))
+		var total int64
+		for i, c := range charges {
+			amounts[i] = c.amount
+			total += c.amount
+		}
+		sort.Slice(amounts, func(i, j int) bool { return amounts[i] < amounts[j] })
+		median := float64(amounts[len(amounts)/2])
+		for _, amount := range amounts {
+			if diff := float64(amount) - median; diff > median*subscriptionAmountTolerance || -diff > median*subscriptionAmountTolerance {
+				return SubscriptionCandidate{}, false
+			}
+		}
+
+		last := charges[len(charges)-1]
+		// Two missed charges in a row means it was most likely cancelled
+		if today.After(rule.advance(last.date, 2)) {
+			return SubscriptionCandidate{}, false
+		}
+		next := rule.advance(last.date, 1)
+		if next.Before(today) {
+			next = rule.advance(last.date, 2)
+		}
+
+		average := total / int64(len(charges))
+		return SubscriptionCandidate{
+			Category:         last.category,
+			Cadence:          rule.cadence,
+			ChargeCount:      len(charges),
+			AverageAmount:    average,
+			LastAmount:       last.amount,
+			LastChargeDate:   last.date,
+			NextExpectedDate: next,
+			AnnualizedCost:   average * rule.perYear,
+		}, true
+	}
+	return SubscriptionCandidate{}, false
+}
+
+func subscriptionRecurrence(cadence SubscriptionCadence, last time.Time) *RecurrenceInput {
+	start := last.AddDate(0, 0, 1)
+	input := &RecurrenceInput{StartDate: &start}
+	for _, rule := range subscriptionCadences {
+		if rule.cadence == cadence {
+			frequency := string(rule.frequency)
+			input.Frequency = &frequency
+		}
+	}
+	switch cadence {
+	case SubscriptionCadenceWeekly:
+		input.ByWeekday = []string{strings.ToUpper(last.Weekday().String()[:2])}
+	case SubscriptionCadenceMonthly:
+		input.ByMonthDay = []int{last.Day()}
+	case SubscriptionCadenceYearly:
+		input.ByMonth = []int{int(last.Month())}
+		input.ByMonthDay = []int{last.Day()}
+	}
+	return input
+}
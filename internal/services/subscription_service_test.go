@@ -0,0 +1,153 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDetectCadence(t *testing.T) {
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	}
+	charges := func(amounts []int64, dates ...time.Time) []subscriptionCharge {
+		var list []subscriptionCharge
+		for i, date := range dates {
+			list = append(list, subscriptionCharge{date: date, amount: amounts[i%len(amounts)], itemName: "Netflix"})
+		}
+		return list
+	}
+	monthly := charges([]int64{50000}, day(2026, time.January, 15), day(2026, time.February, 15), day(2026, time.March, 15), day(2026, time.April, 15))
+
+	tests := []struct {
+		name        string
+		charges     []subscriptionCharge
+		today       time.Time
+		wantFound   bool
+		wantCadence SubscriptionCadence
+		wantAverage int64
+		wantNext    string
+		wantAnnual  int64
+	}{
+		{
+			name:        "monthly",
+			charges:     monthly,
+			today:       day(2026, time.May, 1),
+			wantFound:   true,
+			wantCadence: SubscriptionCadenceMonthly,
+			wantAverage: 50000,
+			wantNext:    "2026-05-15",
+			wantAnnual:  600000,
+		},
+		{
+			name:        "one missed charge expects the next one",
+			charges:     monthly,
+			today:       day(2026, time.May, 20),
+			wantFound:   true,
+			wantCadence: SubscriptionCadenceMonthly,
+			wantAverage: 50000,
+			wantNext:    "2026-06-15",
+			wantAnnual:  600000,
+		},
+		{
+			name:    "two missed charges means cancelled",
+			charges: monthly,
+			today:   day(2026, time.June, 16),
+		},
+		{
+			name:        "end of the month across February",
+			charges:     charges([]int64{50000}, day(2026, time.January, 31), day(2026, time.February, 28), day(2026, time.March, 31)),
+			today:       day(2026, time.April, 10),
+			wantFound:   true,
+			wantCadence: SubscriptionCadenceMonthly,
+			wantAverage: 50000,
+			wantNext:    "2026-04-30",
+			wantAnnual:  600000,
+		},
+		{
+			name:        "small price changes are allowed",
+			charges:     charges([]int64{50000, 55000, 50000}, day(2026, time.January, 15), day(2026, time.February, 15), day(2026, time.March, 15)),
+			today:       day(2026, time.March, 20),
+			wantFound:   true,
+			wantCadence: SubscriptionCadenceMonthly,
+			wantAverage: 51666,
+			wantNext:    "2026-04-15",
+			wantAnnual:  619992,
+		},
+		{
+			name:    "amounts too far apart",
+			charges: charges([]int64{50000, 50000, 65000}, day(2026, time.January, 15), day(2026, time.February, 15), day(2026, time.March, 15)),
+			today:   day(2026, time.March, 20),
+		},
+		{
+			name:    "irregular gaps",
+			charges: charges([]int64{50000}, day(2026, time.January, 1), day(2026, time.January, 30), day(2026, time.March, 20), day(2026, time.April, 18)),
+			today:   day(2026, time.April, 20),
+		},
+		{
+			name:        "weekly",
+			charges:     charges([]int64{20000}, day(2026, time.April, 3), day(2026, time.April, 10), day(2026, time.April, 17), day(2026, time.April, 24)),
+			today:       day(2026, time.April, 25),
+			wantFound:   true,
+			wantCadence: SubscriptionCadenceWeekly,
+			wantAverage: 20000,
+			wantNext:    "2026-05-01",
+			wantAnnual:  1040000,
+		},
+		{
+			name:    "too few weekly charges",
+			charges: charges([]int64{20000}, day(2026, time.April, 10), day(2026, time.April, 17), day(2026, time.April, 24)),
+			today:   day(2026, time.April, 25),
+		},
+		{
+			name:        "yearly",
+			charges:     charges([]int64{120000}, day(2025, time.March, 1), day(2026, time.March, 1)),
+			today:       day(2026, time.April, 1),
+			wantFound:   true,
+			wantCadence: SubscriptionCadenceYearly,
+			wantAverage: 120000,
+			wantNext:    "2027-03-01",
+			wantAnnual:  120000,
+		},
+		{
+			name:    "single charge",
+			charges: charges([]int64{50000}, day(2026, time.April, 15)),
+			today:   day(2026, time.April, 20),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidate, found := detectCadence(tt.charges, tt.today)
+			if found != tt.wantFound {
+				t.Fatalf("found = %v, want %v", found, tt.wantFound)
+			}
+			if !found {
+				return
+			}
+			if candidate.Cadence != tt.wantCadence {
+				t.Errorf("cadence = %s, want %s", candidate.Cadence, tt.wantCadence)
+			}
+			if candidate.ChargeCount != len(tt.charges) {
+				t.Errorf("charge count = %d, want %d", candidate.ChargeCount, len(tt.charges))
+			}
+			if candidate.AverageAmount != tt.wantAverage {
+				t.Errorf("average amount = %d, want %d", candidate.AverageAmount, tt.wantAverage)
+			}
+			if got := candidate.NextExpectedDate.Format("2006-01-02"); got != tt.wantNext {
+				t.Errorf("next expected date = %s, want %s", got, tt.wantNext)
+			}
+			if candidate.AnnualizedCost != tt.wantAnnual {
+				t.Errorf("annualized cost = %d, want %d", candidate.AnnualizedCost, tt.wantAnnual)
+			}
+		})
+	}
+}
+
+func TestSubscriptionKey(t *testing.T) {
+	if got, want := subscriptionKey(nil, "  Netflix   Premium "), "item:netflix premium"; got != want {
+		t.Errorf("subscriptionKey = %q, want %q", got, want)
+	}
+	if got, want := subscriptionKey(nil, "NETFLIX premium"), subscriptionKey(nil, "netflix Premium"); got != want {
+		t.Errorf("item names differing in case give %q and %q", got, want)
+	}
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -38,18 +39,49 @@ type UpcomingDebtPayment struct {
 	PaymentType     string
 }
 
+// UpcomingRecurringItem is one scheduled occurrence of an expense template or
+// recurring income group
+type UpcomingRecurringItem struct {
+	GroupID uuid.UUID
+	Name    string
+	Date    time.Time
+	Amount  int64
+}
+
 type UpcomingPaymentsReport struct {
 	Installments     []UpcomingInstallmentPayment
 	Debts            []UpcomingDebtPayment
 	TotalInstallment int64
 	TotalDebt        int64
 	TotalPayments    int64
+
+	// Receivables are repayments expected from people the user lent money to
+	Receivables     []UpcomingDebtPayment
+	TotalReceivable int64
+
+	RecurringExpenses     []UpcomingRecurringItem
+	RecurringIncomes      []UpcomingRecurringItem
+	TotalRecurringExpense int64
+	TotalRecurringIncome  int64
+}
+
+type recurringExpenseOccurrence struct {
+	Group models.ExpenseTemplateGroup
+	Date  time.Time
+}
+
+type recurringIncomeOccurrence struct {
+	Group models.RecurringIncomeGroup
+	Date  time.Time
 }
 
 func (s *UpcomingPaymentsService) GetUpcomingPayments(userID uuid.UUID, month, year int) (*UpcomingPaymentsReport, error) {
 	report := &UpcomingPaymentsReport{
-		Installments: []UpcomingInstallmentPayment{},
-		Debts:        []UpcomingDebtPayment{},
+		Installments:      []UpcomingInstallmentPayment{},
+		Debts:             []UpcomingDebtPayment{},
+		Receivables:       []UpcomingDebtPayment{},
+		RecurringExpenses: []UpcomingRecurringItem{},
+		RecurringIncomes:  []UpcomingRecurringItem{},
 	}
 
 	// Get all active installments
@@ -61,7 +93,7 @@ func (s *UpcomingPaymentsService) GetUpcomingPayments(userID uuid.UUID, month, y
 
 	// Filter installments that have payments due in the specified month
 	for _, inst := range installments {
-		if isDueInMonth(inst.DueDay, month, year, inst.StartDate, inst.Tenor) {
+		if isDueInMonth(inst.DueDay, month, year, inst.StartDate, inst.MonthSpan()) {
 			dueDate := calculateDueDate(inst.DueDay, month, year)
 
 			payment := UpcomingInstallmentPayment{
@@ -106,15 +138,104 @@ func (s *UpcomingPaymentsService) GetUpcomingPayments(userID uuid.UUID, month, y
 				PaymentType:     string(debt.PaymentType),
 			}
 
+			if debt.IsReceivable() {
+				report.Receivables = append(report.Receivables, payment)
+				report.TotalReceivable += monthlyPayment
+				continue
+			}
+
 			report.Debts = append(report.Debts, payment)
 			report.TotalDebt += monthlyPayment
 			report.TotalPayments += monthlyPayment
 		}
 	}
 
+	// Scheduled expense templates and recurring incomes not posted yet
+	monthStart := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	monthEnd := monthStart.AddDate(0, 1, -1)
+
+	expenseOccurrences, err := s.recurringExpenseOccurrences(userID, monthStart, monthEnd)
+	if err != nil {
+		return nil, err
+	}
+	for _, occ := range expenseOccurrences {
+		report.RecurringExpenses = append(report.RecurringExpenses, UpcomingRecurringItem{
+			GroupID: occ.Group.ID,
+			Name:    occ.Group.Name,
+			Date:    occ.Date,
+			Amount:  occ.Group.Total(),
+		})
+		report.TotalRecurringExpense += occ.Group.Total()
+	}
+
+	incomeOccurrences, err := s.recurringIncomeOccurrences(userID, monthStart, monthEnd)
+	if err != nil {
+		return nil, err
+	}
+	for _, occ := range incomeOccurrences {
+		report.RecurringIncomes = append(report.RecurringIncomes, UpcomingRecurringItem{
+			GroupID: occ.Group.ID,
+			Name:    occ.Group.Name,
+			Date:    occ.Date,
+			Amount:  occ.Group.Total(),
+		})
+		report.TotalRecurringIncome += occ.Group.Total()
+	}
+
 	return report, nil
 }
 
+// upcomingFrom returns the first date still to come for a recurring group: today,
+// or the day after its last posted occurrence if that is later
+func upcomingFrom(from time.Time, lastPosted *time.Time) time.Time {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if from.Before(today) {
+		from = today
+	}
+	if lastPosted != nil && !lastPosted.Before(from) {
+		from = lastPosted.AddDate(0, 0, 1)
+	}
+	return from
+}
+
+// recurringExpenseOccurrences lists the expense template occurrences between from
+// and to that are still to come, ordered by date
+func (s *UpcomingPaymentsService) recurringExpenseOccurrences(userID uuid.UUID, from, to time.Time) ([]recurringExpenseOccurrence, error) {
+	groups, err := s.repos.ExpenseTemplateGroup.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var occurrences []recurringExpenseOccurrence
+	for _, group := range groups {
+		for _, date := range group.Occurrences(upcomingFrom(from, group.LastPostedDate), to) {
+			occurrences = append(occurrences, recurringExpenseOccurrence{Group: group, Date: date})
+		}
+	}
+	sort.SliceStable(occurrences, func(i, j int) bool { return occurrences[i].Date.Before(occurrences[j].Date) })
+	return occurrences, nil
+}
+
+// recurringIncomeOccurrences lists the active recurring income occurrences between
+// from and to that are still to come, ordered by date
+func (s *UpcomingPaymentsService) recurringIncomeOccurrences(userID uuid.UUID, from, to time.Time) ([]recurringIncomeOccurrence, error) {
+	active := true
+	groups, err := s.repos.RecurringIncomeGroup.GetByUserID(userID, &active)
+	if err != nil {
+		return nil, err
+	}
+
+	var occurrences []recurringIncomeOccurrence
+	for _, group := range groups {
+		for _, date := range group.Occurrences(upcomingFrom(from, group.LastPostedDate), to) {
+			occurrences = append(occurrences, recurringIncomeOccurrence{Group: group, Date: date})
+		}
+	}
+	sort.SliceStable(occurrences, func(i, j int) bool { return occurrences[i].Date.Before(occurrences[j].Date) })
+	return occurrences, nil
+}
+
 // isDueInMonth checks if an installment is due in the specified month
 func isDueInMonth(dueDay, month, year int, startDate time.Time, tenor int) bool {
 	targetMonth := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
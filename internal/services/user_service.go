@@ -106,7 +106,7 @@ func (s *UserService) UpdateProfile(userID uuid.UUID, name, email, profileImage,
 	return user, nil
 }
 
-func (s *UserService) UpdateNotificationSettings(userID uuid.UUID, notifyInstallment, notifyDebt, notifySavingsGoal *bool, notifyDaysBefore *int) (*models.User, error) {
+func (s *UserService) UpdateNotificationSettings(userID uuid.UUID, notifyInstallment, notifyDebt, notifySavingsGoal, notifyBudget, notifyReceivable *bool, notifyDaysBefore *int) (*models.User, error) {
 	user, err := s.userRepo.GetByID(userID)
 	if err != nil {
 		return nil, err
@@ -124,6 +124,14 @@ func (s *UserService) UpdateNotificationSettings(userID uuid.UUID, notifyInstall
 		user.NotifySavingsGoal = *notifySavingsGoal
 	}
 
+	if notifyBudget != nil {
+		user.NotifyBudget = *notifyBudget
+	}
+
+	if notifyReceivable != nil {
+		user.NotifyReceivable = *notifyReceivable
+	}
+
 	if notifyDaysBefore != nil {
 		if *notifyDaysBefore < 1 || *notifyDaysBefore > 30 {
 			return nil, utils.ErrBadRequest